@@ -2501,3 +2501,82 @@ func TestSupervisorEmitBootstrapTelemetry(t *testing.T) {
 		require.Truef(t, gotSpan, "expected to find span '%s', but did not find it", expectedSpan)
 	}
 }
+
+func TestSupervisorInstallsFilePackages(t *testing.T) {
+	goodContent := []byte("-----BEGIN CERTIFICATE-----\n")
+	goodHash := sha256.Sum256(goodContent)
+
+	fileServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ca.pem":
+			_, _ = w.Write(goodContent)
+		case "/tampered.pem":
+			_, _ = w.Write([]byte("tampered"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer fileServer.Close()
+
+	var packageStatuses atomic.Value
+	server := newOpAMPServer(
+		t,
+		defaultConnectingHandler,
+		types.ConnectionCallbacks{
+			OnMessage: func(_ context.Context, _ types.Connection, message *protobufs.AgentToServer) *protobufs.ServerToAgent {
+				if message.PackageStatuses != nil {
+					packageStatuses.Store(message.PackageStatuses)
+				}
+				return &protobufs.ServerToAgent{}
+			},
+		})
+
+	storageDir := t.TempDir()
+	s, _ := newSupervisor(t, "packages", map[string]string{"url": server.addr, "storage_dir": storageDir})
+
+	require.Nil(t, s.Start(t.Context()))
+	defer s.Shutdown()
+
+	waitForSupervisorConnection(server.supervisorConnected, true)
+
+	server.sendToSupervisor(&protobufs.ServerToAgent{
+		PackagesAvailable: &protobufs.PackagesAvailable{
+			Packages: map[string]*protobufs.PackageAvailable{
+				"ca.pem": {
+					Type:    protobufs.PackageType_PackageType_Addon,
+					Version: "1.0.0",
+					Hash:    []byte("ca-package-hash"),
+					File: &protobufs.DownloadableFile{
+						DownloadUrl: fileServer.URL + "/ca.pem",
+						ContentHash: goodHash[:],
+					},
+				},
+				"tampered.pem": {
+					Type:    protobufs.PackageType_PackageType_Addon,
+					Version: "1.0.0",
+					Hash:    []byte("tampered-package-hash"),
+					File: &protobufs.DownloadableFile{
+						DownloadUrl: fileServer.URL + "/tampered.pem",
+						ContentHash: goodHash[:],
+					},
+				},
+			},
+			AllPackagesHash: []byte("all-packages-hash"),
+		},
+	})
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		statuses, ok := packageStatuses.Load().(*protobufs.PackageStatuses)
+		require.True(c, ok)
+		require.Contains(c, statuses.Packages, "ca.pem")
+		require.Contains(c, statuses.Packages, "tampered.pem")
+		assert.Equal(c, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, statuses.Packages["ca.pem"].Status)
+		assert.Equal(c, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, statuses.Packages["tampered.pem"].Status)
+		assert.Contains(c, statuses.Packages["tampered.pem"].ErrorMessage, "content hash mismatch")
+	}, 10*time.Second, 250*time.Millisecond, "Package statuses were not reported")
+
+	installed, err := os.ReadFile(filepath.Join(storageDir, "packages", "files", "ca.pem"))
+	require.NoError(t, err)
+	require.Equal(t, goodContent, installed)
+	require.NoFileExists(t, filepath.Join(storageDir, "packages", "files", "tampered.pem"))
+}
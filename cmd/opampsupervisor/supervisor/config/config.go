@@ -38,6 +38,7 @@ type Supervisor struct {
 	Storage      Storage      `mapstructure:"storage"`
 	Telemetry    Telemetry    `mapstructure:"telemetry"`
 	HealthCheck  HealthCheck  `mapstructure:"healthcheck"`
+	Packages     Packages     `mapstructure:"packages"`
 }
 
 // Load loads the Supervisor config from a file.
@@ -91,6 +92,10 @@ func (s Supervisor) Validate() error {
 		return err
 	}
 
+	if err := s.Packages.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -111,6 +116,8 @@ type Capabilities struct {
 	ReportsHealth                  bool `mapstructure:"reports_health"`
 	ReportsRemoteConfig            bool `mapstructure:"reports_remote_config"`
 	ReportsAvailableComponents     bool `mapstructure:"reports_available_components"`
+	AcceptsPackages                bool `mapstructure:"accepts_packages"`
+	ReportsPackageStatuses         bool `mapstructure:"reports_package_statuses"`
 }
 
 func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
@@ -156,9 +163,41 @@ func (c Capabilities) SupportedCapabilities() protobufs.AgentCapabilities {
 		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents
 	}
 
+	if c.AcceptsPackages {
+		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages
+	}
+
+	if c.ReportsPackageStatuses {
+		supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+	}
+
 	return supportedCapabilities
 }
 
+// Packages configures the packages offered by the OpAMP server.
+type Packages struct {
+	// PublicKeyFile is the path to a PEM encoded public key used to verify the signature
+	// of the downloaded packages. If it is empty, packages are not required to be signed,
+	// but they must have a content hash, and the top-level package is rejected unless
+	// InsecureAllowUnsignedAgent is set.
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	// InsecureAllowUnsignedAgent allows installing the agent executable offered in the
+	// top-level package without verifying its signature, when PublicKeyFile is empty.
+	InsecureAllowUnsignedAgent bool `mapstructure:"insecure_allow_unsigned_agent"`
+}
+
+func (p Packages) Validate() error {
+	if p.PublicKeyFile == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(p.PublicKeyFile); err != nil {
+		return fmt.Errorf("could not stat packages::public_key_file path: %w", err)
+	}
+
+	return nil
+}
+
 type OpAMPServer struct {
 	Endpoint string                 `mapstructure:"endpoint"`
 	Headers  http.Header            `mapstructure:"headers"`
@@ -201,6 +240,60 @@ type Agent struct {
 	ConfigFiles             []string          `mapstructure:"config_files"`
 	Arguments               []string          `mapstructure:"args"`
 	Env                     map[string]string `mapstructure:"env"`
+	ConfigRollback          ConfigRollback    `mapstructure:"config_rollback"`
+	ConfigMerge             ConfigMerge       `mapstructure:"config_merge"`
+	ConfigDrift             ConfigDrift       `mapstructure:"config_drift"`
+}
+
+// ConfigRollback configures the rollback to the last known good config when a new config fails.
+type ConfigRollback struct {
+	// Enabled turns on the automatic rollback.
+	Enabled bool `mapstructure:"enabled"`
+	// CrashLoopThreshold is the number of unexpected agent exits within CrashLoopWindow
+	// after which a new config is rolled back.
+	CrashLoopThreshold int `mapstructure:"crash_loop_threshold"`
+	// CrashLoopWindow is the period during which agent exits are counted. A config becomes
+	// the last known good config once the agent has been healthy with it for this period.
+	CrashLoopWindow time.Duration `mapstructure:"crash_loop_window"`
+}
+
+// ConfigMergePolicy is the policy of a config merge rule.
+type ConfigMergePolicy string
+
+const (
+	// ConfigMergePolicyLocalWins uses the value from the local config files when they set it.
+	ConfigMergePolicyLocalWins ConfigMergePolicy = "local_wins"
+	// ConfigMergePolicyRemoteWins uses the value from the remote config when it sets it.
+	ConfigMergePolicyRemoteWins ConfigMergePolicy = "remote_wins"
+)
+
+// ConfigMerge configures how the local config files and the remote config are merged
+// at specific paths, regardless of their order in agent::config_files.
+// Paths are made of keys separated by "::", and a "*" key matches any key.
+type ConfigMerge struct {
+	// LockedKeys are the paths which can only be set by the local config files.
+	// Values set by the remote config at these paths are ignored.
+	LockedKeys []string `mapstructure:"locked_keys"`
+	// Rules set which of the local config files or the remote config wins at a path.
+	Rules []ConfigMergeRule `mapstructure:"rules"`
+}
+
+// ConfigMergeRule sets the merge policy of a path.
+type ConfigMergeRule struct {
+	Path   string            `mapstructure:"path"`
+	Policy ConfigMergePolicy `mapstructure:"policy"`
+}
+
+// ConfigDrift configures the detection of changes made to the agent's effective
+// config file on disk by something other than the Supervisor.
+type ConfigDrift struct {
+	// Enabled turns on the periodic drift checks.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the time between two drift checks.
+	Interval time.Duration `mapstructure:"interval"`
+	// Reapply restores the config composed by the Supervisor and reloads the agent
+	// when a drift is detected.
+	Reapply bool `mapstructure:"reapply"`
 }
 
 func (a Agent) Validate() error {
@@ -229,6 +322,16 @@ func (a Agent) Validate() error {
 		return errors.New("agent::config_apply_timeout must be valid duration")
 	}
 
+	if a.ConfigRollback.Enabled {
+		if a.ConfigRollback.CrashLoopThreshold <= 0 {
+			return errors.New("agent::config_rollback::crash_loop_threshold must be positive")
+		}
+
+		if a.ConfigRollback.CrashLoopWindow <= 0 {
+			return errors.New("agent::config_rollback::crash_loop_window must be positive")
+		}
+	}
+
 	for _, file := range a.ConfigFiles {
 		if !strings.HasPrefix(file, "$") {
 			continue
@@ -238,6 +341,14 @@ func (a Agent) Validate() error {
 		}
 	}
 
+	if err := a.ConfigMerge.Validate(); err != nil {
+		return err
+	}
+
+	if a.ConfigDrift.Enabled && a.ConfigDrift.Interval <= 0 {
+		return errors.New("agent::config_drift::interval must be positive")
+	}
+
 	if runtime.GOOS == "windows" && a.UseHUPConfigReload {
 		return errors.New("agent::use_hup_config_reload is not supported on Windows")
 	}
@@ -245,6 +356,36 @@ func (a Agent) Validate() error {
 	return nil
 }
 
+func (c ConfigMerge) Validate() error {
+	for _, key := range c.LockedKeys {
+		if err := validateConfigPath(key); err != nil {
+			return fmt.Errorf("agent::config_merge::locked_keys contains an invalid path %q: %w", key, err)
+		}
+	}
+
+	for _, rule := range c.Rules {
+		if err := validateConfigPath(rule.Path); err != nil {
+			return fmt.Errorf("agent::config_merge::rules contains an invalid path %q: %w", rule.Path, err)
+		}
+		if rule.Policy != ConfigMergePolicyLocalWins && rule.Policy != ConfigMergePolicyRemoteWins {
+			return fmt.Errorf("agent::config_merge::rules contains an invalid policy %q for path %q. Must be one of %v",
+				rule.Policy, rule.Path, []ConfigMergePolicy{ConfigMergePolicyLocalWins, ConfigMergePolicyRemoteWins})
+		}
+	}
+
+	return nil
+}
+
+func validateConfigPath(path string) error {
+	if path == "" {
+		return errors.New("path must not be empty")
+	}
+	if slices.Contains(strings.Split(path, "::"), "") {
+		return errors.New("path must not contain empty keys")
+	}
+	return nil
+}
+
 type SpecialConfigFile string
 
 const (
@@ -348,6 +489,16 @@ func DefaultSupervisor() Supervisor {
 			ConfigApplyTimeout:      5 * time.Second,
 			BootstrapTimeout:        3 * time.Second,
 			PassthroughLogs:         false,
+			ConfigRollback: ConfigRollback{
+				Enabled:            false,
+				CrashLoopThreshold: 3,
+				CrashLoopWindow:    time.Minute,
+			},
+			ConfigDrift: ConfigDrift{
+				Enabled:  false,
+				Interval: time.Minute,
+				Reapply:  false,
+			},
 		},
 		Telemetry: Telemetry{
 			Logs: Logs{
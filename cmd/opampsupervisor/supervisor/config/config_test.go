@@ -441,6 +441,184 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErrorFunc: simpleError("agent::config_files contains invalid special file: \"$DOESNTEXIST\". Must be one of [$OWN_TELEMETRY_CONFIG $OPAMP_EXTENSION_CONFIG $REMOTE_CONFIG]"),
 		},
+		{
+			name: "Invalid config rollback crash loop threshold",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLS: tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+					ConfigRollback: ConfigRollback{
+						Enabled:            true,
+						CrashLoopThreshold: 0,
+						CrashLoopWindow:    time.Minute,
+					},
+				},
+				Capabilities: Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedErrorFunc: simpleError("agent::config_rollback::crash_loop_threshold must be positive"),
+		},
+		{
+			name: "Invalid config rollback crash loop window",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLS: tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+					ConfigRollback: ConfigRollback{
+						Enabled:            true,
+						CrashLoopThreshold: 3,
+						CrashLoopWindow:    0,
+					},
+				},
+				Capabilities: Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedErrorFunc: simpleError("agent::config_rollback::crash_loop_window must be positive"),
+		},
+		{
+			name: "Invalid config merge locked key",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLS: tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+					ConfigMerge: ConfigMerge{
+						LockedKeys: []string{"extensions::::directory"},
+					},
+				},
+				Capabilities: Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedErrorFunc: simpleError("agent::config_merge::locked_keys contains an invalid path \"extensions::::directory\": path must not contain empty keys"),
+		},
+		{
+			name: "Invalid config merge rule policy",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLS: tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+					ConfigMerge: ConfigMerge{
+						Rules: []ConfigMergeRule{
+							{
+								Path:   "exporters",
+								Policy: "local",
+							},
+						},
+					},
+				},
+				Capabilities: Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedErrorFunc: simpleError("agent::config_merge::rules contains an invalid policy \"local\" for path \"exporters\". Must be one of [local_wins remote_wins]"),
+		},
+		{
+			name: "Invalid config drift interval",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLS: tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+					ConfigDrift: ConfigDrift{
+						Enabled:  true,
+						Interval: 0,
+					},
+				},
+				Capabilities: Capabilities{
+					AcceptsRemoteConfig: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+			},
+			expectedErrorFunc: simpleError("agent::config_drift::interval must be positive"),
+		},
+		{
+			name: "Invalid packages public key file",
+			config: Supervisor{
+				Server: OpAMPServer{
+					Endpoint: "wss://localhost:9090/opamp",
+					Headers: http.Header{
+						"Header1": []string{"HeaderValue"},
+					},
+					TLS: tlsConfig,
+				},
+				Agent: Agent{
+					Executable:              "${file_path}",
+					OrphanDetectionInterval: 5 * time.Second,
+					ConfigApplyTimeout:      2 * time.Second,
+					BootstrapTimeout:        5 * time.Second,
+				},
+				Capabilities: Capabilities{
+					AcceptsPackages: true,
+				},
+				Storage: Storage{
+					Directory: "/etc/opamp-supervisor/storage",
+				},
+				Packages: Packages{
+					PublicKeyFile: "/does/not/exist.pem",
+				},
+			},
+			expectedErrorFunc: simpleError("could not stat packages::public_key_file path"),
+		},
 		{
 			name: "Invalid HealthCheck port",
 			config: Supervisor{
@@ -534,6 +712,8 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				ReportsHealth:                  true,
 				ReportsRemoteConfig:            true,
 				ReportsAvailableComponents:     true,
+				AcceptsPackages:                true,
+				ReportsPackageStatuses:         true,
 			},
 			expectedAgentCapabilities: protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig |
@@ -545,7 +725,9 @@ func TestCapabilities_SupportedCapabilities(t *testing.T) {
 				protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand |
 				protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings |
-				protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents,
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsAvailableComponents |
+				protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+				protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses,
 		},
 	}
 
@@ -595,6 +777,8 @@ agent:
 						OrphanDetectionInterval: DefaultSupervisor().Agent.OrphanDetectionInterval,
 						ConfigApplyTimeout:      DefaultSupervisor().Agent.ConfigApplyTimeout,
 						BootstrapTimeout:        DefaultSupervisor().Agent.BootstrapTimeout,
+						ConfigRollback:          DefaultSupervisor().Agent.ConfigRollback,
+						ConfigDrift:             DefaultSupervisor().Agent.ConfigDrift,
 					},
 					Telemetry: DefaultSupervisor().Telemetry,
 				}
@@ -636,6 +820,20 @@ agent:
   bootstrap_timeout: 8s
   opamp_server_port: 8090
   passthrough_logs: true
+  config_rollback:
+    enabled: true
+    crash_loop_threshold: 5
+    crash_loop_window: 2m
+  config_merge:
+    locked_keys:
+      - extensions::file_storage::directory
+    rules:
+      - path: exporters::*::endpoint
+        policy: local_wins
+  config_drift:
+    enabled: true
+    interval: 30s
+    reapply: true
 
 telemetry:
   logs:
@@ -681,6 +879,25 @@ telemetry:
 						BootstrapTimeout:        8 * time.Second,
 						OpAMPServerPort:         8090,
 						PassthroughLogs:         true,
+						ConfigRollback: ConfigRollback{
+							Enabled:            true,
+							CrashLoopThreshold: 5,
+							CrashLoopWindow:    2 * time.Minute,
+						},
+						ConfigMerge: ConfigMerge{
+							LockedKeys: []string{"extensions::file_storage::directory"},
+							Rules: []ConfigMergeRule{
+								{
+									Path:   "exporters::*::endpoint",
+									Policy: ConfigMergePolicyLocalWins,
+								},
+							},
+						},
+						ConfigDrift: ConfigDrift{
+							Enabled:  true,
+							Interval: 30 * time.Second,
+							Reapply:  true,
+						},
 					},
 					Telemetry: Telemetry{
 						Logs: Logs{
@@ -716,6 +933,8 @@ agent:
 						OrphanDetectionInterval: DefaultSupervisor().Agent.OrphanDetectionInterval,
 						ConfigApplyTimeout:      DefaultSupervisor().Agent.ConfigApplyTimeout,
 						BootstrapTimeout:        DefaultSupervisor().Agent.BootstrapTimeout,
+						ConfigRollback:          DefaultSupervisor().Agent.ConfigRollback,
+						ConfigDrift:             DefaultSupervisor().Agent.ConfigDrift,
 					},
 					Telemetry: DefaultSupervisor().Telemetry,
 				}
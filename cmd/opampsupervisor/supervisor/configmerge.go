@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+const (
+	configPathDelimiter = "::"
+	configPathWildcard  = "*"
+)
+
+// applyConfigMerge overrides the merged config at the paths of the merge rules and
+// locked keys. local holds the merge of the local config files and of the config
+// composed by the Supervisor, and remote holds the remote config.
+func applyConfigMerge(merged, local, remote *koanf.Koanf, merge config.ConfigMerge, logger *zap.Logger) error {
+	for _, rule := range merge.Rules {
+		source := local
+		if rule.Policy == config.ConfigMergePolicyRemoteWins {
+			source = remote
+		}
+		for _, path := range matchConfigPaths(source, rule.Path) {
+			if err := replaceConfigPath(merged, path, source.Get(path)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range merge.LockedKeys {
+		for _, path := range matchConfigPaths(remote, key) {
+			if !reflect.DeepEqual(remote.Get(path), local.Get(path)) {
+				logger.Warn("Ignoring the remote config of a locked key", zap.String("key", path))
+			}
+			merged.Delete(path)
+		}
+		for _, path := range matchConfigPaths(local, key) {
+			if err := replaceConfigPath(merged, path, local.Get(path)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replaceConfigPath sets the value at the given path, replacing any map at this path
+// instead of merging it.
+func replaceConfigPath(k *koanf.Koanf, path string, value any) error {
+	k.Delete(path)
+	return k.Set(path, value)
+}
+
+// matchConfigPaths returns the paths of the config that match the given path pattern,
+// in which a "*" key matches any key.
+func matchConfigPaths(k *koanf.Koanf, pattern string) []string {
+	var matches []string
+
+	var walk func(node any, path, keys []string)
+	walk = func(node any, path, keys []string) {
+		if len(keys) == 0 {
+			matches = append(matches, strings.Join(path, configPathDelimiter))
+			return
+		}
+		m, ok := node.(map[string]any)
+		if !ok {
+			return
+		}
+		if keys[0] != configPathWildcard {
+			if child, ok := m[keys[0]]; ok {
+				walk(child, append(slices.Clone(path), keys[0]), keys[1:])
+			}
+			return
+		}
+
+		// Sort to make sure the order of the matches is stable.
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			walk(m[name], append(slices.Clone(path), name), keys[1:])
+		}
+	}
+	walk(k.Raw(), nil, strings.Split(pattern, configPathDelimiter))
+
+	return matches
+}
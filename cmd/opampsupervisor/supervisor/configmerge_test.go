@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+func loadTestConfig(t *testing.T, configs ...string) *koanf.Koanf {
+	k := koanf.New("::")
+	for _, cfg := range configs {
+		require.NoError(t, k.Load(rawbytes.Provider([]byte(cfg)), yaml.Parser(), koanf.WithMergeFunc(configMergeFunc)))
+	}
+	return k
+}
+
+func TestApplyConfigMerge(t *testing.T) {
+	const localConfig = `
+extensions:
+  file_storage:
+    directory: /var/lib/otelcol
+exporters:
+  otlp/backend:
+    endpoint: local:4317
+    tls:
+      insecure: false
+`
+	const remoteConfig = `
+extensions:
+  file_storage:
+    directory: /tmp
+    timeout: 5s
+  health_check:
+    endpoint: remote:13133
+exporters:
+  otlp/backend:
+    endpoint: remote:4317
+    tls:
+      insecure: true
+  otlp/other:
+    endpoint: other:4317
+processors:
+  batch:
+    timeout: 1s
+`
+
+	tests := []struct {
+		name  string
+		merge config.ConfigMerge
+		// localLast merges the local config after the remote config
+		localLast bool
+		expected  map[string]any
+	}{
+		{
+			name:  "No merge policy",
+			merge: config.ConfigMerge{},
+			expected: map[string]any{
+				"extensions::file_storage::directory": "/tmp",
+				"extensions::file_storage::timeout":   "5s",
+				"exporters::otlp/backend::endpoint":   "remote:4317",
+				"exporters::otlp/backend::tls":        map[string]any{"insecure": true},
+			},
+		},
+		{
+			name: "Locked keys",
+			merge: config.ConfigMerge{
+				LockedKeys: []string{"extensions::file_storage", "extensions::health_check::endpoint"},
+			},
+			expected: map[string]any{
+				"extensions::file_storage": map[string]any{"directory": "/var/lib/otelcol"},
+				"extensions::health_check": nil,
+				"exporters::otlp/other":    map[string]any{"endpoint": "other:4317"},
+			},
+		},
+		{
+			name: "Local wins with wildcard",
+			merge: config.ConfigMerge{
+				Rules: []config.ConfigMergeRule{
+					{Path: "exporters::*::endpoint", Policy: config.ConfigMergePolicyLocalWins},
+				},
+			},
+			expected: map[string]any{
+				"exporters::otlp/backend::endpoint": "local:4317",
+				"exporters::otlp/backend::tls":      map[string]any{"insecure": true},
+				"exporters::otlp/other::endpoint":   "other:4317",
+			},
+		},
+		{
+			name: "Local wins replaces maps",
+			merge: config.ConfigMerge{
+				Rules: []config.ConfigMergeRule{
+					{Path: "extensions::file_storage", Policy: config.ConfigMergePolicyLocalWins},
+				},
+			},
+			expected: map[string]any{
+				"extensions::file_storage": map[string]any{"directory": "/var/lib/otelcol"},
+			},
+		},
+		{
+			name: "Remote wins",
+			merge: config.ConfigMerge{
+				Rules: []config.ConfigMergeRule{
+					{Path: "exporters::otlp/backend::tls", Policy: config.ConfigMergePolicyRemoteWins},
+				},
+			},
+			localLast: true,
+			expected: map[string]any{
+				"exporters::otlp/backend::endpoint": "local:4317",
+				"exporters::otlp/backend::tls":      map[string]any{"insecure": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := loadTestConfig(t, localConfig)
+			remote := loadTestConfig(t, remoteConfig)
+			merged := loadTestConfig(t, localConfig, remoteConfig)
+			if tt.localLast {
+				merged = loadTestConfig(t, remoteConfig, localConfig)
+			}
+
+			require.NoError(t, applyConfigMerge(merged, local, remote, tt.merge, zap.NewNop()))
+			for path, value := range tt.expected {
+				assert.Equal(t, value, merged.Get(path), path)
+			}
+		})
+	}
+}
+
+func TestMatchConfigPaths(t *testing.T) {
+	k := loadTestConfig(t, `
+exporters:
+  otlp/b:
+    endpoint: b:4317
+  otlp/a:
+    endpoint: a:4317
+  debug:
+    verbosity: detailed
+`)
+
+	assert.Equal(t, []string{"exporters::otlp/a::endpoint", "exporters::otlp/b::endpoint"}, matchConfigPaths(k, "exporters::*::endpoint"))
+	assert.Equal(t, []string{"exporters::debug"}, matchConfigPaths(k, "exporters::debug"))
+	assert.Empty(t, matchConfigPaths(k, "exporters::otlp"))
+	assert.Empty(t, matchConfigPaths(k, "exporters::debug::verbosity::level"))
+}
@@ -0,0 +1,532 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+const (
+	packagesDirName                 = "packages"
+	packageFilesDirName             = "files"
+	packagesStateFileName           = "packages.yaml"
+	lastReportedPackageStatusesFile = "last_reported_package_statuses.dat"
+
+	// Package files are staged next to their destination, so that they can be swapped atomically.
+	stagedFileSuffix = ".staged"
+	// The current agent executable is kept until the agent starts with the new one.
+	previousAgentExecutableSuffix = ".previous"
+)
+
+// packagesState is the persisted state of the packages.
+type packagesState struct {
+	// AllPackagesHash is a hex encoded string of the hash of all the packages offered by the server.
+	AllPackagesHash string                   `yaml:"all_packages_hash"`
+	Packages        map[string]*packageState `yaml:"packages"`
+	// AgentUpdate is the agent executable update which is staged or being installed, if any.
+	AgentUpdate *agentUpdate `yaml:"agent_update,omitempty"`
+}
+
+type packageState struct {
+	Type protobufs.PackageType `yaml:"type"`
+	// Hash is a hex encoded string of the hash of the package, as offered by the server.
+	Hash    string `yaml:"hash"`
+	Version string `yaml:"version"`
+	// ContentHash is a hex encoded string of the SHA-256 hash of the installed file.
+	ContentHash string `yaml:"content_hash"`
+}
+
+// agentUpdate is a new agent executable which is staged, but not installed yet.
+type agentUpdate struct {
+	PackageName string `yaml:"package_name"`
+	// Previous is the state of the package before the update, or nil if the package is new.
+	Previous *packageState `yaml:"previous,omitempty"`
+}
+
+// packageManager stores the packages offered by the OpAMP server. It implements the
+// PackagesStateProvider used by the OpAMP client to sync the packages.
+// Top-level packages are the agent executable, which is staged and then swapped by
+// the Supervisor. Addon packages are files installed in the packages directory.
+type packageManager struct {
+	dir                string
+	agentExecutable    string
+	publicKey          crypto.PublicKey
+	allowUnsignedAgent bool
+	logger             *zap.Logger
+
+	mu    sync.Mutex
+	state packagesState
+	// filesChanged is true if addon packages were changed since the last call to takeChanges.
+	filesChanged bool
+	stagedAgent  *agentUpdate
+}
+
+var _ types.PackagesStateProvider = (*packageManager)(nil)
+
+func newPackageManager(storageDir, agentExecutable string, cfg config.Packages, logger *zap.Logger) (*packageManager, error) {
+	pm := &packageManager{
+		dir:                filepath.Join(storageDir, packagesDirName),
+		agentExecutable:    agentExecutable,
+		allowUnsignedAgent: cfg.InsecureAllowUnsignedAgent,
+		logger:             logger,
+		state:              packagesState{Packages: map[string]*packageState{}},
+	}
+
+	if err := os.MkdirAll(filepath.Join(pm.dir, packageFilesDirName), 0o700); err != nil {
+		return nil, fmt.Errorf("error creating packages dir: %w", err)
+	}
+
+	if cfg.PublicKeyFile != "" {
+		var err error
+		if pm.publicKey, err = loadPublicKey(cfg.PublicKeyFile); err != nil {
+			return nil, fmt.Errorf("could not load packages public key: %w", err)
+		}
+	}
+
+	by, err := os.ReadFile(filepath.Join(pm.dir, packagesStateFileName))
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(by, &pm.state); err != nil {
+			return nil, fmt.Errorf("could not parse packages state: %w", err)
+		}
+		if pm.state.Packages == nil {
+			pm.state.Packages = map[string]*packageState{}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("could not read packages state: %w", err)
+	}
+
+	if update := pm.state.AgentUpdate; update != nil {
+		if err = pm.recoverAgentUpdate(update); err != nil {
+			return nil, fmt.Errorf("could not recover the interrupted agent update: %w", err)
+		}
+	}
+
+	// Remove the leftovers of an update interrupted by a restart of the Supervisor.
+	for _, leftover := range []string{agentExecutable + stagedFileSuffix, agentExecutable + previousAgentExecutableSuffix} {
+		if err := os.Remove(leftover); err == nil {
+			logger.Warn("Removed the leftover of an interrupted agent update", zap.String("path", leftover))
+		}
+	}
+
+	return pm, nil
+}
+
+func (pm *packageManager) AllPackagesHash() ([]byte, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return hex.DecodeString(pm.state.AllPackagesHash)
+}
+
+func (pm *packageManager) SetAllPackagesHash(hash []byte) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.state.AllPackagesHash = hex.EncodeToString(hash)
+	return pm.writeState()
+}
+
+func (pm *packageManager) Packages() (map[string]types.PackageState, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	packages := make(map[string]types.PackageState, len(pm.state.Packages))
+	for name, pkg := range pm.state.Packages {
+		state, err := pkg.toPackageState()
+		if err != nil {
+			return nil, fmt.Errorf("package %q: %w", name, err)
+		}
+		packages[name] = state
+	}
+	return packages, nil
+}
+
+func (pm *packageManager) PackageState(packageName string) (types.PackageState, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pkg, ok := pm.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{}, nil
+	}
+	return pkg.toPackageState()
+}
+
+func (pm *packageManager) SetPackageState(packageName string, state types.PackageState) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pkg, ok := pm.state.Packages[packageName]
+	if !ok {
+		pkg = &packageState{}
+		pm.state.Packages[packageName] = pkg
+	}
+	pkg.Type = state.Type
+	pkg.Hash = hex.EncodeToString(state.Hash)
+	pkg.Version = state.Version
+	return pm.writeState()
+}
+
+func (pm *packageManager) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, ok := pm.state.Packages[packageName]; ok {
+		return fmt.Errorf("package %q already exists", packageName)
+	}
+	switch typ {
+	case protobufs.PackageType_PackageType_TopLevel:
+		for name, pkg := range pm.state.Packages {
+			if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+				return fmt.Errorf("cannot create top-level package %q: the agent is already provided by package %q", packageName, name)
+			}
+		}
+	case protobufs.PackageType_PackageType_Addon:
+		if err := validatePackageFileName(packageName); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported type %v for package %q", typ, packageName)
+	}
+	pm.state.Packages[packageName] = &packageState{Type: typ}
+	return pm.writeState()
+}
+
+func (pm *packageManager) FileContentHash(packageName string) ([]byte, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pkg, ok := pm.state.Packages[packageName]
+	if !ok || pkg.ContentHash == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(pkg.ContentHash)
+}
+
+// UpdateContent verifies the downloaded file of a package. The file of an addon package is
+// installed, while the agent executable is staged until it is swapped by the Supervisor.
+func (pm *packageManager) UpdateContent(_ context.Context, packageName string, data io.Reader, contentHash, signature []byte) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pkg, ok := pm.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %q does not exist", packageName)
+	}
+
+	target := pm.agentExecutable
+	if pkg.Type != protobufs.PackageType_PackageType_TopLevel {
+		target = pm.packageFilePath(packageName)
+	}
+	staged := target + stagedFileSuffix
+
+	digest, err := writeFile(staged, data)
+	if err != nil {
+		return fmt.Errorf("could not write package file: %w", err)
+	}
+	if err = pm.verify(pkg.Type, digest, contentHash, signature); err != nil {
+		_ = os.Remove(staged)
+		return err
+	}
+
+	if pkg.Type == protobufs.PackageType_PackageType_TopLevel {
+		if err = os.Chmod(staged, 0o755); err != nil {
+			_ = os.Remove(staged)
+			return fmt.Errorf("could not make agent executable: %w", err)
+		}
+		// The state of the package before a staged, but not installed, update is kept.
+		update := pm.state.AgentUpdate
+		if update == nil || update.PackageName != packageName {
+			update = &agentUpdate{PackageName: packageName}
+			if pkg.ContentHash != "" {
+				previous := *pkg
+				update.Previous = &previous
+			}
+		}
+		pm.state.AgentUpdate = update
+		pm.stagedAgent = update
+	} else {
+		if err = os.Rename(staged, target); err != nil {
+			_ = os.Remove(staged)
+			return fmt.Errorf("could not install package file: %w", err)
+		}
+		pm.filesChanged = true
+	}
+
+	pkg.ContentHash = hex.EncodeToString(digest)
+	return pm.writeState()
+}
+
+func (pm *packageManager) DeletePackage(packageName string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pkg, ok := pm.state.Packages[packageName]
+	if !ok {
+		return nil
+	}
+	// The agent executable is never deleted, the agent is only unmanaged.
+	if pkg.Type != protobufs.PackageType_PackageType_TopLevel {
+		if err := os.Remove(pm.packageFilePath(packageName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not delete package file: %w", err)
+		}
+		pm.filesChanged = true
+	}
+	delete(pm.state.Packages, packageName)
+	return pm.writeState()
+}
+
+func (pm *packageManager) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	statuses := &protobufs.PackageStatuses{}
+	by, err := os.ReadFile(filepath.Join(pm.dir, lastReportedPackageStatusesFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return statuses, nil
+	case err != nil:
+		return nil, err
+	}
+	if err = proto.Unmarshal(by, statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (pm *packageManager) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	by, err := proto.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(pm.dir, lastReportedPackageStatusesFile), by, 0o600)
+}
+
+// takeChanges returns the staged agent update, if any, and whether the addon
+// packages were changed since the last call.
+func (pm *packageManager) takeChanges() (*agentUpdate, bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	update, filesChanged := pm.stagedAgent, pm.filesChanged
+	pm.stagedAgent, pm.filesChanged = nil, false
+	return update, filesChanged
+}
+
+// installAgentExecutable replaces the agent executable with the staged one. The current
+// executable is kept until either commitAgentExecutable or restoreAgentExecutable is called.
+func (pm *packageManager) installAgentExecutable() error {
+	previous := pm.agentExecutable + previousAgentExecutableSuffix
+	_ = os.Remove(previous)
+	if err := os.Link(pm.agentExecutable, previous); err != nil {
+		if err = copyFile(pm.agentExecutable, previous); err != nil {
+			return fmt.Errorf("could not back up the agent executable: %w", err)
+		}
+	}
+	if err := os.Rename(pm.agentExecutable+stagedFileSuffix, pm.agentExecutable); err != nil {
+		return fmt.Errorf("could not replace the agent executable: %w", err)
+	}
+	return nil
+}
+
+// commitAgentExecutable removes the previous agent executable, and completes the update.
+func (pm *packageManager) commitAgentExecutable() {
+	if err := os.Remove(pm.agentExecutable + previousAgentExecutableSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		pm.logger.Warn("Could not remove the previous agent executable", zap.Error(err))
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.state.AgentUpdate = nil
+	if err := pm.writeState(); err != nil {
+		pm.logger.Warn("Could not write packages state", zap.Error(err))
+	}
+}
+
+// restoreAgentExecutable restores the previous agent executable, and the state of its package.
+func (pm *packageManager) restoreAgentExecutable(update *agentUpdate) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if err := pm.rollBackAgentUpdate(update); err != nil {
+		return err
+	}
+	return pm.writeState()
+}
+
+// recoverAgentUpdate completes or rolls back an agent update interrupted by a restart of the
+// Supervisor. The update is complete once the previous agent executable has been removed.
+// Otherwise, the previous executable and package state are restored, and the packages
+// offered by the server are synced again.
+func (pm *packageManager) recoverAgentUpdate(update *agentUpdate) error {
+	_, stagedErr := os.Stat(pm.agentExecutable + stagedFileSuffix)
+	_, previousErr := os.Stat(pm.agentExecutable + previousAgentExecutableSuffix)
+	if errors.Is(stagedErr, os.ErrNotExist) && errors.Is(previousErr, os.ErrNotExist) {
+		pm.logger.Info("Completed the interrupted agent update", zap.String("package", update.PackageName))
+		pm.state.AgentUpdate = nil
+		return pm.writeState()
+	}
+
+	pm.logger.Warn("Rolling back the interrupted agent update", zap.String("package", update.PackageName))
+	if err := pm.rollBackAgentUpdate(update); err != nil {
+		return err
+	}
+	pm.state.AllPackagesHash = ""
+	return pm.writeState()
+}
+
+// rollBackAgentUpdate restores the previous agent executable and the state of its package,
+// without persisting the state.
+func (pm *packageManager) rollBackAgentUpdate(update *agentUpdate) error {
+	_ = os.Remove(pm.agentExecutable + stagedFileSuffix)
+	previous := pm.agentExecutable + previousAgentExecutableSuffix
+	if _, err := os.Stat(previous); err == nil {
+		if err = os.Rename(previous, pm.agentExecutable); err != nil {
+			return fmt.Errorf("could not restore the previous agent executable: %w", err)
+		}
+	}
+
+	if update.Previous != nil {
+		pm.state.Packages[update.PackageName] = update.Previous
+	} else if pkg, ok := pm.state.Packages[update.PackageName]; ok {
+		// The agent executable was not provided by a package before, it is not managed yet.
+		pkg.Hash, pkg.Version, pkg.ContentHash = "", "", ""
+	}
+	pm.state.AgentUpdate = nil
+	return nil
+}
+
+// verify checks the downloaded file of a package. Without a public key, packages must have
+// a content hash, and the agent executable is only installed if unsigned agents are allowed.
+func (pm *packageManager) verify(typ protobufs.PackageType, digest, contentHash, signature []byte) error {
+	if len(contentHash) > 0 && !bytes.Equal(digest, contentHash) {
+		return fmt.Errorf("content hash mismatch: expected %x, got %x", contentHash, digest)
+	}
+	if pm.publicKey == nil {
+		if typ == protobufs.PackageType_PackageType_TopLevel && !pm.allowUnsignedAgent {
+			return errors.New("agent package cannot be verified: packages::public_key_file is not set")
+		}
+		if len(contentHash) == 0 {
+			return errors.New("package file has neither a content hash nor a verified signature")
+		}
+		return nil
+	}
+	if len(signature) == 0 {
+		return errors.New("package file is not signed")
+	}
+	return verifySignature(pm.publicKey, digest, signature)
+}
+
+func (pm *packageManager) packageFilePath(packageName string) string {
+	return filepath.Join(pm.dir, packageFilesDirName, packageName)
+}
+
+func (pm *packageManager) writeState() error {
+	by, err := yaml.Marshal(&pm.state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(pm.dir, packagesStateFileName), by, 0o600)
+}
+
+func (p *packageState) toPackageState() (types.PackageState, error) {
+	hash, err := hex.DecodeString(p.Hash)
+	if err != nil {
+		return types.PackageState{}, err
+	}
+	return types.PackageState{
+		Exists:  true,
+		Type:    p.Type,
+		Hash:    hash,
+		Version: p.Version,
+	}, nil
+}
+
+// validatePackageFileName checks that the name of an addon package can be used as a file name.
+func validatePackageFileName(packageName string) error {
+	if packageName == "" || packageName == "." || packageName == ".." || filepath.Base(packageName) != packageName {
+		return fmt.Errorf("invalid name %q for addon package, must be a valid file name", packageName)
+	}
+	return nil
+}
+
+// writeFile writes data to a file and returns its SHA-256 hash.
+func writeFile(path string, data io.Reader) ([]byte, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(f, h), data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+func copyFile(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = writeFile(dst, f); err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return os.Chmod(dst, info.Mode().Perm())
+}
+
+// loadPublicKey loads a PEM encoded PKIX public key.
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	by, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(by)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// verifySignature verifies the signature of the SHA-256 digest of a package file.
+func verifySignature(publicKey crypto.PublicKey, digest, signature []byte) error {
+	var valid bool
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, digest, signature)
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest, signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	if !valid {
+		return errors.New("invalid package signature")
+	}
+	return nil
+}
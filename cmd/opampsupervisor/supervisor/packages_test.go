@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+func newTestPackageManager(t *testing.T, cfg config.Packages) (*packageManager, string) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "otelcol")
+	require.NoError(t, os.WriteFile(executable, []byte("current executable"), 0o700))
+
+	pm, err := newPackageManager(filepath.Join(dir, "storage"), executable, cfg, zap.NewNop())
+	require.NoError(t, err)
+	return pm, executable
+}
+
+func TestPackageManager_AddonPackage(t *testing.T) {
+	pm, _ := newTestPackageManager(t, config.Packages{})
+
+	content := []byte("geoip database")
+	contentHash := sha256.Sum256(content)
+
+	require.NoError(t, pm.CreatePackage("GeoLite2-City.mmdb", protobufs.PackageType_PackageType_Addon))
+	require.NoError(t, pm.UpdateContent(t.Context(), "GeoLite2-City.mmdb", bytes.NewReader(content), contentHash[:], nil))
+	require.NoError(t, pm.SetPackageState("GeoLite2-City.mmdb", types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte("package-hash"),
+		Version: "2025.1",
+	}))
+
+	installed, err := os.ReadFile(pm.packageFilePath("GeoLite2-City.mmdb"))
+	require.NoError(t, err)
+	assert.Equal(t, content, installed)
+
+	fileHash, err := pm.FileContentHash("GeoLite2-City.mmdb")
+	require.NoError(t, err)
+	assert.Equal(t, contentHash[:], fileHash)
+
+	update, filesChanged := pm.takeChanges()
+	assert.Nil(t, update)
+	assert.True(t, filesChanged)
+
+	// The state is persisted
+	loaded, err := newPackageManager(filepath.Dir(pm.dir), pm.agentExecutable, config.Packages{}, zap.NewNop())
+	require.NoError(t, err)
+	state, err := loaded.PackageState("GeoLite2-City.mmdb")
+	require.NoError(t, err)
+	assert.Equal(t, types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_Addon,
+		Hash:    []byte("package-hash"),
+		Version: "2025.1",
+	}, state)
+
+	require.NoError(t, pm.DeletePackage("GeoLite2-City.mmdb"))
+	assert.NoFileExists(t, pm.packageFilePath("GeoLite2-City.mmdb"))
+	state, err = pm.PackageState("GeoLite2-City.mmdb")
+	require.NoError(t, err)
+	assert.False(t, state.Exists)
+}
+
+func TestPackageManager_InvalidAddonPackageName(t *testing.T) {
+	pm, _ := newTestPackageManager(t, config.Packages{})
+
+	for _, name := range []string{"", ".", "..", "../escape", "dir/file"} {
+		assert.Error(t, pm.CreatePackage(name, protobufs.PackageType_PackageType_Addon), name)
+	}
+}
+
+func TestPackageManager_ContentHashMismatch(t *testing.T) {
+	pm, _ := newTestPackageManager(t, config.Packages{})
+
+	require.NoError(t, pm.CreatePackage("bundle.pem", protobufs.PackageType_PackageType_Addon))
+	err := pm.UpdateContent(t.Context(), "bundle.pem", bytes.NewReader([]byte("tampered")), []byte("expected-hash"), nil)
+	require.ErrorContains(t, err, "content hash mismatch")
+
+	assert.NoFileExists(t, pm.packageFilePath("bundle.pem"))
+	assert.NoFileExists(t, pm.packageFilePath("bundle.pem")+stagedFileSuffix)
+	_, filesChanged := pm.takeChanges()
+	assert.False(t, filesChanged)
+}
+
+func TestPackageManager_Unverified(t *testing.T) {
+	pm, executable := newTestPackageManager(t, config.Packages{})
+
+	require.NoError(t, pm.CreatePackage("bundle.pem", protobufs.PackageType_PackageType_Addon))
+	err := pm.UpdateContent(t.Context(), "bundle.pem", bytes.NewReader([]byte("certificates")), nil, nil)
+	require.ErrorContains(t, err, "neither a content hash nor a verified signature")
+	assert.NoFileExists(t, pm.packageFilePath("bundle.pem"))
+
+	content := []byte("new executable")
+	contentHash := sha256.Sum256(content)
+	require.NoError(t, pm.CreatePackage("otelcol", protobufs.PackageType_PackageType_TopLevel))
+	err = pm.UpdateContent(t.Context(), "otelcol", bytes.NewReader(content), contentHash[:], nil)
+	require.ErrorContains(t, err, "packages::public_key_file is not set")
+	assert.NoFileExists(t, executable+stagedFileSuffix)
+
+	update, _ := pm.takeChanges()
+	assert.Nil(t, update)
+}
+
+func TestPackageManager_Signature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyFile := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	pm, _ := newTestPackageManager(t, config.Packages{PublicKeyFile: publicKeyFile})
+	require.NoError(t, pm.CreatePackage("bundle.pem", protobufs.PackageType_PackageType_Addon))
+
+	content := []byte("certificates")
+	contentHash := sha256.Sum256(content)
+	signature := ed25519.Sign(privateKey, contentHash[:])
+
+	err = pm.UpdateContent(t.Context(), "bundle.pem", bytes.NewReader(content), contentHash[:], nil)
+	require.ErrorContains(t, err, "package file is not signed")
+
+	otherHash := sha256.Sum256([]byte("other certificates"))
+	err = pm.UpdateContent(t.Context(), "bundle.pem", bytes.NewReader(content), contentHash[:], ed25519.Sign(privateKey, otherHash[:]))
+	require.ErrorContains(t, err, "invalid package signature")
+	assert.NoFileExists(t, pm.packageFilePath("bundle.pem"))
+
+	require.NoError(t, pm.UpdateContent(t.Context(), "bundle.pem", bytes.NewReader(content), contentHash[:], signature))
+	assert.FileExists(t, pm.packageFilePath("bundle.pem"))
+}
+
+func TestPackageManager_AgentPackage(t *testing.T) {
+	stage := func(t *testing.T) (*packageManager, string) {
+		pm, executable := newTestPackageManager(t, config.Packages{InsecureAllowUnsignedAgent: true})
+
+		content := []byte("new executable")
+		contentHash := sha256.Sum256(content)
+		require.NoError(t, pm.CreatePackage("otelcol", protobufs.PackageType_PackageType_TopLevel))
+		require.NoError(t, pm.SetAllPackagesHash([]byte("all-hash")))
+		require.NoError(t, pm.UpdateContent(t.Context(), "otelcol", bytes.NewReader(content), contentHash[:], nil))
+		require.NoError(t, pm.SetPackageState("otelcol", types.PackageState{
+			Exists:  true,
+			Type:    protobufs.PackageType_PackageType_TopLevel,
+			Hash:    []byte("new-hash"),
+			Version: "2.0.0",
+		}))
+		return pm, executable
+	}
+
+	setup := func(t *testing.T) (*packageManager, string, *agentUpdate) {
+		pm, executable := stage(t)
+
+		// The executable is staged until it is installed
+		current, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current executable", string(current))
+
+		update, filesChanged := pm.takeChanges()
+		require.NotNil(t, update)
+		assert.False(t, filesChanged)
+		assert.Equal(t, "otelcol", update.PackageName)
+
+		require.NoError(t, pm.installAgentExecutable())
+		installed, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "new executable", string(installed))
+
+		return pm, executable, update
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		pm, executable, _ := setup(t)
+
+		pm.commitAgentExecutable()
+		assert.NoFileExists(t, executable+previousAgentExecutableSuffix)
+
+		state, err := pm.PackageState("otelcol")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", state.Version)
+
+		// The update is complete after a restart
+		loaded, err := newPackageManager(filepath.Dir(pm.dir), executable, config.Packages{}, zap.NewNop())
+		require.NoError(t, err)
+		assert.Nil(t, loaded.state.AgentUpdate)
+		state, err = loaded.PackageState("otelcol")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", state.Version)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		pm, executable, update := setup(t)
+
+		require.NoError(t, pm.restoreAgentExecutable(update))
+		restored, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current executable", string(restored))
+		assert.NoFileExists(t, executable+previousAgentExecutableSuffix)
+
+		state, err := pm.PackageState("otelcol")
+		require.NoError(t, err)
+		assert.Empty(t, state.Hash)
+		assert.Empty(t, state.Version)
+	})
+
+	t.Run("restart before install", func(t *testing.T) {
+		pm, executable := stage(t)
+
+		loaded, err := newPackageManager(filepath.Dir(pm.dir), executable, config.Packages{}, zap.NewNop())
+		require.NoError(t, err)
+		assert.NoFileExists(t, executable+stagedFileSuffix)
+		assert.Nil(t, loaded.state.AgentUpdate)
+
+		current, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current executable", string(current))
+
+		// The package is not managed yet, and the packages are synced again
+		state, err := loaded.PackageState("otelcol")
+		require.NoError(t, err)
+		assert.Empty(t, state.Version)
+		hash, err := loaded.AllPackagesHash()
+		require.NoError(t, err)
+		assert.Empty(t, hash)
+	})
+
+	t.Run("restart before commit", func(t *testing.T) {
+		pm, executable, _ := setup(t)
+
+		loaded, err := newPackageManager(filepath.Dir(pm.dir), executable, config.Packages{}, zap.NewNop())
+		require.NoError(t, err)
+		assert.NoFileExists(t, executable+previousAgentExecutableSuffix)
+		assert.Nil(t, loaded.state.AgentUpdate)
+
+		restored, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "current executable", string(restored))
+
+		state, err := loaded.PackageState("otelcol")
+		require.NoError(t, err)
+		assert.Empty(t, state.Version)
+	})
+
+	t.Run("only one top-level package", func(t *testing.T) {
+		pm, _, _ := setup(t)
+
+		require.Error(t, pm.CreatePackage("other", protobufs.PackageType_PackageType_TopLevel))
+	})
+}
+
+func TestPackageManager_LastReportedStatuses(t *testing.T) {
+	pm, _ := newTestPackageManager(t, config.Packages{})
+
+	statuses, err := pm.LastReportedStatuses()
+	require.NoError(t, err)
+	assert.NotNil(t, statuses)
+
+	require.NoError(t, pm.SetLastReportedStatuses(&protobufs.PackageStatuses{
+		ServerProvidedAllPackagesHash: []byte("all-hash"),
+		Packages: map[string]*protobufs.PackageStatus{
+			"bundle.pem": {
+				Name:   "bundle.pem",
+				Status: protobufs.PackageStatusEnum_PackageStatusEnum_Installed,
+			},
+		},
+	}))
+
+	statuses, err = pm.LastReportedStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("all-hash"), statuses.ServerProvidedAllPackagesHash)
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, statuses.Packages["bundle.pem"].Status)
+}
@@ -18,6 +18,7 @@ import (
 type persistentState struct {
 	InstanceID             uuid.UUID           `yaml:"instance_id"`
 	LastRemoteConfigStatus *RemoteConfigStatus `yaml:"last_remote_config_status"`
+	RolledBackConfig       *RolledBackConfig   `yaml:"rolled_back_config,omitempty"`
 
 	// Path to the config file that the state should be saved to.
 	// This is not marshaled.
@@ -36,6 +37,14 @@ type RemoteConfigStatus struct {
 	ErrorMessage string `yaml:"error_message"`
 }
 
+// RolledBackConfig records a remote config which was rolled back to the last known good config.
+type RolledBackConfig struct {
+	// RemoteConfigHash is a hex encoded string of the hash of the rolled back remote config.
+	RemoteConfigHash string `yaml:"remote_config_hash"`
+	// Reason is the reason why the remote config was rolled back.
+	Reason string `yaml:"reason"`
+}
+
 func (p *persistentState) SetInstanceID(id uuid.UUID) error {
 	p.InstanceID = id
 	return p.writeState()
@@ -66,6 +75,35 @@ func (p *persistentState) GetLastRemoteConfigStatus() *protobufs.RemoteConfigSta
 	}
 }
 
+func (p *persistentState) SetRolledBackConfig(remoteConfigHash []byte, reason string) error {
+	p.RolledBackConfig = &RolledBackConfig{
+		RemoteConfigHash: hex.EncodeToString(remoteConfigHash),
+		Reason:           reason,
+	}
+	return p.writeState()
+}
+
+// ClearRolledBackConfig forgets the rolled back remote config, if any.
+func (p *persistentState) ClearRolledBackConfig() error {
+	if p.RolledBackConfig == nil {
+		return nil
+	}
+	p.RolledBackConfig = nil
+	return p.writeState()
+}
+
+// RolledBackReason returns the reason why the remote config with the given hash was rolled back,
+// and false if it was not.
+func (p *persistentState) RolledBackReason(remoteConfigHash []byte) (string, bool) {
+	if p.RolledBackConfig == nil || len(remoteConfigHash) == 0 {
+		return "", false
+	}
+	if p.RolledBackConfig.RemoteConfigHash != hex.EncodeToString(remoteConfigHash) {
+		return "", false
+	}
+	return p.RolledBackConfig.Reason, true
+}
+
 func (p *persistentState) writeState() error {
 	by, err := yaml.Marshal(p)
 	if err != nil {
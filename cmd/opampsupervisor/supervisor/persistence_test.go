@@ -105,3 +105,34 @@ func TestPersistentState_SetLastRemoteConfigStatus(t *testing.T) {
 	}, loadedState.GetLastRemoteConfigStatus())
 	require.FileExists(t, f)
 }
+
+func TestPersistentState_RolledBackConfig(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "state.yaml")
+	state, err := createNewPersistentState(f, zap.NewNop())
+	require.NoError(t, err)
+
+	_, rolledBack := state.RolledBackReason([]byte("hash"))
+	require.False(t, rolledBack)
+
+	require.NoError(t, state.SetRolledBackConfig([]byte("hash"), "agent is unhealthy"))
+
+	// Test that loading the state after setting the rolled back config has the rolled back config
+	loadedState, err := loadPersistentState(f, zap.NewNop())
+	require.NoError(t, err)
+
+	reason, rolledBack := loadedState.RolledBackReason([]byte("hash"))
+	require.True(t, rolledBack)
+	require.Equal(t, "agent is unhealthy", reason)
+
+	_, rolledBack = loadedState.RolledBackReason([]byte("other-hash"))
+	require.False(t, rolledBack)
+	_, rolledBack = loadedState.RolledBackReason(nil)
+	require.False(t, rolledBack)
+
+	require.NoError(t, loadedState.ClearRolledBackConfig())
+
+	loadedState, err = loadPersistentState(f, zap.NewNop())
+	require.NoError(t, err)
+
+	require.Nil(t, loadedState.RolledBackConfig)
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/tls"
 	_ "embed"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -34,6 +35,7 @@ import (
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/open-telemetry/opamp-go/server"
 	serverTypes "github.com/open-telemetry/opamp-go/server/types"
+	"github.com/pmezard/go-difflib/difflib"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -71,6 +73,7 @@ var (
 
 	lastRecvRemoteConfigFile       = "last_recv_remote_config.dat"
 	lastRecvOwnTelemetryConfigFile = "last_recv_own_telemetry_config.dat"
+	lastKnownGoodRemoteConfigFile  = "last_known_good_remote_config.dat"
 
 	errNonMatchingInstanceUID = errors.New("received collector instance UID does not match expected UID set by the supervisor")
 )
@@ -79,6 +82,10 @@ const (
 	persistentStateFileName     = "persistent_state.yaml"
 	agentConfigFileName         = "effective.yaml"
 	AllowNoPipelinesFeatureGate = "service.AllowNoPipelines"
+
+	// configDriftConfigMapKey is the key of the effective config map entry holding the
+	// difference between the agent's config file and the config composed by the Supervisor.
+	configDriftConfigMapKey = "config_drift.diff"
 )
 
 const maxBufferedCustomMessages = 10
@@ -126,6 +133,10 @@ type Supervisor struct {
 	// Supervisor's persistent state
 	persistentState *persistentState
 
+	// Stores the packages offered by the OpAMP server, nil if packages are not supported.
+	packageManager *packageManager
+	packagesWG     sync.WaitGroup
+
 	noopPipelineTemplate         *template.Template
 	opampextensionTemplate       *template.Template
 	extraTelemetryConfigTemplate *template.Template
@@ -145,6 +156,11 @@ type Supervisor struct {
 	// Final effective config of the Collector.
 	effectiveConfig *atomic.Value
 
+	// The config last written to the agent's config file.
+	writtenAgentConfig atomic.Value
+	// The difference between the agent's config file on disk and the written config.
+	configDrift atomic.Value
+
 	// Last received remote config.
 	remoteConfig *protobufs.AgentRemoteConfig
 
@@ -333,6 +349,13 @@ func (s *Supervisor) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+
+	if s.config.Capabilities.AcceptsPackages || s.config.Capabilities.ReportsPackageStatuses {
+		s.packageManager, err = newPackageManager(s.config.Storage.Directory, s.config.Agent.Executable, s.config.Packages, s.telemetrySettings.Logger)
+		if err != nil {
+			return err
+		}
+	}
 	if err = s.getFeatureGates(); err != nil {
 		return fmt.Errorf("could not get feature gates from the Collector: %w", err)
 	}
@@ -693,6 +716,9 @@ func (s *Supervisor) startOpAMPClient() error {
 			},
 		},
 	}
+	if s.packageManager != nil {
+		settings.PackagesStateProvider = s.packageManager
+	}
 	ad := s.agentDescription.Load().(*protobufs.AgentDescription)
 	if err := s.opampClient.SetAgentDescription(ad); err != nil {
 		return err
@@ -1210,6 +1236,9 @@ type configComposer func() []byte
 
 func (s *Supervisor) composeAgentConfigFiles(incomingConfig *protobufs.AgentRemoteConfig) ([]byte, error) {
 	conf := koanf.New("::")
+	// The local and remote configs are also merged separately to apply the merge policy.
+	local := koanf.New("::")
+	remote := koanf.New("::")
 
 	specialConfigComposers := map[config.SpecialConfigFile][]configComposer{
 		config.SpecialConfigFileOwnTelemetry:   {s.composeOwnTelemetryConfig, s.composeExtraTelemetryConfig},
@@ -1226,6 +1255,10 @@ func (s *Supervisor) composeAgentConfigFiles(incomingConfig *protobufs.AgentRemo
 		// will return an error.
 		// Normal config files with invalid yaml should be just ignored.
 		if strings.HasPrefix(file, "$") {
+			source := local
+			if config.SpecialConfigFile(file) == config.SpecialConfigFileRemoteConfig {
+				source = remote
+			}
 			cfgProviders := specialConfigComposers[config.SpecialConfigFile(file)]
 			for _, cfgProvider := range cfgProviders {
 				cfgBytes := cfgProvider()
@@ -1234,6 +1267,9 @@ func (s *Supervisor) composeAgentConfigFiles(incomingConfig *protobufs.AgentRemo
 					s.telemetrySettings.Logger.Error("Could not merge special config file", zap.String("specialConfig", file), zap.Error(err))
 					return nil, err
 				}
+				if err = source.Load(rawbytes.Provider(cfgBytes), yaml.Parser(), koanf.WithMergeFunc(configMergeFunc)); err != nil {
+					return nil, err
+				}
 			}
 			continue
 		}
@@ -1249,6 +1285,14 @@ func (s *Supervisor) composeAgentConfigFiles(incomingConfig *protobufs.AgentRemo
 			s.telemetrySettings.Logger.Error("Could not merge local config file: "+file, zap.Error(err))
 			continue
 		}
+		if err = local.Load(rawbytes.Provider(cfgBytes), yaml.Parser(), koanf.WithMergeFunc(configMergeFunc)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyConfigMerge(conf, local, remote, s.config.Agent.ConfigMerge, s.telemetrySettings.Logger); err != nil {
+		s.telemetrySettings.Logger.Error("Could not apply the config merge policy", zap.Error(err))
+		return nil, err
 	}
 
 	b, err := conf.Marshal(yaml.Parser())
@@ -1276,8 +1320,7 @@ func (s *Supervisor) loadAndWriteInitialMergedConfig() error {
 	}
 
 	// write the initial merged config to disk
-	cfgState := s.cfgState.Load().(*configState)
-	if err := os.WriteFile(s.agentConfigFilePath(), []byte(cfgState.mergedConfig), 0o600); err != nil {
+	if err := s.writeAgentConfig(); err != nil {
 		s.telemetrySettings.Logger.Error("Failed to write agent config.", zap.Error(err))
 	}
 
@@ -1355,6 +1398,13 @@ func (s *Supervisor) createEffectiveConfigMsg() *protobufs.EffectiveConfig {
 		},
 	}
 
+	if drift, _ := s.configDrift.Load().(string); drift != "" {
+		cfg.ConfigMap.ConfigMap[configDriftConfigMapKey] = &protobufs.AgentConfigFile{
+			Body:        []byte(drift),
+			ContentType: "text/x-diff",
+		}
+	}
+
 	return cfg
 }
 
@@ -1404,6 +1454,18 @@ func (s *Supervisor) setupOwnTelemetry(_ context.Context, settings *protobufs.Co
 // 2) the own metrics config section
 // 3) the local override config that is hard-coded in the Supervisor.
 func (s *Supervisor) composeMergedConfig(incomingConfig *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
+	if _, rolledBack := s.rolledBackReason(incomingConfig); rolledBack {
+		// Keep the last known good remote config until the server provides a different remote config.
+		// It is composed again, as the port of the OpAMP extension and the own telemetry settings
+		// may have changed since it was saved.
+		lastKnownGood, err := s.loadLastKnownGoodRemoteConfig()
+		if err == nil {
+			incomingConfig = lastKnownGood
+		} else {
+			s.telemetrySettings.Logger.Error("Could not read last known good remote config, composing the rolled back config", zap.Error(err))
+		}
+	}
+
 	k := koanf.New("::")
 
 	s.addSpecialConfigFiles()
@@ -1445,15 +1507,18 @@ func (s *Supervisor) composeMergedConfig(incomingConfig *protobufs.AgentRemoteCo
 		configMapIsEmpty: (incomingConfig != nil && !hasIncomingConfigMap),
 	}
 
-	configChanged = false
+	return s.swapConfigState(newConfigState), nil
+}
 
+// swapConfigState replaces the config state, returning true if the config has changed.
+func (s *Supervisor) swapConfigState(newConfigState *configState) (configChanged bool) {
 	oldConfigState := s.cfgState.Swap(newConfigState)
 	if oldConfigState == nil || !oldConfigState.(*configState).equal(newConfigState) {
 		s.telemetrySettings.Logger.Debug("Merged config changed.")
 		configChanged = true
 	}
 
-	return configChanged, nil
+	return configChanged
 }
 
 func (s *Supervisor) handleRestartCommand() error {
@@ -1510,9 +1575,39 @@ func (s *Supervisor) runAgentProcess() {
 	configApplyTimeoutTimer := time.NewTimer(0)
 	configApplyTimeoutTimer.Stop()
 
+	// lastKnownGoodTimer fires once the agent has run the current config for the crash
+	// loop window, after which the config becomes the last known good config.
+	lastKnownGoodTimer := time.NewTimer(0)
+	lastKnownGoodTimer.Stop()
+
+	rollback := s.config.Agent.ConfigRollback
+	// applyingConfig is true until the current config is either rolled back or
+	// becomes the last known good config.
+	applyingConfig := false
+	// agentExits holds the times of the unexpected agent exits while applying the config.
+	var agentExits []time.Time
+
+	// configDriftTicker is only set when the drift checks are enabled.
+	var configDriftTicker <-chan time.Time
+	if drift := s.config.Agent.ConfigDrift; drift.Enabled {
+		ticker := time.NewTicker(drift.Interval)
+		defer ticker.Stop()
+		configDriftTicker = ticker.C
+	}
+
+	if rollback.Enabled && s.commander.IsRunning() {
+		// The initial config is checked as a new config would be, as the Supervisor
+		// may have been restarted before it was confirmed.
+		applyingConfig = true
+		configApplyTimeoutTimer.Reset(s.config.Agent.ConfigApplyTimeout)
+	}
+
 	for {
 		select {
 		case <-s.hasNewConfig:
+			applyingConfig = rollback.Enabled
+			agentExits = nil
+			lastKnownGoodTimer.Stop()
 			s.lastHealthFromClient.Store(nil)
 			s.telemetrySettings.Logger.Debug("agent has new config", zap.String("previous_health", s.lastHealthFromClient.Load().String()))
 			if !configApplyTimeoutTimer.Stop() {
@@ -1548,6 +1643,7 @@ func (s *Supervisor) runAgentProcess() {
 				// not starting agent because of nop config: clear timer, report applied status, report healthy status
 				s.telemetrySettings.Logger.Debug("No config present, nothing to apply")
 				configApplyTimeoutTimer.Stop()
+				applyingConfig = false
 				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
 				if err := s.opampClient.SetHealth(&protobufs.ComponentHealth{Healthy: true, LastError: ""}); err != nil {
 					s.telemetrySettings.Logger.Error("Could not report healthy status to OpAMP server", zap.Error(err))
@@ -1578,8 +1674,22 @@ func (s *Supervisor) runAgentProcess() {
 				s.telemetrySettings.Logger.Error("Could not report health to OpAMP server", zap.Error(err))
 			}
 
-			// TODO: decide why the agent stopped. If it was due to bad config, report it to server.
-			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21079
+			if applyingConfig {
+				now := time.Now()
+				agentExits = append(slices.DeleteFunc(agentExits, func(t time.Time) bool {
+					return now.Sub(t) > rollback.CrashLoopWindow
+				}), now)
+				if len(agentExits) >= rollback.CrashLoopThreshold {
+					reason := fmt.Sprintf("agent exited %d times within %s", len(agentExits), rollback.CrashLoopWindow)
+					if s.rollbackConfig(reason) {
+						applyingConfig = false
+						agentExits = nil
+						configApplyTimeoutTimer.Stop()
+						lastKnownGoodTimer.Stop()
+						continue
+					}
+				}
+			}
 
 			// Wait 5 seconds before starting again.
 			if !restartTimer.Stop() {
@@ -1601,9 +1711,41 @@ func (s *Supervisor) runAgentProcess() {
 		case <-configApplyTimeoutTimer.C:
 			lastHealth := s.lastHealthFromClient.Load()
 			if lastHealth == nil || !lastHealth.Healthy {
+				if applyingConfig {
+					reason := fmt.Sprintf("agent was not healthy after %s", s.config.Agent.ConfigApplyTimeout)
+					if lastHealth.GetLastError() != "" {
+						reason = fmt.Sprintf("%s: %s", reason, lastHealth.GetLastError())
+					}
+					if s.rollbackConfig(reason) {
+						applyingConfig = false
+						agentExits = nil
+						restartTimer.Stop()
+						continue
+					}
+				}
 				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, "Config apply timeout exceeded")
 			} else {
 				s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+				if applyingConfig {
+					lastKnownGoodTimer.Reset(rollback.CrashLoopWindow)
+				}
+			}
+
+		case <-lastKnownGoodTimer.C:
+			lastHealth := s.lastHealthFromClient.Load()
+			recentExit := len(agentExits) > 0 && time.Since(agentExits[len(agentExits)-1]) < rollback.CrashLoopWindow
+			if recentExit || !lastHealth.GetHealthy() {
+				// Wait for the agent to run without exits for a whole window
+				lastKnownGoodTimer.Reset(rollback.CrashLoopWindow)
+				continue
+			}
+			applyingConfig = false
+			agentExits = nil
+			s.saveLastKnownGoodConfig()
+
+		case <-configDriftTicker:
+			if s.checkConfigDrift() && s.config.Agent.ConfigDrift.Reapply {
+				s.reapplyAgentConfig()
 			}
 
 		case <-s.doneChan:
@@ -1714,9 +1856,168 @@ func (s *Supervisor) writeAgentConfig() error {
 	if err := os.WriteFile(s.agentConfigFilePath(), []byte(cfgState.mergedConfig), 0o600); err != nil {
 		return err
 	}
+	s.writtenAgentConfig.Store(cfgState.mergedConfig)
 	return nil
 }
 
+// checkConfigDrift compares the agent's config file with the config last written by the
+// Supervisor, and reports the difference in the effective config. It returns true if
+// the config file has drifted.
+func (s *Supervisor) checkConfigDrift() bool {
+	written, ok := s.writtenAgentConfig.Load().(string)
+	if !ok {
+		// The Supervisor has not written the config file yet
+		return false
+	}
+
+	onDisk, err := os.ReadFile(s.agentConfigFilePath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.telemetrySettings.Logger.Error("Could not read agent config file to check for drift", zap.Error(err))
+		return false
+	}
+
+	var drift string
+	if string(onDisk) != written {
+		drift, err = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(written),
+			B:        difflib.SplitLines(string(onDisk)),
+			FromFile: "supervisor",
+			ToFile:   s.agentConfigFilePath(),
+			Context:  3,
+		})
+		if err != nil {
+			s.telemetrySettings.Logger.Error("Could not compute agent config drift", zap.Error(err))
+			return false
+		}
+	}
+
+	if previous, _ := s.configDrift.Swap(drift).(string); previous != drift {
+		if drift != "" {
+			s.telemetrySettings.Logger.Warn("The agent config file was modified outside of the Supervisor", zap.String("diff", drift))
+		} else {
+			s.telemetrySettings.Logger.Info("The agent config file matches the config written by the Supervisor again")
+		}
+		if err := s.opampClient.UpdateEffectiveConfig(s.runCtx); err != nil {
+			s.telemetrySettings.Logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+		}
+	}
+
+	return drift != ""
+}
+
+// reapplyAgentConfig writes the config composed by the Supervisor over a drifted
+// config file and reloads the agent with it.
+func (s *Supervisor) reapplyAgentConfig() {
+	s.telemetrySettings.Logger.Info("Reapplying the agent config after a drift")
+	if s.config.Agent.UseHUPConfigReload {
+		if err := s.hupReloadAgent(); err != nil {
+			s.telemetrySettings.Logger.Error("Failed to HUP restart agent", zap.Error(err))
+			return
+		}
+	} else {
+		s.stopAgentApplyConfig()
+	}
+
+	if _, err := s.startAgent(); err != nil {
+		s.telemetrySettings.Logger.Error("starting agent with reapplied config failed", zap.Error(err))
+	}
+	s.checkConfigDrift()
+}
+
+// rollbackConfig restarts the agent with the last known good remote config and reports the
+// current remote config as failed. It returns false if there is no last known good
+// remote config to roll back to.
+func (s *Supervisor) rollbackConfig(reason string) bool {
+	lastKnownGood, err := s.loadLastKnownGoodRemoteConfig()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			s.telemetrySettings.Logger.Error("Could not read last known good remote config", zap.Error(err))
+		}
+		s.telemetrySettings.Logger.Warn("No last known good config to roll back to", zap.String("reason", reason))
+		return false
+	}
+	// The agent already runs the last known good remote config if the current remote config
+	// is the last known good one, or if it was rolled back before.
+	_, rolledBack := s.rolledBackReason(s.remoteConfig)
+	if rolledBack || bytes.Equal(s.remoteConfig.GetConfigHash(), lastKnownGood.GetConfigHash()) {
+		s.telemetrySettings.Logger.Warn("The last known good config is failing, not rolling back", zap.String("reason", reason))
+		return false
+	}
+
+	// The last known good remote config is composed with the current port of the OpAMP
+	// extension and own telemetry settings, which may differ from when it was saved.
+	if _, err := s.composeMergedConfig(lastKnownGood); err != nil {
+		s.telemetrySettings.Logger.Error("Could not compose the last known good config, not rolling back", zap.Error(err))
+		return false
+	}
+
+	s.telemetrySettings.Logger.Warn("Rolling back to the last known good config", zap.String("reason", reason))
+	if hash := s.remoteConfig.GetConfigHash(); len(hash) > 0 {
+		if err := s.persistentState.SetRolledBackConfig(hash, reason); err != nil {
+			s.telemetrySettings.Logger.Error("Could not save rolled back remote config", zap.Error(err))
+		}
+	}
+	s.lastHealthFromClient.Store(nil)
+
+	s.stopAgentApplyConfig()
+	if _, err := s.startAgent(); err != nil {
+		s.telemetrySettings.Logger.Error("starting agent with last known good config failed", zap.Error(err))
+	}
+
+	s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, rollbackErrorMessage(reason))
+	if err := s.opampClient.UpdateEffectiveConfig(s.runCtx); err != nil {
+		s.telemetrySettings.Logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+	}
+	return true
+}
+
+// saveLastKnownGoodConfig saves the current remote config as the one to roll back to.
+// The remote config is saved rather than the merged config, as the merged config holds
+// settings of the Supervisor, like the port of the OpAMP extension, which change across
+// restarts. An empty file is saved if there is no remote config.
+func (s *Supervisor) saveLastKnownGoodConfig() {
+	var cfg []byte
+	if s.remoteConfig != nil {
+		var err error
+		if cfg, err = proto.Marshal(s.remoteConfig); err != nil {
+			s.telemetrySettings.Logger.Error("Could not marshal last known good remote config", zap.Error(err))
+			return
+		}
+	}
+	if err := os.WriteFile(s.lastKnownGoodRemoteConfigFilePath(), cfg, 0o600); err != nil {
+		s.telemetrySettings.Logger.Error("Could not save last known good remote config", zap.Error(err))
+		return
+	}
+	s.telemetrySettings.Logger.Debug("Saved last known good remote config")
+}
+
+// loadLastKnownGoodRemoteConfig loads the remote config saved by saveLastKnownGoodConfig.
+// It returns nil if there was no remote config.
+func (s *Supervisor) loadLastKnownGoodRemoteConfig() (*protobufs.AgentRemoteConfig, error) {
+	cfg, err := os.ReadFile(s.lastKnownGoodRemoteConfigFilePath())
+	if err != nil || len(cfg) == 0 {
+		return nil, err
+	}
+	config := &protobufs.AgentRemoteConfig{}
+	if err := proto.Unmarshal(cfg, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// rolledBackReason returns the reason why the given remote config was rolled back,
+// and false if it was not.
+func (s *Supervisor) rolledBackReason(remoteConfig *protobufs.AgentRemoteConfig) (string, bool) {
+	if !s.config.Agent.ConfigRollback.Enabled || s.persistentState == nil {
+		return "", false
+	}
+	return s.persistentState.RolledBackReason(remoteConfig.GetConfigHash())
+}
+
+func rollbackErrorMessage(reason string) string {
+	return fmt.Sprintf("Config rolled back to the last known good config: %s", reason)
+}
+
 func (s *Supervisor) stopAgentApplyConfig() {
 	s.telemetrySettings.Logger.Debug("Stopping the agent to apply new config")
 	err := s.commander.Stop(s.runCtx)
@@ -1736,6 +2037,7 @@ func (s *Supervisor) Shutdown() {
 	close(s.doneChan)
 
 	// Shutdown in order from producer to consumer (agent -> customMessageForwarder -> local OpAMP server -> client to remote OpAMP server).
+	s.packagesWG.Wait()
 	s.agentWG.Wait()
 	s.customMessageWG.Wait()
 
@@ -1887,6 +2189,10 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		configChanged = s.processRemoteConfigMessage(ctx, msg.RemoteConfig) || configChanged
 	}
 
+	if msg.PackageSyncer != nil {
+		s.processPackagesMessage(msg.PackageSyncer)
+	}
+
 	if msg.OwnMetricsConnSettings != nil || msg.OwnTracesConnSettings != nil || msg.OwnLogsConnSettings != nil {
 		configChanged = s.processOwnTelemetryConnSettingsMessage(ctx, &protobufs.ConnectionSettingsOffers{
 			OwnMetrics: msg.OwnMetricsConnSettings,
@@ -1958,6 +2264,13 @@ func (s *Supervisor) processRemoteConfigMessage(ctx context.Context, msg *protob
 	s.remoteConfig = msg
 	s.telemetrySettings.Logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", s.remoteConfig.ConfigHash)))
 
+	reason, rolledBack := s.rolledBackReason(msg)
+	if !rolledBack {
+		if err := s.persistentState.ClearRolledBackConfig(); err != nil {
+			s.telemetrySettings.Logger.Error("Could not clear rolled back remote config", zap.Error(err))
+		}
+	}
+
 	var err error
 	configChanged, err := s.composeMergedConfig(s.remoteConfig)
 	if err != nil {
@@ -1966,10 +2279,15 @@ func (s *Supervisor) processRemoteConfigMessage(ctx context.Context, msg *protob
 		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
 		return false
 	}
-	if configChanged {
+	switch {
+	case rolledBack:
+		// the config was rolled back before, keep reporting it as failed
+		s.telemetrySettings.Logger.Warn("Received remote config which was rolled back, keeping the last known good config", zap.String("reason", reason))
+		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, rollbackErrorMessage(reason))
+	case configChanged:
 		// only report applying if the config has changed and will run agent with new config
 		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, "")
-	} else {
+	default:
 		// if the config has not changed report applied status, we should still report a status to the server in this case
 		s.saveAndReportConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
 	}
@@ -1978,6 +2296,131 @@ func (s *Supervisor) processRemoteConfigMessage(ctx context.Context, msg *protob
 	return configChanged
 }
 
+// processPackagesMessage syncs the packages offered by the server, and installs them once they are synced.
+func (s *Supervisor) processPackagesMessage(syncer types.PackagesSyncer) {
+	if !s.config.Capabilities.AcceptsPackages || s.packageManager == nil {
+		s.telemetrySettings.Logger.Warn("Got packages available message, but the supervisor does not accept packages. Ignoring packages.")
+		return
+	}
+
+	if err := syncer.Sync(s.runCtx); err != nil {
+		s.telemetrySettings.Logger.Error("Could not sync packages", zap.Error(err))
+		return
+	}
+
+	s.packagesWG.Add(1)
+	go func() {
+		defer s.packagesWG.Done()
+		select {
+		case <-syncer.Done():
+			s.installPackages()
+		case <-s.doneChan:
+		}
+	}()
+}
+
+// installPackages restarts the agent if its executable or the files of its packages have changed.
+func (s *Supervisor) installPackages() {
+	update, filesChanged := s.packageManager.takeChanges()
+	if update != nil {
+		// Restarting the agent with the new executable also reloads the files.
+		s.installAgentPackage(update)
+		return
+	}
+
+	if filesChanged && s.commander.IsRunning() {
+		s.telemetrySettings.Logger.Info("Package files changed, restarting agent")
+		s.agentRestarting.Store(true)
+		defer s.agentRestarting.Store(false)
+		if err := s.commander.Restart(s.runCtx); err != nil {
+			s.telemetrySettings.Logger.Error("Could not restart agent process", zap.Error(err))
+		}
+		s.resetAgentReady()
+	}
+}
+
+// installAgentPackage replaces the agent executable with the one of the package and restarts
+// the agent. The previous executable is restored if the agent does not start with the new one.
+func (s *Supervisor) installAgentPackage(update *agentUpdate) {
+	s.agentRestarting.Store(true)
+	defer s.agentRestarting.Store(false)
+
+	wasRunning := s.commander.IsRunning()
+	if err := s.commander.Stop(s.runCtx); err != nil {
+		s.telemetrySettings.Logger.Error("Could not stop agent process", zap.Error(err))
+	}
+	s.resetAgentReady()
+
+	err := s.packageManager.installAgentExecutable()
+	if err == nil && wasRunning {
+		err = s.startAgentAndWaitReady()
+	}
+	if err == nil {
+		s.packageManager.commitAgentExecutable()
+		s.telemetrySettings.Logger.Info("Installed new agent executable", zap.String("package", update.PackageName))
+		return
+	}
+
+	s.telemetrySettings.Logger.Error("Could not install new agent executable, restoring the previous one", zap.String("package", update.PackageName), zap.Error(err))
+	if err := s.commander.Stop(s.runCtx); err != nil {
+		s.telemetrySettings.Logger.Error("Could not stop agent process", zap.Error(err))
+	}
+	s.resetAgentReady()
+	if err := s.packageManager.restoreAgentExecutable(update); err != nil {
+		s.telemetrySettings.Logger.Error("Could not restore the previous agent executable", zap.Error(err))
+	}
+	if wasRunning {
+		if _, err := s.startAgent(); err != nil {
+			s.telemetrySettings.Logger.Error("Starting agent with the previous executable failed", zap.Error(err))
+		}
+	}
+	s.reportPackageInstallFailed(update, err)
+}
+
+func (s *Supervisor) startAgentAndWaitReady() error {
+	status, err := s.startAgent()
+	if err != nil || status == agentNotStarting {
+		return err
+	}
+	return s.waitForAgentReady()
+}
+
+// reportPackageInstallFailed reports the package of the agent as failed to install,
+// as the OpAMP client reported it as installed once its file was downloaded.
+func (s *Supervisor) reportPackageInstallFailed(update *agentUpdate, installErr error) {
+	statuses, err := s.packageManager.LastReportedStatuses()
+	if err != nil {
+		s.telemetrySettings.Logger.Error("Could not load last reported package statuses", zap.Error(err))
+		return
+	}
+	if statuses.Packages == nil {
+		statuses.Packages = map[string]*protobufs.PackageStatus{}
+	}
+	status, ok := statuses.Packages[update.PackageName]
+	if !ok {
+		status = &protobufs.PackageStatus{Name: update.PackageName}
+		statuses.Packages[update.PackageName] = status
+	}
+	status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+	status.ErrorMessage = fmt.Sprintf("agent failed to start with the new executable, the previous executable was restored: %s", installErr)
+	status.AgentHasVersion = ""
+	status.AgentHasHash = nil
+	if update.Previous != nil {
+		status.AgentHasVersion = update.Previous.Version
+		status.AgentHasHash, _ = hex.DecodeString(update.Previous.Hash)
+	}
+
+	if err := s.packageManager.SetLastReportedStatuses(statuses); err != nil {
+		s.telemetrySettings.Logger.Error("Could not save last reported package statuses", zap.Error(err))
+	}
+	if !s.config.Capabilities.ReportsPackageStatuses {
+		return
+	}
+	if err := s.opampClient.SetPackageStatuses(statuses); err != nil {
+		s.telemetrySettings.Logger.Error("Could not report package statuses to OpAMP server", zap.Error(err))
+	}
+}
+
 // processOwnTelemetryConnSettingsMessage processes a TelemetryConnectionSettings message, returning true if the agent config has changed.
 func (s *Supervisor) processOwnTelemetryConnSettingsMessage(ctx context.Context, msg *protobufs.ConnectionSettingsOffers) bool {
 	if err := s.saveLastReceivedOwnTelemetrySettings(msg, lastRecvOwnTelemetryConfigFile); err != nil {
@@ -2026,6 +2469,10 @@ func (s *Supervisor) agentConfigFilePath() string {
 	return filepath.Join(s.config.Storage.Directory, agentConfigFileName)
 }
 
+func (s *Supervisor) lastKnownGoodRemoteConfigFilePath() string {
+	return filepath.Join(s.config.Storage.Directory, lastKnownGoodRemoteConfigFile)
+}
+
 func (s *Supervisor) getSupervisorOpAMPServerPort() (int, error) {
 	if s.config.Agent.OpAMPServerPort != 0 {
 		return s.config.Agent.OpAMPServerPort, nil
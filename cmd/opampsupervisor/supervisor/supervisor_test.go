@@ -2218,3 +2218,220 @@ func TestSupervisor_HealthCheckServer(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSupervisor_configRollback(t *testing.T) {
+	lastKnownGoodConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {
+					Body: []byte("receivers:\n  nop/good:\n"),
+				},
+			},
+		},
+		ConfigHash: []byte("good-hash"),
+	}
+
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {
+					Body: []byte("receivers:\n  debug/remote:\n"),
+				},
+			},
+		},
+		ConfigHash: []byte("bad-hash"),
+	}
+
+	newSupervisor := func(t *testing.T, mc *mockOpAMPClient) *Supervisor {
+		storageDir := t.TempDir()
+		state, err := createNewPersistentState(filepath.Join(storageDir, persistentStateFileName), zap.NewNop())
+		require.NoError(t, err)
+
+		s := &Supervisor{
+			telemetrySettings: newNopTelemetrySettings(),
+			pidProvider:       staticPIDProvider(88888),
+			config: config.Supervisor{
+				Capabilities: config.Capabilities{AcceptsRemoteConfig: true},
+				Storage: config.Storage{
+					Directory: storageDir,
+				},
+				Agent: config.Agent{
+					ConfigRollback: config.ConfigRollback{
+						Enabled:            true,
+						CrashLoopThreshold: 3,
+						CrashLoopWindow:    time.Minute,
+					},
+				},
+			},
+			hasNewConfig:                   make(chan struct{}, 1),
+			persistentState:                state,
+			agentConfigOwnTelemetrySection: &atomic.Value{},
+			effectiveConfig:                &atomic.Value{},
+			opampClient:                    mc,
+			agentDescription:               &atomic.Value{},
+			cfgState:                       &atomic.Value{},
+			customMessageToServer:          make(chan *protobufs.CustomMessage, 10),
+			doneChan:                       make(chan struct{}),
+			opampServerPort:                4321,
+		}
+		require.NoError(t, s.createTemplates())
+		s.agentDescription.Store(&protobufs.AgentDescription{})
+		return s
+	}
+
+	saveLastKnownGoodConfig := func(t *testing.T, s *Supervisor) {
+		s.remoteConfig = lastKnownGoodConfig
+		s.saveLastKnownGoodConfig()
+		s.remoteConfig = nil
+		_, err := os.Stat(s.lastKnownGoodRemoteConfigFilePath())
+		require.NoError(t, err)
+	}
+
+	t.Run("Rolled back remote config keeps the last known good config", func(t *testing.T) {
+		var lastStatus *protobufs.RemoteConfigStatus
+		mc := &mockOpAMPClient{
+			setRemoteConfigStatusFunc: func(rcs *protobufs.RemoteConfigStatus) error {
+				lastStatus = rcs
+				return nil
+			},
+			updateEffectiveConfigFunc: func(context.Context) error {
+				return nil
+			},
+		}
+		s := newSupervisor(t, mc)
+		saveLastKnownGoodConfig(t, s)
+		require.NoError(t, s.persistentState.SetRolledBackConfig(remoteConfig.ConfigHash, "agent exited 3 times within 1m0s"))
+
+		s.onMessage(t.Context(), &types.MessageData{
+			RemoteConfig: remoteConfig,
+		})
+
+		mergedConfig := s.cfgState.Load().(*configState).mergedConfig
+		assert.Contains(t, mergedConfig, "nop/good")
+		assert.NotContains(t, mergedConfig, "debug/remote")
+		require.NotNil(t, lastStatus)
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, lastStatus.Status)
+		assert.Equal(t, remoteConfig.ConfigHash, lastStatus.LastRemoteConfigHash)
+		assert.Equal(t, "Config rolled back to the last known good config: agent exited 3 times within 1m0s", lastStatus.ErrorMessage)
+	})
+
+	t.Run("New remote config clears the rolled back config", func(t *testing.T) {
+		var lastStatus *protobufs.RemoteConfigStatus
+		mc := &mockOpAMPClient{
+			setRemoteConfigStatusFunc: func(rcs *protobufs.RemoteConfigStatus) error {
+				lastStatus = rcs
+				return nil
+			},
+			updateEffectiveConfigFunc: func(context.Context) error {
+				return nil
+			},
+		}
+		s := newSupervisor(t, mc)
+		saveLastKnownGoodConfig(t, s)
+		require.NoError(t, s.persistentState.SetRolledBackConfig([]byte("other-hash"), "agent exited 3 times within 1m0s"))
+
+		s.onMessage(t.Context(), &types.MessageData{
+			RemoteConfig: remoteConfig,
+		})
+
+		assert.Contains(t, s.cfgState.Load().(*configState).mergedConfig, "debug/remote")
+		assert.Nil(t, s.persistentState.RolledBackConfig)
+		require.NotNil(t, lastStatus)
+		assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, lastStatus.Status)
+	})
+
+	t.Run("No rollback without a different last known good config", func(t *testing.T) {
+		s := newSupervisor(t, &mockOpAMPClient{})
+		s.remoteConfig = lastKnownGoodConfig
+
+		assert.False(t, s.rollbackConfig("agent is unhealthy"))
+
+		s.saveLastKnownGoodConfig()
+		assert.False(t, s.rollbackConfig("agent is unhealthy"))
+		assert.Nil(t, s.persistentState.RolledBackConfig)
+
+		// The last known good config already runs in place of a rolled back remote config.
+		s.remoteConfig = remoteConfig
+		require.NoError(t, s.persistentState.SetRolledBackConfig(remoteConfig.ConfigHash, "agent is unhealthy"))
+		assert.False(t, s.rollbackConfig("agent is unhealthy"))
+	})
+
+	t.Run("Last known good config is saved", func(t *testing.T) {
+		s := newSupervisor(t, &mockOpAMPClient{})
+		saveLastKnownGoodConfig(t, s)
+
+		loaded, err := s.loadLastKnownGoodRemoteConfig()
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(lastKnownGoodConfig, loaded))
+
+		// No remote config is saved as an empty file.
+		s.saveLastKnownGoodConfig()
+		loaded, err = s.loadLastKnownGoodRemoteConfig()
+		require.NoError(t, err)
+		assert.Nil(t, loaded)
+	})
+
+	t.Run("Last known good config is composed with the current port", func(t *testing.T) {
+		s := newSupervisor(t, &mockOpAMPClient{})
+		saveLastKnownGoodConfig(t, s)
+		require.NoError(t, s.persistentState.SetRolledBackConfig(remoteConfig.ConfigHash, "agent is unhealthy"))
+
+		// The Supervisor listens on a new port after a restart.
+		s.opampServerPort = 5678
+		_, err := s.composeMergedConfig(remoteConfig)
+		require.NoError(t, err)
+
+		mergedConfig := s.cfgState.Load().(*configState).mergedConfig
+		assert.Contains(t, mergedConfig, "nop/good")
+		assert.Contains(t, mergedConfig, "ws://127.0.0.1:5678/v1/opamp")
+	})
+}
+
+func TestSupervisor_configDrift(t *testing.T) {
+	const composedConfig = "receivers:\n    nop: null\n"
+
+	effectiveConfigUpdates := 0
+	mc := &mockOpAMPClient{
+		updateEffectiveConfigFunc: func(context.Context) error {
+			effectiveConfigUpdates++
+			return nil
+		},
+	}
+	s := &Supervisor{
+		telemetrySettings: newNopTelemetrySettings(),
+		config: config.Supervisor{
+			Storage: config.Storage{
+				Directory: t.TempDir(),
+			},
+		},
+		opampClient:     mc,
+		effectiveConfig: &atomic.Value{},
+		cfgState:        &atomic.Value{},
+	}
+	s.cfgState.Store(&configState{mergedConfig: composedConfig})
+
+	// No drift is reported before the config file is written
+	assert.False(t, s.checkConfigDrift())
+
+	require.NoError(t, s.writeAgentConfig())
+	assert.False(t, s.checkConfigDrift())
+	assert.NotContains(t, s.createEffectiveConfigMsg().ConfigMap.ConfigMap, configDriftConfigMapKey)
+	assert.Equal(t, 0, effectiveConfigUpdates)
+
+	require.NoError(t, os.WriteFile(s.agentConfigFilePath(), []byte("receivers:\n    nop: null\n    debug: null\n"), 0o600))
+	assert.True(t, s.checkConfigDrift())
+	drift := s.createEffectiveConfigMsg().ConfigMap.ConfigMap[configDriftConfigMapKey]
+	require.NotNil(t, drift)
+	assert.Equal(t, "text/x-diff", drift.ContentType)
+	assert.Contains(t, string(drift.Body), "+    debug: null\n")
+	assert.Equal(t, 1, effectiveConfigUpdates)
+
+	// The effective config is only updated when the drift changes
+	assert.True(t, s.checkConfigDrift())
+	assert.Equal(t, 1, effectiveConfigUpdates)
+
+	require.NoError(t, s.writeAgentConfig())
+	assert.False(t, s.checkConfigDrift())
+	assert.NotContains(t, s.createEffectiveConfigMsg().ConfigMap.ConfigMap, configDriftConfigMapKey)
+	assert.Equal(t, 2, effectiveConfigUpdates)
+}
@@ -7,12 +7,18 @@ import (
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
 var (
 	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
 	errInvalidRetryIntervals = errors.New("Retry interval must be positive")
+	errInvalidErrorRate      = errors.New("Error rate threshold must be between 0 and 1")
+	errInvalidHealthWindow   = errors.New("Health window must be positive")
+	errInvalidOpenDuration   = errors.New("Open duration must be positive")
+	errInvalidHalfOpenProbes = errors.New("Half open probes must be at least 1")
+	errInvalidMinRequests    = errors.New("Min requests must not be negative")
 )
 
 type Config struct {
@@ -33,6 +39,40 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"` // **Deprecated**
+
+	// Health configures failover driven by a circuit breaker per priority level instead of the retry_interval
+	Health HealthConfig `mapstructure:"health"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+type HealthConfig struct {
+	// Enabled replaces the error based failover and the periodic retries with a circuit breaker per priority level
+	Enabled bool `mapstructure:"enabled"`
+
+	// StatusSource is the ID of an extension aggregating component status events, such as healthcheckv2. When set,
+	// a priority level is considered unhealthy as long as one of the exporters of its pipelines reports an error status
+	StatusSource *component.ID `mapstructure:"status_source"`
+
+	// ErrorRateThreshold is the ratio of failed requests over the Window at which the circuit breaker of a level opens,
+	// 0 disables the error rate check
+	ErrorRateThreshold float64 `mapstructure:"error_rate_threshold"`
+
+	// LatencyThreshold is the average latency over the Window at which the circuit breaker of a level opens,
+	// 0 disables the latency check
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// Window is the rolling window used to compute the error rate and the average latency of a level
+	Window time.Duration `mapstructure:"window"`
+
+	// MinRequests is the minimum number of requests in the Window before the thresholds are evaluated
+	MinRequests int `mapstructure:"min_requests"`
+
+	// OpenDuration is how long the circuit breaker of a level stays open before probing the level again
+	OpenDuration time.Duration `mapstructure:"open_duration"`
+
+	// HalfOpenProbes is the number of successful probe requests required to close the circuit breaker of a level
+	HalfOpenProbes int `mapstructure:"half_open_probes"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -47,3 +87,25 @@ func (c *Config) Validate() error {
 	}
 	return nil
 }
+
+func (c *HealthConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ErrorRateThreshold < 0 || c.ErrorRateThreshold > 1 {
+		return errInvalidErrorRate
+	}
+	if c.Window <= 0 {
+		return errInvalidHealthWindow
+	}
+	if c.OpenDuration <= 0 {
+		return errInvalidOpenDuration
+	}
+	if c.HalfOpenProbes < 1 {
+		return errInvalidHalfOpenProbes
+	}
+	if c.MinRequests < 0 {
+		return errInvalidMinRequests
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 )
 
 func TestLoadConfig(t *testing.T) {
+	healthcheckID := component.MustNewID("healthcheckv2")
 	testcases := []struct {
 		id       component.ID
 		expected *Config
@@ -32,6 +33,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				RetryInterval: 10 * time.Minute,
+				Health:        createDefaultConfig().(*Config).Health,
 			},
 		},
 		{
@@ -53,6 +55,31 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				RetryInterval: 5 * time.Minute,
+				Health:        createDefaultConfig().(*Config).Health,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "health"),
+			expected: &Config{
+				PipelinePriority: [][]pipeline.ID{
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "first"),
+					},
+					{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "second"),
+					},
+				},
+				RetryInterval: 10 * time.Minute,
+				Health: HealthConfig{
+					Enabled:            true,
+					StatusSource:       &healthcheckID,
+					ErrorRateThreshold: 0.2,
+					LatencyThreshold:   2 * time.Second,
+					Window:             30 * time.Second,
+					MinRequests:        5,
+					OpenDuration:       time.Minute,
+					HalfOpenProbes:     3,
+				},
 			},
 		},
 	}
@@ -91,6 +118,21 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "invalid health error_rate_threshold",
+			id:   component.NewIDWithName(metadata.Type, "invalid_error_rate"),
+			err:  errInvalidErrorRate,
+		},
+		{
+			name: "invalid health half_open_probes",
+			id:   component.NewIDWithName(metadata.Type, "invalid_half_open_probes"),
+			err:  errInvalidHalfOpenProbes,
+		},
+		{
+			name: "invalid health min_requests",
+			id:   component.NewIDWithName(metadata.Type, "invalid_min_requests"),
+			err:  errInvalidMinRequests,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -29,6 +29,13 @@ func createDefaultConfig() component.Config {
 		RetryInterval: 10 * time.Minute,
 		RetryGap:      0,
 		MaxRetries:    0,
+		Health: HealthConfig{
+			ErrorRateThreshold: 0.5,
+			Window:             time.Minute,
+			MinRequests:        10,
+			OpenDuration:       30 * time.Second,
+			HalfOpenProbes:     1,
+		},
 	}
 }
 
@@ -4,8 +4,11 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
@@ -23,6 +26,7 @@ type baseFailoverRouter[C any] struct {
 	cfg       *Config
 	pS        *state.PipelineSelector
 	consumers []C
+	health    *healthMonitor
 
 	errTryLock  *state.TryLock
 	notifyRetry chan struct{}
@@ -49,7 +53,36 @@ func (f *baseFailoverRouter[C]) reportConsumerError(idx int) {
 	f.errTryLock.TryExecute(f.pS.HandleError, idx)
 }
 
+// consumeByCircuitBreakers consumes the data by the highest priority level whose circuit breaker allows it,
+// falling back to the next levels in case of an error
+func (f *baseFailoverRouter[C]) consumeByCircuitBreakers(consume func(C) error) error {
+	for idx, c := range f.consumers {
+		breaker := f.health.breakers[idx]
+		if !breaker.Allow() {
+			continue
+		}
+
+		start := time.Now()
+		err := consume(c)
+		breaker.Record(err, time.Since(start))
+		if err == nil {
+			return nil
+		}
+	}
+	return errNoValidPipeline
+}
+
+func (f *baseFailoverRouter[C]) Start(_ context.Context, host component.Host) error {
+	if f.health != nil {
+		return f.health.start(host, f.done)
+	}
+	return nil
+}
+
 func (f *baseFailoverRouter[C]) Shutdown() {
+	if f.health != nil {
+		f.health.shutdown()
+	}
 	close(f.done)
 }
 
@@ -71,11 +104,17 @@ func newBaseFailoverRouter[C any](provider consumerProvider[C], cfg *Config) (*b
 		consumers = append(consumers, baseConsumer)
 	}
 
+	var health *healthMonitor
+	if cfg.Health.Enabled {
+		health = newHealthMonitor(cfg)
+	}
+
 	selector := state.NewPipelineSelector(notifyRetry, done, pSConstants)
 	return &baseFailoverRouter[C]{
 		consumers:   consumers,
 		cfg:         cfg,
 		pS:          selector,
+		health:      health,
 		errTryLock:  state.NewTryLock(),
 		done:        done,
 		notifyRetry: notifyRetry,
@@ -98,3 +137,7 @@ func (f *baseFailoverRouter[C]) TestSetStableConsumerIndex(idx int) {
 func (f *baseFailoverRouter[C]) TestGetConsumerAtIndex(idx int) C {
 	return f.consumers[idx]
 }
+
+func (f *baseFailoverRouter[C]) TestGetBreakerState(idx int) state.BreakerState {
+	return f.health.breakers[idx].State()
+}
@@ -3,8 +3,10 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/connector/failo
 go 1.24.0
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status v0.134.0
 	github.com/stretchr/testify v1.11.1
 	go.opentelemetry.io/collector/component v1.40.1-0.20250908133507-3166bac6544f
+	go.opentelemetry.io/collector/component/componentstatus v0.134.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/component/componenttest v0.134.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/confmap v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/confmap/xconfmap v0.134.1-0.20250908133507-3166bac6544f
@@ -61,3 +63,5 @@ require (
 	google.golang.org/protobuf v1.36.8 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status => ../../pkg/status
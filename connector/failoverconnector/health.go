@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+const exporterKeyPrefix = "exporter:"
+
+// statusSubscriber is implemented by extensions aggregating component status events, such as healthcheckv2
+type statusSubscriber interface {
+	Subscribe(scope status.Scope, verbosity status.Verbosity) (<-chan *status.AggregateStatus, status.UnsubscribeFunc)
+}
+
+// healthMonitor holds the circuit breaker of each priority level and feeds them with the component
+// status of the exporters of their pipelines
+type healthMonitor struct {
+	cfg      *Config
+	breakers []*state.CircuitBreaker
+
+	lock               sync.Mutex
+	unhealthyPipelines map[pipeline.ID]bool
+	unsubscribeFuncs   []status.UnsubscribeFunc
+}
+
+func newHealthMonitor(cfg *Config) *healthMonitor {
+	settings := state.BreakerSettings{
+		ErrorRateThreshold: cfg.Health.ErrorRateThreshold,
+		LatencyThreshold:   cfg.Health.LatencyThreshold,
+		Window:             cfg.Health.Window,
+		MinRequests:        cfg.Health.MinRequests,
+		OpenDuration:       cfg.Health.OpenDuration,
+		HalfOpenProbes:     cfg.Health.HalfOpenProbes,
+	}
+
+	breakers := make([]*state.CircuitBreaker, 0, len(cfg.PipelinePriority))
+	for range cfg.PipelinePriority {
+		breakers = append(breakers, state.NewCircuitBreaker(settings))
+	}
+
+	return &healthMonitor{
+		cfg:                cfg,
+		breakers:           breakers,
+		unhealthyPipelines: make(map[pipeline.ID]bool),
+	}
+}
+
+// start subscribes to the status of every pipeline in the priority list if a status source is configured
+func (h *healthMonitor) start(host component.Host, done <-chan struct{}) error {
+	if h.cfg.Health.StatusSource == nil {
+		return nil
+	}
+
+	ext, ok := host.GetExtensions()[*h.cfg.Health.StatusSource]
+	if !ok {
+		return fmt.Errorf("status source extension '%s' not found", h.cfg.Health.StatusSource)
+	}
+
+	subscriber, ok := ext.(statusSubscriber)
+	if !ok {
+		return fmt.Errorf("extension '%s' does not provide component status", h.cfg.Health.StatusSource)
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, pipelines := range h.cfg.PipelinePriority {
+		for _, id := range pipelines {
+			statusCh, unsubscribe := subscriber.Subscribe(status.Scope(id.String()), status.Verbose)
+			h.unsubscribeFuncs = append(h.unsubscribeFuncs, unsubscribe)
+			go h.watch(id, statusCh, done)
+		}
+	}
+	return nil
+}
+
+func (h *healthMonitor) watch(id pipeline.ID, statusCh <-chan *status.AggregateStatus, done <-chan struct{}) {
+	for {
+		select {
+		case st, ok := <-statusCh:
+			if !ok {
+				return
+			}
+			// An initial nil is sent for pipelines that have not reported yet
+			if st == nil {
+				continue
+			}
+			h.setPipelineHealthy(id, exportersHealthy(st))
+		case <-done:
+			return
+		}
+	}
+}
+
+// setPipelineHealthy updates the status health of every level the pipeline belongs to, a level
+// is healthy if all of its pipelines are healthy
+func (h *healthMonitor) setPipelineHealthy(id pipeline.ID, healthy bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.unhealthyPipelines[id] = !healthy
+	for idx, pipelines := range h.cfg.PipelinePriority {
+		levelHealthy := true
+		found := false
+		for _, pl := range pipelines {
+			if pl == id {
+				found = true
+			}
+			if h.unhealthyPipelines[pl] {
+				levelHealthy = false
+			}
+		}
+		if found {
+			h.breakers[idx].SetStatusHealthy(levelHealthy)
+		}
+	}
+}
+
+func (h *healthMonitor) shutdown() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, unsubscribe := range h.unsubscribeFuncs {
+		unsubscribe()
+	}
+	h.unsubscribeFuncs = nil
+}
+
+// exportersHealthy reports whether none of the exporters of a pipeline is in an error status
+func exportersHealthy(st *status.AggregateStatus) bool {
+	for key, cs := range st.ComponentStatusMap {
+		if !strings.HasPrefix(key, exporterKeyPrefix) || cs.Event == nil {
+			continue
+		}
+		switch cs.Status() {
+		case componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+			return false
+		}
+	}
+	return true
+}
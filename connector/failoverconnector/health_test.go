@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+var statusSourceID = component.MustNewID("healthcheckv2")
+
+type fakeStatusSource struct {
+	component.StartFunc
+	component.ShutdownFunc
+	statusChs map[status.Scope]chan *status.AggregateStatus
+}
+
+func (f *fakeStatusSource) Subscribe(scope status.Scope, _ status.Verbosity) (<-chan *status.AggregateStatus, status.UnsubscribeFunc) {
+	statusCh := make(chan *status.AggregateStatus, 1)
+	statusCh <- nil
+	f.statusChs[scope] = statusCh
+	return statusCh, func() {}
+}
+
+type statusHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *statusHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func pipelineStatus(exporterStatus *componentstatus.Event) *status.AggregateStatus {
+	return &status.AggregateStatus{
+		Event: exporterStatus,
+		ComponentStatusMap: map[string]*status.AggregateStatus{
+			"receiver:failover": {Event: componentstatus.NewEvent(componentstatus.StatusOK)},
+			"exporter:otlp":     {Event: exporterStatus},
+		},
+	}
+}
+
+func newHealthTestConfig(tracesFirst, tracesSecond pipeline.ID) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PipelinePriority = [][]pipeline.ID{{tracesFirst}, {tracesSecond}}
+	cfg.Health.Enabled = true
+	cfg.Health.MinRequests = 1
+	cfg.Health.OpenDuration = time.Hour
+	return cfg
+}
+
+func TestTracesFailoverOnComponentStatus(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := newHealthTestConfig(tracesFirst, tracesSecond)
+	cfg.Health.StatusSource = &statusSourceID
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	source := &fakeStatusSource{statusChs: make(map[status.Scope]chan *status.AggregateStatus)}
+	host := &statusHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{statusSourceID: source},
+	}
+	require.NoError(t, conn.Start(t.Context(), host))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	failoverConnector := conn.(*tracesFailover)
+	tr := sampleTrace()
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Len(t, sinkFirst.AllTraces(), 1)
+
+	// The exporter of the first pipeline reports an error, even though consuming does not fail
+	source.statusChs[status.Scope(tracesFirst.String())] <- pipelineStatus(componentstatus.NewRecoverableErrorEvent(errTracesConsumer))
+	require.Eventually(t, func() bool {
+		return failoverConnector.failover.TestGetBreakerState(0) == state.BreakerOpen
+	}, 3*time.Second, 5*time.Millisecond)
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Len(t, sinkFirst.AllTraces(), 1)
+	require.Len(t, sinkSecond.AllTraces(), 1)
+
+	// The exporter recovers, the first level is probed and closed again
+	source.statusChs[status.Scope(tracesFirst.String())] <- pipelineStatus(componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Eventually(t, func() bool {
+		return failoverConnector.failover.TestGetBreakerState(0) == state.BreakerHalfOpen
+	}, 3*time.Second, 5*time.Millisecond)
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Len(t, sinkFirst.AllTraces(), 2)
+	require.Equal(t, state.BreakerClosed, failoverConnector.failover.TestGetBreakerState(0))
+}
+
+func TestTracesFailoverOnErrorRate(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := newHealthTestConfig(tracesFirst, tracesSecond)
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	failoverConnector := conn.(*tracesFailover)
+	failoverConnector.failover.ModifyConsumerAtIndex(0, consumertest.NewErr(errTracesConsumer))
+	tr := sampleTrace()
+
+	// The failed request falls back to the second level and opens the breaker of the first one
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Len(t, sinkSecond.AllTraces(), 1)
+	require.Equal(t, state.BreakerOpen, failoverConnector.failover.TestGetBreakerState(0))
+
+	// The first level is skipped while its breaker is open
+	failoverConnector.failover.ModifyConsumerAtIndex(0, &sinkFirst)
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+	require.Empty(t, sinkFirst.AllTraces())
+	require.Len(t, sinkSecond.AllTraces(), 2)
+}
+
+func TestStartWithMissingStatusSource(t *testing.T) {
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := newHealthTestConfig(tracesFirst, tracesSecond)
+	cfg.Health.StatusSource = &statusSourceID
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  consumertest.NewNop(),
+		tracesSecond: consumertest.NewNop(),
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	require.ErrorContains(t, conn.Start(t.Context(), componenttest.NewNopHost()), "status source extension 'healthcheckv2' not found")
+	require.NoError(t, conn.Shutdown(t.Context()))
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
+
+import (
+	"sync"
+	"time"
+)
+
+// numWindowBuckets is the number of buckets the rolling window is divided into
+const numWindowBuckets = 10
+
+type BreakerState int
+
+const (
+	// BreakerClosed lets all requests through to the level
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects all requests to the level
+	BreakerOpen
+	// BreakerHalfOpen lets a limited number of probe requests through to the level
+	BreakerHalfOpen
+)
+
+type BreakerSettings struct {
+	ErrorRateThreshold float64
+	LatencyThreshold   time.Duration
+	Window             time.Duration
+	MinRequests        int
+	OpenDuration       time.Duration
+	HalfOpenProbes     int
+}
+
+type windowBucket struct {
+	start    time.Time
+	requests int
+	failures int
+	latency  time.Duration
+}
+
+// CircuitBreaker tracks the health of a single priority level. The breaker opens when the error rate
+// or the average latency over a rolling window exceed the configured thresholds, or when the level is
+// reported unhealthy by its exporters. After OpenDuration it lets HalfOpenProbes probe requests through,
+// closing again once they all succeed.
+type CircuitBreaker struct {
+	settings BreakerSettings
+	now      func() time.Time
+
+	lock            sync.Mutex
+	state           BreakerState
+	openedAt        time.Time
+	statusUnhealthy bool
+	probesInFlight  int
+	probeSuccesses  int
+	buckets         [numWindowBuckets]windowBucket
+}
+
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	return &CircuitBreaker{
+		settings: settings,
+		now:      time.Now,
+	}
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() BreakerState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.maybeHalfOpen()
+	return b.state
+}
+
+// Allow reports whether a request can be sent to the level. In the half-open state, a true return value
+// reserves a probe slot that is released by the following call to Record.
+func (b *CircuitBreaker) Allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.statusUnhealthy {
+		return false
+	}
+
+	b.maybeHalfOpen()
+	switch b.state {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if b.probesInFlight < b.settings.HalfOpenProbes {
+			b.probesInFlight++
+			return true
+		}
+	}
+	return false
+}
+
+// Record reports the outcome of a request previously allowed by Allow
+func (b *CircuitBreaker) Record(err error, latency time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	failed := err != nil || b.exceedsLatency(latency)
+
+	switch b.state {
+	case BreakerClosed:
+		bucket := b.currentBucket()
+		bucket.requests++
+		bucket.latency += latency
+		if err != nil {
+			bucket.failures++
+		}
+		if b.thresholdsExceeded() {
+			b.open()
+		}
+	case BreakerHalfOpen:
+		if b.probesInFlight > 0 {
+			b.probesInFlight--
+		}
+		if failed {
+			b.open()
+			return
+		}
+		b.probeSuccesses++
+		if b.probeSuccesses >= b.settings.HalfOpenProbes {
+			b.close()
+		}
+	case BreakerOpen:
+		// The request was allowed before the breaker opened, nothing to do.
+	}
+}
+
+// SetStatusHealthy updates the health of the level as reported by the component status of its exporters.
+// An unhealthy level rejects all requests until it is reported healthy again, at which point the breaker
+// moves to the half-open state to probe it.
+func (b *CircuitBreaker) SetStatusHealthy(healthy bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !healthy {
+		if !b.statusUnhealthy {
+			b.statusUnhealthy = true
+			b.open()
+		}
+		return
+	}
+
+	if b.statusUnhealthy {
+		b.statusUnhealthy = false
+		b.halfOpen()
+	}
+}
+
+// maybeHalfOpen must be called holding lock.
+func (b *CircuitBreaker) maybeHalfOpen() {
+	if b.state == BreakerOpen && !b.statusUnhealthy && b.now().Sub(b.openedAt) >= b.settings.OpenDuration {
+		b.halfOpen()
+	}
+}
+
+// open must be called holding lock.
+func (b *CircuitBreaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+	b.probesInFlight = 0
+	b.probeSuccesses = 0
+}
+
+// halfOpen must be called holding lock.
+func (b *CircuitBreaker) halfOpen() {
+	b.state = BreakerHalfOpen
+	b.probesInFlight = 0
+	b.probeSuccesses = 0
+}
+
+// close must be called holding lock.
+func (b *CircuitBreaker) close() {
+	b.state = BreakerClosed
+	b.buckets = [numWindowBuckets]windowBucket{}
+}
+
+func (b *CircuitBreaker) exceedsLatency(latency time.Duration) bool {
+	return b.settings.LatencyThreshold > 0 && latency > b.settings.LatencyThreshold
+}
+
+// currentBucket returns the bucket of the rolling window for the current time, resetting it if it
+// belongs to a previous window. Must be called holding lock.
+func (b *CircuitBreaker) currentBucket() *windowBucket {
+	bucketSize := b.settings.Window / numWindowBuckets
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+
+	now := b.now()
+	start := now.Truncate(bucketSize)
+	bucket := &b.buckets[(start.UnixNano()/int64(bucketSize))%numWindowBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = windowBucket{start: start}
+	}
+	return bucket
+}
+
+// thresholdsExceeded evaluates the rolling window against the thresholds. Must be called holding lock.
+func (b *CircuitBreaker) thresholdsExceeded() bool {
+	windowStart := b.now().Add(-b.settings.Window)
+
+	var requests, failures int
+	var latency time.Duration
+	for i := range b.buckets {
+		if b.buckets[i].start.Before(windowStart) {
+			continue
+		}
+		requests += b.buckets[i].requests
+		failures += b.buckets[i].failures
+		latency += b.buckets[i].latency
+	}
+
+	if requests == 0 || requests < b.settings.MinRequests {
+		return false
+	}
+
+	if b.settings.ErrorRateThreshold > 0 && float64(failures)/float64(requests) >= b.settings.ErrorRateThreshold {
+		return true
+	}
+
+	return b.exceedsLatency(latency / time.Duration(requests))
+}
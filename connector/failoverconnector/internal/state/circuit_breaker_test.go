@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errConsume = errors.New("consume error")
+
+func newTestBreaker(settings BreakerSettings) (*CircuitBreaker, *time.Time) {
+	now := time.Unix(1000, 0)
+	b := NewCircuitBreaker(settings)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestCircuitBreakerErrorRate(t *testing.T) {
+	b, now := newTestBreaker(BreakerSettings{
+		ErrorRateThreshold: 0.5,
+		Window:             time.Minute,
+		MinRequests:        4,
+		OpenDuration:       time.Second,
+		HalfOpenProbes:     2,
+	})
+
+	// Below MinRequests, the breaker stays closed
+	for i := 0; i < 3; i++ {
+		require.True(t, b.Allow())
+		b.Record(errConsume, time.Millisecond)
+	}
+	require.Equal(t, BreakerClosed, b.State())
+
+	require.True(t, b.Allow())
+	b.Record(errConsume, time.Millisecond)
+	require.Equal(t, BreakerOpen, b.State())
+	require.False(t, b.Allow())
+
+	// After OpenDuration, the breaker lets HalfOpenProbes probes through
+	*now = now.Add(time.Second)
+	require.Equal(t, BreakerHalfOpen, b.State())
+	require.True(t, b.Allow())
+	require.True(t, b.Allow())
+	require.False(t, b.Allow())
+
+	b.Record(nil, time.Millisecond)
+	require.Equal(t, BreakerHalfOpen, b.State())
+	b.Record(nil, time.Millisecond)
+	require.Equal(t, BreakerClosed, b.State())
+	require.True(t, b.Allow())
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	b, now := newTestBreaker(BreakerSettings{
+		ErrorRateThreshold: 0.5,
+		Window:             time.Minute,
+		MinRequests:        1,
+		OpenDuration:       time.Second,
+		HalfOpenProbes:     1,
+	})
+
+	require.True(t, b.Allow())
+	b.Record(errConsume, time.Millisecond)
+	require.Equal(t, BreakerOpen, b.State())
+
+	*now = now.Add(time.Second)
+	require.True(t, b.Allow())
+	b.Record(errConsume, time.Millisecond)
+	require.Equal(t, BreakerOpen, b.State())
+	require.False(t, b.Allow())
+}
+
+func TestCircuitBreakerLatency(t *testing.T) {
+	b, _ := newTestBreaker(BreakerSettings{
+		LatencyThreshold: 100 * time.Millisecond,
+		Window:           time.Minute,
+		MinRequests:      2,
+		OpenDuration:     time.Second,
+		HalfOpenProbes:   1,
+	})
+
+	require.True(t, b.Allow())
+	b.Record(nil, 50*time.Millisecond)
+	require.True(t, b.Allow())
+	b.Record(nil, 100*time.Millisecond)
+	require.Equal(t, BreakerClosed, b.State())
+
+	require.True(t, b.Allow())
+	b.Record(nil, time.Second)
+	require.Equal(t, BreakerOpen, b.State())
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	b, now := newTestBreaker(BreakerSettings{
+		ErrorRateThreshold: 0.5,
+		Window:             time.Minute,
+		MinRequests:        2,
+		OpenDuration:       time.Second,
+		HalfOpenProbes:     1,
+	})
+
+	require.True(t, b.Allow())
+	b.Record(errConsume, time.Millisecond)
+
+	// The first failure is out of the window by the time of the second one
+	*now = now.Add(2 * time.Minute)
+	require.True(t, b.Allow())
+	b.Record(errConsume, time.Millisecond)
+	require.Equal(t, BreakerClosed, b.State())
+}
+
+func TestCircuitBreakerStatus(t *testing.T) {
+	b, now := newTestBreaker(BreakerSettings{
+		Window:         time.Minute,
+		OpenDuration:   time.Second,
+		HalfOpenProbes: 1,
+	})
+
+	b.SetStatusHealthy(false)
+	require.Equal(t, BreakerOpen, b.State())
+	require.False(t, b.Allow())
+
+	// The breaker stays open while the status is unhealthy
+	*now = now.Add(time.Minute)
+	require.Equal(t, BreakerOpen, b.State())
+	require.False(t, b.Allow())
+
+	b.SetStatusHealthy(true)
+	require.Equal(t, BreakerHalfOpen, b.State())
+	require.True(t, b.Allow())
+	b.Record(nil, time.Millisecond)
+	require.Equal(t, BreakerClosed, b.State())
+}
@@ -27,6 +27,12 @@ func newLogsRouter(provider consumerProvider[consumer.Logs], cfg *Config) (*logs
 
 // Consume is the logs-specific consumption method
 func (f *logsRouter) Consume(ctx context.Context, ld plog.Logs) error {
+	if f.health != nil {
+		return f.consumeByCircuitBreakers(func(c consumer.Logs) error {
+			return c.ConsumeLogs(ctx, ld)
+		})
+	}
+
 	select {
 	case <-f.notifyRetry:
 		if !f.sampleRetryConsumers(ctx, ld) {
@@ -70,7 +76,6 @@ func (f *logsRouter) sampleRetryConsumers(ctx context.Context, ld plog.Logs) boo
 }
 
 type logsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,6 +92,10 @@ func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return f.failover.Consume(ctx, ld)
 }
 
+func (f *logsFailover) Start(ctx context.Context, host component.Host) error {
+	return f.failover.Start(ctx, host)
+}
+
 func (f *logsFailover) Shutdown(context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -27,6 +27,12 @@ func newMetricsRouter(provider consumerProvider[consumer.Metrics], cfg *Config)
 
 // Consume is the metrics-specific consumption method
 func (f *metricsRouter) Consume(ctx context.Context, md pmetric.Metrics) error {
+	if f.health != nil {
+		return f.consumeByCircuitBreakers(func(c consumer.Metrics) error {
+			return c.ConsumeMetrics(ctx, md)
+		})
+	}
+
 	select {
 	case <-f.notifyRetry:
 		if !f.sampleRetryConsumers(ctx, md) {
@@ -70,7 +76,6 @@ func (f *metricsRouter) sampleRetryConsumers(ctx context.Context, md pmetric.Met
 }
 
 type metricsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -87,6 +92,10 @@ func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 	return f.failover.Consume(ctx, md)
 }
 
+func (f *metricsFailover) Start(ctx context.Context, host component.Host) error {
+	return f.failover.Start(ctx, host)
+}
+
 func (f *metricsFailover) Shutdown(context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -28,6 +28,12 @@ func newTracesRouter(provider consumerProvider[consumer.Traces], cfg *Config) (*
 
 // Consume is the traces-specific consumption method
 func (f *tracesRouter) Consume(ctx context.Context, td ptrace.Traces) error {
+	if f.health != nil {
+		return f.consumeByCircuitBreakers(func(c consumer.Traces) error {
+			return c.ConsumeTraces(ctx, td)
+		})
+	}
+
 	select {
 	case <-f.notifyRetry:
 		if !f.sampleRetryConsumers(ctx, td) {
@@ -71,7 +77,6 @@ func (f *tracesRouter) sampleRetryConsumers(ctx context.Context, td ptrace.Trace
 }
 
 type tracesFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -88,6 +93,10 @@ func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) er
 	return f.failover.Consume(ctx, td)
 }
 
+func (f *tracesFailover) Start(ctx context.Context, host component.Host) error {
+	return f.failover.Start(ctx, host)
+}
+
 func (f *tracesFailover) Shutdown(context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
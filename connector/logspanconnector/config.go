@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logspanconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+// Config defines the configuration options for the logspan connector.
+type Config struct {
+	// CorrelationKey is the OTTL value expression identifying the span a log record
+	// belongs to, e.g. `attributes["request.id"]`. Log records for which the expression
+	// evaluates to nil or an empty string are ignored.
+	CorrelationKey string `mapstructure:"correlation_key"`
+
+	// ParentKey is the OTTL value expression evaluating to the correlation key of the
+	// parent span, if any.
+	// Optional.
+	ParentKey string `mapstructure:"parent_key"`
+
+	// SpanName is the OTTL value expression evaluated against the start log record
+	// to name the span. Defaults to the body of the start log record.
+	// Optional.
+	SpanName string `mapstructure:"span_name"`
+
+	// StartConditions are the OTTL conditions identifying the log records marking the start
+	// of a span. A log record marks the start of a span if any of the conditions match.
+	StartConditions []string `mapstructure:"start_conditions"`
+
+	// EndConditions are the OTTL conditions identifying the log records marking the end
+	// of a span. A log record marks the end of a span if any of the conditions match.
+	EndConditions []string `mapstructure:"end_conditions"`
+
+	// Timeout is the duration after which a span missing its start or end log record is expired.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxItems is the maximum number of spans waiting for their start or end log record.
+	MaxItems int `mapstructure:"max_items"`
+
+	// EmitIncomplete defines if spans missing their start or end log record are emitted when they expire.
+	// Incomplete spans have an error status.
+	EmitIncomplete bool `mapstructure:"emit_incomplete"`
+
+	// ErrorMode determines how errors returned from OTTL expressions are handled.
+	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (c *Config) Validate() error {
+	var errs error
+	if c.CorrelationKey == "" {
+		errs = errors.Join(errs, errors.New("correlation_key must be specified"))
+	}
+	if len(c.StartConditions) == 0 {
+		errs = errors.Join(errs, errors.New("start_conditions must be specified"))
+	}
+	if len(c.EndConditions) == 0 {
+		errs = errors.Join(errs, errors.New("end_conditions must be specified"))
+	}
+	if c.Timeout <= 0 {
+		errs = errors.Join(errs, errors.New("timeout must be greater than 0"))
+	}
+	if c.MaxItems <= 0 {
+		errs = errors.Join(errs, errors.New("max_items must be greater than 0"))
+	}
+	if errs != nil {
+		return errs
+	}
+
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	parser, err := ottllog.NewParser(filterottl.StandardLogFuncs(), set)
+	if err != nil {
+		return err
+	}
+	for name, expr := range map[string]string{
+		"correlation_key": c.CorrelationKey,
+		"parent_key":      c.ParentKey,
+		"span_name":       c.SpanName,
+	} {
+		if expr == "" {
+			continue
+		}
+		if _, err := parser.ParseValueExpression(expr); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	if _, err := filterottl.NewBoolExprForLog(c.StartConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("start_conditions: %w", err))
+	}
+	if _, err := filterottl.NewBoolExprForLog(c.EndConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("end_conditions: %w", err))
+	}
+	return errs
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logspanconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id          component.ID
+		expect      *Config
+		errContains string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expect: &Config{
+				CorrelationKey:  `attributes["request.id"]`,
+				StartConditions: []string{`attributes["event"] == "start"`},
+				EndConditions:   []string{`attributes["event"] == "end"`},
+				Timeout:         defaultTimeout,
+				MaxItems:        defaultMaxItems,
+				ErrorMode:       ottl.PropagateError,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expect: &Config{
+				CorrelationKey:  `attributes["request.id"]`,
+				ParentKey:       `attributes["parent.request.id"]`,
+				SpanName:        `attributes["operation"]`,
+				StartConditions: []string{`attributes["event"] == "start"`, `IsMatch(body, "^BEGIN ")`},
+				EndConditions:   []string{`attributes["event"] == "end"`},
+				Timeout:         time.Minute,
+				MaxItems:        100,
+				EmitIncomplete:  true,
+				ErrorMode:       ottl.IgnoreError,
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_conditions"),
+			errContains: "start_conditions must be specified",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_correlation_key"),
+			errContains: "correlation_key:",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_timeout"),
+			errContains: "timeout must be greater than 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			cfg := createDefaultConfig()
+			sub, err := cm.Sub(tc.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tc.errContains != "" {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tc.errContains)
+				return
+			}
+			require.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tc.expect, cfg)
+		})
+	}
+}
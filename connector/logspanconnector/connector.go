@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logspanconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector"
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/store"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+// storeExpirationLoop is the interval at which expired pairs are evicted from the store.
+const storeExpirationLoop = time.Second
+
+type logSpan struct {
+	config         *Config
+	logger         *zap.Logger
+	tracesConsumer consumer.Traces
+
+	correlationKey *ottl.ValueExpression[ottllog.TransformContext]
+	parentKey      *ottl.ValueExpression[ottllog.TransformContext]
+	spanName       *ottl.ValueExpression[ottllog.TransformContext]
+	startCondition *ottl.ConditionSequence[ottllog.TransformContext]
+	endCondition   *ottl.ConditionSequence[ottllog.TransformContext]
+
+	store *store.Store
+
+	// pending contains the spans built from the pairs completed or expired
+	// since the last flush.
+	mu      sync.Mutex
+	pending ptrace.Traces
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newConnector(set component.TelemetrySettings, cfg *Config, nextConsumer consumer.Traces) (*logSpan, error) {
+	parser, err := ottllog.NewParser(filterottl.StandardLogFuncs(), set)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &logSpan{
+		config:         cfg,
+		logger:         set.Logger,
+		tracesConsumer: nextConsumer,
+		pending:        ptrace.NewTraces(),
+	}
+	if c.correlationKey, err = parser.ParseValueExpression(cfg.CorrelationKey); err != nil {
+		return nil, err
+	}
+	if cfg.ParentKey != "" {
+		if c.parentKey, err = parser.ParseValueExpression(cfg.ParentKey); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SpanName != "" {
+		if c.spanName, err = parser.ParseValueExpression(cfg.SpanName); err != nil {
+			return nil, err
+		}
+	}
+	if c.startCondition, err = filterottl.NewBoolExprForLog(cfg.StartConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set); err != nil {
+		return nil, err
+	}
+	if c.endCondition, err = filterottl.NewBoolExprForLog(cfg.EndConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set); err != nil {
+		return nil, err
+	}
+	c.store = store.NewStore(cfg.Timeout, cfg.MaxItems, c.onComplete, c.onExpire)
+	return c, nil
+}
+
+func (c *logSpan) Start(context.Context, component.Host) error {
+	c.shutdownCh = make(chan struct{})
+	c.wg.Add(1)
+	go c.expireLoop()
+	return nil
+}
+
+func (c *logSpan) Shutdown(ctx context.Context) error {
+	if c.shutdownCh != nil {
+		close(c.shutdownCh)
+		c.wg.Wait()
+	}
+	// The pairs still waiting for their start or end log record are handled as if
+	// they expired, so they are emitted as incomplete spans only with emit_incomplete.
+	c.store.Drain(c.onExpire)
+	return c.flush(ctx)
+}
+
+func (*logSpan) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logSpan) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var errs error
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				if err := c.consumeLogRecord(ctx, rl, sl, lr); err != nil {
+					errs = errors.Join(errs, err)
+				}
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return c.flush(ctx)
+}
+
+func (c *logSpan) consumeLogRecord(ctx context.Context, rl plog.ResourceLogs, sl plog.ScopeLogs, lr plog.LogRecord) error {
+	tCtx := ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl)
+
+	isStart, err := c.startCondition.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	isEnd, err := c.endCondition.Eval(ctx, tCtx)
+	if err != nil {
+		return err
+	}
+	if !isStart && !isEnd {
+		return nil
+	}
+
+	key, err := c.evalString(ctx, c.correlationKey, tCtx)
+	if err != nil || key == "" {
+		return err
+	}
+
+	var name, parentKey string
+	var parent store.Pair
+	var hasParent bool
+	if isStart {
+		if c.spanName != nil {
+			if name, err = c.evalString(ctx, c.spanName, tCtx); err != nil {
+				return err
+			}
+		} else {
+			name = lr.Body().AsString()
+		}
+		if c.parentKey != nil {
+			if parentKey, err = c.evalString(ctx, c.parentKey, tCtx); err != nil {
+				return err
+			}
+		}
+		if parentKey != "" {
+			// The store must not be called from the upsert callback, look up the parent beforehand
+			parent, hasParent = c.store.Get(parentKey)
+		}
+	}
+
+	_, err = c.store.UpsertPair(key, func(p *store.Pair) {
+		if p.SpanID.IsEmpty() {
+			p.TraceID = traceIDFromKey(key)
+			p.SpanID = spanIDFromKey(key)
+		}
+		if isStart && p.Start == nil {
+			p.Start = store.NewEvent(rl, sl, lr)
+			p.Name = name
+			if parentKey != "" {
+				// Spans inherit the trace of their parent if it is still pending, otherwise
+				// the parent is assumed to be the root of the trace.
+				p.TraceID = traceIDFromKey(parentKey)
+				if hasParent {
+					p.TraceID = parent.TraceID
+				}
+				p.ParentSpanID = spanIDFromKey(parentKey)
+			}
+		}
+		if isEnd && p.End == nil {
+			p.End = store.NewEvent(rl, sl, lr)
+		}
+	})
+	if errors.Is(err, store.ErrTooManyItems) {
+		c.logger.Debug("dropping log record, too many pending spans", zap.String("key", key))
+		return nil
+	}
+	return err
+}
+
+// evalString evaluates the expression and returns its result as a string. Errors
+// are returned depending on the error mode, and are otherwise treated as an empty result.
+func (c *logSpan) evalString(ctx context.Context, expr *ottl.ValueExpression[ottllog.TransformContext], tCtx ottllog.TransformContext) (string, error) {
+	val, err := expr.Eval(ctx, tCtx)
+	if err != nil {
+		switch c.config.ErrorMode {
+		case ottl.PropagateError:
+			return "", err
+		case ottl.IgnoreError:
+			c.logger.Warn("failed to evaluate expression", zap.Error(err))
+		}
+		return "", nil
+	}
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case pcommon.Value:
+		return v.AsString(), nil
+	}
+	v := pcommon.NewValueEmpty()
+	if err := v.FromRaw(val); err != nil {
+		return "", err
+	}
+	return v.AsString(), nil
+}
+
+func (c *logSpan) onComplete(p *store.Pair) {
+	c.appendSpan(p)
+}
+
+func (c *logSpan) onExpire(p *store.Pair) {
+	if c.config.EmitIncomplete {
+		c.appendSpan(p)
+	}
+}
+
+// appendSpan builds the span of the pair into the pending traces.
+func (c *logSpan) appendSpan(p *store.Pair) {
+	first := p.Start
+	if first == nil {
+		first = p.End
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rs := c.pending.ResourceSpans().AppendEmpty()
+	first.Resource.CopyTo(rs.Resource())
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(metadata.ScopeName)
+	span := ss.Spans().AppendEmpty()
+
+	span.SetTraceID(p.TraceID)
+	span.SetSpanID(p.SpanID)
+	span.SetParentSpanID(p.ParentSpanID)
+	span.SetKind(ptrace.SpanKindInternal)
+	span.SetName(p.Name)
+	if p.Name == "" {
+		span.SetName(p.Key)
+	}
+
+	span.SetStartTimestamp(first.Timestamp())
+	span.SetEndTimestamp(first.Timestamp())
+	if p.Start != nil {
+		p.Start.Record.Attributes().CopyTo(span.Attributes())
+	}
+	if p.End != nil {
+		span.SetEndTimestamp(p.End.Timestamp())
+		for k, v := range p.End.Record.Attributes().All() {
+			v.CopyTo(span.Attributes().PutEmpty(k))
+		}
+	}
+
+	switch {
+	case p.Start == nil:
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("incomplete span: start log record not received")
+	case p.End == nil:
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage("incomplete span: end log record not received")
+	case p.End.Record.SeverityNumber() >= plog.SeverityNumberError:
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage(p.End.Record.Body().AsString())
+	}
+}
+
+// flush sends the pending spans to the next consumer.
+func (c *logSpan) flush(ctx context.Context) error {
+	c.mu.Lock()
+	td := c.pending
+	c.pending = ptrace.NewTraces()
+	c.mu.Unlock()
+
+	if td.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	return c.tracesConsumer.ConsumeTraces(ctx, td)
+}
+
+func (c *logSpan) expireLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(storeExpirationLoop)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.store.Expire()
+			if err := c.flush(context.Background()); err != nil {
+				c.logger.Warn("failed to send expired spans", zap.Error(err))
+			}
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// traceIDFromKey derives a trace ID from a correlation key.
+func traceIDFromKey(key string) pcommon.TraceID {
+	h := fnv.New128a()
+	_, _ = h.Write([]byte(key))
+	var id pcommon.TraceID
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// spanIDFromKey derives a span ID from a correlation key.
+func spanIDFromKey(key string) pcommon.SpanID {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	var id pcommon.SpanID
+	copy(id[:], h.Sum(nil))
+	return id
+}
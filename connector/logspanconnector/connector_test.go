@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logspanconnector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/metadata"
+)
+
+func testConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.CorrelationKey = `attributes["request.id"]`
+	cfg.ParentKey = `attributes["parent.request.id"]`
+	cfg.SpanName = `attributes["operation"]`
+	cfg.StartConditions = []string{`attributes["event"] == "start"`}
+	cfg.EndConditions = []string{`attributes["event"] == "end"`}
+	return cfg
+}
+
+type testRecord struct {
+	requestID string
+	parentID  string
+	event     string
+	operation string
+	ts        int64
+	severity  plog.SeverityNumber
+}
+
+func testLogs(records ...testRecord) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "legacy")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, r := range records {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(pcommon.Timestamp(r.ts * int64(time.Millisecond)))
+		lr.SetSeverityNumber(r.severity)
+		lr.Body().SetStr(r.event + " " + r.requestID)
+		lr.Attributes().PutStr("event", r.event)
+		if r.requestID != "" {
+			lr.Attributes().PutStr("request.id", r.requestID)
+		}
+		if r.parentID != "" {
+			lr.Attributes().PutStr("parent.request.id", r.parentID)
+		}
+		if r.operation != "" {
+			lr.Attributes().PutStr("operation", r.operation)
+		}
+	}
+	return ld
+}
+
+func spansByName(t *testing.T, sink *consumertest.TracesSink) map[string]ptrace.Span {
+	spans := make(map[string]ptrace.Span)
+	for _, td := range sink.AllTraces() {
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			serviceName, ok := rs.Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, "legacy", serviceName.Str())
+			for j := 0; j < rs.ScopeSpans().Len(); j++ {
+				ss := rs.ScopeSpans().At(j)
+				assert.Equal(t, metadata.ScopeName, ss.Scope().Name())
+				for k := 0; k < ss.Spans().Len(); k++ {
+					span := ss.Spans().At(k)
+					spans[span.Name()] = span
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func TestConsumeLogs(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	conn, err := NewFactory().CreateLogsToTraces(t.Context(), connectortest.NewNopSettings(metadata.Type), testConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, conn.Shutdown(t.Context()))
+	}()
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), testLogs(
+		testRecord{requestID: "req-1", event: "start", operation: "GET /orders", ts: 1000},
+		testRecord{requestID: "req-2", parentID: "req-1", event: "start", operation: "SELECT orders", ts: 1010},
+		testRecord{event: "start", operation: "no correlation key", ts: 1010},
+		testRecord{requestID: "req-2", event: "log", ts: 1015},
+	)))
+	// No span is complete yet
+	assert.Zero(t, sink.SpanCount())
+
+	// End records can be received in any order
+	require.NoError(t, conn.ConsumeLogs(t.Context(), testLogs(
+		testRecord{requestID: "req-1", event: "end", ts: 1100, severity: plog.SeverityNumberError},
+		testRecord{requestID: "req-2", event: "end", ts: 1050},
+	)))
+	require.Equal(t, 2, sink.SpanCount())
+
+	spans := spansByName(t, sink)
+	require.Contains(t, spans, "GET /orders")
+	require.Contains(t, spans, "SELECT orders")
+	parent, child := spans["GET /orders"], spans["SELECT orders"]
+
+	assert.Equal(t, parent.TraceID(), child.TraceID())
+	assert.Equal(t, parent.SpanID(), child.ParentSpanID())
+	assert.True(t, parent.ParentSpanID().IsEmpty())
+	assert.Equal(t, ptrace.SpanKindInternal, parent.Kind())
+
+	assert.Equal(t, pcommon.Timestamp(1000*time.Millisecond), parent.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(1100*time.Millisecond), parent.EndTimestamp())
+	assert.Equal(t, pcommon.Timestamp(1010*time.Millisecond), child.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(1050*time.Millisecond), child.EndTimestamp())
+
+	// The end record marks the span as failed
+	assert.Equal(t, ptrace.StatusCodeError, parent.Status().Code())
+	assert.Equal(t, ptrace.StatusCodeUnset, child.Status().Code())
+
+	// Attributes of the end record override the ones of the start record
+	event, ok := parent.Attributes().Get("event")
+	require.True(t, ok)
+	assert.Equal(t, "end", event.Str())
+	operation, ok := parent.Attributes().Get("operation")
+	require.True(t, ok)
+	assert.Equal(t, "GET /orders", operation.Str())
+}
+
+func TestConsumeLogsDefaultSpanName(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	cfg := testConfig()
+	cfg.SpanName = ""
+	conn, err := newConnector(componenttest.NewNopTelemetrySettings(), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), testLogs(
+		testRecord{requestID: "req-1", event: "start", ts: 1000},
+		testRecord{requestID: "req-1", event: "end", ts: 1001},
+	)))
+	spans := spansByName(t, sink)
+	assert.Contains(t, spans, "start req-1")
+}
+
+func TestExpiredPairs(t *testing.T) {
+	for _, emitIncomplete := range []bool{false, true} {
+		sink := &consumertest.TracesSink{}
+		cfg := testConfig()
+		cfg.Timeout = time.Nanosecond
+		cfg.EmitIncomplete = emitIncomplete
+		conn, err := newConnector(componenttest.NewNopTelemetrySettings(), cfg, sink)
+		require.NoError(t, err)
+
+		require.NoError(t, conn.ConsumeLogs(t.Context(), testLogs(
+			testRecord{requestID: "req-1", event: "start", operation: "GET /orders", ts: 1000},
+			testRecord{requestID: "req-2", event: "end", ts: 1050},
+		)))
+		assert.Equal(t, 2, conn.store.Len())
+
+		time.Sleep(time.Millisecond)
+		conn.store.Expire()
+		require.NoError(t, conn.flush(t.Context()))
+		assert.Zero(t, conn.store.Len())
+
+		if !emitIncomplete {
+			assert.Zero(t, sink.SpanCount())
+			continue
+		}
+		spans := spansByName(t, sink)
+		require.Len(t, spans, 2)
+		assert.Equal(t, "incomplete span: end log record not received", spans["GET /orders"].Status().Message())
+		// Spans without start record are named after their correlation key
+		assert.Equal(t, "incomplete span: start log record not received", spans["req-2"].Status().Message())
+		assert.Equal(t, ptrace.StatusCodeError, spans["req-2"].Status().Code())
+	}
+}
+
+func TestShutdownPendingPairs(t *testing.T) {
+	for _, emitIncomplete := range []bool{false, true} {
+		sink := &consumertest.TracesSink{}
+		cfg := testConfig()
+		cfg.EmitIncomplete = emitIncomplete
+		conn, err := NewFactory().CreateLogsToTraces(t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, sink)
+		require.NoError(t, err)
+		require.NoError(t, conn.Start(t.Context(), componenttest.NewNopHost()))
+
+		require.NoError(t, conn.ConsumeLogs(t.Context(), testLogs(
+			testRecord{requestID: "req-1", event: "start", operation: "GET /orders", ts: 1000},
+			testRecord{requestID: "req-2", event: "end", ts: 1050},
+		)))
+		assert.Zero(t, sink.SpanCount())
+
+		// The pending pairs are emitted as incomplete spans on shutdown only with emit_incomplete
+		require.NoError(t, conn.Shutdown(t.Context()))
+		if !emitIncomplete {
+			assert.Zero(t, sink.SpanCount())
+			continue
+		}
+		spans := spansByName(t, sink)
+		require.Len(t, spans, 2)
+		assert.Equal(t, ptrace.StatusCodeError, spans["GET /orders"].Status().Code())
+		assert.Equal(t, "incomplete span: end log record not received", spans["GET /orders"].Status().Message())
+		assert.Equal(t, "incomplete span: start log record not received", spans["req-2"].Status().Message())
+	}
+}
+
+func TestMaxItems(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	cfg := testConfig()
+	cfg.MaxItems = 1
+	conn, err := newConnector(componenttest.NewNopTelemetrySettings(), cfg, sink)
+	require.NoError(t, err)
+
+	// Log records exceeding the capacity of the store are dropped
+	require.NoError(t, conn.ConsumeLogs(t.Context(), testLogs(
+		testRecord{requestID: "req-1", event: "start", ts: 1000},
+		testRecord{requestID: "req-2", event: "start", ts: 1000},
+	)))
+	assert.Equal(t, 1, conn.store.Len())
+}
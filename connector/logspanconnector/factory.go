@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package logspanconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	defaultTimeout  = 30 * time.Second
+	defaultMaxItems = 10000
+)
+
+// NewFactory returns a ConnectorFactory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithLogsToTraces(createLogsToTraces, metadata.LogsToTracesStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Timeout:   defaultTimeout,
+		MaxItems:  defaultMaxItems,
+		ErrorMode: ottl.PropagateError,
+	}
+}
+
+// createLogsToTraces creates a logs to traces connector based on provided config.
+func createLogsToTraces(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (connector.Logs, error) {
+	return newConnector(set.TelemetrySettings, cfg.(*Config), nextConsumer)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/store"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Event is a log record marking the start or the end of a span, along with
+// the resource and scope it was emitted with.
+type Event struct {
+	Record   plog.LogRecord
+	Resource pcommon.Resource
+	Scope    pcommon.InstrumentationScope
+}
+
+// NewEvent copies the given log record, resource and scope into a new Event.
+func NewEvent(rl plog.ResourceLogs, sl plog.ScopeLogs, lr plog.LogRecord) *Event {
+	e := &Event{
+		Record:   plog.NewLogRecord(),
+		Resource: pcommon.NewResource(),
+		Scope:    pcommon.NewInstrumentationScope(),
+	}
+	lr.CopyTo(e.Record)
+	rl.Resource().CopyTo(e.Resource)
+	sl.Scope().CopyTo(e.Scope)
+	return e
+}
+
+// Timestamp returns the timestamp of the log record, or its observed
+// timestamp if the former is not set.
+func (e *Event) Timestamp() pcommon.Timestamp {
+	if ts := e.Record.Timestamp(); ts != 0 {
+		return ts
+	}
+	return e.Record.ObservedTimestamp()
+}
+
+// Pair is a pair of start and end events sharing the same correlation key.
+type Pair struct {
+	Key string
+
+	TraceID      pcommon.TraceID
+	SpanID       pcommon.SpanID
+	ParentSpanID pcommon.SpanID
+	Name         string
+
+	Start, End *Event
+
+	// expiration is the time at which the Pair expires, expressed as Unix time
+	expiration time.Time
+}
+
+func newPair(key string, ttl time.Duration) *Pair {
+	return &Pair{
+		Key:        key,
+		expiration: time.Now().Add(ttl),
+	}
+}
+
+// isComplete returns true if both the start and the end events
+// have been processed for the given Pair
+func (p *Pair) isComplete() bool {
+	return p.Start != nil && p.End != nil
+}
+
+func (p *Pair) isExpired() bool {
+	return time.Now().After(p.expiration)
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logspanconnector/internal/store"
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrTooManyItems = errors.New("too many items")
+
+type Callback func(p *Pair)
+
+// Store pairs start and end log records into spans. Pairs that have not
+// found their counterpart are deleted after ttl time.
+type Store struct {
+	l   *list.List
+	mtx sync.Mutex
+	m   map[string]*list.Element
+
+	onComplete Callback
+	onExpire   Callback
+
+	ttl      time.Duration
+	maxItems int
+}
+
+// NewStore creates a Store. Complete pairs are passed to onComplete and removed
+// from the store, incomplete pairs are passed to onExpire once they expire.
+// Callbacks are called while holding the lock of the store, and must not call
+// the store themselves.
+func NewStore(ttl time.Duration, maxItems int, onComplete, onExpire Callback) *Store {
+	return &Store{
+		l: list.New(),
+		m: make(map[string]*list.Element),
+
+		onComplete: onComplete,
+		onExpire:   onExpire,
+
+		ttl:      ttl,
+		maxItems: maxItems,
+	}
+}
+
+// Len returns the number of pairs waiting for their start or end event.
+func (s *Store) Len() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.l.Len()
+}
+
+// UpsertPair fetches a Pair from the store and updates it using the given callback. If the Pair
+// doesn't exist yet, it creates a new one with the default TTL.
+// If the Pair is complete after applying the callback, it's completed and removed.
+func (s *Store) UpsertPair(key string, update Callback) (isNew bool, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if storedPair, ok := s.m[key]; ok {
+		pair := storedPair.Value.(*Pair)
+		update(pair)
+
+		if pair.isComplete() {
+			s.onComplete(pair)
+			delete(s.m, key)
+			s.l.Remove(storedPair)
+		}
+
+		return false, nil
+	}
+
+	pair := newPair(key, s.ttl)
+	update(pair)
+
+	if pair.isComplete() {
+		s.onComplete(pair)
+		return true, nil
+	}
+
+	// Check we can add new pairs
+	if s.l.Len() >= s.maxItems {
+		return false, ErrTooManyItems
+	}
+
+	ele := s.l.PushBack(pair)
+	s.m[key] = ele
+
+	return true, nil
+}
+
+// Get returns a copy of the identifiers of the pending Pair with the given key, if any.
+// It is used to link spans to the trace of a parent span which has not ended yet.
+func (s *Store) Get(key string) (Pair, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ele, ok := s.m[key]
+	if !ok {
+		return Pair{}, false
+	}
+	pair := ele.Value.(*Pair)
+	return Pair{
+		Key:          pair.Key,
+		TraceID:      pair.TraceID,
+		SpanID:       pair.SpanID,
+		ParentSpanID: pair.ParentSpanID,
+		Name:         pair.Name,
+	}, true
+}
+
+// Expire evicts all expired items in the store.
+func (s *Store) Expire() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// Iterates until no more items can be evicted
+	for s.tryEvictHead() {
+	}
+}
+
+// Drain removes all the pending pairs from the store, passing them to the given
+// callback in the order they were created. It is used to release the pending
+// pairs on shutdown.
+func (s *Store) Drain(callback Callback) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for ele := s.l.Front(); ele != nil; ele = ele.Next() {
+		callback(ele.Value.(*Pair))
+	}
+	s.l.Init()
+	s.m = make(map[string]*list.Element)
+}
+
+// tryEvictHead checks if the oldest item (head of list) can be evicted and will delete it if so.
+// Returns true if the head was evicted.
+//
+// Must be called holding lock.
+func (s *Store) tryEvictHead() bool {
+	head := s.l.Front()
+	if head == nil {
+		return false // list is empty
+	}
+
+	headPair := head.Value.(*Pair)
+	if !headPair.isExpired() {
+		return false
+	}
+
+	s.onExpire(headPair)
+	delete(s.m, headPair.Key)
+	s.l.Remove(head)
+
+	return true
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newTestEvent() *Event {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	sl := rl.ScopeLogs().AppendEmpty()
+	return NewEvent(rl, sl, sl.LogRecords().AppendEmpty())
+}
+
+func countingCallback(counter *int) Callback {
+	return func(*Pair) {
+		*counter++
+	}
+}
+
+func TestStoreUpsertPair(t *testing.T) {
+	var onCompletedCount int
+	var onExpireCount int
+
+	s := NewStore(time.Hour, 1, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	assert.Equal(t, 0, s.Len())
+
+	// Insert the start of a pair
+	isNew, err := s.UpsertPair("req-1", func(p *Pair) {
+		p.Start = newTestEvent()
+		p.Name = "GET /"
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+	assert.Equal(t, 1, s.Len())
+
+	pending, ok := s.Get("req-1")
+	require.True(t, ok)
+	assert.Equal(t, "GET /", pending.Name)
+	_, ok = s.Get("req-2")
+	assert.False(t, ok)
+
+	// Nothing should be evicted as TTL is set to 1h
+	assert.False(t, s.tryEvictHead())
+	assert.Equal(t, 0, onCompletedCount)
+	assert.Equal(t, 0, onExpireCount)
+
+	// Insert the end of the pair
+	isNew, err = s.UpsertPair("req-1", func(p *Pair) {
+		assert.NotNil(t, p.Start)
+		p.End = newTestEvent()
+	})
+	require.NoError(t, err)
+	require.False(t, isNew)
+	// Pair is complete and should have been removed
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, 1, onCompletedCount)
+	assert.Equal(t, 0, onExpireCount)
+
+	// Insert a pair that will immediately expire
+	isNew, err = s.UpsertPair("req-1", func(p *Pair) {
+		p.End = newTestEvent()
+		p.expiration = time.UnixMicro(0)
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+	assert.Equal(t, 1, s.Len())
+
+	s.Expire()
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, 1, onCompletedCount)
+	assert.Equal(t, 1, onExpireCount)
+}
+
+func TestStoreUpsertPair_errTooManyItems(t *testing.T) {
+	var onCallbackCounter int
+
+	s := NewStore(time.Hour, 1, countingCallback(&onCallbackCounter), countingCallback(&onCallbackCounter))
+
+	isNew, err := s.UpsertPair("req-1", func(p *Pair) {
+		p.Start = newTestEvent()
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+
+	_, err = s.UpsertPair("req-2", func(p *Pair) {
+		p.Start = newTestEvent()
+	})
+	require.ErrorIs(t, err, ErrTooManyItems)
+	assert.Equal(t, 1, s.Len())
+
+	// Pairs completed on insertion do not count against the limit
+	isNew, err = s.UpsertPair("req-3", func(p *Pair) {
+		p.Start = newTestEvent()
+		p.End = newTestEvent()
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+	assert.Equal(t, 1, onCallbackCounter)
+}
+
+func TestStoreDrain(t *testing.T) {
+	var onCallbackCounter int
+
+	s := NewStore(time.Hour, 10, countingCallback(&onCallbackCounter), countingCallback(&onCallbackCounter))
+	for _, key := range []string{"req-1", "req-2"} {
+		_, err := s.UpsertPair(key, func(p *Pair) {
+			p.Start = newTestEvent()
+		})
+		require.NoError(t, err)
+	}
+
+	var drained []string
+	s.Drain(func(p *Pair) {
+		drained = append(drained, p.Key)
+	})
+	assert.Equal(t, []string{"req-1", "req-2"}, drained)
+	assert.Equal(t, 0, s.Len())
+	_, ok := s.Get("req-1")
+	assert.False(t, ok)
+	assert.Equal(t, 0, onCallbackCounter)
+}
+
+func TestEventTimestamp(t *testing.T) {
+	e := newTestEvent()
+	e.Record.SetObservedTimestamp(2)
+	assert.EqualValues(t, 2, e.Timestamp())
+	e.Record.SetTimestamp(1)
+	assert.EqualValues(t, 1, e.Timestamp())
+}
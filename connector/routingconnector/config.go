@@ -18,6 +18,12 @@ var (
 	errNoPipelines            = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer     = errors.New("expected consumer to be a connector router")
 	errNoTableItems           = errors.New("invalid routing table: the routing table is empty")
+	errSplitAndPipelines      = errors.New("invalid route: both split and pipelines provided")
+	errNoSplitTargets         = errors.New("invalid split: no targets defined")
+	errNoSplitTargetPipelines = errors.New("invalid split: no pipelines defined for target")
+	errNoSplitWeight          = errors.New("invalid split: the sum of the target weights must be greater than 0")
+	errMirrorPercentage       = errors.New("invalid mirror: percentage must be greater than 0 and less than or equal to 100")
+	errNoMirrorPipelines      = errors.New("invalid mirror: no pipelines defined")
 )
 
 // Config defines configuration for the Routing processor.
@@ -59,9 +65,21 @@ func (c *Config) Validate() error {
 		if item.Statement != "" && item.Condition != "" {
 			return errConditionAndStatement
 		}
-		if len(item.Pipelines) == 0 {
+		if item.Split != nil {
+			if len(item.Pipelines) > 0 {
+				return errSplitAndPipelines
+			}
+			if err := item.Split.validate(); err != nil {
+				return err
+			}
+		} else if len(item.Pipelines) == 0 {
 			return errNoPipelines
 		}
+		if item.Mirror != nil {
+			if err := item.Mirror.validate(); err != nil {
+				return err
+			}
+		}
 
 		switch item.Context {
 		case "", "resource", "span", "metric", "datapoint", "log": // ok
@@ -102,6 +120,93 @@ type RoutingTableItem struct {
 	// The routing processor will fail upon the first failure from these pipelines.
 	// Optional.
 	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// Split distributes the matched data across weighted targets instead of sending it
+	// to Pipelines. Either 'Split' or 'Pipelines' must be provided.
+	// Optional.
+	Split *SplitConfig `mapstructure:"split"`
+
+	// Mirror sends a copy of a percentage of the matched data to additional pipelines.
+	// Failures of the mirror pipelines do not affect the routing of the matched data.
+	// Optional.
+	Mirror *MirrorConfig `mapstructure:"mirror"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// SplitConfig specifies how the matched data is distributed across weighted targets.
+// The target of each span, log record or data point is selected deterministically
+// from the hash of its key, so that data with the same key is always sent to the
+// same target.
+type SplitConfig struct {
+	// HashKey is the name of the attribute used as key. The attribute is looked up in
+	// the span, log record or data point attributes first, then in the resource attributes.
+	// When not provided, spans and log records are keyed by their trace ID, log records
+	// without a trace ID by their body, and data points by their stream identity.
+	// Optional.
+	HashKey string `mapstructure:"hash_key"`
+
+	// Targets contains the weighted targets of the split.
+	// Required.
+	Targets []SplitTarget `mapstructure:"targets"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// SplitTarget specifies the pipelines receiving a weighted share of the matched data.
+type SplitTarget struct {
+	// Pipelines contains the list of pipelines of the target.
+	// Required.
+	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// Weight is the weight of the target relative to the sum of the weights of all targets.
+	// A weight of 0 disables the target.
+	Weight uint32 `mapstructure:"weight"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (s *SplitConfig) validate() error {
+	if len(s.Targets) == 0 {
+		return errNoSplitTargets
+	}
+	var totalWeight uint64
+	for _, target := range s.Targets {
+		if len(target.Pipelines) == 0 {
+			return errNoSplitTargetPipelines
+		}
+		totalWeight += uint64(target.Weight)
+	}
+	if totalWeight == 0 {
+		return errNoSplitWeight
+	}
+	return nil
+}
+
+// MirrorConfig specifies the pipelines receiving a copy of a percentage of the matched data.
+// As for splits, the mirrored data is selected deterministically from the hash of its key.
+type MirrorConfig struct {
+	// HashKey is the name of the attribute used as key, see SplitConfig.HashKey.
+	// Optional.
+	HashKey string `mapstructure:"hash_key"`
+
+	// Percentage of the matched data to mirror, between 0 (excluded) and 100.
+	// Required.
+	Percentage float64 `mapstructure:"percentage"`
+
+	// Pipelines contains the list of pipelines receiving the mirrored data.
+	// Required.
+	Pipelines []pipeline.ID `mapstructure:"pipelines"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
+
+func (m *MirrorConfig) validate() error {
+	if m.Percentage <= 0 || m.Percentage > 100 {
+		return errMirrorPercentage
+	}
+	if len(m.Pipelines) == 0 {
+		return errNoMirrorPipelines
+	}
+	return nil
+}
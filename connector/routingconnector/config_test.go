@@ -375,6 +375,126 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "split provided",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Split: &SplitConfig{
+							HashKey: "tenant",
+							Targets: []SplitTarget{
+								{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "otlp")}, Weight: 90},
+								{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "canary")}, Weight: 10},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "both split and pipelines provided",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "otlp")},
+						Split: &SplitConfig{
+							Targets: []SplitTarget{
+								{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "canary")}, Weight: 10},
+							},
+						},
+					},
+				},
+			},
+			error: "invalid route: both split and pipelines provided",
+		},
+		{
+			name: "split without targets",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Split:     &SplitConfig{},
+					},
+				},
+			},
+			error: "invalid split: no targets defined",
+		},
+		{
+			name: "split target without pipelines",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Split: &SplitConfig{
+							Targets: []SplitTarget{{Weight: 10}},
+						},
+					},
+				},
+			},
+			error: "invalid split: no pipelines defined for target",
+		},
+		{
+			name: "split with zero weights",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Split: &SplitConfig{
+							Targets: []SplitTarget{
+								{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "otlp")}},
+							},
+						},
+					},
+				},
+			},
+			error: "invalid split: the sum of the target weights must be greater than 0",
+		},
+		{
+			name: "mirror provided",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "otlp")},
+						Mirror: &MirrorConfig{
+							Percentage: 5,
+							Pipelines:  []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "canary")},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "mirror with invalid percentage",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "otlp")},
+						Mirror: &MirrorConfig{
+							Percentage: 150,
+							Pipelines:  []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "canary")},
+						},
+					},
+				},
+			},
+			error: "invalid mirror: percentage must be greater than 0 and less than or equal to 100",
+		},
+		{
+			name: "mirror without pipelines",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "otlp")},
+						Mirror:    &MirrorConfig{Percentage: 5},
+					},
+				},
+			},
+			error: "invalid mirror: no pipelines defined",
+		},
 	}
 
 	for _, tt := range tests {
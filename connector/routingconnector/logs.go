@@ -61,6 +61,7 @@ func (*logsConnector) Capabilities() consumer.Capabilities {
 
 func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	groups := make(map[consumer.Logs]plog.Logs)
+	mirrors := make(map[consumer.Logs]plog.Logs)
 	var errs error
 	for i := 0; i < len(c.router.routeSlice) && ld.ResourceLogs().Len() > 0; i++ {
 		route := c.router.routeSlice[i]
@@ -68,7 +69,7 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 		switch route.statementContext {
 		case "request":
 			if route.requestCondition.matchRequest(ctx) {
-				groupAllLogs(groups, route.consumer, ld)
+				routeLogs(groups, mirrors, route, ld)
 				ld = plog.NewLogs() // all logs have been routed
 			}
 		case "", "resource":
@@ -96,16 +97,54 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 			}
 			groupAllLogs(groups, c.router.defaultConsumer, matchedLogs)
 		}
-		groupAllLogs(groups, route.consumer, matchedLogs)
+		routeLogs(groups, mirrors, route, matchedLogs)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
 	groupAllLogs(groups, c.router.defaultConsumer, ld)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeLogs(ctx, group))
 	}
+	// failures of the mirror pipelines are not propagated
+	for consumer, group := range mirrors {
+		if err := consumer.ConsumeLogs(ctx, group); err != nil {
+			c.logger.Warn("failed to send data to mirror pipelines", zap.Error(err))
+		}
+	}
 	return errs
 }
 
+// routeLogs sends the data matched by the route to its consumer, or to the consumers
+// of its split targets, and copies the mirrored data to the consumer of its mirror.
+func routeLogs(
+	groups, mirrors map[consumer.Logs]plog.Logs,
+	route routingItem[consumer.Logs],
+	logs plog.Logs,
+) {
+	if route.mirror != nil {
+		candidates, mirrored := plog.NewLogs(), plog.NewLogs()
+		logs.CopyTo(candidates)
+		plogutil.MoveRecordsWithContextIf(candidates, mirrored,
+			func(rl plog.ResourceLogs, _ plog.ScopeLogs, lr plog.LogRecord) bool {
+				return route.mirror.sampled(logHash(route.mirror.hashKey, rl, lr))
+			},
+		)
+		groupAllLogs(mirrors, route.mirror.consumer, mirrored)
+	}
+	if route.split == nil {
+		groupAllLogs(groups, route.consumer, logs)
+		return
+	}
+	for i := 0; i < len(route.split.consumers) && logs.ResourceLogs().Len() > 0; i++ {
+		target := plog.NewLogs()
+		plogutil.MoveRecordsWithContextIf(logs, target,
+			func(rl plog.ResourceLogs, _ plog.ScopeLogs, lr plog.LogRecord) bool {
+				return route.split.target(logHash(route.split.hashKey, rl, lr)) == i
+			},
+		)
+		groupAllLogs(groups, route.split.consumers[i], target)
+	}
+}
+
 func groupAllLogs(
 	groups map[consumer.Logs]plog.Logs,
 	cons consumer.Logs,
@@ -5,6 +5,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -390,6 +391,49 @@ func TestLogsResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestLogsMirrorFailureIsNotPropagated(t *testing.T) {
+	logsOut := pipeline.NewIDWithName(pipeline.SignalLogs, "out")
+	logsCanary := pipeline.NewIDWithName(pipeline.SignalLogs, "canary")
+
+	cfg := &Config{
+		Table: []RoutingTableItem{{
+			Context:   "log",
+			Condition: `severity_text == "ERROR"`,
+			Pipelines: []pipeline.ID{logsOut},
+			Mirror: &MirrorConfig{
+				Percentage: 100,
+				Pipelines:  []pipeline.ID{logsCanary},
+			},
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var sinkOut consumertest.LogsSink
+	router := connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+		logsOut:    &sinkOut,
+		logsCanary: consumertest.NewErr(errors.New("canary failure")),
+	})
+
+	conn, err := NewFactory().CreateLogsToLogs(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Logs),
+	)
+	require.NoError(t, err)
+
+	input := plog.NewLogs()
+	records := input.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 10; i++ {
+		lr := records.AppendEmpty()
+		lr.SetSeverityText("ERROR")
+		lr.Body().SetInt(int64(i))
+	}
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), input))
+	assert.Equal(t, 10, sinkOut.LogRecordCount())
+}
+
 func TestLogsConnectorCapabilities(t *testing.T) {
 	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
 	logsOther := pipeline.NewIDWithName(pipeline.SignalLogs, "other")
@@ -62,6 +62,7 @@ func (*metricsConnector) Capabilities() consumer.Capabilities {
 
 func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	groups := make(map[consumer.Metrics]pmetric.Metrics)
+	mirrors := make(map[consumer.Metrics]pmetric.Metrics)
 	var errs error
 	for i := 0; i < len(c.router.routeSlice) && md.ResourceMetrics().Len() > 0; i++ {
 		route := c.router.routeSlice[i]
@@ -69,7 +70,7 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 		switch route.statementContext {
 		case "request":
 			if route.requestCondition.matchRequest(ctx) {
-				groupAllMetrics(groups, route.consumer, md)
+				routeMetrics(groups, mirrors, route, md)
 				md = pmetric.NewMetrics() // all metrics have been routed
 			}
 		case "", "resource":
@@ -106,16 +107,54 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 			}
 			groupAllMetrics(groups, c.router.defaultConsumer, matchedMetrics)
 		}
-		groupAllMetrics(groups, route.consumer, matchedMetrics)
+		routeMetrics(groups, mirrors, route, matchedMetrics)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
 	groupAllMetrics(groups, c.router.defaultConsumer, md)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeMetrics(ctx, group))
 	}
+	// failures of the mirror pipelines are not propagated
+	for consumer, group := range mirrors {
+		if err := consumer.ConsumeMetrics(ctx, group); err != nil {
+			c.logger.Warn("failed to send data to mirror pipelines", zap.Error(err))
+		}
+	}
 	return errs
 }
 
+// routeMetrics sends the data matched by the route to its consumer, or to the consumers
+// of its split targets, and copies the mirrored data to the consumer of its mirror.
+func routeMetrics(
+	groups, mirrors map[consumer.Metrics]pmetric.Metrics,
+	route routingItem[consumer.Metrics],
+	metrics pmetric.Metrics,
+) {
+	if route.mirror != nil {
+		candidates, mirrored := pmetric.NewMetrics(), pmetric.NewMetrics()
+		metrics.CopyTo(candidates)
+		pmetricutil.MoveDataPointsWithContextIf(candidates, mirrored,
+			func(rm pmetric.ResourceMetrics, _ pmetric.ScopeMetrics, m pmetric.Metric, dp any) bool {
+				return route.mirror.sampled(dataPointHash(route.mirror.hashKey, rm, m, dp))
+			},
+		)
+		groupAllMetrics(mirrors, route.mirror.consumer, mirrored)
+	}
+	if route.split == nil {
+		groupAllMetrics(groups, route.consumer, metrics)
+		return
+	}
+	for i := 0; i < len(route.split.consumers) && metrics.ResourceMetrics().Len() > 0; i++ {
+		target := pmetric.NewMetrics()
+		pmetricutil.MoveDataPointsWithContextIf(metrics, target,
+			func(rm pmetric.ResourceMetrics, _ pmetric.ScopeMetrics, m pmetric.Metric, dp any) bool {
+				return route.split.target(dataPointHash(route.split.hashKey, rm, m, dp)) == i
+			},
+		)
+		groupAllMetrics(groups, route.split.consumers[i], target)
+	}
+}
+
 func groupAllMetrics(
 	groups map[consumer.Metrics]pmetric.Metrics,
 	cons consumer.Metrics,
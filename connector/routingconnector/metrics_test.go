@@ -411,6 +411,78 @@ func TestMetricsResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestMetricsSplitByHashKey(t *testing.T) {
+	metricsA := pipeline.NewIDWithName(pipeline.SignalMetrics, "a")
+	metricsB := pipeline.NewIDWithName(pipeline.SignalMetrics, "b")
+
+	cfg := &Config{
+		Table: []RoutingTableItem{{
+			Context:   "datapoint",
+			Condition: `attributes["tenant"] != nil`,
+			Split: &SplitConfig{
+				HashKey: "tenant",
+				Targets: []SplitTarget{
+					{Pipelines: []pipeline.ID{metricsA}, Weight: 1},
+					{Pipelines: []pipeline.ID{metricsB}, Weight: 1},
+				},
+			},
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var sinkA, sinkB consumertest.MetricsSink
+	router := connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+		metricsA: &sinkA,
+		metricsB: &sinkB,
+	})
+
+	conn, err := NewFactory().CreateMetricsToMetrics(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Metrics),
+	)
+	require.NoError(t, err)
+
+	input := pmetric.NewMetrics()
+	metrics := input.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{"requests", "errors"} {
+		m := metrics.AppendEmpty()
+		m.SetName(name)
+		dps := m.SetEmptySum().DataPoints()
+		for i := 0; i < 50; i++ {
+			dp := dps.AppendEmpty()
+			dp.Attributes().PutInt("tenant", int64(i))
+		}
+	}
+
+	require.NoError(t, conn.ConsumeMetrics(t.Context(), input))
+	assert.Equal(t, 100, sinkA.DataPointCount()+sinkB.DataPointCount())
+
+	tenants := func(sink *consumertest.MetricsSink) map[int64]int {
+		found := make(map[int64]int)
+		for _, md := range sink.AllMetrics() {
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < ms.Len(); i++ {
+				dps := ms.At(i).Sum().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					tenant, _ := dps.At(j).Attributes().Get("tenant")
+					found[tenant.Int()]++
+				}
+			}
+		}
+		return found
+	}
+	tenantsA, tenantsB := tenants(&sinkA), tenants(&sinkB)
+	assert.NotEmpty(t, tenantsA)
+	assert.NotEmpty(t, tenantsB)
+	// the data points of both metrics are split by tenant
+	for tenant, count := range tenantsA {
+		assert.Equal(t, 2, count)
+		assert.NotContains(t, tenantsB, tenant)
+	}
+}
+
 func TestMetricsConnectorCapabilities(t *testing.T) {
 	metricsDefault := pipeline.NewIDWithName(pipeline.SignalMetrics, "default")
 	metricsOther := pipeline.NewIDWithName(pipeline.SignalMetrics, "other")
@@ -73,6 +73,8 @@ func newRouter[C any](
 
 type routingItem[C any] struct {
 	consumer           C
+	split              *splitter[C]
+	mirror             *mirror[C]
 	requestCondition   *requestCondition
 	resourceStatement  *ottl.Statement[ottlresource.TransformContext]
 	spanStatement      *ottl.Statement[ottlspan.TransformContext]
@@ -254,11 +256,9 @@ func (r *router[C]) registerRouteConsumers() (err error) {
 			r.logger.Warn(fmt.Sprintf(`Statement %q already exists in the routing table, the route with target pipeline(s) %q will be ignored.`, item.Statement, exporters))
 		}
 
-		consumer, err := r.consumerProvider(item.Pipelines...)
-		if err != nil {
+		if err = r.registerRouteTargets(&route, item); err != nil {
 			return fmt.Errorf("%w: %s", errPipelineNotFound, err.Error())
 		}
-		route.consumer = consumer
 		if !ok {
 			r.routeSlice = append(r.routeSlice, route)
 		}
@@ -268,6 +268,25 @@ func (r *router[C]) registerRouteConsumers() (err error) {
 	return nil
 }
 
+// registerRouteTargets registers the consumers of the pipelines a route sends its matched data to,
+// either directly, through a weighted split, and/or through a mirror.
+func (r *router[C]) registerRouteTargets(route *routingItem[C], item RoutingTableItem) (err error) {
+	var zero C
+	route.consumer, route.split, route.mirror = zero, nil, nil
+	if item.Split != nil {
+		route.split, err = newSplitter(item.Split, r.consumerProvider)
+	} else {
+		route.consumer, err = r.consumerProvider(item.Pipelines...)
+	}
+	if err != nil {
+		return err
+	}
+	if item.Mirror != nil {
+		route.mirror, err = newMirror(item.Mirror, r.consumerProvider)
+	}
+	return err
+}
+
 func key(entry RoutingTableItem) string {
 	switch entry.Context {
 	case "", "resource":
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// mirrorScale is the resolution of the mirrored percentage, 0.01%.
+const mirrorScale = 10000
+
+// splitter selects a target consumer for a key hash, proportionally to the
+// weights of the targets.
+type splitter[C any] struct {
+	hashKey   string
+	consumers []C
+	// cumulative contains the running sum of the target weights.
+	cumulative []uint64
+}
+
+func newSplitter[C any](cfg *SplitConfig, provider consumerProvider[C]) (*splitter[C], error) {
+	s := &splitter[C]{hashKey: cfg.HashKey}
+	var total uint64
+	for _, target := range cfg.Targets {
+		if target.Weight == 0 {
+			continue
+		}
+		consumer, err := provider(target.Pipelines...)
+		if err != nil {
+			return nil, err
+		}
+		total += uint64(target.Weight)
+		s.consumers = append(s.consumers, consumer)
+		s.cumulative = append(s.cumulative, total)
+	}
+	return s, nil
+}
+
+// target returns the index of the target consumer for the given key hash.
+func (s *splitter[C]) target(hash uint64) int {
+	point := hash % s.cumulative[len(s.cumulative)-1]
+	return sort.Search(len(s.cumulative), func(i int) bool {
+		return point < s.cumulative[i]
+	})
+}
+
+// mirror selects the data to copy to the mirror consumer.
+type mirror[C any] struct {
+	hashKey   string
+	consumer  C
+	threshold uint64
+}
+
+func newMirror[C any](cfg *MirrorConfig, provider consumerProvider[C]) (*mirror[C], error) {
+	consumer, err := provider(cfg.Pipelines...)
+	if err != nil {
+		return nil, err
+	}
+	return &mirror[C]{
+		hashKey:   cfg.HashKey,
+		consumer:  consumer,
+		threshold: uint64(math.Round(cfg.Percentage * mirrorScale / 100)),
+	}, nil
+}
+
+// sampled returns true if the data with the given key hash must be mirrored.
+// The hash is remixed so that the mirrored data is independent of the split targets
+// when both use the same key.
+func (m *mirror[C]) sampled(hash uint64) bool {
+	return mix(hash)%mirrorScale < m.threshold
+}
+
+// mix is the finalizer of splitmix64.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// attributeHash looks up the key in the given attributes, in order, and returns
+// the hash of the first value found.
+func attributeHash(key string, attrs ...pcommon.Map) (uint64, bool) {
+	for _, m := range attrs {
+		if v, ok := m.Get(key); ok {
+			return pdatautil.Hash64(pdatautil.WithValue(v)), true
+		}
+	}
+	return 0, false
+}
+
+func traceIDHash(id pcommon.TraceID) uint64 {
+	return pdatautil.Hash64(pdatautil.WithString(string(id[:])))
+}
+
+func spanHash(hashKey string, rs ptrace.ResourceSpans, s ptrace.Span) uint64 {
+	if hashKey != "" {
+		if h, ok := attributeHash(hashKey, s.Attributes(), rs.Resource().Attributes()); ok {
+			return h
+		}
+	}
+	return traceIDHash(s.TraceID())
+}
+
+func logHash(hashKey string, rl plog.ResourceLogs, lr plog.LogRecord) uint64 {
+	if hashKey != "" {
+		if h, ok := attributeHash(hashKey, lr.Attributes(), rl.Resource().Attributes()); ok {
+			return h
+		}
+	}
+	if !lr.TraceID().IsEmpty() {
+		return traceIDHash(lr.TraceID())
+	}
+	return pdatautil.Hash64(pdatautil.WithValue(lr.Body()))
+}
+
+func dataPointHash(hashKey string, rm pmetric.ResourceMetrics, m pmetric.Metric, dp any) uint64 {
+	attrs := dataPointAttributes(dp)
+	if hashKey != "" {
+		if h, ok := attributeHash(hashKey, attrs, rm.Resource().Attributes()); ok {
+			return h
+		}
+	}
+	// default to the identity of the stream the data point belongs to
+	return pdatautil.Hash64(
+		pdatautil.WithMap(rm.Resource().Attributes()),
+		pdatautil.WithString(m.Name()),
+		pdatautil.WithMap(attrs),
+	)
+}
+
+func dataPointAttributes(dp any) pcommon.Map {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		return dp.Attributes()
+	case pmetric.HistogramDataPoint:
+		return dp.Attributes()
+	case pmetric.ExponentialHistogramDataPoint:
+		return dp.Attributes()
+	case pmetric.SummaryDataPoint:
+		return dp.Attributes()
+	}
+	return pcommon.NewMap()
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func namedProvider(ids ...pipeline.ID) (string, error) {
+	return ids[0].String(), nil
+}
+
+func TestSplitterWeights(t *testing.T) {
+	s, err := newSplitter(&SplitConfig{
+		Targets: []SplitTarget{
+			{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "a")}, Weight: 3},
+			{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "disabled")}},
+			{Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "b")}, Weight: 1},
+		},
+	}, namedProvider)
+	require.NoError(t, err)
+	// targets without weight are not registered
+	require.Equal(t, []string{"traces/a", "traces/b"}, s.consumers)
+
+	counts := make([]int, len(s.consumers))
+	for i := uint64(0); i < 4000; i++ {
+		counts[s.target(mix(i))]++
+	}
+	assert.InDelta(t, 3000, counts[0], 150)
+	assert.InDelta(t, 1000, counts[1], 150)
+
+	// the same hash is always sent to the same target
+	for i := uint64(0); i < 100; i++ {
+		assert.Equal(t, s.target(i), s.target(i))
+	}
+}
+
+func TestMirrorSampled(t *testing.T) {
+	for _, percentage := range []float64{0.5, 10, 50, 100} {
+		m, err := newMirror(&MirrorConfig{
+			Percentage: percentage,
+			Pipelines:  []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "canary")},
+		}, namedProvider)
+		require.NoError(t, err)
+
+		var sampled int
+		for i := uint64(0); i < 100000; i++ {
+			if m.sampled(i) {
+				sampled++
+			}
+		}
+		assert.InDelta(t, percentage*1000, sampled, percentage*100+100, "percentage %v", percentage)
+	}
+}
+
+func TestSpanHash(t *testing.T) {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("tenant", "acme")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	first, second := spans.AppendEmpty(), spans.AppendEmpty()
+	first.SetTraceID([16]byte{1})
+	second.SetTraceID([16]byte{2})
+
+	// spans are keyed by trace ID by default
+	assert.NotEqual(t, spanHash("", rs, first), spanHash("", rs, second))
+	// the resource attribute is used when the span does not have the key
+	assert.Equal(t, spanHash("tenant", rs, first), spanHash("tenant", rs, second))
+	// the span attribute takes precedence over the resource attribute
+	second.Attributes().PutStr("tenant", "other")
+	assert.NotEqual(t, spanHash("tenant", rs, first), spanHash("tenant", rs, second))
+	// missing keys fall back to the trace ID
+	assert.Equal(t, spanHash("", rs, first), spanHash("missing", rs, first))
+}
+
+func TestLogHash(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	first, second, third := records.AppendEmpty(), records.AppendEmpty(), records.AppendEmpty()
+	first.Body().SetStr("hello")
+	second.Body().SetStr("hello")
+	third.Body().SetStr("hello")
+	third.SetTraceID([16]byte{1})
+
+	// records without trace ID are keyed by their body
+	assert.Equal(t, logHash("", rl, first), logHash("", rl, second))
+	assert.NotEqual(t, logHash("", rl, first), logHash("", rl, third))
+}
+
+func TestDataPointHash(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	dps := m.SetEmptySum().DataPoints()
+	first, second, third := dps.AppendEmpty(), dps.AppendEmpty(), dps.AppendEmpty()
+	first.Attributes().PutStr("path", "/a")
+	first.SetIntValue(1)
+	second.Attributes().PutStr("path", "/a")
+	second.SetIntValue(2)
+	third.Attributes().PutStr("path", "/b")
+
+	// data points of the same stream have the same key
+	assert.Equal(t, dataPointHash("", rm, m, first), dataPointHash("", rm, m, second))
+	assert.NotEqual(t, dataPointHash("", rm, m, first), dataPointHash("", rm, m, third))
+	assert.Equal(t, dataPointHash("service.name", rm, m, first), dataPointHash("service.name", rm, m, third))
+}
@@ -61,6 +61,7 @@ func (*tracesConnector) Capabilities() consumer.Capabilities {
 
 func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	groups := make(map[consumer.Traces]ptrace.Traces)
+	mirrors := make(map[consumer.Traces]ptrace.Traces)
 	var errs error
 	for i := 0; i < len(c.router.routeSlice) && td.ResourceSpans().Len() > 0; i++ {
 		route := c.router.routeSlice[i]
@@ -68,7 +69,7 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 		switch route.statementContext {
 		case "request":
 			if route.requestCondition.matchRequest(ctx) {
-				groupAllTraces(groups, route.consumer, td)
+				routeTraces(groups, mirrors, route, td)
 				td = ptrace.NewTraces() // all traces have been routed
 			}
 		case "", "resource":
@@ -96,16 +97,54 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 			}
 			groupAllTraces(groups, c.router.defaultConsumer, matchedSpans)
 		}
-		groupAllTraces(groups, route.consumer, matchedSpans)
+		routeTraces(groups, mirrors, route, matchedSpans)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
 	groupAllTraces(groups, c.router.defaultConsumer, td)
 	for consumer, group := range groups {
 		errs = errors.Join(errs, consumer.ConsumeTraces(ctx, group))
 	}
+	// failures of the mirror pipelines are not propagated
+	for consumer, group := range mirrors {
+		if err := consumer.ConsumeTraces(ctx, group); err != nil {
+			c.logger.Warn("failed to send data to mirror pipelines", zap.Error(err))
+		}
+	}
 	return errs
 }
 
+// routeTraces sends the data matched by the route to its consumer, or to the consumers
+// of its split targets, and copies the mirrored data to the consumer of its mirror.
+func routeTraces(
+	groups, mirrors map[consumer.Traces]ptrace.Traces,
+	route routingItem[consumer.Traces],
+	traces ptrace.Traces,
+) {
+	if route.mirror != nil {
+		candidates, mirrored := ptrace.NewTraces(), ptrace.NewTraces()
+		traces.CopyTo(candidates)
+		ptraceutil.MoveSpansWithContextIf(candidates, mirrored,
+			func(rs ptrace.ResourceSpans, _ ptrace.ScopeSpans, s ptrace.Span) bool {
+				return route.mirror.sampled(spanHash(route.mirror.hashKey, rs, s))
+			},
+		)
+		groupAllTraces(mirrors, route.mirror.consumer, mirrored)
+	}
+	if route.split == nil {
+		groupAllTraces(groups, route.consumer, traces)
+		return
+	}
+	for i := 0; i < len(route.split.consumers) && traces.ResourceSpans().Len() > 0; i++ {
+		target := ptrace.NewTraces()
+		ptraceutil.MoveSpansWithContextIf(traces, target,
+			func(rs ptrace.ResourceSpans, _ ptrace.ScopeSpans, s ptrace.Span) bool {
+				return route.split.target(spanHash(route.split.hashKey, rs, s)) == i
+			},
+		)
+		groupAllTraces(groups, route.split.consumers[i], target)
+	}
+}
+
 func groupAllTraces(
 	groups map[consumer.Traces]ptrace.Traces,
 	cons consumer.Traces,
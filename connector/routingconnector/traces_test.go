@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 
@@ -368,6 +369,83 @@ func TestTracesResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestTracesSplitAndMirror(t *testing.T) {
+	tracesStable := pipeline.NewIDWithName(pipeline.SignalTraces, "stable")
+	tracesNext := pipeline.NewIDWithName(pipeline.SignalTraces, "next")
+	tracesCanary := pipeline.NewIDWithName(pipeline.SignalTraces, "canary")
+
+	cfg := &Config{
+		Table: []RoutingTableItem{{
+			Condition: `attributes["X-Tenant"] == "acme"`,
+			Split: &SplitConfig{
+				Targets: []SplitTarget{
+					{Pipelines: []pipeline.ID{tracesStable}, Weight: 1},
+					{Pipelines: []pipeline.ID{tracesNext}, Weight: 1},
+				},
+			},
+			Mirror: &MirrorConfig{
+				Percentage: 100,
+				Pipelines:  []pipeline.ID{tracesCanary},
+			},
+		}},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var sinkStable, sinkNext, sinkCanary consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesStable: &sinkStable,
+		tracesNext:   &sinkNext,
+		tracesCanary: &sinkCanary,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Traces),
+	)
+	require.NoError(t, err)
+
+	input := ptrace.NewTraces()
+	rs := input.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("X-Tenant", "acme")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 100; i++ {
+		// two spans per trace
+		for j := 0; j < 2; j++ {
+			span := spans.AppendEmpty()
+			span.SetTraceID([16]byte{byte(i)})
+			span.SetSpanID([8]byte{byte(i), byte(j)})
+		}
+	}
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), input))
+	assert.Equal(t, 200, sinkCanary.SpanCount())
+
+	traceIDs := func(sink *consumertest.TracesSink) map[pcommon.TraceID]int {
+		ids := make(map[pcommon.TraceID]int)
+		for _, td := range sink.AllTraces() {
+			spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+			for i := 0; i < spans.Len(); i++ {
+				ids[spans.At(i).TraceID()]++
+			}
+		}
+		return ids
+	}
+	stableIDs, nextIDs := traceIDs(&sinkStable), traceIDs(&sinkNext)
+	assert.Equal(t, 200, sinkStable.SpanCount()+sinkNext.SpanCount())
+	assert.NotEmpty(t, stableIDs)
+	assert.NotEmpty(t, nextIDs)
+	// all the spans of a trace are sent to the same target
+	for id, count := range stableIDs {
+		assert.Equal(t, 2, count)
+		assert.NotContains(t, nextIDs, id)
+	}
+	for _, count := range nextIDs {
+		assert.Equal(t, 2, count)
+	}
+}
+
 func TestTraceConnectorCapabilities(t *testing.T) {
 	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
 	tracesOther := pipeline.NewIDWithName(pipeline.SignalTraces, "0")
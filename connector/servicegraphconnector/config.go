@@ -6,6 +6,8 @@ package servicegraphconnector // import "github.com/open-telemetry/opentelemetry
 import (
 	"errors"
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config defines the configuration options for servicegraphprocessor.
@@ -32,7 +34,7 @@ type Config struct {
 	// https://github.com/open-telemetry/opentelemetry-collector/blob/main/model/semconv/opentelemetry.go.
 	Dimensions []string `mapstructure:"dimensions"`
 
-	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
+	// Store contains the config for the store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
 
 	// CacheLoop is the time to cleans the cache periodically.
@@ -68,6 +70,14 @@ type StoreConfig struct {
 	MaxItems int `mapstructure:"max_items"`
 	// TTL is the time to live for items in the store.
 	TTL time.Duration `mapstructure:"ttl"`
+	// StorageID is the ID of a storage extension used to persist edges waiting for their matching span.
+	// When set, pairing survives restarts and works across collectors sharing the same storage backend.
+	// If unset, edges are kept in memory.
+	StorageID *component.ID `mapstructure:"storage"`
+	// InstanceID identifies this collector among the collectors sharing the storage. It must be
+	// unique among them and stable across restarts, e.g. the name of a StatefulSet pod.
+	// Only used with a storage extension.
+	InstanceID string `mapstructure:"instance_id"`
 
 	// prevent unkeyed literal initialization
 	_ struct{}
@@ -16,10 +16,12 @@ import (
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.uber.org/zap"
 
@@ -54,6 +56,13 @@ var (
 	defaultMetricsFlushInterval = 60 * time.Second // 1 DPM
 )
 
+const (
+	defaultStoreInstanceID = "default"
+	// minStoreOrphanTimeout is the minimum time after which the pending edges of a collector
+	// sharing the storage are adopted by the others, if it has not saved its index.
+	minStoreOrphanTimeout = time.Minute
+)
+
 type metricSeries struct {
 	dimensions  pcommon.Map
 	lastUpdated int64 // Used to remove stale series
@@ -62,11 +71,12 @@ type metricSeries struct {
 var _ processor.Traces = (*serviceGraphConnector)(nil)
 
 type serviceGraphConnector struct {
+	id              component.ID
 	config          *Config
 	logger          *zap.Logger
 	metricsConsumer consumer.Metrics
 
-	store *store.Store
+	store store.EdgeStore
 
 	startTime time.Time
 
@@ -159,8 +169,28 @@ func newConnector(set component.TelemetrySettings, config component.Config, next
 	}, nil
 }
 
-func (p *serviceGraphConnector) Start(context.Context, component.Host) error {
-	p.store = store.NewStore(p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
+func (p *serviceGraphConnector) Start(ctx context.Context, host component.Host) error {
+	if p.config.Store.StorageID == nil {
+		p.store = store.NewStore(p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
+	} else {
+		client, err := getStorageClient(ctx, host, *p.config.Store.StorageID, p.id)
+		if err != nil {
+			return err
+		}
+
+		p.store, err = store.NewPersistentStore(ctx, client, p.storeInstanceID(), p.storeOrphanTimeout(), p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
+		if err != nil {
+			return fmt.Errorf("failed to create persistent store: %w", err)
+		}
+	}
+
+	err := p.telemetryBuilder.RegisterConnectorServicegraphUnpairedEdgesCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		observer.Observe(int64(p.store.Len()))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
 	go p.metricFlushLoop(*p.config.MetricsFlushInterval)
 
@@ -207,12 +237,45 @@ func (p *serviceGraphConnector) flushMetrics(ctx context.Context) error {
 	return p.metricsConsumer.ConsumeMetrics(ctx, md)
 }
 
-func (p *serviceGraphConnector) Shutdown(context.Context) error {
+func (p *serviceGraphConnector) Shutdown(ctx context.Context) error {
 	p.logger.Info("Shutting down servicegraphconnector")
 	close(p.shutdownCh)
+	p.telemetryBuilder.Shutdown()
+
+	if p.store != nil {
+		return p.store.Close(ctx)
+	}
 	return nil
 }
 
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindConnector, componentID, "")
+}
+
+// storeInstanceID identifies this collector instance in a storage shared with other instances.
+func (p *serviceGraphConnector) storeInstanceID() string {
+	if p.config.Store.InstanceID == "" {
+		return defaultStoreInstanceID
+	}
+	return p.config.Store.InstanceID
+}
+
+// storeOrphanTimeout is the time after which the index of pending edges of an instance which did
+// not save it is considered orphaned. The instances save their index on every expiration loop.
+func (p *serviceGraphConnector) storeOrphanTimeout() time.Duration {
+	return max(minStoreOrphanTimeout, 10*max(p.config.Store.TTL, p.config.StoreExpirationLoop))
+}
+
 func (*serviceGraphConnector) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
@@ -307,17 +370,17 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 					continue
 				}
 
-				if errors.Is(err, store.ErrTooManyItems) {
+				if err != nil {
+					// The in-memory store only returns ErrTooManyItems, the persistent store may
+					// also fail to read or write edges.
+					if !errors.Is(err, store.ErrTooManyItems) {
+						p.logger.Warn("failed to upsert edge", zap.Error(err))
+					}
 					totalDroppedSpans++
 					p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
 					continue
 				}
 
-				// UpsertEdge will only return ErrTooManyItems
-				if err != nil {
-					return err
-				}
-
 				if isNew {
 					p.telemetryBuilder.ConnectorServicegraphTotalEdges.Add(ctx, 1)
 				}
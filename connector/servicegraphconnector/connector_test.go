@@ -52,6 +52,22 @@ func TestConnectorStart(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestConnectorStartMissingStorage(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	storageID := component.MustNewID("file_storage")
+	cfg.Store.StorageID = &storageID
+
+	procCreationParams := connectortest.NewNopSettings(metadata.Type)
+	traceConnector, err := factory.CreateTracesToMetrics(t.Context(), procCreationParams, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	smp := traceConnector.(*serviceGraphConnector)
+	err = smp.Start(t.Context(), componenttest.NewNopHost())
+	require.ErrorContains(t, err, "storage extension 'file_storage' not found")
+	require.NoError(t, smp.Shutdown(t.Context()))
+}
+
 func TestConnectorShutdown(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
@@ -0,0 +1,460 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
+
+import (
+	"container/list"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	edgeKeyPrefix  = "edge_"
+	indexKeyPrefix = "index_"
+	// instancesKey lists the IDs of the instances which saved an index to the storage.
+	instancesKey = "instances"
+)
+
+var _ EdgeStore = (*PersistentStore)(nil)
+
+// PersistentStore is an EdgeStore backed by a storage extension client. Edges are kept in the
+// storage so that a span can be paired with its counterpart after a restart, or by another
+// collector sharing the same storage backend.
+//
+// Each instance keeps a local index of the edges it created, which is used to expire them and
+// to enforce maxItems. The index is saved to the storage under an instance specific key so that
+// pending edges are still expired after a restart. The indexes of the instances which have not
+// saved them for orphanTimeout, e.g. replicas that were scaled down or renamed, are adopted on
+// start, so that their edges are expired and deleted rather than left in the storage.
+type PersistentStore struct {
+	client        storage.Client
+	instanceID    string
+	orphanTimeout time.Duration
+
+	mtx       sync.Mutex
+	l         *list.List
+	m         map[Key]*list.Element
+	dirty     bool
+	lastSaved time.Time
+
+	onComplete Callback
+	onExpire   Callback
+
+	ttl      time.Duration
+	maxItems int
+}
+
+// indexEntry is an element of the local index of pending edges.
+type indexEntry struct {
+	key        Key
+	expiration time.Time
+}
+
+type persistedIndex struct {
+	// Updated is the last time the index was saved, used to detect the orphaned indexes.
+	Updated int64                 `json:"updated"`
+	Entries []persistedIndexEntry `json:"entries"`
+}
+
+type persistedIndexEntry struct {
+	TraceID    string `json:"trace_id"`
+	SpanID     string `json:"span_id"`
+	Expiration int64  `json:"expiration"`
+}
+
+type persistedEdge struct {
+	TraceID          string            `json:"trace_id"`
+	SpanID           string            `json:"span_id"`
+	ConnectionType   ConnectionType    `json:"connection_type"`
+	ServerService    string            `json:"server_service"`
+	ClientService    string            `json:"client_service"`
+	ServerLatencySec float64           `json:"server_latency_sec"`
+	ClientLatencySec float64           `json:"client_latency_sec"`
+	Failed           bool              `json:"failed"`
+	Dimensions       map[string]string `json:"dimensions"`
+	Peer             map[string]string `json:"peer"`
+	VirtualNodeLabel VirtualNodeLabel  `json:"virtual_node_label"`
+	Expiration       int64             `json:"expiration"`
+}
+
+// NewPersistentStore creates a PersistentStore using the given storage client. The index of
+// pending edges previously saved under instanceID is loaded from the storage, along with the
+// indexes of the other instances which have not been saved for orphanTimeout.
+func NewPersistentStore(ctx context.Context, client storage.Client, instanceID string, orphanTimeout, ttl time.Duration, maxItems int, onComplete, onExpire Callback) (*PersistentStore, error) {
+	s := &PersistentStore{
+		client:        client,
+		instanceID:    instanceID,
+		orphanTimeout: orphanTimeout,
+
+		l: list.New(),
+		m: make(map[Key]*list.Element),
+
+		onComplete: onComplete,
+		onExpire:   onExpire,
+
+		ttl:      ttl,
+		maxItems: maxItems,
+	}
+
+	if err := s.loadIndexes(ctx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Len returns the number of edges created by this instance that are waiting for their matching span.
+func (s *PersistentStore) Len() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.l.Len()
+}
+
+// UpsertEdge fetches an Edge from the storage and updates it using the given callback. If the Edge
+// doesn't exist yet, it creates a new one with the default TTL.
+// If the Edge is complete after applying the callback, it's completed and removed.
+func (s *PersistentStore) UpsertEdge(key Key, update Callback) (isNew bool, err error) {
+	ctx := context.Background()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	storedEdge, err := s.getEdge(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	if storedEdge != nil {
+		update(storedEdge)
+
+		if storedEdge.isComplete() {
+			s.onComplete(storedEdge)
+			s.removeFromIndex(key)
+			if err := s.client.Delete(ctx, edgeKey(key)); err != nil {
+				return false, fmt.Errorf("failed to delete edge: %w", err)
+			}
+			return false, nil
+		}
+
+		return false, s.setEdge(ctx, storedEdge)
+	}
+
+	edge := newEdge(key, s.ttl)
+	update(edge)
+
+	if edge.isComplete() {
+		s.onComplete(edge)
+		return true, nil
+	}
+
+	// Check we can add new edges
+	if s.l.Len() >= s.maxItems {
+		return false, ErrTooManyItems
+	}
+
+	if err := s.setEdge(ctx, edge); err != nil {
+		return false, err
+	}
+
+	s.m[key] = s.l.PushBack(&indexEntry{key: key, expiration: edge.expiration})
+	s.dirty = true
+
+	return true, nil
+}
+
+// Expire evicts all expired items created by this instance. Edges that were completed by
+// another instance sharing the storage are dropped from the index without calling onExpire.
+func (s *PersistentStore) Expire() {
+	ctx := context.Background()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for {
+		head := s.l.Front()
+		if head == nil {
+			break
+		}
+
+		entry := head.Value.(*indexEntry)
+		if !time.Now().After(entry.expiration) {
+			break
+		}
+
+		edge, err := s.getEdge(ctx, entry.key)
+		if err != nil {
+			// Keep the entry, it will be retried on the next expiration loop.
+			break
+		}
+
+		if edge != nil {
+			s.onExpire(edge)
+			// A failed delete leaves the edge in the storage, where it can still be paired later.
+			_ = s.client.Delete(ctx, edgeKey(entry.key))
+		}
+
+		s.removeFromIndex(entry.key)
+	}
+
+	// The index is also saved periodically so that the other instances don't consider it orphaned.
+	if s.dirty || time.Since(s.lastSaved) >= s.orphanTimeout/2 {
+		_ = s.saveIndex(ctx)
+	}
+}
+
+// Close saves the index of pending edges and closes the storage client.
+func (s *PersistentStore) Close(ctx context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.saveIndex(ctx); err != nil {
+		return err
+	}
+
+	return s.client.Close(ctx)
+}
+
+// removeFromIndex must be called holding lock.
+func (s *PersistentStore) removeFromIndex(key Key) {
+	if ele, ok := s.m[key]; ok {
+		s.l.Remove(ele)
+		delete(s.m, key)
+		s.dirty = true
+	}
+}
+
+// getEdge must be called holding lock. It returns nil if the edge is not found.
+func (s *PersistentStore) getEdge(ctx context.Context, key Key) (*Edge, error) {
+	data, err := s.client.Get(ctx, edgeKey(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edge: %w", err)
+	}
+
+	// If key is not found, data and error is nil
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var pe persistedEdge
+	if err := json.Unmarshal(data, &pe); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal edge: %w", err)
+	}
+
+	e := &Edge{
+		Key:              key,
+		TraceID:          key.tid,
+		ConnectionType:   pe.ConnectionType,
+		ServerService:    pe.ServerService,
+		ClientService:    pe.ClientService,
+		ServerLatencySec: pe.ServerLatencySec,
+		ClientLatencySec: pe.ClientLatencySec,
+		Failed:           pe.Failed,
+		Dimensions:       pe.Dimensions,
+		expiration:       time.Unix(0, pe.Expiration),
+		Peer:             pe.Peer,
+		VirtualNodeLabel: pe.VirtualNodeLabel,
+	}
+	if e.Dimensions == nil {
+		e.Dimensions = make(map[string]string)
+	}
+	if e.Peer == nil {
+		e.Peer = make(map[string]string)
+	}
+
+	return e, nil
+}
+
+// setEdge must be called holding lock.
+func (s *PersistentStore) setEdge(ctx context.Context, e *Edge) error {
+	data, err := json.Marshal(persistedEdge{
+		TraceID:          hex.EncodeToString(e.Key.tid[:]),
+		SpanID:           hex.EncodeToString(e.Key.sid[:]),
+		ConnectionType:   e.ConnectionType,
+		ServerService:    e.ServerService,
+		ClientService:    e.ClientService,
+		ServerLatencySec: e.ServerLatencySec,
+		ClientLatencySec: e.ClientLatencySec,
+		Failed:           e.Failed,
+		Dimensions:       e.Dimensions,
+		Peer:             e.Peer,
+		VirtualNodeLabel: e.VirtualNodeLabel,
+		Expiration:       e.expiration.UnixNano(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal edge: %w", err)
+	}
+
+	if err := s.client.Set(ctx, edgeKey(e.Key), data); err != nil {
+		return fmt.Errorf("failed to store edge: %w", err)
+	}
+
+	return nil
+}
+
+// saveIndex must be called holding lock.
+func (s *PersistentStore) saveIndex(ctx context.Context, ops ...*storage.Operation) error {
+	now := time.Now()
+	entries := make([]persistedIndexEntry, 0, s.l.Len())
+	for ele := s.l.Front(); ele != nil; ele = ele.Next() {
+		entry := ele.Value.(*indexEntry)
+		entries = append(entries, persistedIndexEntry{
+			TraceID:    hex.EncodeToString(entry.key.tid[:]),
+			SpanID:     hex.EncodeToString(entry.key.sid[:]),
+			Expiration: entry.expiration.UnixNano(),
+		})
+	}
+
+	data, err := json.Marshal(persistedIndex{Updated: now.UnixNano(), Entries: entries})
+	if err != nil {
+		return fmt.Errorf("failed to marshal edge index: %w", err)
+	}
+
+	ops = append([]*storage.Operation{storage.SetOperation(indexKey(s.instanceID), data)}, ops...)
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to store edge index: %w", err)
+	}
+
+	s.dirty = false
+	s.lastSaved = now
+	return nil
+}
+
+// loadIndexes loads the index of this instance and adopts the orphaned indexes of the other
+// instances, which are deleted from the storage. The instance is registered in the list of
+// instances so that its index can in turn be adopted if it never comes back.
+func (s *PersistentStore) loadIndexes(ctx context.Context) error {
+	index, err := s.getIndex(ctx, s.instanceID)
+	if err != nil {
+		return err
+	}
+	var entries []indexEntry
+	if index != nil {
+		if entries, err = parseIndexEntries(index); err != nil {
+			return err
+		}
+	}
+
+	instances, err := s.getInstances(ctx)
+	if err != nil {
+		return err
+	}
+
+	registered := []string{s.instanceID}
+	var ops []*storage.Operation
+	for _, id := range instances {
+		if id == s.instanceID {
+			continue
+		}
+		orphan, err := s.getIndex(ctx, id)
+		if err != nil {
+			return err
+		}
+		if orphan == nil {
+			continue
+		}
+		if time.Since(time.Unix(0, orphan.Updated)) < s.orphanTimeout {
+			registered = append(registered, id)
+			continue
+		}
+		orphanEntries, err := parseIndexEntries(orphan)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, orphanEntries...)
+		ops = append(ops, storage.DeleteOperation(indexKey(id)))
+	}
+
+	// The adopted edges are merged with the ones of this instance in the order of their expiration
+	slices.SortStableFunc(entries, func(a, b indexEntry) int { return a.expiration.Compare(b.expiration) })
+	for _, entry := range entries {
+		if _, ok := s.m[entry.key]; !ok {
+			s.m[entry.key] = s.l.PushBack(&entry)
+		}
+	}
+
+	data, err := json.Marshal(registered)
+	if err != nil {
+		return fmt.Errorf("failed to marshal instances: %w", err)
+	}
+	return s.saveIndex(ctx, append(ops, storage.SetOperation(instancesKey, data))...)
+}
+
+// getIndex returns nil if the index of the instance is not found.
+func (s *PersistentStore) getIndex(ctx context.Context, instanceID string) (*persistedIndex, error) {
+	data, err := s.client.Get(ctx, indexKey(instanceID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get edge index: %w", err)
+	}
+
+	// If key is not found, data and error is nil
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var index persistedIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal edge index: %w", err)
+	}
+	return &index, nil
+}
+
+func (s *PersistentStore) getInstances(ctx context.Context) ([]string, error) {
+	data, err := s.client.Get(ctx, instancesKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instances: %w", err)
+	}
+
+	// If key is not found, data and error is nil
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var instances []string
+	if err := json.Unmarshal(data, &instances); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instances: %w", err)
+	}
+	return instances, nil
+}
+
+func parseIndexEntries(index *persistedIndex) ([]indexEntry, error) {
+	entries := make([]indexEntry, 0, len(index.Entries))
+	for _, pe := range index.Entries {
+		key, err := parseKey(pe.TraceID, pe.SpanID)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, indexEntry{key: key, expiration: time.Unix(0, pe.Expiration)})
+	}
+	return entries, nil
+}
+
+func indexKey(instanceID string) string {
+	return indexKeyPrefix + instanceID
+}
+
+func edgeKey(k Key) string {
+	return edgeKeyPrefix + hex.EncodeToString(k.tid[:]) + hex.EncodeToString(k.sid[:])
+}
+
+func parseKey(traceID, spanID string) (Key, error) {
+	var tid pcommon.TraceID
+	var sid pcommon.SpanID
+
+	if n, err := hex.Decode(tid[:], []byte(traceID)); err != nil || n != len(tid) {
+		return Key{}, fmt.Errorf("invalid trace id %q in edge index", traceID)
+	}
+	if n, err := hex.Decode(sid[:], []byte(spanID)); err != nil || n != len(sid) {
+		return Key{}, fmt.Errorf("invalid span id %q in edge index", spanID)
+	}
+
+	return NewKey(tid, sid), nil
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type mapClient struct {
+	mtx  sync.Mutex
+	data map[string][]byte
+	err  error
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{data: make(map[string][]byte)}
+}
+
+func (c *mapClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.data[key], nil
+}
+
+func (c *mapClient) Set(_ context.Context, key string, value []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.data[key] = value
+	return nil
+}
+
+func (c *mapClient) Delete(_ context.Context, key string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	delete(c.data, key)
+	return nil
+}
+
+func (c *mapClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*mapClient) Close(context.Context) error {
+	return nil
+}
+
+func TestPersistentStoreUpsertEdge(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := newMapClient()
+
+	var onCompletedCount int
+	var onExpireCount int
+
+	s, err := NewPersistentStore(t.Context(), client, "test", time.Hour, time.Hour, 1, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	require.NoError(t, err)
+	assert.Equal(t, 0, s.Len())
+
+	// Insert first half of an edge
+	isNew, err := s.UpsertEdge(key, func(e *Edge) {
+		e.ClientService = clientService
+		e.Dimensions["client_dim"] = "value"
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+	assert.Equal(t, 1, s.Len())
+	assert.Contains(t, client.data, edgeKey(key))
+
+	// Nothing should be evicted as TTL is set to 1h
+	s.Expire()
+	assert.Equal(t, 1, s.Len())
+	assert.Equal(t, 0, onExpireCount)
+
+	// Insert the second half of an edge
+	isNew, err = s.UpsertEdge(key, func(e *Edge) {
+		assert.Equal(t, clientService, e.ClientService)
+		assert.Equal(t, "value", e.Dimensions["client_dim"])
+		e.ServerService = "server"
+	})
+	require.NoError(t, err)
+	require.False(t, isNew)
+
+	// Edge is complete and should have been removed
+	assert.Equal(t, 0, s.Len())
+	assert.NotContains(t, client.data, edgeKey(key))
+	assert.Equal(t, 1, onCompletedCount)
+	assert.Equal(t, 0, onExpireCount)
+}
+
+func TestPersistentStoreUpsertEdge_errTooManyItems(t *testing.T) {
+	key1 := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	key2 := NewKey(pcommon.TraceID([16]byte{4, 5, 6}), pcommon.SpanID([8]byte{1, 2, 3}))
+
+	s, err := NewPersistentStore(t.Context(), newMapClient(), "test", time.Hour, time.Hour, 1, noopCallback, noopCallback)
+	require.NoError(t, err)
+
+	_, err = s.UpsertEdge(key1, func(e *Edge) {
+		e.ClientService = clientService
+	})
+	require.NoError(t, err)
+
+	_, err = s.UpsertEdge(key2, func(e *Edge) {
+		e.ClientService = clientService
+	})
+	require.ErrorIs(t, err, ErrTooManyItems)
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestPersistentStoreUpsertEdge_storageError(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := newMapClient()
+
+	s, err := NewPersistentStore(t.Context(), client, "test", time.Hour, time.Hour, 1, noopCallback, noopCallback)
+	require.NoError(t, err)
+
+	client.err = errors.New("storage unavailable")
+	_, err = s.UpsertEdge(key, func(e *Edge) {
+		e.ClientService = clientService
+	})
+	require.ErrorIs(t, err, client.err)
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestPersistentStoreSharedStorage(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := newMapClient()
+
+	var onCompletedCount int
+	var onExpireCount int
+
+	s1, err := NewPersistentStore(t.Context(), client, "instance-1", time.Hour, -time.Second, 10, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	require.NoError(t, err)
+	s2, err := NewPersistentStore(t.Context(), client, "instance-2", time.Hour, -time.Second, 10, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	require.NoError(t, err)
+
+	// The client span is received by the first instance
+	isNew, err := s1.UpsertEdge(key, func(e *Edge) {
+		e.ClientService = clientService
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+
+	// The server span is received by the second instance
+	isNew, err = s2.UpsertEdge(key, func(e *Edge) {
+		assert.Equal(t, clientService, e.ClientService)
+		e.ServerService = "server"
+	})
+	require.NoError(t, err)
+	require.False(t, isNew)
+	assert.Equal(t, 1, onCompletedCount)
+
+	// The first instance drops the completed edge from its index without expiring it
+	assert.Equal(t, 1, s1.Len())
+	s1.Expire()
+	assert.Equal(t, 0, s1.Len())
+	assert.Equal(t, 0, onExpireCount)
+}
+
+func TestPersistentStoreRestart(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := newMapClient()
+
+	var onCompletedCount int
+	var onExpireCount int
+
+	s, err := NewPersistentStore(t.Context(), client, "test", time.Hour, time.Hour, 10, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	require.NoError(t, err)
+
+	_, err = s.UpsertEdge(key, func(e *Edge) {
+		e.ClientService = clientService
+		e.expiration = time.UnixMicro(0)
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Close(t.Context()))
+
+	// The pending edge is loaded from the index and expired after a restart
+	s, err = NewPersistentStore(t.Context(), client, "test", time.Hour, time.Hour, 10, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Len())
+
+	s.Expire()
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, 0, onCompletedCount)
+	assert.Equal(t, 1, onExpireCount)
+	assert.NotContains(t, client.data, edgeKey(key))
+}
+
+func TestPersistentStoreAdoptOrphanedIndex(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := newMapClient()
+
+	var onExpireCount int
+
+	gone, err := NewPersistentStore(t.Context(), client, "gone", time.Hour, -time.Second, 10, noopCallback, noopCallback)
+	require.NoError(t, err)
+	_, err = gone.UpsertEdge(key, func(e *Edge) {
+		e.ClientService = clientService
+	})
+	require.NoError(t, err)
+	require.NoError(t, gone.Close(t.Context()))
+
+	// The index of the instance is recent, it is not adopted
+	s, err := NewPersistentStore(t.Context(), client, "test", time.Hour, time.Hour, 10, noopCallback, countingCallback(&onExpireCount))
+	require.NoError(t, err)
+	assert.Equal(t, 0, s.Len())
+	assert.Contains(t, client.data, indexKey("gone"))
+
+	// The index of the instance is orphaned, its edges are adopted and expired
+	s, err = NewPersistentStore(t.Context(), client, "test", 0, time.Hour, 10, noopCallback, countingCallback(&onExpireCount))
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.Len())
+	assert.NotContains(t, client.data, indexKey("gone"))
+
+	s.Expire()
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, 1, onExpireCount)
+	assert.NotContains(t, client.data, edgeKey(key))
+
+	instances, err := s.getInstances(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test"}, instances)
+}
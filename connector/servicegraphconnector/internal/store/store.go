@@ -5,6 +5,7 @@ package store // import "github.com/open-telemetry/opentelemetry-collector-contr
 
 import (
 	"container/list"
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -29,6 +30,23 @@ func NewKey(tid pcommon.TraceID, sid pcommon.SpanID) Key {
 	return Key{tid: tid, sid: sid}
 }
 
+// EdgeStore pairs client and server spans into edges.
+type EdgeStore interface {
+	// UpsertEdge fetches an Edge from the store and updates it using the given callback. If the Edge
+	// doesn't exist yet, it creates a new one with the default TTL.
+	// If the Edge is complete after applying the callback, it's completed and removed.
+	UpsertEdge(key Key, update Callback) (isNew bool, err error)
+	// Expire evicts all expired items in the store.
+	Expire()
+	// Len returns the number of edges waiting for their matching span.
+	Len() int
+	// Close releases any resources held by the store.
+	Close(ctx context.Context) error
+}
+
+var _ EdgeStore = (*Store)(nil)
+
+// Store is the in-memory EdgeStore.
 type Store struct {
 	l   *list.List
 	mtx sync.Mutex
@@ -59,11 +77,18 @@ func NewStore(ttl time.Duration, maxItems int, onComplete, onExpire Callback) *S
 	return s
 }
 
-// Len is only used for testing.
+// Len returns the number of edges waiting for their matching span.
 func (s *Store) Len() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
 	return s.l.Len()
 }
 
+// Close is a no-op for the in-memory store.
+func (*Store) Close(context.Context) error {
+	return nil
+}
+
 // UpsertEdge fetches an Edge from the store and updates it using the given callback. If the Edge
 // doesn't exist yet, it creates a new one with the default TTL.
 // If the Edge is complete after applying the callback, it's completed and removed.
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
@@ -32,12 +33,20 @@ const (
 	// error of less than 5%.
 	// Ref: https://opentelemetry.io/docs/specs/otel/metrics/sdk/#base2-exponential-bucket-histogram-aggregation
 	defaultExponentialHistogramMaxSize = 160
+	// defaultDistinctCountPrecision is the default precision of the
+	// HyperLogLog sketch used for distinct counts. A precision of 12
+	// uses 4KiB per data point with a standard error of ~1.6%.
+	defaultDistinctCountPrecision = 12
+	minDistinctCountPrecision     = 4
+	maxDistinctCountPrecision     = 18
 )
 
 var defaultHistogramBuckets = []float64{
 	2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 }
 
+var defaultSummaryQuantiles = []float64{0.5, 0.9, 0.99}
+
 // Regex for [key] selector after ExtractGrokPatterns
 var grokPatternKey = regexp.MustCompile(`ExtractGrokPatterns\([^)]*\)\s*\[[^\]]+\]`)
 
@@ -50,15 +59,37 @@ type Config struct {
 	Datapoints []MetricInfo `mapstructure:"datapoints"`
 	Logs       []MetricInfo `mapstructure:"logs"`
 	Profiles   []MetricInfo `mapstructure:"profiles"`
+	// Window, if set, aggregates the signals into tumbling windows based
+	// on the timestamp of the signal instead of producing metrics for each
+	// batch of consumed data.
+	Window configoptional.Optional[Window] `mapstructure:"window"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
+// Window configures tumbling windows for the metrics produced by the
+// connector.
+type Window struct {
+	// Size is the duration of each window. Windows are aligned to
+	// multiples of the size.
+	Size time.Duration `mapstructure:"size"`
+	// Lateness is the time a window is kept open after its end to
+	// accept out of order data. The watermark is computed as the
+	// latest timestamp observed minus the lateness, data older than
+	// the watermark is dropped.
+	Lateness time.Duration `mapstructure:"lateness"`
+}
+
 func (c *Config) Validate() error {
 	if len(c.Spans) == 0 && len(c.Datapoints) == 0 && len(c.Logs) == 0 && len(c.Profiles) == 0 {
 		return errors.New("no configuration provided, at least one should be specified")
 	}
 	var multiError error // collect all errors at once
+	if c.Window.HasValue() {
+		if err := c.Window.Get().validate(); err != nil {
+			multiError = errors.Join(multiError, fmt.Errorf("failed to validate window configuration: %w", err))
+		}
+	}
 	if len(c.Spans) > 0 {
 		parser, err := ottlspan.NewParser(
 			customottl.SpanFuncs(),
@@ -147,6 +178,16 @@ func (c *Config) Unmarshal(collectorCfg *confmap.Conf) error {
 	return nil
 }
 
+func (w *Window) validate() error {
+	if w.Size <= 0 {
+		return errors.New("size must be greater than 0")
+	}
+	if w.Lateness < 0 {
+		return errors.New("lateness must not be negative")
+	}
+	return nil
+}
+
 type Attribute struct {
 	Key          string `mapstructure:"key"`
 	Optional     bool   `mapstructure:"optional"`
@@ -169,8 +210,33 @@ type Sum struct {
 	Value string `mapstructure:"value"`
 }
 
+// GaugeAggregation defines how the values recorded for a gauge are
+// aggregated into a single data point.
+type GaugeAggregation string
+
+const (
+	GaugeAggregationLast GaugeAggregation = "last"
+	GaugeAggregationMin  GaugeAggregation = "min"
+	GaugeAggregationMax  GaugeAggregation = "max"
+)
+
 type Gauge struct {
-	Value string `mapstructure:"value"`
+	Value       string           `mapstructure:"value"`
+	Aggregation GaugeAggregation `mapstructure:"aggregation"`
+}
+
+// DistinctCount estimates the number of distinct values returned by
+// the value OTTL expression using a HyperLogLog sketch.
+type DistinctCount struct {
+	Value     string `mapstructure:"value"`
+	Precision uint8  `mapstructure:"precision"`
+}
+
+type Summary struct {
+	Quantiles []float64 `mapstructure:"quantiles"`
+	MaxSize   int32     `mapstructure:"max_size"`
+	Count     string    `mapstructure:"count"`
+	Value     string    `mapstructure:"value"`
 }
 
 // MetricInfo defines the structure of the metric produced by the connector.
@@ -192,6 +258,8 @@ type MetricInfo struct {
 	ExponentialHistogram configoptional.Optional[ExponentialHistogram] `mapstructure:"exponential_histogram"`
 	Sum                  configoptional.Optional[Sum]                  `mapstructure:"sum"`
 	Gauge                configoptional.Optional[Gauge]                `mapstructure:"gauge"`
+	DistinctCount        configoptional.Optional[DistinctCount]        `mapstructure:"distinct_count"`
+	Summary              configoptional.Optional[Summary]              `mapstructure:"summary"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -208,6 +276,24 @@ func (mi *MetricInfo) ensureDefaults() {
 			mi.ExponentialHistogram.Get().MaxSize = defaultExponentialHistogramMaxSize
 		}
 	}
+	if mi.Gauge.HasValue() {
+		if mi.Gauge.Get().Aggregation == "" {
+			mi.Gauge.Get().Aggregation = GaugeAggregationLast
+		}
+	}
+	if mi.DistinctCount.HasValue() {
+		if mi.DistinctCount.Get().Precision == 0 {
+			mi.DistinctCount.Get().Precision = defaultDistinctCountPrecision
+		}
+	}
+	if mi.Summary.HasValue() {
+		if len(mi.Summary.Get().Quantiles) == 0 {
+			mi.Summary.Get().Quantiles = defaultSummaryQuantiles
+		}
+		if mi.Summary.Get().MaxSize == 0 {
+			mi.Summary.Get().MaxSize = defaultExponentialHistogramMaxSize
+		}
+	}
 }
 
 func (mi *MetricInfo) validateAttributes() error {
@@ -266,9 +352,54 @@ func (mi *MetricInfo) validateSum() error {
 
 func (mi *MetricInfo) validateGauge() error {
 	if mi.Gauge.HasValue() {
-		if mi.Gauge.Get().Value == "" {
+		g := mi.Gauge.Get()
+		if g.Value == "" {
 			return errors.New("value must be defined for gauge metrics")
 		}
+		switch g.Aggregation {
+		case "", GaugeAggregationLast, GaugeAggregationMin, GaugeAggregationMax:
+		default:
+			return fmt.Errorf("unsupported aggregation %q, must be one of last, min or max", g.Aggregation)
+		}
+	}
+	return nil
+}
+
+func (mi *MetricInfo) validateDistinctCount() error {
+	if mi.DistinctCount.HasValue() {
+		dc := mi.DistinctCount.Get()
+		if dc.Value == "" {
+			return errors.New("value must be defined for distinct count metrics")
+		}
+		if dc.Precision < minDistinctCountPrecision || dc.Precision > maxDistinctCountPrecision {
+			return fmt.Errorf(
+				"precision must be between %d and %d, %d found",
+				minDistinctCountPrecision, maxDistinctCountPrecision, dc.Precision,
+			)
+		}
+	}
+	return nil
+}
+
+func (mi *MetricInfo) validateSummary() error {
+	if mi.Summary.HasValue() {
+		s := mi.Summary.Get()
+		if len(s.Quantiles) == 0 {
+			return errors.New("summary quantiles missing")
+		}
+		for _, q := range s.Quantiles {
+			if q < 0 || q > 1 {
+				return fmt.Errorf("quantiles must be between 0 and 1, %v found", q)
+			}
+		}
+		if _, err := structure.NewConfig(
+			structure.WithMaxSize(s.MaxSize),
+		).Validate(); err != nil {
+			return err
+		}
+		if s.Value == "" {
+			return errors.New("value OTTL statement is required")
+		}
 	}
 	return nil
 }
@@ -291,6 +422,12 @@ func validateMetricInfo[K any](mi MetricInfo, parser ottl.Parser[K]) error {
 	if err := mi.validateGauge(); err != nil {
 		return fmt.Errorf("gauge validation failed: %w", err)
 	}
+	if err := mi.validateDistinctCount(); err != nil {
+		return fmt.Errorf("distinct count validation failed: %w", err)
+	}
+	if err := mi.validateSummary(); err != nil {
+		return fmt.Errorf("summary validation failed: %w", err)
+	}
 
 	// Exactly one metric should be defined. Also, validate OTTL expressions,
 	// note that, here we only evaluate if statements are valid. Check for
@@ -340,6 +477,24 @@ func validateMetricInfo[K any](mi MetricInfo, parser ottl.Parser[K]) error {
 			}
 		}
 	}
+	if mi.DistinctCount.HasValue() {
+		metricsDefinedCount++
+		if _, err := parser.ParseValueExpression(mi.DistinctCount.Get().Value); err != nil {
+			return fmt.Errorf("failed to parse value OTTL expression for distinct count: %w", err)
+		}
+	}
+	if mi.Summary.HasValue() {
+		metricsDefinedCount++
+		s := mi.Summary.Get()
+		if s.Count != "" {
+			if _, err := parser.ParseValueExpression(s.Count); err != nil {
+				return fmt.Errorf("failed to parse count OTTL expression for summary: %w", err)
+			}
+		}
+		if _, err := parser.ParseValueExpression(s.Value); err != nil {
+			return fmt.Errorf("failed to parse value OTTL expression for summary: %w", err)
+		}
+	}
 	if metricsDefinedCount != 1 {
 		return fmt.Errorf("exactly one of the metrics must be defined, %d found", metricsDefinedCount)
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -91,6 +92,37 @@ func TestConfig(t *testing.T) {
 				fullErrorForSignal(t, "profiles", "sum validation failed"),
 			},
 		},
+		{
+			path: "invalid_gauge_aggregation",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "gauge validation failed: unsupported aggregation"),
+				fullErrorForSignal(t, "datapoints", "gauge validation failed: unsupported aggregation"),
+				fullErrorForSignal(t, "logs", "gauge validation failed: unsupported aggregation"),
+				fullErrorForSignal(t, "profiles", "gauge validation failed: unsupported aggregation"),
+			},
+		},
+		{
+			path: "invalid_distinct_count",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "distinct count validation failed: precision must be between 4 and 18"),
+				fullErrorForSignal(t, "datapoints", "distinct count validation failed: precision must be between 4 and 18"),
+				fullErrorForSignal(t, "logs", "distinct count validation failed: precision must be between 4 and 18"),
+				fullErrorForSignal(t, "profiles", "distinct count validation failed: precision must be between 4 and 18"),
+			},
+		},
+		{
+			path: "invalid_summary",
+			errorMsgs: []string{
+				fullErrorForSignal(t, "spans", "summary validation failed: quantiles must be between 0 and 1"),
+				fullErrorForSignal(t, "datapoints", "summary validation failed: quantiles must be between 0 and 1"),
+				fullErrorForSignal(t, "logs", "summary validation failed: quantiles must be between 0 and 1"),
+				fullErrorForSignal(t, "profiles", "summary validation failed: quantiles must be between 0 and 1"),
+			},
+		},
+		{
+			path:      "invalid_window",
+			errorMsgs: []string{"failed to validate window configuration: size must be greater than 0"},
+		},
 		{
 			path: "multiple_metric",
 			errorMsgs: []string{
@@ -224,6 +256,42 @@ func TestConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			path: "valid_windowed",
+			expected: &Config{
+				Logs: []MetricInfo{
+					{
+						Name:        "log.users.distinct_count",
+						Description: "Unique users per minute",
+						DistinctCount: configoptional.Some(DistinctCount{
+							Value:     `attributes["user.id"]`,
+							Precision: defaultDistinctCountPrecision,
+						}),
+					},
+					{
+						Name:        "log.duration.summary",
+						Description: "Summary of log record durations",
+						Summary: configoptional.Some(Summary{
+							Quantiles: defaultSummaryQuantiles,
+							MaxSize:   defaultExponentialHistogramMaxSize,
+							Value:     `attributes["duration"]`,
+						}),
+					},
+					{
+						Name:        "log.duration.last",
+						Description: "Last recorded log duration",
+						Gauge: configoptional.Some(Gauge{
+							Value:       `attributes["duration"]`,
+							Aggregation: GaugeAggregationLast,
+						}),
+					},
+				},
+				Window: configoptional.Some(Window{
+					Size:     time.Minute,
+					Lateness: 10 * time.Second,
+				}),
+			},
+		},
 	} {
 		t.Run(tc.path, func(t *testing.T) {
 			dir := filepath.Join("..", "testdata", "configs")
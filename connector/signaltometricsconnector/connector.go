@@ -5,7 +5,10 @@ package signaltometricsconnector // import "github.com/open-telemetry/openteleme
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -34,8 +37,69 @@ type signalToMetrics struct {
 	logMetricDefs     []model.MetricDef[ottllog.TransformContext]
 	profileMetricDefs []model.MetricDef[ottlprofile.TransformContext]
 
-	component.StartFunc
-	component.ShutdownFunc
+	// Windowed aggregations are only set if windows are configured, in
+	// which case windows refers to the windowed aggregation of the signal.
+	spanWindows    *aggregator.Windows[ottlspan.TransformContext]
+	dpWindows      *aggregator.Windows[ottldatapoint.TransformContext]
+	logWindows     *aggregator.Windows[ottllog.TransformContext]
+	profileWindows *aggregator.Windows[ottlprofile.TransformContext]
+	windows        windowFlusher
+	flushInterval  time.Duration
+
+	shutdownCh chan struct{}
+	wg         sync.WaitGroup
+}
+
+// maxWindowFlushInterval is the maximum interval at which closed windows
+// are checked for and flushed when no data is received.
+const maxWindowFlushInterval = time.Second
+
+type windowFlusher interface {
+	Flush(now time.Time, force bool) []pmetric.Metrics
+}
+
+func (sm *signalToMetrics) Start(context.Context, component.Host) error {
+	if sm.windows == nil {
+		return nil
+	}
+	sm.shutdownCh = make(chan struct{})
+	sm.wg.Add(1)
+	go func() {
+		defer sm.wg.Done()
+		ticker := time.NewTicker(sm.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sm.shutdownCh:
+				return
+			case <-ticker.C:
+				if err := sm.flushWindows(context.Background(), false); err != nil {
+					sm.logger.Warn("failed to export windowed metrics", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (sm *signalToMetrics) Shutdown(ctx context.Context) error {
+	if sm.windows == nil {
+		return nil
+	}
+	if sm.shutdownCh != nil {
+		close(sm.shutdownCh)
+		sm.wg.Wait()
+	}
+	// Flush all the open windows to avoid losing the data aggregated so far
+	return sm.flushWindows(ctx, true)
+}
+
+func (sm *signalToMetrics) flushWindows(ctx context.Context, force bool) error {
+	var errs error
+	for _, m := range sm.windows.Flush(time.Now(), force) {
+		errs = errors.Join(errs, sm.next.ConsumeMetrics(ctx, m))
+	}
+	return errs
 }
 
 func (*signalToMetrics) Capabilities() consumer.Capabilities {
@@ -80,13 +144,19 @@ func (sm *signalToMetrics) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 					}
 
 					filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
-					if err := aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, filteredSpanAttrs, 1); err != nil {
+					if err := aggregate(
+						ctx, sm.logger, aggregator, sm.spanWindows, span.EndTimestamp(),
+						tCtx, md, filteredResAttrs, filteredSpanAttrs,
+					); err != nil {
 						return err
 					}
 				}
 			}
 		}
 	}
+	if sm.windows != nil {
+		return sm.flushWindows(ctx, false)
+	}
 	aggregator.Finalize(sm.spanMetricDefs)
 	return sm.next.ConsumeMetrics(ctx, processedMetrics)
 }
@@ -109,7 +179,7 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 				metric := metrics.At(k)
 				for _, md := range sm.dpMetricDefs {
 					filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
-					aggregate := func(dp any, dpAttrs pcommon.Map) error {
+					aggregateDP := func(dp any, dpAttrs pcommon.Map, timestamp pcommon.Timestamp) error {
 						// The transform context is created from original attributes so that the
 						// OTTL expressions are also applied on the original attributes.
 						tCtx := ottldatapoint.NewTransformContext(dp, metric, metrics, scopeMetric.Scope(), resourceMetric.Resource(), scopeMetric, resourceMetric)
@@ -123,7 +193,10 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 								return nil
 							}
 						}
-						return aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, dpAttrs, 1)
+						return aggregate(
+							ctx, sm.logger, aggregator, sm.dpWindows, timestamp,
+							tCtx, md, filteredResAttrs, dpAttrs,
+						)
 					}
 
 					//exhaustive:enforce
@@ -136,7 +209,7 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 							if !ok {
 								continue
 							}
-							if err := aggregate(dp, filteredDPAttrs); err != nil {
+							if err := aggregateDP(dp, filteredDPAttrs, dp.Timestamp()); err != nil {
 								return err
 							}
 						}
@@ -148,7 +221,7 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 							if !ok {
 								continue
 							}
-							if err := aggregate(dp, filteredDPAttrs); err != nil {
+							if err := aggregateDP(dp, filteredDPAttrs, dp.Timestamp()); err != nil {
 								return err
 							}
 						}
@@ -160,7 +233,7 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 							if !ok {
 								continue
 							}
-							if err := aggregate(dp, filteredDPAttrs); err != nil {
+							if err := aggregateDP(dp, filteredDPAttrs, dp.Timestamp()); err != nil {
 								return err
 							}
 						}
@@ -172,7 +245,7 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 							if !ok {
 								continue
 							}
-							if err := aggregate(dp, filteredDPAttrs); err != nil {
+							if err := aggregateDP(dp, filteredDPAttrs, dp.Timestamp()); err != nil {
 								return err
 							}
 						}
@@ -184,7 +257,7 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 							if !ok {
 								continue
 							}
-							if err := aggregate(dp, filteredDPAttrs); err != nil {
+							if err := aggregateDP(dp, filteredDPAttrs, dp.Timestamp()); err != nil {
 								return err
 							}
 						}
@@ -195,6 +268,9 @@ func (sm *signalToMetrics) ConsumeMetrics(ctx context.Context, m pmetric.Metrics
 			}
 		}
 	}
+	if sm.windows != nil {
+		return sm.flushWindows(ctx, false)
+	}
 	aggregator.Finalize(sm.dpMetricDefs)
 	return sm.next.ConsumeMetrics(ctx, processedMetrics)
 }
@@ -235,13 +311,19 @@ func (sm *signalToMetrics) ConsumeLogs(ctx context.Context, logs plog.Logs) erro
 						}
 					}
 					filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
-					if err := aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, filteredLogAttrs, 1); err != nil {
+					if err := aggregate(
+						ctx, sm.logger, aggregator, sm.logWindows, logTimestamp(log),
+						tCtx, md, filteredResAttrs, filteredLogAttrs,
+					); err != nil {
 						return err
 					}
 				}
 			}
 		}
 	}
+	if sm.windows != nil {
+		return sm.flushWindows(ctx, false)
+	}
 	aggregator.Finalize(sm.logMetricDefs)
 	return sm.next.ConsumeMetrics(ctx, processedMetrics)
 }
@@ -286,13 +368,54 @@ func (sm *signalToMetrics) ConsumeProfiles(ctx context.Context, profiles pprofil
 						}
 					}
 					filteredResAttrs := md.FilterResourceAttributes(resourceAttrs, sm.collectorInstanceInfo)
-					if err := aggregator.Aggregate(ctx, tCtx, md, filteredResAttrs, filteredProfileAttrs, 1); err != nil {
+					if err := aggregate(
+						ctx, sm.logger, aggregator, sm.profileWindows, profile.Time(),
+						tCtx, md, filteredResAttrs, filteredProfileAttrs,
+					); err != nil {
 						return err
 					}
 				}
 			}
 		}
 	}
+	if sm.windows != nil {
+		return sm.flushWindows(ctx, false)
+	}
 	aggregator.Finalize(sm.profileMetricDefs)
 	return sm.next.ConsumeMetrics(ctx, processedMetrics)
 }
+
+// aggregate aggregates the data into the aggregator of the consumed batch
+// or, if windows are configured, into the window of the data's timestamp.
+func aggregate[K any](
+	ctx context.Context,
+	logger *zap.Logger,
+	batch *aggregator.Aggregator[K],
+	windows *aggregator.Windows[K],
+	timestamp pcommon.Timestamp,
+	tCtx K,
+	md model.MetricDef[K],
+	resAttrs, srcAttrs pcommon.Map,
+) error {
+	if windows == nil {
+		return batch.Aggregate(ctx, tCtx, md, resAttrs, srcAttrs, 1)
+	}
+	t := time.Now()
+	if timestamp != 0 {
+		t = timestamp.AsTime()
+	}
+	ok, err := windows.Aggregate(ctx, t, tCtx, md, resAttrs, srcAttrs, 1)
+	if !ok {
+		logger.Debug("window already closed, dropping late data", zap.String("name", md.Key.Name))
+	}
+	return err
+}
+
+// logTimestamp returns the timestamp of the log record, falling back to
+// the observed timestamp if it is not set.
+func logTimestamp(log plog.LogRecord) pcommon.Timestamp {
+	if log.Timestamp() != 0 {
+		return log.Timestamp()
+	}
+	return log.ObservedTimestamp()
+}
@@ -7,10 +7,12 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -21,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.uber.org/zap/zapcore"
@@ -108,6 +111,9 @@ func TestConnectorWithLogs(t *testing.T) {
 		"exponential_histograms",
 		"metric_identity",
 		"gauge",
+		"gauge_min_max",
+		"distinct_count",
+		"summary",
 	}
 
 	ctx, cancel := context.WithCancel(t.Context())
@@ -135,6 +141,106 @@ func TestConnectorWithLogs(t *testing.T) {
 	}
 }
 
+func TestConnectorWithLogsWindowed(t *testing.T) {
+	inputLogs, err := golden.ReadLogs(filepath.Join(testDataDir, "logs", "logs.yaml"))
+	require.NoError(t, err)
+	// All the log records of the input share the same timestamp
+	timestamp := inputLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Timestamp().AsTime()
+	windowStart := timestamp.Truncate(time.Minute)
+
+	laterLogs := plog.NewLogs()
+	inputLogs.CopyTo(laterLogs)
+	for i := 0; i < laterLogs.ResourceLogs().Len(); i++ {
+		scopeLogs := laterLogs.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			logRecords := scopeLogs.At(j).LogRecords()
+			for k := 0; k < logRecords.Len(); k++ {
+				logRecords.At(k).SetTimestamp(pcommon.NewTimestampFromTime(timestamp.Add(2 * time.Minute)))
+			}
+		}
+	}
+
+	cfg := &config.Config{
+		Logs: []config.MetricInfo{
+			{
+				Name:        "total.logrecords.sum",
+				Description: "Count total number of log records",
+				Sum:         configoptional.Some(config.Sum{Value: "1"}),
+			},
+		},
+		Window: configoptional.Some(config.Window{
+			Size:     time.Minute,
+			Lateness: 10 * time.Second,
+		}),
+	}
+	require.NoError(t, cfg.Unmarshal(confmap.New())) // set required fields to default
+	require.NoError(t, cfg.Validate())
+
+	next := &consumertest.MetricsSink{}
+	connector, err := NewFactory().(xconnector.Factory).CreateLogsToMetrics(
+		t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, next,
+	)
+	require.NoError(t, err)
+	require.NoError(t, connector.Start(t.Context(), componenttest.NewNopHost()))
+
+	// The window is kept open until the watermark passes its end
+	require.NoError(t, connector.ConsumeLogs(t.Context(), inputLogs))
+	require.Empty(t, next.AllMetrics())
+
+	require.NoError(t, connector.ConsumeLogs(t.Context(), laterLogs))
+	require.Len(t, next.AllMetrics(), 1)
+	assertWindowedSum(t, next.AllMetrics()[0], windowStart, windowStart.Add(time.Minute), 4)
+
+	// Data for a closed window is dropped
+	require.NoError(t, connector.ConsumeLogs(t.Context(), inputLogs))
+	require.Len(t, next.AllMetrics(), 1)
+
+	// Open windows are flushed on shutdown
+	require.NoError(t, connector.Shutdown(t.Context()))
+	require.Len(t, next.AllMetrics(), 2)
+	assertWindowedSum(t, next.AllMetrics()[1], windowStart.Add(2*time.Minute), windowStart.Add(3*time.Minute), 4)
+}
+
+func TestConnectorWithLogsWindowedDeadline(t *testing.T) {
+	inputLogs, err := golden.ReadLogs(filepath.Join(testDataDir, "logs", "logs.yaml"))
+	require.NoError(t, err)
+	timestamp := inputLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Timestamp().AsTime()
+	windowStart := timestamp.Truncate(time.Minute)
+
+	cfg := &config.Config{
+		Logs: []config.MetricInfo{
+			{
+				Name:        "total.logrecords.sum",
+				Description: "Count total number of log records",
+				Sum:         configoptional.Some(config.Sum{Value: "1"}),
+			},
+		},
+		Window: configoptional.Some(config.Window{
+			Size:     time.Minute,
+			Lateness: 10 * time.Second,
+		}),
+	}
+	require.NoError(t, cfg.Unmarshal(confmap.New())) // set required fields to default
+	require.NoError(t, cfg.Validate())
+
+	next := &consumertest.MetricsSink{}
+	connector, err := NewFactory().(xconnector.Factory).CreateLogsToMetrics(
+		t.Context(), connectortest.NewNopSettings(metadata.Type), cfg, next,
+	)
+	require.NoError(t, err)
+	sm := connector.(*signalToMetrics)
+
+	// The window is closed at its deadline although the watermark has not passed its end
+	require.NoError(t, connector.ConsumeLogs(t.Context(), inputLogs))
+	flushed := sm.windows.Flush(time.Now().Add(time.Hour), false)
+	require.Len(t, flushed, 1)
+	assertWindowedSum(t, flushed[0], windowStart, windowStart.Add(time.Minute), 4)
+
+	// Late data for the closed window is dropped rather than reopening the window
+	require.NoError(t, connector.ConsumeLogs(t.Context(), inputLogs))
+	assert.Empty(t, sm.windows.Flush(time.Now(), true))
+}
+
 func TestConnectorWithProfiles(t *testing.T) {
 	testCases := []string{
 		"sum",
@@ -354,6 +460,15 @@ func telemetryResource(t *testing.T) pcommon.Resource {
 	return r
 }
 
+func assertWindowedSum(t *testing.T, md pmetric.Metrics, start, end time.Time, expected int64) {
+	t.Helper()
+	require.Equal(t, 1, md.DataPointCount())
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, expected, dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dp.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(end), dp.Timestamp())
+}
+
 func assertAggregatedMetrics(t *testing.T, expected, actual pmetric.Metrics) {
 	t.Helper()
 	assert.NoError(t, pmetrictest.CompareMetrics(
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/customottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
@@ -59,14 +60,21 @@ func createTracesToMetrics(
 		metricDefs = append(metricDefs, md)
 	}
 
-	return &signalToMetrics{
+	sm := &signalToMetrics{
 		logger: set.Logger,
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
 		next:           nextConsumer,
 		spanMetricDefs: metricDefs,
-	}, nil
+	}
+	if c.Window.HasValue() {
+		w := c.Window.Get()
+		sm.spanWindows = aggregator.NewWindows(w.Size, w.Lateness, metricDefs)
+		sm.windows = sm.spanWindows
+		sm.flushInterval = min(w.Size, maxWindowFlushInterval)
+	}
+	return sm, nil
 }
 
 func createMetricsToMetrics(
@@ -90,14 +98,21 @@ func createMetricsToMetrics(
 		metricDefs = append(metricDefs, md)
 	}
 
-	return &signalToMetrics{
+	sm := &signalToMetrics{
 		logger: set.Logger,
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
 		next:         nextConsumer,
 		dpMetricDefs: metricDefs,
-	}, nil
+	}
+	if c.Window.HasValue() {
+		w := c.Window.Get()
+		sm.dpWindows = aggregator.NewWindows(w.Size, w.Lateness, metricDefs)
+		sm.windows = sm.dpWindows
+		sm.flushInterval = min(w.Size, maxWindowFlushInterval)
+	}
+	return sm, nil
 }
 
 func createLogsToMetrics(
@@ -121,14 +136,21 @@ func createLogsToMetrics(
 		metricDefs = append(metricDefs, md)
 	}
 
-	return &signalToMetrics{
+	sm := &signalToMetrics{
 		logger: set.Logger,
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
 		next:          nextConsumer,
 		logMetricDefs: metricDefs,
-	}, nil
+	}
+	if c.Window.HasValue() {
+		w := c.Window.Get()
+		sm.logWindows = aggregator.NewWindows(w.Size, w.Lateness, metricDefs)
+		sm.windows = sm.logWindows
+		sm.flushInterval = min(w.Size, maxWindowFlushInterval)
+	}
+	return sm, nil
 }
 
 func createProfilesToMetrics(
@@ -152,12 +174,19 @@ func createProfilesToMetrics(
 		metricDefs = append(metricDefs, md)
 	}
 
-	return &signalToMetrics{
+	sm := &signalToMetrics{
 		logger: set.Logger,
 		collectorInstanceInfo: model.NewCollectorInstanceInfo(
 			set.TelemetrySettings,
 		),
 		next:              nextConsumer,
 		profileMetricDefs: metricDefs,
-	}, nil
+	}
+	if c.Window.HasValue() {
+		w := c.Window.Get()
+		sm.profileWindows = aggregator.NewWindows(w.Size, w.Lateness, metricDefs)
+		sm.windows = sm.profileWindows
+		sm.flushInterval = min(w.Size, maxWindowFlushInterval)
+	}
+	return sm, nil
 }
@@ -29,7 +29,10 @@ type Aggregator[K any] struct {
 	valueCounts map[model.MetricKey]map[[16]byte]map[[16]byte]*valueCountDP
 	sums        map[model.MetricKey]map[[16]byte]map[[16]byte]*sumDP
 	gauges      map[model.MetricKey]map[[16]byte]map[[16]byte]*gaugeDP
-	timestamp   time.Time
+	distincts   map[model.MetricKey]map[[16]byte]map[[16]byte]*distinctCountDP
+	// startTimestamp is only set for windowed aggregations.
+	startTimestamp time.Time
+	timestamp      time.Time
 }
 
 // NewAggregator creates a new instance of aggregator.
@@ -40,10 +43,20 @@ func NewAggregator[K any](metrics pmetric.Metrics) *Aggregator[K] {
 		valueCounts: make(map[model.MetricKey]map[[16]byte]map[[16]byte]*valueCountDP),
 		sums:        make(map[model.MetricKey]map[[16]byte]map[[16]byte]*sumDP),
 		gauges:      make(map[model.MetricKey]map[[16]byte]map[[16]byte]*gaugeDP),
+		distincts:   make(map[model.MetricKey]map[[16]byte]map[[16]byte]*distinctCountDP),
 		timestamp:   time.Now(),
 	}
 }
 
+// newWindowAggregator creates a new instance of aggregator for the
+// window starting at start and ending at end.
+func newWindowAggregator[K any](start, end time.Time) *Aggregator[K] {
+	a := NewAggregator[K](pmetric.NewMetrics())
+	a.startTimestamp = start
+	a.timestamp = end
+	return a
+}
+
 func (a *Aggregator[K]) Aggregate(
 	ctx context.Context,
 	tCtx K,
@@ -74,6 +87,17 @@ func (a *Aggregator[K]) Aggregate(
 			return err
 		}
 		return a.aggregateValueCount(md, resAttrs, srcAttrs, val, count)
+	case pmetric.MetricTypeSummary:
+		val, count, err := getValueCount(
+			ctx, tCtx,
+			md.Summary.Value,
+			md.Summary.Count,
+			defaultCount,
+		)
+		if err != nil {
+			return err
+		}
+		return a.aggregateValueCount(md, resAttrs, srcAttrs, val, count)
 	case pmetric.MetricTypeSum:
 		raw, err := md.Sum.Value.Eval(ctx, tCtx)
 		if err != nil {
@@ -91,6 +115,20 @@ func (a *Aggregator[K]) Aggregate(
 			)
 		}
 	case pmetric.MetricTypeGauge:
+		if md.DistinctCount != nil {
+			raw, err := md.DistinctCount.Value.Eval(ctx, tCtx)
+			if err != nil {
+				return fmt.Errorf("failed to execute OTTL value for distinct count: %w", err)
+			}
+			if raw == nil {
+				return nil
+			}
+			v, err := getDistinctValue(raw)
+			if err != nil {
+				return err
+			}
+			return a.aggregateDistinct(md, resAttrs, srcAttrs, v)
+		}
 		raw, err := md.Gauge.Value.Eval(ctx, tCtx)
 		if err != nil {
 			if strings.Contains(err.Error(), "key not found in map") {
@@ -126,6 +164,7 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 			var (
 				destExpHist      pmetric.ExponentialHistogram
 				destExplicitHist pmetric.Histogram
+				destSummary      pmetric.Summary
 			)
 			switch md.Key.Type {
 			case pmetric.MetricTypeExponentialHistogram:
@@ -144,12 +183,21 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 				destExplicitHist = destMetric.SetEmptyHistogram()
 				destExplicitHist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 				destExplicitHist.DataPoints().EnsureCapacity(len(dpMap))
+			case pmetric.MetricTypeSummary:
+				destMetric := metrics.AppendEmpty()
+				destMetric.SetName(md.Key.Name)
+				destMetric.SetUnit(md.Key.Unit)
+				destMetric.SetDescription(md.Key.Description)
+				destSummary = destMetric.SetEmptySummary()
+				destSummary.DataPoints().EnsureCapacity(len(dpMap))
 			}
 			for _, dp := range dpMap {
 				dp.Copy(
+					a.startTimestamp,
 					a.timestamp,
 					destExpHist,
 					destExplicitHist,
+					destSummary,
 				)
 			}
 		}
@@ -166,7 +214,7 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 			destCounter.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 			destCounter.DataPoints().EnsureCapacity(len(dpMap))
 			for _, dp := range dpMap {
-				dp.Copy(a.timestamp, destCounter.DataPoints().AppendEmpty())
+				dp.Copy(a.startTimestamp, a.timestamp, destCounter.DataPoints().AppendEmpty())
 			}
 		}
 		for resID, dpMap := range a.gauges[md.Key] {
@@ -181,7 +229,22 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 			destGauge := destMetric.SetEmptyGauge()
 			destGauge.DataPoints().EnsureCapacity(len(dpMap))
 			for _, dp := range dpMap {
-				dp.Copy(a.timestamp, destGauge.DataPoints().AppendEmpty())
+				dp.Copy(a.startTimestamp, a.timestamp, destGauge.DataPoints().AppendEmpty())
+			}
+		}
+		for resID, dpMap := range a.distincts[md.Key] {
+			if md.DistinctCount == nil {
+				continue
+			}
+			metrics := a.smLookup[resID].Metrics()
+			destMetric := metrics.AppendEmpty()
+			destMetric.SetName(md.Key.Name)
+			destMetric.SetUnit(md.Key.Unit)
+			destMetric.SetDescription(md.Key.Description)
+			destGauge := destMetric.SetEmptyGauge()
+			destGauge.DataPoints().EnsureCapacity(len(dpMap))
+			for _, dp := range dpMap {
+				dp.Copy(a.startTimestamp, a.timestamp, destGauge.DataPoints().AppendEmpty())
 			}
 		}
 		// If there are two metric defined with the same key required by metricKey
@@ -190,6 +253,7 @@ func (a *Aggregator[K]) Finalize(mds []model.MetricDef[K]) {
 		delete(a.valueCounts, md.Key)
 		delete(a.sums, md.Key)
 		delete(a.gauges, md.Key)
+		delete(a.distincts, md.Key)
 	}
 }
 
@@ -247,12 +311,32 @@ func (a *Aggregator[K]) aggregateGauge(
 		a.gauges[md.Key][resID] = make(map[[16]byte]*gaugeDP)
 	}
 	if _, ok := a.gauges[md.Key][resID][attrID]; !ok {
-		a.gauges[md.Key][resID][attrID] = newGaugeDP(srcAttrs)
+		a.gauges[md.Key][resID][attrID] = newGaugeDP(srcAttrs, md.Gauge.Aggregation)
 	}
 	a.gauges[md.Key][resID][attrID].Aggregate(v)
 	return nil
 }
 
+func (a *Aggregator[K]) aggregateDistinct(
+	md model.MetricDef[K],
+	resAttrs, srcAttrs pcommon.Map,
+	v string,
+) error {
+	resID := a.getResourceID(resAttrs)
+	attrID := pdatautil.MapHash(srcAttrs)
+	if _, ok := a.distincts[md.Key]; !ok {
+		a.distincts[md.Key] = make(map[[16]byte]map[[16]byte]*distinctCountDP)
+	}
+	if _, ok := a.distincts[md.Key][resID]; !ok {
+		a.distincts[md.Key][resID] = make(map[[16]byte]*distinctCountDP)
+	}
+	if _, ok := a.distincts[md.Key][resID][attrID]; !ok {
+		a.distincts[md.Key][resID][attrID] = newDistinctCountDP(srcAttrs, md.DistinctCount.Precision)
+	}
+	a.distincts[md.Key][resID][attrID].Aggregate(v)
+	return nil
+}
+
 func (a *Aggregator[K]) aggregateValueCount(
 	md model.MetricDef[K],
 	resAttrs, srcAttrs pcommon.Map,
@@ -314,6 +398,35 @@ func getValueCount[K any](
 	return val, count, nil
 }
 
+// getDistinctValue returns the string representation of a value returned
+// by an OTTL expression. Values are compared by their string representation
+// for distinct counts.
+func getDistinctValue(raw any) (string, error) {
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	case pcommon.Value:
+		return v.AsString(), nil
+	case pcommon.Map:
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val.SetEmptyMap())
+		return val.AsString(), nil
+	case pcommon.Slice:
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val.SetEmptySlice())
+		return val.AsString(), nil
+	default:
+		val := pcommon.NewValueEmpty()
+		if err := val.FromRaw(v); err != nil {
+			return "", fmt.Errorf(
+				"failed to parse distinct count OTTL value of type %T: %w",
+				v, err,
+			)
+		}
+		return val.AsString(), nil
+	}
+}
+
 func getIntFromOTTL[K any](
 	ctx context.Context,
 	tCtx K,
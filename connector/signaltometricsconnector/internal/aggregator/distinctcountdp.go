@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// distinctCountDP estimates the number of distinct values recorded for
+// the attribute set.
+type distinctCountDP struct {
+	attrs pcommon.Map
+	hll   *hyperLogLog
+}
+
+func newDistinctCountDP(attrs pcommon.Map, precision uint8) *distinctCountDP {
+	return &distinctCountDP{
+		attrs: attrs,
+		hll:   newHyperLogLog(precision),
+	}
+}
+
+func (dp *distinctCountDP) Aggregate(v string) {
+	dp.hll.Insert(v)
+}
+
+func (dp *distinctCountDP) Copy(
+	startTimestamp, timestamp time.Time,
+	dest pmetric.NumberDataPoint,
+) {
+	dp.attrs.CopyTo(dest.Attributes())
+	dest.SetIntValue(int64(dp.hll.Estimate()))
+	setTimestamps(startTimestamp, timestamp, dest)
+}
@@ -30,7 +30,7 @@ func (dp *exponentialHistogramDP) Aggregate(value float64, count int64) {
 }
 
 func (dp *exponentialHistogramDP) Copy(
-	timestamp time.Time,
+	startTimestamp, timestamp time.Time,
 	dest pmetric.ExponentialHistogramDataPoint,
 ) {
 	dp.attrs.CopyTo(dest.Attributes())
@@ -42,8 +42,7 @@ func (dp *exponentialHistogramDP) Copy(
 		dest.SetMin(dp.data.Min())
 		dest.SetMax(dp.data.Max())
 	}
-	// TODO determine appropriate start time
-	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	setTimestamps(startTimestamp, timestamp, dest)
 
 	copyBucketRange(dp.data.Positive(), dest.Positive())
 	copyBucketRange(dp.data.Negative(), dest.Negative())
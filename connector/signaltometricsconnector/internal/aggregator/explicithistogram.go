@@ -48,7 +48,7 @@ func (dp *explicitHistogramDP) Aggregate(value float64, count int64) {
 }
 
 func (dp *explicitHistogramDP) Copy(
-	timestamp time.Time,
+	startTimestamp, timestamp time.Time,
 	dest pmetric.HistogramDataPoint,
 ) {
 	dp.attrs.CopyTo(dest.Attributes())
@@ -56,6 +56,5 @@ func (dp *explicitHistogramDP) Copy(
 	dest.BucketCounts().FromRaw(dp.counts)
 	dest.SetCount(dp.count)
 	dest.SetSum(dp.sum)
-	// TODO determine appropriate start time
-	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	setTimestamps(startTimestamp, timestamp, dest)
 }
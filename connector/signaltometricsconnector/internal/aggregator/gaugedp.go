@@ -8,32 +8,52 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/config"
 )
 
-// gaugeDP is a data point for gauge metrics.
+// gaugeDP is a data point for gauge metrics. Depending on the aggregation
+// it records the last, the minimum or the maximum value.
 type gaugeDP struct {
-	attrs pcommon.Map
-	val   any
+	attrs       pcommon.Map
+	aggregation config.GaugeAggregation
+	val         any
 }
 
-func newGaugeDP(attrs pcommon.Map) *gaugeDP {
+func newGaugeDP(attrs pcommon.Map, aggregation config.GaugeAggregation) *gaugeDP {
 	return &gaugeDP{
-		attrs: attrs,
+		attrs:       attrs,
+		aggregation: aggregation,
 	}
 }
 
 func (dp *gaugeDP) Aggregate(v any) {
-	switch v := v.(type) {
+	switch v.(type) {
 	case float64, int64:
-		dp.val = v
 	default:
 		panic("unexpected usage of gauge datapoint, only double or int value expected")
 	}
+	if dp.val == nil {
+		dp.val = v
+		return
+	}
+	switch dp.aggregation {
+	case config.GaugeAggregationMin:
+		if toFloat64(v) < toFloat64(dp.val) {
+			dp.val = v
+		}
+	case config.GaugeAggregationMax:
+		if toFloat64(v) > toFloat64(dp.val) {
+			dp.val = v
+		}
+	default:
+		dp.val = v
+	}
 }
 
 // Copy copies the gauge data point to the destination number data point
 func (dp *gaugeDP) Copy(
-	timestamp time.Time,
+	startTimestamp, timestamp time.Time,
 	dest pmetric.NumberDataPoint,
 ) {
 	dp.attrs.CopyTo(dest.Attributes())
@@ -43,6 +63,15 @@ func (dp *gaugeDP) Copy(
 	case int64:
 		dest.SetIntValue(v)
 	}
-	// TODO determine appropriate start time
-	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	setTimestamps(startTimestamp, timestamp, dest)
+}
+
+func toFloat64(v any) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	}
+	return 0
 }
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		precision uint8
+		distinct  int
+		// tolerance is the allowed relative error of the estimate
+		tolerance float64
+	}{
+		{name: "small", precision: 12, distinct: 10, tolerance: 0.01},
+		{name: "medium", precision: 12, distinct: 10_000, tolerance: 0.05},
+		{name: "large", precision: 14, distinct: 100_000, tolerance: 0.02},
+		{name: "min_precision", precision: 4, distinct: 1000, tolerance: 0.5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHyperLogLog(tc.precision)
+			for i := 0; i < tc.distinct; i++ {
+				// Duplicates must not change the estimate
+				h.Insert("user-" + strconv.Itoa(i))
+				h.Insert("user-" + strconv.Itoa(i))
+			}
+			assert.InEpsilon(t, float64(tc.distinct), float64(h.Estimate()), tc.tolerance)
+		})
+	}
+}
+
+func TestHyperLogLogEmpty(t *testing.T) {
+	assert.Equal(t, uint64(0), newHyperLogLog(12).Estimate())
+}
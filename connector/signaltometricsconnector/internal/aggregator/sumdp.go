@@ -41,7 +41,7 @@ func (dp *sumDP) AggregateDouble(v float64) {
 }
 
 func (dp *sumDP) Copy(
-	timestamp time.Time,
+	startTimestamp, timestamp time.Time,
 	dest pmetric.NumberDataPoint,
 ) {
 	dp.attrs.CopyTo(dest.Attributes())
@@ -50,6 +50,5 @@ func (dp *sumDP) Copy(
 	} else {
 		dest.SetIntValue(dp.intVal)
 	}
-	// TODO determine appropriate start time
-	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	setTimestamps(startTimestamp, timestamp, dest)
 }
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"math"
+	"time"
+
+	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// summaryDP records values in an exponential histogram and estimates the
+// configured quantiles from it when copied. The relative error of the
+// estimates is bounded by the bucket width of the histogram, which
+// depends on its max size and the range of the recorded values.
+type summaryDP struct {
+	attrs     pcommon.Map
+	quantiles []float64
+	data      *structure.Histogram[float64]
+}
+
+func newSummaryDP(attrs pcommon.Map, quantiles []float64, maxSize int32) *summaryDP {
+	return &summaryDP{
+		attrs:     attrs,
+		quantiles: quantiles,
+		data: structure.NewFloat64(
+			structure.NewConfig(structure.WithMaxSize(maxSize)),
+		),
+	}
+}
+
+func (dp *summaryDP) Aggregate(value float64, count int64) {
+	dp.data.UpdateByIncr(value, uint64(count))
+}
+
+func (dp *summaryDP) Copy(
+	startTimestamp, timestamp time.Time,
+	dest pmetric.SummaryDataPoint,
+) {
+	dp.attrs.CopyTo(dest.Attributes())
+	dest.SetCount(dp.data.Count())
+	dest.SetSum(dp.data.Sum())
+	setTimestamps(startTimestamp, timestamp, dest)
+
+	if dp.data.Count() == 0 {
+		return
+	}
+	dest.QuantileValues().EnsureCapacity(len(dp.quantiles))
+	for _, q := range dp.quantiles {
+		qv := dest.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q)
+		qv.SetValue(dp.quantile(q))
+	}
+}
+
+// quantile estimates the value at the given quantile as the midpoint of
+// the bucket holding the rank of the quantile, bounded by the recorded
+// minimum and maximum.
+func (dp *summaryDP) quantile(q float64) float64 {
+	if q <= 0 {
+		return dp.data.Min()
+	}
+	if q >= 1 {
+		return dp.data.Max()
+	}
+
+	rank := q * float64(dp.data.Count())
+	scale := dp.data.Scale()
+	var cumulative float64
+
+	// Negative buckets are iterated from the largest magnitude so that
+	// values are visited in increasing order.
+	neg := dp.data.Negative()
+	for i := int64(neg.Len()) - 1; i >= 0; i-- {
+		cumulative += float64(neg.At(uint32(i)))
+		if cumulative >= rank {
+			return dp.clamp(-bucketMidpoint(scale, neg.Offset()+int32(i)))
+		}
+	}
+	cumulative += float64(dp.data.ZeroCount())
+	if cumulative >= rank {
+		return dp.clamp(0)
+	}
+	pos := dp.data.Positive()
+	for i := uint32(0); i < pos.Len(); i++ {
+		cumulative += float64(pos.At(i))
+		if cumulative >= rank {
+			return dp.clamp(bucketMidpoint(scale, pos.Offset()+int32(i)))
+		}
+	}
+	return dp.data.Max()
+}
+
+func (dp *summaryDP) clamp(v float64) float64 {
+	return math.Min(math.Max(v, dp.data.Min()), dp.data.Max())
+}
+
+// bucketMidpoint returns the midpoint of the exponential histogram bucket
+// with the given index, i.e. of the range (base^index, base^(index+1)]
+// where base = 2^(2^-scale).
+func bucketMidpoint(scale, index int32) float64 {
+	inverseFactor := math.Exp2(-float64(scale))
+	lower := math.Exp2(float64(index) * inverseFactor)
+	upper := math.Exp2(float64(index+1) * inverseFactor)
+	return lower + (upper-lower)/2
+}
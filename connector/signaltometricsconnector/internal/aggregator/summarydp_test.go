@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestSummaryDP(t *testing.T) {
+	quantiles := []float64{0, 0.5, 0.9, 0.99, 1}
+	dp := newSummaryDP(pcommon.NewMap(), quantiles, 160)
+	for i := 1; i <= 1000; i++ {
+		dp.Aggregate(float64(i), 1)
+	}
+	dp.Aggregate(-5, 2)
+
+	start := time.Unix(60, 0)
+	end := time.Unix(120, 0)
+	dest := pmetric.NewSummaryDataPoint()
+	dp.Copy(start, end, dest)
+
+	assert.Equal(t, uint64(1002), dest.Count())
+	assert.Equal(t, float64(500490), dest.Sum())
+	assert.Equal(t, pcommon.NewTimestampFromTime(start), dest.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(end), dest.Timestamp())
+
+	require.Equal(t, len(quantiles), dest.QuantileValues().Len())
+	expected := []float64{-5, 499, 900, 990, 1000}
+	for i, q := range quantiles {
+		qv := dest.QuantileValues().At(i)
+		assert.Equal(t, q, qv.Quantile())
+		// The estimate is bounded by the relative error of the buckets
+		assert.InEpsilon(t, expected[i], qv.Value(), 0.02)
+	}
+}
+
+func TestSummaryDPEmpty(t *testing.T) {
+	dp := newSummaryDP(pcommon.NewMap(), []float64{0.5}, 160)
+	dest := pmetric.NewSummaryDataPoint()
+	dp.Copy(time.Time{}, time.Unix(120, 0), dest)
+
+	assert.Equal(t, uint64(0), dest.Count())
+	assert.Equal(t, 0, dest.QuantileValues().Len())
+	assert.Equal(t, pcommon.Timestamp(0), dest.StartTimestamp())
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type timestampSetter interface {
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+}
+
+// setTimestamps sets the timestamps of the destination data point. The
+// start timestamp is only known for windowed aggregations and is left
+// unset otherwise.
+func setTimestamps(startTimestamp, timestamp time.Time, dest timestampSetter) {
+	// TODO determine appropriate start time for non-windowed aggregations
+	if !startTimestamp.IsZero() {
+		dest.SetStartTimestamp(pcommon.NewTimestampFromTime(startTimestamp))
+	}
+	dest.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregator // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/aggregator"
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/signaltometricsconnector/internal/model"
+)
+
+// Windows aggregates data into tumbling windows based on the timestamp
+// of the data. A window is closed once the watermark, the latest observed
+// timestamp minus the allowed lateness, passes the end of the window, or
+// at the latest `size + lateness` after the window received its first
+// data so that windows are closed even if no new data is received. Data
+// for windows older than the watermark, or for windows already closed at
+// their deadline, is dropped so that a window is never emitted twice.
+type Windows[K any] struct {
+	size     time.Duration
+	lateness time.Duration
+	mds      []model.MetricDef[K]
+
+	mu        sync.Mutex
+	windows   map[int64]*window[K]
+	watermark time.Time
+	// closed records the starts of the windows closed at their deadline
+	// while the watermark had not passed their end yet.
+	closed map[int64]time.Time
+}
+
+type window[K any] struct {
+	aggregator *Aggregator[K]
+	end        time.Time
+	deadline   time.Time
+}
+
+// NewWindows creates a new instance of windowed aggregations for the
+// given metric definitions.
+func NewWindows[K any](size, lateness time.Duration, mds []model.MetricDef[K]) *Windows[K] {
+	return &Windows[K]{
+		size:     size,
+		lateness: lateness,
+		mds:      mds,
+		windows:  make(map[int64]*window[K]),
+		closed:   make(map[int64]time.Time),
+	}
+}
+
+// Aggregate aggregates the data into the window of the given timestamp.
+// It returns false if the data is dropped as its window is already closed.
+func (w *Windows[K]) Aggregate(
+	ctx context.Context,
+	timestamp time.Time,
+	tCtx K,
+	md model.MetricDef[K],
+	resAttrs, srcAttrs pcommon.Map,
+	defaultCount int64,
+) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	start := timestamp.Truncate(w.size)
+	end := start.Add(w.size)
+	if !end.After(w.watermark) {
+		return false, nil
+	}
+	if _, ok := w.closed[start.UnixNano()]; ok {
+		return false, nil
+	}
+
+	win, ok := w.windows[start.UnixNano()]
+	if !ok {
+		win = &window[K]{
+			aggregator: newWindowAggregator[K](start, end),
+			end:        end,
+			deadline:   time.Now().Add(w.size + w.lateness),
+		}
+		w.windows[start.UnixNano()] = win
+	}
+	if err := win.aggregator.Aggregate(ctx, tCtx, md, resAttrs, srcAttrs, defaultCount); err != nil {
+		return true, err
+	}
+	if watermark := timestamp.Add(-w.lateness); watermark.After(w.watermark) {
+		w.watermark = watermark
+	}
+	return true, nil
+}
+
+// Flush finalizes the closed windows and returns the produced metrics
+// ordered by the start of the windows. If force is true then all windows
+// are finalized, irrespective of them being closed or not.
+func (w *Windows[K]) Flush(now time.Time, force bool) []pmetric.Metrics {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var closed []int64
+	for start, win := range w.windows {
+		if force || !win.end.After(w.watermark) || !now.Before(win.deadline) {
+			closed = append(closed, start)
+		}
+	}
+	slices.Sort(closed)
+
+	result := make([]pmetric.Metrics, 0, len(closed))
+	for _, start := range closed {
+		win := w.windows[start]
+		delete(w.windows, start)
+		if win.end.After(w.watermark) {
+			w.closed[start] = win.end
+		}
+		win.aggregator.Finalize(w.mds)
+		if win.aggregator.result.DataPointCount() > 0 {
+			result = append(result, win.aggregator.result)
+		}
+	}
+	// The data of the windows older than the watermark is dropped anyway
+	for start, end := range w.closed {
+		if !end.After(w.watermark) {
+			delete(w.closed, start)
+		}
+	}
+	return result
+}
@@ -107,7 +107,8 @@ func (s *Sum[K]) fromConfig(
 }
 
 type Gauge[K any] struct {
-	Value *ottl.ValueExpression[K]
+	Value       *ottl.ValueExpression[K]
+	Aggregation config.GaugeAggregation
 }
 
 func (s *Gauge[K]) fromConfig(
@@ -119,6 +120,10 @@ func (s *Gauge[K]) fromConfig(
 	}
 
 	var err error
+	s.Aggregation = mi.Aggregation
+	if s.Aggregation == "" {
+		s.Aggregation = config.GaugeAggregationLast
+	}
 	s.Value, err = parser.ParseValueExpression(mi.Value)
 	if err != nil {
 		return fmt.Errorf("failed to parse value OTTL expression for gauge: %w", err)
@@ -126,6 +131,59 @@ func (s *Gauge[K]) fromConfig(
 	return nil
 }
 
+type DistinctCount[K any] struct {
+	Precision uint8
+	Value     *ottl.ValueExpression[K]
+}
+
+func (dc *DistinctCount[K]) fromConfig(
+	mi *config.DistinctCount,
+	parser ottl.Parser[K],
+) error {
+	if mi == nil {
+		return nil
+	}
+
+	var err error
+	dc.Precision = mi.Precision
+	dc.Value, err = parser.ParseValueExpression(mi.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse value OTTL expression for distinct count: %w", err)
+	}
+	return nil
+}
+
+type Summary[K any] struct {
+	Quantiles []float64
+	MaxSize   int32
+	Count     *ottl.ValueExpression[K]
+	Value     *ottl.ValueExpression[K]
+}
+
+func (s *Summary[K]) fromConfig(
+	mi *config.Summary,
+	parser ottl.Parser[K],
+) error {
+	if mi == nil {
+		return nil
+	}
+
+	var err error
+	s.Quantiles = mi.Quantiles
+	s.MaxSize = mi.MaxSize
+	if mi.Count != "" {
+		s.Count, err = parser.ParseValueExpression(mi.Count)
+		if err != nil {
+			return fmt.Errorf("failed to parse count OTTL expression for summary: %w", err)
+		}
+	}
+	s.Value, err = parser.ParseValueExpression(mi.Value)
+	if err != nil {
+		return fmt.Errorf("failed to parse value OTTL expression for summary: %w", err)
+	}
+	return nil
+}
+
 type MetricDef[K any] struct {
 	Key                       MetricKey
 	IncludeResourceAttributes []AttributeKeyValue
@@ -135,6 +193,8 @@ type MetricDef[K any] struct {
 	ExplicitHistogram         *ExplicitHistogram[K]
 	Sum                       *Sum[K]
 	Gauge                     *Gauge[K]
+	DistinctCount             *DistinctCount[K]
+	Summary                   *Summary[K]
 }
 
 func (md *MetricDef[K]) FromMetricInfo(
@@ -195,6 +255,21 @@ func (md *MetricDef[K]) FromMetricInfo(
 			return fmt.Errorf("failed to parse gauge config: %w", err)
 		}
 	}
+	if mi.DistinctCount.HasValue() {
+		// Distinct counts are not additive and are thus produced as gauges
+		md.Key.Type = pmetric.MetricTypeGauge
+		md.DistinctCount = new(DistinctCount[K])
+		if err := md.DistinctCount.fromConfig(mi.DistinctCount.Get(), parser); err != nil {
+			return fmt.Errorf("failed to parse distinct count config: %w", err)
+		}
+	}
+	if mi.Summary.HasValue() {
+		md.Key.Type = pmetric.MetricTypeSummary
+		md.Summary = new(Summary[K])
+		if err := md.Summary.fromConfig(mi.Summary.Get(), parser); err != nil {
+			return fmt.Errorf("failed to parse summary config: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ package prometheusexporter // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"sync"
@@ -90,6 +91,8 @@ func (a *lastValueAccumulator) addMetric(metric pmetric.Metric, scopeName, scope
 		return a.accumulateHistogram(metric, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes, resourceAttrs, now)
 	case pmetric.MetricTypeSummary:
 		return a.accumulateSummary(metric, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes, resourceAttrs, now)
+	case pmetric.MetricTypeExponentialHistogram:
+		return a.accumulateExponentialHistogram(metric, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes, resourceAttrs, now)
 	default:
 		a.logger.With(
 			zap.String("data_type", string(metric.Type())),
@@ -297,6 +300,65 @@ func (a *lastValueAccumulator) accumulateHistogram(metric pmetric.Metric, scopeN
 	return
 }
 
+func (a *lastValueAccumulator) accumulateExponentialHistogram(metric pmetric.Metric, scopeName, scopeVersion, scopeSchemaURL string, scopeAttributes, resourceAttrs pcommon.Map, now time.Time) (n int) {
+	histogram := metric.ExponentialHistogram()
+	dps := histogram.DataPoints()
+
+	for i := 0; i < dps.Len(); i++ {
+		ip := dps.At(i)
+
+		signature := timeseriesSignature(scopeName, scopeVersion, scopeSchemaURL, scopeAttributes, metric, ip.Attributes(), resourceAttrs)
+		if ip.Flags().NoRecordedValue() {
+			a.registeredMetrics.Delete(signature)
+			return 0
+		}
+
+		v, ok := a.registeredMetrics.Load(signature)
+		if !ok {
+			m := copyMetricMetadata(metric)
+			ip.CopyTo(m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty())
+			m.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scopeName: scopeName, scopeVersion: scopeVersion, scopeSchemaURL: scopeSchemaURL, scopeAttributes: scopeAttributes, updated: now})
+			n++
+			continue
+		}
+		mv := v.(*accumulatedValue)
+
+		m := copyMetricMetadata(metric)
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		switch histogram.AggregationTemporality() {
+		case pmetric.AggregationTemporalityDelta:
+			pp := mv.value.ExponentialHistogram().DataPoints().At(0)
+			if ip.StartTimestamp().AsTime() != pp.Timestamp().AsTime() {
+				// treat misalignment as restart and reset, or violation of single-writer principle and drop
+				if !ip.StartTimestamp().AsTime().After(pp.Timestamp().AsTime()) {
+					a.logger.With(
+						zap.String("metric_name", metric.Name()),
+					).Warn("Dropped misaligned exponential histogram datapoint")
+					continue
+				}
+				ip.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+			} else {
+				accumulateExponentialHistogramValues(pp, ip, m.ExponentialHistogram().DataPoints().AppendEmpty())
+			}
+		case pmetric.AggregationTemporalityCumulative:
+			if ip.Timestamp().AsTime().Before(mv.value.ExponentialHistogram().DataPoints().At(0).Timestamp().AsTime()) {
+				// only keep datapoint with latest timestamp
+				continue
+			}
+
+			ip.CopyTo(m.ExponentialHistogram().DataPoints().AppendEmpty())
+		default:
+			// unsupported temporality
+			continue
+		}
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scopeName: scopeName, scopeVersion: scopeVersion, scopeSchemaURL: scopeSchemaURL, scopeAttributes: scopeAttributes, updated: now})
+		n++
+	}
+	return
+}
+
 // Collect returns a slice with relevant aggregated metrics and their resource attributes.
 func (a *lastValueAccumulator) Collect() ([]pmetric.Metric, []pcommon.Map, []string, []string, []string, []pcommon.Map) {
 	a.logger.Debug("Accumulator collect called")
@@ -406,3 +468,100 @@ func accumulateHistogramValues(prev, current, dest pmetric.HistogramDataPoint) {
 
 	dest.ExplicitBounds().FromRaw(newer.ExplicitBounds().AsRaw())
 }
+
+// accumulateExponentialHistogramValues adds two exponential histogram datapoints. When their
+// scales differ, the buckets of the finer one are merged down to the coarser scale. When their
+// zero thresholds differ, the larger one is kept and the buckets within it are merged into the
+// zero count.
+func accumulateExponentialHistogramValues(prev, current, dest pmetric.ExponentialHistogramDataPoint) {
+	dest.SetStartTimestamp(prev.StartTimestamp())
+
+	older := prev
+	newer := current
+	if current.Timestamp().AsTime().Before(prev.Timestamp().AsTime()) {
+		older = current
+		newer = prev
+	}
+
+	newer.Attributes().CopyTo(dest.Attributes())
+	newer.Exemplars().CopyTo(dest.Exemplars())
+	dest.SetTimestamp(newer.Timestamp())
+
+	dest.SetCount(newer.Count() + older.Count())
+	dest.SetSum(newer.Sum() + older.Sum())
+	if newer.HasMin() && older.HasMin() {
+		dest.SetMin(min(newer.Min(), older.Min()))
+	}
+	if newer.HasMax() && older.HasMax() {
+		dest.SetMax(max(newer.Max(), older.Max()))
+	}
+
+	scale := min(newer.Scale(), older.Scale())
+	dest.SetScale(scale)
+	mergeExponentialBuckets(dest.Positive(), newer.Positive(), newer.Scale()-scale, older.Positive(), older.Scale()-scale)
+	mergeExponentialBuckets(dest.Negative(), newer.Negative(), newer.Scale()-scale, older.Negative(), older.Scale()-scale)
+
+	zeroThreshold := max(newer.ZeroThreshold(), older.ZeroThreshold())
+	zeroCount := newer.ZeroCount() + older.ZeroCount()
+	if newer.ZeroThreshold() != older.ZeroThreshold() {
+		zeroCount += foldZeroBuckets(dest.Positive(), scale, zeroThreshold)
+		zeroCount += foldZeroBuckets(dest.Negative(), scale, zeroThreshold)
+	}
+	dest.SetZeroThreshold(zeroThreshold)
+	dest.SetZeroCount(zeroCount)
+}
+
+// foldZeroBuckets removes the lowest buckets whose upper bound is within the zero threshold, and
+// returns their count to be added to the zero count.
+func foldZeroBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, scale int32, zeroThreshold float64) uint64 {
+	counts := buckets.BucketCounts()
+	var folded uint64
+	n := 0
+	for n < counts.Len() && exponentialBucketUpperBound(buckets.Offset()+int32(n), scale) <= zeroThreshold {
+		folded += counts.At(n)
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	buckets.SetOffset(buckets.Offset() + int32(n))
+	counts.FromRaw(counts.AsRaw()[n:])
+	return folded
+}
+
+// exponentialBucketUpperBound returns the upper bound of the absolute values of a bucket, which
+// is base^(index+1) with base = 2^(2^-scale).
+func exponentialBucketUpperBound(index, scale int32) float64 {
+	return math.Exp2(float64(index+1) * math.Exp2(-float64(scale)))
+}
+
+// mergeExponentialBuckets adds the counts of two bucket ranges, after reducing their scales
+// by the given amounts. Reducing the scale by one merges pairs of adjacent buckets.
+func mergeExponentialBuckets(dest, a pmetric.ExponentialHistogramDataPointBuckets, aScaleDown int32, b pmetric.ExponentialHistogramDataPointBuckets, bScaleDown int32) {
+	counts := make(map[int32]uint64, a.BucketCounts().Len()+b.BucketCounts().Len())
+	lowest, highest := int32(math.MaxInt32), int32(math.MinInt32)
+	add := func(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) {
+		for i := 0; i < buckets.BucketCounts().Len(); i++ {
+			count := buckets.BucketCounts().At(i)
+			if count == 0 {
+				continue
+			}
+			index := (buckets.Offset() + int32(i)) >> scaleDown
+			counts[index] += count
+			lowest = min(lowest, index)
+			highest = max(highest, index)
+		}
+	}
+	add(a, aScaleDown)
+	add(b, bScaleDown)
+	if len(counts) == 0 {
+		return
+	}
+
+	raw := make([]uint64, highest-lowest+1)
+	for index, count := range counts {
+		raw[index-lowest] = count
+	}
+	dest.SetOffset(lowest)
+	dest.BucketCounts().FromRaw(raw)
+}
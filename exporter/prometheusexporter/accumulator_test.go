@@ -567,6 +567,132 @@ func TestAccumulateDeltaToCumulativeHistogram(t *testing.T) {
 	})
 }
 
+func TestAccumulateExponentialHistogram(t *testing.T) {
+	appendExponentialHistogram := func(temporality pmetric.AggregationTemporality, startTs, ts time.Time, scale, offset int32, counts []uint64, metrics pmetric.MetricSlice) {
+		metric := metrics.AppendEmpty()
+		metric.SetName("test_metric")
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(temporality)
+		dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetScale(scale)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		count := dp.ZeroCount()
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(float64(count))
+		dp.Attributes().PutStr("label_1", "1")
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTs))
+	}
+
+	tests := []struct {
+		name        string
+		temporality pmetric.AggregationTemporality
+		second      func(startTs, ts1, ts2 time.Time, metrics pmetric.MetricSlice)
+		wantScale   int32
+		wantOffset  int32
+		wantCounts  []uint64
+		wantCount   uint64
+		wantZero    uint64
+	}{
+		{
+			name:        "delta with the same scale",
+			temporality: pmetric.AggregationTemporalityDelta,
+			second: func(_, ts1, ts2 time.Time, metrics pmetric.MetricSlice) {
+				appendExponentialHistogram(pmetric.AggregationTemporalityDelta, ts1, ts2, 1, 1, []uint64{3, 0, 1}, metrics)
+			},
+			wantScale:  1,
+			wantOffset: 0,
+			wantCounts: []uint64{1, 4, 1, 2},
+			wantCount:  10,
+			wantZero:   2,
+		},
+		{
+			name:        "delta with a lower scale",
+			temporality: pmetric.AggregationTemporalityDelta,
+			second: func(_, ts1, ts2 time.Time, metrics pmetric.MetricSlice) {
+				appendExponentialHistogram(pmetric.AggregationTemporalityDelta, ts1, ts2, 0, 0, []uint64{2}, metrics)
+			},
+			wantScale:  0,
+			wantOffset: 0,
+			wantCounts: []uint64{4, 2},
+			wantCount:  8,
+			wantZero:   2,
+		},
+		{
+			name:        "delta with misaligned timestamps is dropped",
+			temporality: pmetric.AggregationTemporalityDelta,
+			second: func(startTs, _, ts2 time.Time, metrics pmetric.MetricSlice) {
+				appendExponentialHistogram(pmetric.AggregationTemporalityDelta, startTs, ts2, 0, 0, []uint64{2}, metrics)
+			},
+			wantScale:  1,
+			wantOffset: 0,
+			wantCounts: []uint64{1, 1, 1, 1},
+			wantCount:  5,
+			wantZero:   1,
+		},
+		{
+			name:        "cumulative keeps the latest",
+			temporality: pmetric.AggregationTemporalityCumulative,
+			second: func(startTs, _, ts2 time.Time, metrics pmetric.MetricSlice) {
+				appendExponentialHistogram(pmetric.AggregationTemporalityCumulative, startTs, ts2, 0, 2, []uint64{5}, metrics)
+			},
+			wantScale:  0,
+			wantOffset: 2,
+			wantCounts: []uint64{5},
+			wantCount:  6,
+			wantZero:   1,
+		},
+		{
+			name:        "delta with a larger zero threshold",
+			temporality: pmetric.AggregationTemporalityDelta,
+			second: func(_, ts1, ts2 time.Time, metrics pmetric.MetricSlice) {
+				appendExponentialHistogram(pmetric.AggregationTemporalityDelta, ts1, ts2, 1, 2, []uint64{1}, metrics)
+				metrics.At(metrics.Len() - 1).ExponentialHistogram().DataPoints().At(0).SetZeroThreshold(2)
+			},
+			// The buckets with an upper bound up to 2 are merged into the zero count
+			wantScale:  1,
+			wantOffset: 2,
+			wantCounts: []uint64{2, 1},
+			wantCount:  7,
+			wantZero:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startTs := time.Now().Add(-5 * time.Second)
+			ts1 := time.Now().Add(-4 * time.Second)
+			ts2 := time.Now().Add(-3 * time.Second)
+			resourceMetrics := pmetric.NewResourceMetrics()
+			ilm := resourceMetrics.ScopeMetrics().AppendEmpty()
+			ilm.Scope().SetName("test")
+			appendExponentialHistogram(tt.temporality, startTs, ts1, 1, 0, []uint64{1, 1, 1, 1}, ilm.Metrics())
+			tt.second(startTs, ts1, ts2, ilm.Metrics())
+
+			a := newAccumulator(zap.NewNop(), 1*time.Hour).(*lastValueAccumulator)
+			a.Accumulate(resourceMetrics)
+
+			signature := timeseriesSignature(ilm.Scope().Name(), ilm.Scope().Version(), ilm.SchemaUrl(), ilm.Scope().Attributes(), ilm.Metrics().At(0), ilm.Metrics().At(0).ExponentialHistogram().DataPoints().At(0).Attributes(), pcommon.NewMap())
+			m, ok := a.registeredMetrics.Load(signature)
+			require.True(t, ok)
+			v := m.(*accumulatedValue).value
+			require.Equal(t, pmetric.AggregationTemporalityCumulative, v.ExponentialHistogram().AggregationTemporality())
+
+			dp := v.ExponentialHistogram().DataPoints().At(0)
+			require.Equal(t, tt.wantScale, dp.Scale())
+			require.Equal(t, tt.wantOffset, dp.Positive().Offset())
+			require.Equal(t, tt.wantCounts, dp.Positive().BucketCounts().AsRaw())
+			require.Equal(t, tt.wantCount, dp.Count())
+			require.Equal(t, tt.wantZero, dp.ZeroCount())
+			require.Equal(t, startTs.UnixNano(), dp.StartTimestamp().AsTime().UnixNano())
+		})
+	}
+}
+
 func TestAccumulateDroppedMetrics(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -164,6 +164,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertDoubleHistogram(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
 	}
 
 	return nil, errUnknownMetricType
@@ -378,6 +380,80 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+const (
+	// The range of schemas supported by native histograms.
+	nativeHistogramMinScale = -4
+	nativeHistogramMaxScale = 8
+)
+
+// convertExponentialHistogram converts an exponential histogram into a native histogram. Native
+// histograms can only be served in the protobuf format, the text formats only contain their
+// count and sum.
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map, scopeName, scopeVersion, scopeSchemaURL string, scopeAttributes pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+	if ip.Scale() < nativeHistogramMinScale {
+		return nil, fmt.Errorf("cannot convert exponential histogram with scale %d to a native histogram, the scale must be at least %d", ip.Scale(), nativeHistogramMinScale)
+	}
+
+	desc, attributes, err := c.getMetricMetadata(metric, dto.MetricType_HISTOGRAM.Enum(), ip.Attributes(), resourceAttrs, scopeName, scopeVersion, scopeSchemaURL, scopeAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scales above the maximum schema are reduced by merging the adjacent buckets.
+	scale := min(ip.Scale(), nativeHistogramMaxScale)
+	scaleDown := ip.Scale() - scale
+
+	var createdTimestamp time.Time
+	if ip.StartTimestamp().AsTime().Unix() > 0 {
+		createdTimestamp = ip.StartTimestamp().AsTime()
+	}
+
+	m, err := prometheus.NewConstNativeHistogram(
+		desc,
+		ip.Count(),
+		ip.Sum(),
+		nativeHistogramBuckets(ip.Positive(), scaleDown),
+		nativeHistogramBuckets(ip.Negative(), scaleDown),
+		ip.ZeroCount(),
+		scale,
+		ip.ZeroThreshold(),
+		createdTimestamp,
+		attributes...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if exemplars := convertExemplars(ip.Exemplars()); len(exemplars) > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.sendTimestamps {
+		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
+	}
+	return m, nil
+}
+
+// nativeHistogramBuckets returns the counts of the native histogram buckets by index. The OTLP
+// bucket of index i covers (base^i, base^(i+1)], while the native histogram one covers
+// (base^(i-1), base^i], hence the indexes are shifted by one.
+func nativeHistogramBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) map[int]int64 {
+	result := make(map[int]int64, buckets.BucketCounts().Len())
+	for i := 0; i < buckets.BucketCounts().Len(); i++ {
+		count := buckets.BucketCounts().At(i)
+		if count == 0 {
+			continue
+		}
+		index := int((buckets.Offset()+int32(i))>>scaleDown) + 1
+		result[index] += int64(count)
+	}
+	return result
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var lastErr error
 
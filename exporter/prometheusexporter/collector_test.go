@@ -287,6 +287,97 @@ func TestConvertMonotonicSumExemplar(t *testing.T) {
 	exemplarsEqual(t, exemplar, promCounter.GetExemplar())
 }
 
+func TestConvertExponentialHistogram(t *testing.T) {
+	tests := []struct {
+		name          string
+		scale         int32
+		positive      []uint64
+		negative      []uint64
+		wantSchema    int32
+		wantPositive  []*io_prometheus_client.BucketSpan
+		wantPosDeltas []int64
+		wantNegative  []*io_prometheus_client.BucketSpan
+		wantNegDeltas []int64
+	}{
+		{
+			name:          "buckets are shifted by one",
+			scale:         2,
+			positive:      []uint64{1, 2},
+			negative:      []uint64{3},
+			wantSchema:    2,
+			wantPositive:  []*io_prometheus_client.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(2)}},
+			wantPosDeltas: []int64{1, 1},
+			wantNegative:  []*io_prometheus_client.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(1)}},
+			wantNegDeltas: []int64{3},
+		},
+		{
+			name:          "scale above the maximum schema is reduced",
+			scale:         10,
+			positive:      []uint64{1, 1, 1, 1, 1},
+			wantSchema:    8,
+			wantPositive:  []*io_prometheus_client.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(2)}},
+			wantPosDeltas: []int64{4, -3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := pmetric.NewMetric()
+			metric.SetName("test_exponential_histogram")
+			dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetScale(tt.scale)
+			dp.SetZeroCount(2)
+			dp.SetZeroThreshold(0.001)
+			dp.Positive().BucketCounts().FromRaw(tt.positive)
+			dp.Negative().BucketCounts().FromRaw(tt.negative)
+			count := dp.ZeroCount()
+			for _, c := range append(tt.positive, tt.negative...) {
+				count += c
+			}
+			dp.SetCount(count)
+			dp.SetSum(42)
+			startTs := time.Unix(1700000000, 0)
+			dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTs))
+
+			exemplar := dp.Exemplars().AppendEmpty()
+			setTestExemplarWithDoubleValue(exemplar, 1.5)
+			exemplar.SetTimestamp(pcommon.NewTimestampFromTime(startTs.Add(time.Second)))
+
+			c := newCollector(&Config{}, zap.NewNop())
+			promMetric, err := c.convertExponentialHistogram(metric, pcommon.NewMap(), "test", "1.0.0", "http://test.com", pcommon.NewMap())
+			require.NoError(t, err)
+
+			outMetric := io_prometheus_client.Metric{}
+			require.NoError(t, promMetric.Write(&outMetric))
+
+			h := outMetric.GetHistogram()
+			require.Equal(t, count, h.GetSampleCount())
+			require.Equal(t, 42.0, h.GetSampleSum())
+			require.Equal(t, tt.wantSchema, h.GetSchema())
+			require.Equal(t, uint64(2), h.GetZeroCount())
+			require.Equal(t, 0.001, h.GetZeroThreshold())
+			require.Equal(t, tt.wantPositive, h.GetPositiveSpan())
+			require.Equal(t, tt.wantPosDeltas, h.GetPositiveDelta())
+			require.Equal(t, tt.wantNegative, h.GetNegativeSpan())
+			require.Equal(t, tt.wantNegDeltas, h.GetNegativeDelta())
+			require.Equal(t, startTs, h.GetCreatedTimestamp().AsTime())
+			require.Len(t, h.GetExemplars(), 1)
+			exemplarsEqual(t, exemplar, h.GetExemplars()[0])
+		})
+	}
+}
+
+func TestConvertExponentialHistogramInvalidScale(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_exponential_histogram")
+	dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetScale(-5)
+
+	c := newCollector(&Config{}, zap.NewNop())
+	_, err := c.convertExponentialHistogram(metric, pcommon.NewMap(), "test", "1.0.0", "http://test.com", pcommon.NewMap())
+	require.EqualError(t, err, "cannot convert exponential histogram with scale -5 to a native histogram, the scale must be at least -4")
+}
+
 // errorCheckCore keeps track of logged errors
 type errorCheckCore struct {
 	errorMessages []string
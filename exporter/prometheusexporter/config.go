@@ -37,6 +37,10 @@ type Config struct {
 	// EnableOpenMetrics enables the use of the OpenMetrics encoding option for the prometheus exporter.
 	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
 
+	// EnableOpenMetricsCreatedSamples adds the created timestamps of the counters, histograms and
+	// summaries as "_created" samples when the metrics are served in the OpenMetrics format.
+	EnableOpenMetricsCreatedSamples bool `mapstructure:"enable_open_metrics_created_samples"`
+
 	// AddMetricSuffixes controls whether suffixes are added to metric names. Defaults to true.
 	// Deprecated: Use TranslationStrategy instead. This setting is ignored when TranslationStrategy is explicitly set.
 	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
@@ -52,9 +52,11 @@ func TestLoadConfig(t *testing.T) {
 					"label1":        "value1",
 					"another label": "spaced value",
 				},
-				SendTimestamps:    true,
-				MetricExpiration:  60 * time.Minute,
-				AddMetricSuffixes: false,
+				SendTimestamps:                  true,
+				MetricExpiration:                60 * time.Minute,
+				EnableOpenMetrics:               true,
+				EnableOpenMetricsCreatedSamples: true,
+				AddMetricSuffixes:               false,
 			},
 		},
 	}
@@ -11,9 +11,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 )
 
 type prometheusExporter struct {
@@ -45,18 +48,59 @@ func newPrometheusExporter(config *Config, set exporter.Settings) (*prometheusEx
 		collector:    collector,
 		registry:     registry,
 		shutdownFunc: func(_ context.Context) error { return nil },
-		handler: promhttp.HandlerFor(
-			registry,
-			promhttp.HandlerOpts{
-				ErrorHandling:     promhttp.ContinueOnError,
-				ErrorLog:          newPromLogger(set.Logger),
-				EnableOpenMetrics: config.EnableOpenMetrics,
-			},
-		),
-		settings: set.TelemetrySettings,
+		handler:      newHandler(config, registry, set.Logger),
+		settings:     set.TelemetrySettings,
 	}, nil
 }
 
+// newHandler returns the handler serving the metrics in the format negotiated with the scraper:
+// the Prometheus text format, the OpenMetrics text format when enabled, or the protobuf format.
+// Exemplars are only served in the OpenMetrics format.
+func newHandler(config *Config, registry *prometheus.Registry, logger *zap.Logger) http.Handler {
+	opts := promhttp.HandlerOpts{
+		ErrorHandling:                       promhttp.ContinueOnError,
+		ErrorLog:                            newPromLogger(logger),
+		EnableOpenMetrics:                   config.EnableOpenMetrics,
+		EnableOpenMetricsTextCreatedSamples: config.EnableOpenMetricsCreatedSamples,
+	}
+	withoutExemplars := promhttp.HandlerFor(withoutExemplarsGatherer(registry), opts)
+	if !config.EnableOpenMetrics {
+		return withoutExemplars
+	}
+
+	withExemplars := promhttp.HandlerFor(registry, opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// promhttp negotiates the same format from the request headers.
+		if expfmt.NegotiateIncludingOpenMetrics(r.Header).FormatType() == expfmt.TypeOpenMetrics {
+			withExemplars.ServeHTTP(w, r)
+			return
+		}
+		withoutExemplars.ServeHTTP(w, r)
+	})
+}
+
+// withoutExemplarsGatherer removes the exemplars of the gathered metrics, so that they aren't
+// served in the protobuf format.
+func withoutExemplarsGatherer(gatherer prometheus.Gatherer) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := gatherer.Gather()
+		for _, mf := range mfs {
+			for _, m := range mf.GetMetric() {
+				if m.Counter != nil {
+					m.Counter.Exemplar = nil
+				}
+				if m.Histogram != nil {
+					m.Histogram.Exemplars = nil
+					for _, b := range m.Histogram.GetBucket() {
+						b.Exemplar = nil
+					}
+				}
+			}
+		}
+		return mfs, err
+	})
+}
+
 func (pe *prometheusExporter) Start(ctx context.Context, host component.Host) error {
 	ln, err := pe.config.ToListener(ctx)
 	if err != nil {
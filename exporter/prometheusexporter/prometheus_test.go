@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -696,3 +698,147 @@ this_one_there_where_{arch="x86",instance="test-instance",job="test-service",os=
 		})
 	}
 }
+
+func TestPrometheusExporter_ContentNegotiation(t *testing.T) {
+	const (
+		openMetricsAccept = "application/openmetrics-text;version=1.0.0"
+		textAccept        = "text/plain;version=0.0.4"
+		protobufAccept    = "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited"
+	)
+
+	tests := []struct {
+		name              string
+		enableOpenMetrics bool
+		accept            string
+		wantContentType   expfmt.FormatType
+		wantExemplars     bool
+	}{
+		{
+			name:              "OpenMetrics",
+			enableOpenMetrics: true,
+			accept:            openMetricsAccept,
+			wantContentType:   expfmt.TypeOpenMetrics,
+			wantExemplars:     true,
+		},
+		{
+			name:              "OpenMetrics disabled",
+			enableOpenMetrics: false,
+			accept:            openMetricsAccept,
+			wantContentType:   expfmt.TypeTextPlain,
+		},
+		{
+			name:              "Text",
+			enableOpenMetrics: true,
+			accept:            textAccept,
+			wantContentType:   expfmt.TypeTextPlain,
+		},
+		{
+			name:              "Protobuf",
+			enableOpenMetrics: true,
+			accept:            protobufAccept,
+			wantContentType:   expfmt.TypeProtoDelim,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.ServerConfig = confighttp.ServerConfig{Endpoint: addr}
+			cfg.EnableOpenMetrics = tt.enableOpenMetrics
+
+			exp, err := factory.CreateMetrics(t.Context(), exportertest.NewNopSettings(metadata.Type), cfg)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, exp.Shutdown(t.Context()))
+			}()
+			require.NoError(t, exp.Start(t.Context(), componenttest.NewNopHost()))
+
+			require.NoError(t, exp.ConsumeMetrics(t.Context(), exemplarMetrics()))
+
+			req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/metrics", http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Accept", tt.accept)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			format := expfmt.ResponseFormat(res.Header)
+			require.Equal(t, tt.wantContentType, format.FormatType())
+
+			if format.FormatType() != expfmt.TypeProtoDelim {
+				blob, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				if tt.wantExemplars {
+					assert.Contains(t, string(blob), `# {span_id="7436d6ac76178623",trace_id="641d68e314a58152cc2581e7663435d1"} 1`)
+				} else {
+					assert.NotContains(t, string(blob), "trace_id")
+				}
+				return
+			}
+
+			families := map[string]*dto.MetricFamily{}
+			decoder := expfmt.NewDecoder(res.Body, format)
+			for {
+				mf := &dto.MetricFamily{}
+				if err := decoder.Decode(mf); err != nil {
+					require.ErrorIs(t, err, io.EOF)
+					break
+				}
+				families[mf.GetName()] = mf
+			}
+
+			counter := families["requests_total"]
+			require.NotNil(t, counter)
+			assert.Nil(t, counter.GetMetric()[0].GetCounter().GetExemplar())
+
+			histogram := families["latency"]
+			require.NotNil(t, histogram)
+			h := histogram.GetMetric()[0].GetHistogram()
+			assert.Equal(t, int32(3), h.GetSchema())
+			assert.Equal(t, uint64(3), h.GetSampleCount())
+			assert.Empty(t, h.GetExemplars())
+		})
+	}
+}
+
+// exemplarMetrics returns a counter and an exponential histogram with an exemplar.
+func exemplarMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(string(conventions.ServiceNameKey), "test-service")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	now := time.Now()
+
+	counter := metrics.AppendEmpty()
+	counter.SetName("requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Minute)))
+	dp.SetIntValue(10)
+	exemplar := dp.Exemplars().AppendEmpty()
+	setTextExemplarWithIntValue(exemplar, 1)
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(now))
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	expHistogram := histogram.SetEmptyExponentialHistogram()
+	expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := expHistogram.DataPoints().AppendEmpty()
+	hdp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	hdp.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Minute)))
+	hdp.SetScale(3)
+	hdp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.SetCount(3)
+	hdp.SetSum(2.5)
+	hexemplar := hdp.Exemplars().AppendEmpty()
+	setTestExemplarWithDoubleValue(hexemplar, 1.1)
+	hexemplar.SetTimestamp(pcommon.NewTimestampFromTime(now))
+
+	return md
+}
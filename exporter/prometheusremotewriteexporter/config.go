@@ -6,6 +6,7 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/prometheus/config"
 	"go.opentelemetry.io/collector/component"
@@ -60,6 +61,9 @@ type Config struct {
 
 	// RemoteWriteProtoMsg controls whether prometheus remote write v1 or v2 is sent.
 	RemoteWriteProtoMsg config.RemoteWriteProtoMsg `mapstructure:"protobuf_message,omitempty"`
+
+	// Tenants configures isolated queues per tenant, so that a slow tenant doesn't block the others.
+	Tenants TenantsConfig `mapstructure:"tenants"`
 }
 
 type TargetInfo struct {
@@ -84,10 +88,128 @@ type RemoteWriteQueue struct {
 	// the collector to fan out remote write requests.
 	NumConsumers int `mapstructure:"num_consumers"`
 
+	// Sharding configures the automatic scaling of the number of shards sending
+	// remote write requests concurrently. When enabled, it replaces max_batch_request_parallelism.
+	Sharding ShardingConfig `mapstructure:"sharding"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+const (
+	defaultMinShards              = 1
+	defaultMaxShards              = 50
+	defaultShardingUpdateInterval = 10 * time.Second
+)
+
+// ShardingConfig configures the dynamic sharding of the remote write requests. The time series are
+// assigned to the shards by the hash of their labels, and each shard sends its requests in order.
+type ShardingConfig struct {
+	// Enabled enables the dynamic sharding.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinShards is the minimum number of shards. Defaults to 1.
+	MinShards int `mapstructure:"min_shards"`
+
+	// MaxShards is the maximum number of shards. Defaults to 50.
+	MaxShards int `mapstructure:"max_shards"`
+
+	// UpdateInterval is the interval at which the number of shards is recalculated. Defaults to 10s.
+	UpdateInterval time.Duration `mapstructure:"update_interval"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
 
+func (sc *ShardingConfig) minShards() int {
+	if sc.MinShards > 0 {
+		return sc.MinShards
+	}
+	return defaultMinShards
+}
+
+func (sc *ShardingConfig) maxShards() int {
+	if sc.MaxShards > 0 {
+		return sc.MaxShards
+	}
+	return max(defaultMaxShards, sc.minShards())
+}
+
+func (sc *ShardingConfig) updateInterval() time.Duration {
+	if sc.UpdateInterval > 0 {
+		return sc.UpdateInterval
+	}
+	return defaultShardingUpdateInterval
+}
+
+const (
+	defaultTenantHeader      = "X-Scope-OrgID"
+	defaultTenantConcurrency = 10
+	defaultMaxTenants        = 1000
+	defaultTenantIdleTimeout = 5 * time.Minute
+)
+
+// TenantsConfig configures the isolation of the tenants. Each tenant has its own shards and
+// write-ahead-log directory, and its requests are sent with the tenant header.
+type TenantsConfig struct {
+	// Enabled enables the per-tenant queues.
+	Enabled bool `mapstructure:"enabled"`
+
+	// FromAttribute is the resource attribute holding the tenant of the metrics.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// FromMetadata is the client metadata key holding the tenant of the metrics.
+	// It is used when the resource attribute is not set or not found.
+	FromMetadata string `mapstructure:"from_metadata"`
+
+	// DefaultTenant is the tenant of the metrics without tenant.
+	DefaultTenant string `mapstructure:"default_tenant"`
+
+	// Header is the HTTP header set to the tenant in the remote write requests. Defaults to X-Scope-OrgID.
+	Header string `mapstructure:"header"`
+
+	// MaxConcurrency is the maximum number of tenants of a batch exported concurrently. Defaults to 10.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+
+	// MaxTenants is the maximum number of active tenants. Defaults to 1000.
+	MaxTenants int `mapstructure:"max_tenants"`
+
+	// IdleTimeout is the duration after which the shards and write-ahead-log of a tenant
+	// without metrics are released. Defaults to 5m.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (tc *TenantsConfig) header() string {
+	if tc.Header != "" {
+		return tc.Header
+	}
+	return defaultTenantHeader
+}
+
+func (tc *TenantsConfig) maxConcurrency() int {
+	if tc.MaxConcurrency > 0 {
+		return tc.MaxConcurrency
+	}
+	return defaultTenantConcurrency
+}
+
+func (tc *TenantsConfig) maxTenants() int {
+	if tc.MaxTenants > 0 {
+		return tc.MaxTenants
+	}
+	return defaultMaxTenants
+}
+
+func (tc *TenantsConfig) idleTimeout() time.Duration {
+	if tc.IdleTimeout > 0 {
+		return tc.IdleTimeout
+	}
+	return defaultTenantIdleTimeout
+}
+
 // TODO(jbd): Add capacity, max_samples_per_send to QueueConfig.
 
 var _ component.Config = (*Config)(nil)
@@ -110,6 +232,27 @@ func (cfg *Config) Validate() error {
 		return errors.New("remote write consumer number can't be negative")
 	}
 
+	if sharding := cfg.RemoteWriteQueue.Sharding; sharding.Enabled {
+		if sharding.MinShards < 0 || sharding.MaxShards < 0 {
+			return errors.New("remote write queue shards can't be negative")
+		}
+		if sharding.MaxShards > 0 && sharding.MaxShards < sharding.minShards() {
+			return errors.New("remote write queue max_shards can't be lower than min_shards")
+		}
+		if sharding.UpdateInterval < 0 {
+			return errors.New("remote write queue sharding update_interval can't be negative")
+		}
+	}
+
+	if cfg.Tenants.Enabled {
+		if cfg.Tenants.FromAttribute == "" && cfg.Tenants.FromMetadata == "" {
+			return errors.New("tenants requires from_attribute or from_metadata to be set")
+		}
+		if cfg.Tenants.MaxConcurrency < 0 || cfg.Tenants.MaxTenants < 0 || cfg.Tenants.IdleTimeout < 0 {
+			return errors.New("tenants max_concurrency, max_tenants and idle_timeout can't be negative")
+		}
+	}
+
 	if cfg.MaxBatchSizeBytes < 0 {
 		return errors.New("max_batch_byte_size must be greater than 0")
 	}
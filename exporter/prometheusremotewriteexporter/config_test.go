@@ -85,6 +85,37 @@ func TestLoadConfig(t *testing.T) {
 				RemoteWriteProtoMsg: config.RemoteWriteProtoMsgV1,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "sharding_and_tenants"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.ClientConfig.Endpoint = "localhost:8888"
+				cfg.RemoteWriteQueue.Sharding = ShardingConfig{
+					Enabled:        true,
+					MinShards:      2,
+					MaxShards:      20,
+					UpdateInterval: 5 * time.Second,
+				}
+				cfg.Tenants = TenantsConfig{
+					Enabled:        true,
+					FromAttribute:  "tenant",
+					FromMetadata:   "x-tenant",
+					DefaultTenant:  "anonymous",
+					MaxConcurrency: 5,
+					MaxTenants:     500,
+					IdleTimeout:    10 * time.Minute,
+				}
+				return cfg
+			}(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "max_shards_lower_than_min_shards"),
+			errorMessage: "remote write queue max_shards can't be lower than min_shards",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenants_without_source"),
+			errorMessage: "tenants requires from_attribute or from_metadata to be set",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "negative_queue_size"),
 			errorMessage: "remote write queue size can't be negative",
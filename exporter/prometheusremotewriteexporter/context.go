@@ -14,6 +14,7 @@ type ctxKey int
 
 const (
 	loggerCtxKey ctxKey = iota
+	tenantCtxKey
 )
 
 func contextWithLogger(ctx context.Context, log *zap.Logger) context.Context {
@@ -33,3 +34,12 @@ func loggerFromContext(ctx context.Context) (*zap.Logger, error) {
 
 	return l, nil
 }
+
+func contextWithTenantQueue(ctx context.Context, queue *tenantQueue) context.Context {
+	return context.WithValue(ctx, tenantCtxKey, queue)
+}
+
+func tenantQueueFromContext(ctx context.Context) (*tenantQueue, bool) {
+	queue, ok := ctx.Value(tenantCtxKey).(*tenantQueue)
+	return queue, ok
+}
@@ -135,6 +135,9 @@ type prwExporter struct {
 	retrySettings       configretry.BackOffConfig
 	retryOnHTTP429      bool
 	wal                 *prweWAL
+	shards              *shards
+	tenants             *tenantQueues
+	tenantHeader        string
 	exporterSettings    prometheusremotewrite.Settings
 	telemetry           prwTelemetry
 	RemoteWriteProtoMsg config.RemoteWriteProtoMsg
@@ -193,7 +196,10 @@ func newPRWExporter(cfg *Config, set exporter.Settings) (*prwExporter, error) {
 	}
 
 	// Set the desired number of consumers as a metric for the exporter.
-	telemetry.setNumberConsumer(context.Background(), int64(concurrency))
+	// With sharding, the number of consumers is the number of shards, set when they are created.
+	if !cfg.RemoteWriteQueue.Sharding.Enabled {
+		telemetry.setNumberConsumer(context.Background(), int64(concurrency))
+	}
 
 	prwe := &prwExporter{
 		endpointURL:         endpointURL,
@@ -215,15 +221,25 @@ func newPRWExporter(cfg *Config, set exporter.Settings) (*prwExporter, error) {
 			SendMetadata:      cfg.SendMetadata,
 		},
 		telemetry:      telemetry,
+		tenantHeader:   cfg.Tenants.header(),
 		batchStatePool: sync.Pool{New: func() any { return newBatchTimeServicesState() }},
 	}
 
 	prwe.settings.Logger.Info("starting prometheus remote write exporter", zap.Any("ProtoMsg", cfg.RemoteWriteProtoMsg))
 
+	// Each tenant has its own write-ahead-log and shards, created with its queue.
+	if cfg.Tenants.Enabled {
+		prwe.tenants = newTenantQueues(cfg, set, prwe)
+		return prwe, nil
+	}
+
 	prwe.wal, err = newWAL(cfg.WAL.Get(), set, prwe.export)
 	if err != nil {
 		return nil, err
 	}
+	if cfg.RemoteWriteQueue.Sharding.Enabled {
+		prwe.shards = newShards(cfg.RemoteWriteQueue.Sharding, set.Logger, telemetry)
+	}
 	return prwe, nil
 }
 
@@ -233,6 +249,12 @@ func (prwe *prwExporter) Start(ctx context.Context, host component.Host) (err er
 	if err != nil {
 		return err
 	}
+	if prwe.shards != nil {
+		prwe.shards.start()
+	}
+	if prwe.tenants != nil {
+		prwe.tenants.start()
+	}
 	return prwe.turnOnWALIfEnabled(contextWithLogger(ctx, prwe.settings.Logger.Named("prw.wal")))
 }
 
@@ -253,6 +275,12 @@ func (prwe *prwExporter) Shutdown(context.Context) error {
 	}
 	err := prwe.shutdownWALIfEnabled()
 	prwe.wg.Wait()
+	if prwe.tenants != nil {
+		err = multierr.Append(err, prwe.tenants.stop())
+	}
+	if prwe.shards != nil {
+		prwe.shards.stop()
+	}
 	return err
 }
 
@@ -284,21 +312,28 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-
-		// If feature flag not enabled support only RW1.
-		if !enableSendingRW2FeatureGate.IsEnabled() {
-			return prwe.pushMetricsV1(ctx, md)
+		if prwe.tenants != nil {
+			// The metrics of each tenant are exported with the shards and the write-ahead-log of the tenant.
+			return prwe.tenants.push(ctx, md)
 		}
+		return prwe.pushMetrics(ctx, md)
+	}
+}
 
-		// If feature flag was enabled check if we want to send RW1 or RW2.
-		switch prwe.RemoteWriteProtoMsg {
-		case config.RemoteWriteProtoMsgV1:
-			return prwe.pushMetricsV1(ctx, md)
-		case config.RemoteWriteProtoMsgV2:
-			return prwe.pushMetricsV2(ctx, md)
-		default:
-			return fmt.Errorf("unsupported remote-write protobuf message: %v", prwe.RemoteWriteProtoMsg)
-		}
+func (prwe *prwExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	// If feature flag not enabled support only RW1.
+	if !enableSendingRW2FeatureGate.IsEnabled() {
+		return prwe.pushMetricsV1(ctx, md)
+	}
+
+	// If feature flag was enabled check if we want to send RW1 or RW2.
+	switch prwe.RemoteWriteProtoMsg {
+	case config.RemoteWriteProtoMsgV1:
+		return prwe.pushMetricsV1(ctx, md)
+	case config.RemoteWriteProtoMsgV2:
+		return prwe.pushMetricsV2(ctx, md)
+	default:
+		return fmt.Errorf("unsupported remote-write protobuf message: %v", prwe.RemoteWriteProtoMsg)
 	}
 }
 
@@ -328,19 +363,20 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 	if err != nil {
 		return err
 	}
-	if !prwe.walEnabled() {
+	wal := prwe.walFor(ctx)
+	if wal == nil {
 		// Perform a direct export otherwise.
 		return prwe.export(ctx, requests)
 	}
 
 	// Otherwise the WAL is enabled, and just persist the requests to the WAL
-	prwe.wal.telemetry.recordWALWrites(ctx)
+	wal.telemetry.recordWALWrites(ctx)
 	start := time.Now()
-	err = prwe.wal.persistToWAL(ctx, requests)
+	err = wal.persistToWAL(ctx, requests)
 	duration := time.Since(start)
-	prwe.wal.telemetry.recordWALWriteLatency(ctx, duration.Milliseconds())
+	wal.telemetry.recordWALWriteLatency(ctx, duration.Milliseconds())
 	if err != nil {
-		prwe.wal.telemetry.recordWALWritesFailures(ctx)
+		wal.telemetry.recordWALWritesFailures(ctx)
 		return err
 	}
 	return nil
@@ -348,6 +384,12 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
+	if shards := prwe.shardsFor(ctx); shards != nil {
+		return shards.export(ctx, func(numShards int) [][]shardedRequest {
+			return splitRequests(requests, numShards, prwe.sendRequest)
+		})
+	}
+
 	input := make(chan *prompb.WriteRequest, len(requests))
 	for _, request := range requests {
 		input <- request
@@ -405,6 +447,21 @@ func (prwe *prwExporter) handleRequests(ctx context.Context, input chan *prompb.
 	}
 }
 
+// sendRequest sends a single WriteRequest, it is used by the shards.
+func (prwe *prwExporter) sendRequest(ctx context.Context, request *prompb.WriteRequest) error {
+	buf := bufferPool.Get().(*buffer)
+	defer bufferPool.Put(buf)
+
+	reqBuf, err := buf.MarshalAndEncode(request)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	if err := prwe.execute(ctx, reqBuf); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return nil
+}
+
 func (prwe *prwExporter) execute(ctx context.Context, buf []byte) error {
 	retryCount := 0
 	// executeFunc can be used for backoff and non backoff scenarios.
@@ -429,6 +486,9 @@ func (prwe *prwExporter) execute(ctx context.Context, buf []byte) error {
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
+		if queue, ok := tenantQueueFromContext(ctx); ok && queue.tenant != "" {
+			req.Header.Set(prwe.tenantHeader, queue.tenant)
+		}
 
 		switch {
 		// If feature flag not enabled support only RW1
@@ -518,6 +578,22 @@ func (prwe *prwExporter) execute(ctx context.Context, buf []byte) error {
 
 func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil }
 
+// walFor returns the write-ahead-log of the tenant of the context, or the exporter one.
+func (prwe *prwExporter) walFor(ctx context.Context) *prweWAL {
+	if queue, ok := tenantQueueFromContext(ctx); ok {
+		return queue.wal
+	}
+	return prwe.wal
+}
+
+// shardsFor returns the shards of the tenant of the context, or the exporter ones.
+func (prwe *prwExporter) shardsFor(ctx context.Context) *shards {
+	if queue, ok := tenantQueueFromContext(ctx); ok {
+		return queue.shards
+	}
+	return prwe.shards
+}
+
 func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 	if !prwe.walEnabled() {
 		return nil
@@ -33,6 +33,12 @@ func (prwe *prwExporter) pushMetricsV2(ctx context.Context, md pmetric.Metrics)
 
 // exportV2 sends a Snappy-compressed writev2.Request containing writev2.TimeSeries to a remote write endpoint.
 func (prwe *prwExporter) exportV2(ctx context.Context, requests []*writev2.Request) error {
+	if shards := prwe.shardsFor(ctx); shards != nil {
+		return shards.export(ctx, func(numShards int) [][]shardedRequest {
+			return splitRequestsV2(requests, numShards, prwe.sendRequestV2)
+		})
+	}
+
 	input := make(chan *writev2.Request, len(requests))
 	for _, request := range requests {
 		input <- request
@@ -90,6 +96,18 @@ func (prwe *prwExporter) handleRequestsV2(ctx context.Context, input chan *write
 	}
 }
 
+// sendRequestV2 sends a single writev2.Request, it is used by the shards.
+func (prwe *prwExporter) sendRequestV2(ctx context.Context, request *writev2.Request) error {
+	buf := bufferPool.Get().(*buffer)
+	defer bufferPool.Put(buf)
+
+	reqBuf, err := buf.MarshalAndEncode(request)
+	if err != nil {
+		return err
+	}
+	return prwe.execute(ctx, reqBuf)
+}
+
 func (prwe *prwExporter) handleExportV2(ctx context.Context, symbolsTable writev2.SymbolsTable, tsMap map[string]*writev2.TimeSeries) error {
 	// There are no metrics to export, so return.
 	if len(tsMap) == 0 {
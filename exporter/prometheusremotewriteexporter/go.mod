@@ -14,6 +14,7 @@ require (
 	github.com/prometheus/prometheus v0.304.3-0.20250703114031-419d436a447a
 	github.com/stretchr/testify v1.11.1
 	github.com/tidwall/wal v1.2.0
+	go.opentelemetry.io/collector/client v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/component v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/component/componenttest v0.134.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/config/confighttp v0.134.1-0.20250908133507-3166bac6544f
@@ -108,7 +109,6 @@ require (
 	github.com/tidwall/pretty v1.2.0 // indirect
 	github.com/tidwall/tinylru v1.1.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
-	go.opentelemetry.io/collector/client v1.40.1-0.20250908133507-3166bac6544f // indirect
 	go.opentelemetry.io/collector/config/configauth v0.134.1-0.20250908133507-3166bac6544f // indirect
 	go.opentelemetry.io/collector/config/configcompression v1.40.1-0.20250908133507-3166bac6544f // indirect
 	go.opentelemetry.io/collector/config/configmiddleware v0.134.1-0.20250908133507-3166bac6544f // indirect
@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	// shardQueueCapacity is the number of requests that can be queued per shard.
+	shardQueueCapacity = 10
+	// ewmaWeight is the weight of the latest interval in the moving averages of the rates.
+	ewmaWeight = 0.2
+	// shardToleranceFraction is the fraction of the current number of shards within which the
+	// desired number of shards is ignored, to avoid resharding too often.
+	shardToleranceFraction = 0.3
+)
+
+var errShardsStopped = errors.New("shards are stopped")
+
+// shardedRequest is a remote write request assigned to a shard.
+type shardedRequest struct {
+	samples int
+	send    func(context.Context) error
+
+	ctx  context.Context
+	done chan error
+}
+
+// shards sends the remote write requests through a dynamic number of shards. Each shard has a single
+// worker sending its requests in order, and the time series are assigned to the shards by the hash of
+// their labels so that the samples of a series are always delivered in order.
+//
+// Similarly to the Prometheus remote write queue manager, the number of shards is periodically
+// recalculated from the moving averages of the incoming samples rate, the sent samples rate and the
+// time spent sending, and from the backlog of samples waiting to be sent.
+type shards struct {
+	cfg       ShardingConfig
+	logger    *zap.Logger
+	telemetry prwTelemetry
+
+	// mu is held for reading while enqueueing requests, and for writing while resharding, so that
+	// the requests of a series are never queued in two shards at the same time.
+	mu      sync.RWMutex
+	queues  []chan *shardedRequest
+	workers sync.WaitGroup
+
+	samplesIn      atomic.Int64
+	samplesOut     atomic.Int64
+	sendNanos      atomic.Int64
+	pendingSamples atomic.Int64
+
+	// The moving averages are only accessed by the update loop.
+	samplesInRate  ewmaRate
+	samplesOutRate ewmaRate
+	sendRate       ewmaRate
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+	loop     sync.WaitGroup
+}
+
+func newShards(cfg ShardingConfig, logger *zap.Logger, telemetry prwTelemetry) *shards {
+	s := &shards{
+		cfg:       cfg,
+		logger:    logger,
+		telemetry: telemetry,
+		stopChan:  make(chan struct{}),
+	}
+	s.startWorkers(cfg.minShards())
+	telemetry.setNumberConsumer(context.Background(), int64(cfg.minShards()))
+	return s
+}
+
+// start runs the loop recalculating the number of shards.
+func (s *shards) start() {
+	s.loop.Add(1)
+	go func() {
+		defer s.loop.Done()
+		ticker := time.NewTicker(s.cfg.updateInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stopChan:
+				return
+			case <-ticker.C:
+				s.update()
+			}
+		}
+	}()
+}
+
+// stop stops the update loop and waits for the queued requests to be sent.
+func (s *shards) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+		s.loop.Wait()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.telemetry.setNumberConsumer(context.Background(), -int64(len(s.queues)))
+		s.stopWorkers()
+	})
+}
+
+func (s *shards) startWorkers(n int) {
+	s.queues = make([]chan *shardedRequest, n)
+	for i := range s.queues {
+		queue := make(chan *shardedRequest, shardQueueCapacity)
+		s.queues[i] = queue
+		s.workers.Add(1)
+		go s.runShard(queue)
+	}
+}
+
+func (s *shards) stopWorkers() {
+	for _, queue := range s.queues {
+		close(queue)
+	}
+	s.workers.Wait()
+	s.queues = nil
+}
+
+func (s *shards) runShard(queue chan *shardedRequest) {
+	defer s.workers.Done()
+	for req := range queue {
+		start := time.Now()
+		err := req.send(req.ctx)
+		s.sendNanos.Add(int64(time.Since(start)))
+		s.samplesOut.Add(int64(req.samples))
+		s.pendingSamples.Add(-int64(req.samples))
+		req.done <- err
+	}
+}
+
+// export assigns the requests returned by split to the shards, and waits for them to be sent.
+// split is called with the current number of shards and returns the requests of each shard.
+func (s *shards) export(ctx context.Context, split func(numShards int) [][]shardedRequest) error {
+	var errs error
+	var pending []chan error
+
+	s.mu.RLock()
+	if s.queues == nil {
+		s.mu.RUnlock()
+		return errShardsStopped
+	}
+enqueue:
+	for i, requests := range split(len(s.queues)) {
+		for _, req := range requests {
+			req.ctx = ctx
+			req.done = make(chan error, 1)
+			s.samplesIn.Add(int64(req.samples))
+			s.pendingSamples.Add(int64(req.samples))
+			select {
+			case s.queues[i] <- &req:
+				pending = append(pending, req.done)
+			case <-ctx.Done():
+				s.pendingSamples.Add(-int64(req.samples))
+				errs = multierr.Append(errs, ctx.Err())
+				break enqueue
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, done := range pending {
+		errs = multierr.Append(errs, <-done)
+	}
+	return errs
+}
+
+// update updates the moving averages and reshards if the desired number of shards changed.
+func (s *shards) update() {
+	seconds := s.cfg.updateInterval().Seconds()
+	s.samplesInRate.update(float64(s.samplesIn.Swap(0)) / seconds)
+	s.samplesOutRate.update(float64(s.samplesOut.Swap(0)) / seconds)
+	s.sendRate.update(float64(s.sendNanos.Swap(0)) / float64(time.Second) / seconds)
+
+	s.mu.RLock()
+	current := len(s.queues)
+	s.mu.RUnlock()
+
+	desired := s.desiredShards(current, float64(s.pendingSamples.Load()))
+	if desired == current {
+		return
+	}
+	s.logger.Debug("Remote write resharding", zap.Int("from", current), zap.Int("to", desired))
+	s.reshard(desired)
+}
+
+// desiredShards calculates the number of shards needed to send the incoming samples and the
+// backlog of samples within an update interval.
+func (s *shards) desiredShards(current int, backlog float64) int {
+	if s.samplesOutRate.value <= 0 {
+		// Nothing was sent yet, the time needed to send a sample is unknown.
+		return current
+	}
+	// The send rate is the number of seconds spent sending per second, which is the number of
+	// shards busy on average.
+	timePerSample := s.sendRate.value / s.samplesOutRate.value
+	desired := timePerSample * (s.samplesInRate.value + backlog/s.cfg.updateInterval().Seconds())
+
+	lowerBound := float64(current) * (1 - shardToleranceFraction)
+	upperBound := float64(current) * (1 + shardToleranceFraction)
+	if lowerBound <= desired && desired <= upperBound {
+		return current
+	}
+	return min(max(int(math.Ceil(desired)), s.cfg.minShards()), s.cfg.maxShards())
+}
+
+// reshard waits for the queued requests to be sent, and restarts the workers with the new number
+// of shards.
+func (s *shards) reshard(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queues == nil {
+		return
+	}
+	s.telemetry.setNumberConsumer(context.Background(), int64(n-len(s.queues)))
+	s.stopWorkers()
+	s.startWorkers(n)
+}
+
+// ewmaRate is an exponentially weighted moving average of a rate.
+type ewmaRate struct {
+	value       float64
+	initialized bool
+}
+
+func (r *ewmaRate) update(rate float64) {
+	if !r.initialized {
+		r.value = rate
+		r.initialized = true
+		return
+	}
+	r.value = ewmaWeight*rate + (1-ewmaWeight)*r.value
+}
+
+// seriesShard returns the shard of a series from the hash of its label names and values.
+func seriesShard(numShards int, labels func(yield func(name, value string))) int {
+	h := fnv.New64a()
+	labels(func(name, value string) {
+		_, _ = h.Write([]byte(name))
+		_, _ = h.Write([]byte{'\xff'})
+		_, _ = h.Write([]byte(value))
+		_, _ = h.Write([]byte{'\xff'})
+	})
+	return int(h.Sum64() % uint64(numShards))
+}
+
+// splitRequests splits the time series of remote write v1 requests by shard. The metadata is sent
+// with the first request of the first shard.
+func splitRequests(requests []*prompb.WriteRequest, numShards int, send func(context.Context, *prompb.WriteRequest) error) [][]shardedRequest {
+	result := make([][]shardedRequest, numShards)
+	for _, request := range requests {
+		split := make([]*prompb.WriteRequest, numShards)
+		samples := make([]int, numShards)
+		for _, ts := range request.Timeseries {
+			i := seriesShard(numShards, func(yield func(name, value string)) {
+				for _, l := range ts.Labels {
+					yield(l.Name, l.Value)
+				}
+			})
+			if split[i] == nil {
+				split[i] = &prompb.WriteRequest{}
+			}
+			split[i].Timeseries = append(split[i].Timeseries, ts)
+			samples[i] += len(ts.Samples) + len(ts.Histograms)
+		}
+		if len(request.Metadata) > 0 {
+			i := 0
+			for i < numShards-1 && split[i] == nil {
+				i++
+			}
+			if split[i] == nil {
+				split[i] = &prompb.WriteRequest{}
+			}
+			split[i].Metadata = request.Metadata
+		}
+		for i, req := range split {
+			if req == nil {
+				continue
+			}
+			result[i] = append(result[i], shardedRequest{
+				samples: samples[i],
+				send:    func(ctx context.Context) error { return send(ctx, req) },
+			})
+		}
+	}
+	return result
+}
+
+// splitRequestsV2 splits the time series of remote write v2 requests by shard. The requests of the
+// shards share the symbols table of the original request.
+func splitRequestsV2(requests []*writev2.Request, numShards int, send func(context.Context, *writev2.Request) error) [][]shardedRequest {
+	result := make([][]shardedRequest, numShards)
+	for _, request := range requests {
+		split := make([]*writev2.Request, numShards)
+		samples := make([]int, numShards)
+		for _, ts := range request.Timeseries {
+			i := seriesShard(numShards, func(yield func(name, value string)) {
+				for j := 0; j+1 < len(ts.LabelsRefs); j += 2 {
+					yield(request.Symbols[ts.LabelsRefs[j]], request.Symbols[ts.LabelsRefs[j+1]])
+				}
+			})
+			if split[i] == nil {
+				split[i] = &writev2.Request{Symbols: request.Symbols}
+			}
+			split[i].Timeseries = append(split[i].Timeseries, ts)
+			samples[i] += len(ts.Samples) + len(ts.Histograms)
+		}
+		for i, req := range split {
+			if req == nil {
+				continue
+			}
+			result[i] = append(result[i], shardedRequest{
+				samples: samples[i],
+				send:    func(ctx context.Context) error { return send(ctx, req) },
+			})
+		}
+	}
+	return result
+}
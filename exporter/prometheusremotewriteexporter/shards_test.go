@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadata"
+)
+
+func newTestShards(t *testing.T, cfg ShardingConfig) *shards {
+	endpointURL, err := url.Parse("http://localhost:9090/api/v1/write")
+	require.NoError(t, err)
+	telemetry, err := newPRWTelemetry(exportertest.NewNopSettings(metadata.Type), endpointURL)
+	require.NoError(t, err)
+	s := newShards(cfg, zap.NewNop(), telemetry)
+	t.Cleanup(s.stop)
+	return s
+}
+
+func testSeries(n int) []prompb.TimeSeries {
+	series := make([]prompb.TimeSeries, 0, n)
+	for i := 0; i < n; i++ {
+		series = append(series, prompb.TimeSeries{
+			Labels:  []prompb.Label{{Name: "__name__", Value: "test_metric"}, {Name: "id", Value: strconv.Itoa(i)}},
+			Samples: []prompb.Sample{{Value: float64(i), Timestamp: 100}},
+		})
+	}
+	return series
+}
+
+func TestSplitRequests(t *testing.T) {
+	requests := []*prompb.WriteRequest{
+		{
+			Timeseries: testSeries(100),
+			Metadata:   []prompb.MetricMetadata{{MetricFamilyName: "test_metric", Type: prompb.MetricMetadata_GAUGE}},
+		},
+		{Timeseries: testSeries(100)},
+	}
+
+	// The series are always assigned to the same shard
+	shardOf := make(map[string]int)
+	for range 2 {
+		var samples, metadataCount int
+		for i, shardRequests := range splitRequests(requests, 4, func(_ context.Context, req *prompb.WriteRequest) error {
+			metadataCount += len(req.Metadata)
+			return nil
+		}) {
+			assert.NotEmpty(t, shardRequests, "shard %d", i)
+			for _, req := range shardRequests {
+				samples += req.samples
+				require.NoError(t, req.send(t.Context()))
+			}
+		}
+		assert.Equal(t, 200, samples)
+		// The metadata is only sent once
+		assert.Equal(t, 1, metadataCount)
+
+		for i, shardRequests := range splitRequests(requests, 4, func(_ context.Context, req *prompb.WriteRequest) error {
+			for _, ts := range req.Timeseries {
+				id := ts.Labels[1].Value
+				if shard, ok := shardOf[id]; ok {
+					assert.Equal(t, shard, i, "series %s", id)
+				}
+				shardOf[id] = i
+			}
+			return nil
+		}) {
+			for _, req := range shardRequests {
+				require.NoError(t, req.send(t.Context()))
+			}
+		}
+	}
+	assert.Len(t, shardOf, 100)
+}
+
+func TestSplitRequestsV2(t *testing.T) {
+	symbols := writev2.NewSymbolTable()
+	request := &writev2.Request{}
+	for i := 0; i < 50; i++ {
+		request.Timeseries = append(request.Timeseries, writev2.TimeSeries{
+			LabelsRefs: []uint32{symbols.Symbolize("__name__"), symbols.Symbolize("test_metric"), symbols.Symbolize("id"), symbols.Symbolize(strconv.Itoa(i))},
+			Samples:    []writev2.Sample{{Value: 1, Timestamp: 100}, {Value: 2, Timestamp: 200}},
+		})
+	}
+	request.Symbols = symbols.Symbols()
+
+	var mu sync.Mutex
+	var sent []*writev2.Request
+	split := splitRequestsV2([]*writev2.Request{request}, 3, func(_ context.Context, req *writev2.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, req)
+		return nil
+	})
+	require.Len(t, split, 3)
+
+	var samples int
+	for _, shardRequests := range split {
+		for _, req := range shardRequests {
+			samples += req.samples
+			require.NoError(t, req.send(t.Context()))
+		}
+	}
+	assert.Equal(t, 100, samples)
+
+	var series int
+	for _, req := range sent {
+		// The requests of the shards share the symbols table of the original request
+		assert.Equal(t, request.Symbols, req.Symbols)
+		series += len(req.Timeseries)
+	}
+	assert.Equal(t, 50, series)
+}
+
+func TestShardsExport(t *testing.T) {
+	s := newTestShards(t, ShardingConfig{Enabled: true, MinShards: 4, MaxShards: 8})
+
+	var mu sync.Mutex
+	received := make(map[string][]float64)
+	send := func(_ context.Context, req *prompb.WriteRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ts := range req.Timeseries {
+			for _, sample := range ts.Samples {
+				received[ts.Labels[1].Value] = append(received[ts.Labels[1].Value], sample.Value)
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < 10; i++ {
+		series := testSeries(20)
+		for j := range series {
+			series[j].Samples[0].Value = float64(i)
+		}
+		if i == 5 {
+			// The order of the samples of a series is kept across resharding
+			s.reshard(6)
+		}
+		err := s.export(t.Context(), func(numShards int) [][]shardedRequest {
+			return splitRequests([]*prompb.WriteRequest{{Timeseries: series}}, numShards, send)
+		})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, received, 20)
+	for id, values := range received {
+		assert.Equal(t, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values, "series %s", id)
+	}
+	assert.Zero(t, s.pendingSamples.Load())
+}
+
+func TestShardsExportError(t *testing.T) {
+	s := newTestShards(t, ShardingConfig{Enabled: true, MinShards: 2})
+	errSend := errors.New("send failed")
+
+	err := s.export(t.Context(), func(numShards int) [][]shardedRequest {
+		return splitRequests([]*prompb.WriteRequest{{Timeseries: testSeries(10)}}, numShards, func(context.Context, *prompb.WriteRequest) error {
+			return errSend
+		})
+	})
+	assert.ErrorIs(t, err, errSend)
+
+	s.stop()
+	err = s.export(t.Context(), func(int) [][]shardedRequest { return nil })
+	assert.ErrorIs(t, err, errShardsStopped)
+}
+
+func TestShardsDesiredShards(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       int
+		samplesIn     float64
+		samplesOut    float64
+		sendRate      float64
+		backlog       float64
+		expectedShard int
+	}{
+		{
+			name:          "nothing sent yet",
+			current:       1,
+			samplesIn:     1000,
+			expectedShard: 1,
+		},
+		{
+			name:    "within tolerance",
+			current: 10,
+			// 10ms per sample, 1000 samples per second need 10 shards
+			samplesIn:     1000,
+			samplesOut:    1000,
+			sendRate:      10,
+			expectedShard: 10,
+		},
+		{
+			name:          "scale up",
+			current:       10,
+			samplesIn:     2000,
+			samplesOut:    1000,
+			sendRate:      10,
+			expectedShard: 20,
+		},
+		{
+			name:       "scale up with backlog",
+			current:    10,
+			samplesIn:  1000,
+			samplesOut: 1000,
+			sendRate:   10,
+			// 10s of backlog to send within the 10s update interval
+			backlog:       10000,
+			expectedShard: 20,
+		},
+		{
+			name:          "scale down",
+			current:       10,
+			samplesIn:     500,
+			samplesOut:    1000,
+			sendRate:      10,
+			expectedShard: 5,
+		},
+		{
+			name:          "clamped to max shards",
+			current:       10,
+			samplesIn:     100000,
+			samplesOut:    1000,
+			sendRate:      10,
+			expectedShard: 50,
+		},
+		{
+			name:          "clamped to min shards",
+			current:       10,
+			samplesIn:     1,
+			samplesOut:    1000,
+			sendRate:      10,
+			expectedShard: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &shards{cfg: ShardingConfig{Enabled: true, MinShards: 2, MaxShards: 50, UpdateInterval: 10 * time.Second}}
+			s.samplesInRate.update(tt.samplesIn)
+			s.samplesOutRate.update(tt.samplesOut)
+			s.sendRate.update(tt.sendRate)
+			assert.Equal(t, tt.expectedShard, s.desiredShards(tt.current, tt.backlog))
+		})
+	}
+}
+
+func TestEWMARate(t *testing.T) {
+	var r ewmaRate
+	r.update(100)
+	assert.InDelta(t, 100, r.value, 1e-9)
+	r.update(200)
+	assert.InDelta(t, 120, r.value, 1e-9)
+}
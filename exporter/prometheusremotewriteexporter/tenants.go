@@ -0,0 +1,323 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+var errTooManyTenants = errors.New("too many tenants")
+
+// tenantQueue holds the shards and the write-ahead-log of a tenant, so that the requests of a
+// tenant are not queued behind the requests of the other tenants.
+type tenantQueue struct {
+	tenant string
+	wal    *prweWAL
+	shards *shards
+
+	// inUse is the number of exports of the tenant in progress, and lastUsed the end of the last
+	// one. Both are guarded by the lock of the tenantQueues.
+	inUse    int
+	lastUsed time.Time
+	// stopped is set while the tenant is evicted, and closed once its write-ahead-log and shards
+	// are stopped. The queue stays registered meanwhile, so that the write-ahead-log of the tenant
+	// isn't opened again before it's closed.
+	stopped chan struct{}
+}
+
+// tenantQueues exports the metrics of each tenant with the shards and the write-ahead-log of the
+// tenant. The tenants without metrics for idle_timeout are released.
+type tenantQueues struct {
+	cfg       TenantsConfig
+	sharding  ShardingConfig
+	walConfig *WALConfig
+	set       exporter.Settings
+	prwe      *prwExporter
+
+	// walCtx is the context the write-ahead-logs of the tenants run with.
+	walCtx    context.Context
+	walCancel context.CancelFunc
+
+	mu     sync.Mutex
+	closed bool
+	queues map[string]*tenantQueue
+
+	stopCh  chan struct{}
+	evictWg sync.WaitGroup
+}
+
+func newTenantQueues(cfg *Config, set exporter.Settings, prwe *prwExporter) *tenantQueues {
+	walCtx, walCancel := context.WithCancel(contextWithLogger(context.Background(), set.Logger.Named("prw.wal")))
+	return &tenantQueues{
+		cfg:       cfg.Tenants,
+		sharding:  cfg.RemoteWriteQueue.Sharding,
+		walConfig: cfg.WAL.Get(),
+		set:       set,
+		prwe:      prwe,
+		walCtx:    walCtx,
+		walCancel: walCancel,
+		queues:    make(map[string]*tenantQueue),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// start runs the eviction of the idle tenants.
+func (tq *tenantQueues) start() {
+	tq.evictWg.Add(1)
+	go func() {
+		defer tq.evictWg.Done()
+		ticker := time.NewTicker(tq.cfg.idleTimeout() / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-tq.stopCh:
+				return
+			case now := <-ticker.C:
+				tq.evictIdle(now)
+			}
+		}
+	}()
+}
+
+// push splits the metrics by tenant and exports the metrics of the tenants concurrently, up to
+// max_concurrency at a time. The metrics of the tenants that failed with a retryable error are
+// returned in the error so that only them are retried. The metrics of the tenants that failed
+// with a permanent error, e.g. above max_tenants, are dropped.
+func (tq *tenantQueues) push(ctx context.Context, md pmetric.Metrics) error {
+	var (
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+		retryableErrs error
+		permanentErrs error
+		failed        = pmetric.NewMetrics()
+	)
+	sem := make(chan struct{}, tq.cfg.maxConcurrency())
+	for tenant, tenantMetrics := range tq.splitByTenant(ctx, md) {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := tq.export(ctx, tenant, tenantMetrics)
+			if err == nil {
+				return
+			}
+			err = fmt.Errorf("failed to export the metrics of tenant %q: %w", tenant, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if consumererror.IsPermanent(err) {
+				permanentErrs = multierr.Append(permanentErrs, err)
+				return
+			}
+			retryableErrs = multierr.Append(retryableErrs, err)
+			tenantMetrics.ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+		}()
+	}
+	wg.Wait()
+
+	if retryableErrs == nil {
+		return permanentErrs
+	}
+	// A permanent error would prevent the retry of the other tenants, it is logged instead.
+	if permanentErrs != nil {
+		tq.set.Logger.Error("dropping the metrics of tenants", zap.Error(permanentErrs))
+	}
+	return consumererror.NewMetrics(retryableErrs, failed)
+}
+
+// export exports the metrics of a tenant, holding its queue so that it isn't evicted meanwhile.
+func (tq *tenantQueues) export(ctx context.Context, tenant string, md pmetric.Metrics) error {
+	queue, err := tq.acquire(tenant)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	defer tq.release(queue)
+	return tq.prwe.pushMetrics(contextWithTenantQueue(ctx, queue), md)
+}
+
+// splitByTenant groups the resource metrics by tenant. The tenant is read from the resource
+// attribute, then from the client metadata, and falls back to the default tenant.
+func (tq *tenantQueues) splitByTenant(ctx context.Context, md pmetric.Metrics) map[string]pmetric.Metrics {
+	metadataTenant := tq.cfg.DefaultTenant
+	if tq.cfg.FromMetadata != "" {
+		if values := client.FromContext(ctx).Metadata.Get(tq.cfg.FromMetadata); len(values) > 0 && values[0] != "" {
+			metadataTenant = values[0]
+		}
+	}
+
+	byTenant := make(map[string]pmetric.Metrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		tenant := metadataTenant
+		if tq.cfg.FromAttribute != "" {
+			if value, ok := rm.Resource().Attributes().Get(tq.cfg.FromAttribute); ok && value.AsString() != "" {
+				tenant = value.AsString()
+			}
+		}
+		tenantMetrics, ok := byTenant[tenant]
+		if !ok {
+			tenantMetrics = pmetric.NewMetrics()
+			byTenant[tenant] = tenantMetrics
+		}
+		rm.CopyTo(tenantMetrics.ResourceMetrics().AppendEmpty())
+	}
+	return byTenant
+}
+
+// acquire returns the queue of the tenant, creating it if needed, and marks it in use. If the
+// tenant is being evicted, it waits for its queue to be stopped before creating a new one.
+func (tq *tenantQueues) acquire(tenant string) (*tenantQueue, error) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	if tq.closed {
+		return nil, errors.New("shutdown has been called")
+	}
+
+	queue, ok := tq.queues[tenant]
+	for ok && queue.stopped != nil {
+		stopped := queue.stopped
+		tq.mu.Unlock()
+		<-stopped
+		tq.mu.Lock()
+		if tq.closed {
+			return nil, errors.New("shutdown has been called")
+		}
+		queue, ok = tq.queues[tenant]
+	}
+	if !ok {
+		if len(tq.queues) >= tq.cfg.maxTenants() {
+			return nil, fmt.Errorf("%w: dropping metrics of tenant %q, max_tenants is %d", errTooManyTenants, tenant, tq.cfg.maxTenants())
+		}
+		var err error
+		if queue, err = tq.newQueue(tenant); err != nil {
+			return nil, err
+		}
+		tq.queues[tenant] = queue
+	}
+	queue.inUse++
+	return queue, nil
+}
+
+func (tq *tenantQueues) release(queue *tenantQueue) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+	queue.inUse--
+	queue.lastUsed = time.Now()
+}
+
+// newQueue creates and starts the write-ahead-log and the shards of a tenant. The write-ahead-log
+// of a tenant that was evicted resumes from the requests it had not sent yet.
+func (tq *tenantQueues) newQueue(tenant string) (*tenantQueue, error) {
+	queue := &tenantQueue{tenant: tenant}
+	if tq.walConfig != nil {
+		walConfig := *tq.walConfig
+		walConfig.Directory = tenantWALDirectory(tq.walConfig.Directory, tenant)
+		wal, err := newWAL(&walConfig, tq.set, func(ctx context.Context, requests []*prompb.WriteRequest) error {
+			return tq.prwe.export(contextWithTenantQueue(ctx, queue), requests)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := wal.run(tq.walCtx); err != nil {
+			return nil, err
+		}
+		queue.wal = wal
+	}
+	if tq.sharding.Enabled {
+		queue.shards = newShards(tq.sharding, tq.set.Logger.With(zap.String("tenant", tenant)), tq.prwe.telemetry)
+		queue.shards.start()
+	}
+	return queue, nil
+}
+
+// evictIdle releases the tenants without export in progress nor since idle_timeout. The tenants
+// are unregistered once their queue is stopped.
+func (tq *tenantQueues) evictIdle(now time.Time) {
+	var idle []*tenantQueue
+	tq.mu.Lock()
+	for _, queue := range tq.queues {
+		if queue.stopped == nil && queue.inUse == 0 && now.Sub(queue.lastUsed) >= tq.cfg.idleTimeout() {
+			queue.stopped = make(chan struct{})
+			idle = append(idle, queue)
+		}
+	}
+	tq.mu.Unlock()
+
+	for _, queue := range idle {
+		if err := queue.stop(); err != nil {
+			tq.set.Logger.Warn("failed to stop the write-ahead-log of an idle tenant", zap.String("tenant", queue.tenant), zap.Error(err))
+		}
+		tq.mu.Lock()
+		delete(tq.queues, queue.tenant)
+		tq.mu.Unlock()
+		close(queue.stopped)
+	}
+}
+
+// stop stops the write-ahead-log of the tenant, waiting for the requests being sent, then its shards.
+func (queue *tenantQueue) stop() error {
+	var err error
+	if queue.wal != nil {
+		err = queue.wal.stop()
+	}
+	if queue.shards != nil {
+		queue.shards.stop()
+	}
+	return err
+}
+
+// stop stops the eviction of the idle tenants and the write-ahead-logs and the shards of the
+// tenants. It must be called once the exports in progress are done.
+func (tq *tenantQueues) stop() error {
+	tq.mu.Lock()
+	if tq.closed {
+		tq.mu.Unlock()
+		return nil
+	}
+	tq.closed = true
+	tq.mu.Unlock()
+
+	// The evictions in progress are done once the eviction loop returns, so that the queues left
+	// are not being stopped.
+	close(tq.stopCh)
+	tq.evictWg.Wait()
+
+	tq.mu.Lock()
+	queues := tq.queues
+	tq.queues = make(map[string]*tenantQueue)
+	tq.mu.Unlock()
+
+	var errs error
+	for _, queue := range queues {
+		errs = multierr.Append(errs, queue.stop())
+	}
+	tq.walCancel()
+	return errs
+}
+
+// tenantWALDirectory returns the write-ahead-log directory of a tenant. The tenant is hex encoded
+// as it can contain characters that aren't valid in a path.
+func tenantWALDirectory(directory, tenant string) string {
+	if tenant == "" {
+		return filepath.Join(directory, "tenants", "default")
+	}
+	return filepath.Join(directory, "tenants", hex.EncodeToString([]byte(tenant)))
+}
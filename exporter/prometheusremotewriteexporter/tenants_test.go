@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadata"
+)
+
+func tenantMetrics(tenants ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tenant := range tenants {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant", tenant)
+		}
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("test_metric")
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetDoubleValue(1)
+		dp.Attributes().PutStr("tenant", tenant)
+	}
+	return md
+}
+
+func TestTenantsSplitByTenant(t *testing.T) {
+	tq := &tenantQueues{cfg: TenantsConfig{
+		Enabled:       true,
+		FromAttribute: "tenant",
+		FromMetadata:  "x-tenant",
+		DefaultTenant: "anonymous",
+	}}
+
+	split := tq.splitByTenant(t.Context(), tenantMetrics("a", "b", "a", ""))
+	require.Len(t, split, 3)
+	assert.Equal(t, 2, split["a"].ResourceMetrics().Len())
+	assert.Equal(t, 1, split["b"].ResourceMetrics().Len())
+	assert.Equal(t, 1, split["anonymous"].ResourceMetrics().Len())
+
+	// The client metadata is used for the resources without tenant attribute
+	ctx := client.NewContext(t.Context(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"c"}}),
+	})
+	split = tq.splitByTenant(ctx, tenantMetrics("a", ""))
+	require.Len(t, split, 2)
+	assert.Equal(t, 1, split["a"].ResourceMetrics().Len())
+	assert.Equal(t, 1, split["c"].ResourceMetrics().Len())
+}
+
+func TestTenantWALDirectory(t *testing.T) {
+	assert.Equal(t, filepath.Join("wal", "tenants", "default"), tenantWALDirectory("wal", ""))
+	assert.Equal(t, filepath.Join("wal", "tenants", "74656e616e742f61"), tenantWALDirectory("wal", "tenant/a"))
+}
+
+func newTenantsTestExporter(t *testing.T, endpoint string, tenants TenantsConfig, wal bool) *prwExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig = confighttp.NewDefaultClientConfig()
+	cfg.ClientConfig.Endpoint = endpoint
+	cfg.RemoteWriteProtoMsg = config.RemoteWriteProtoMsgV1
+	cfg.TargetInfo.Enabled = false
+	cfg.BackOffConfig.Enabled = false
+	cfg.RemoteWriteQueue.Sharding = ShardingConfig{Enabled: true, MinShards: 2}
+	cfg.Tenants = tenants
+	if wal {
+		cfg.WAL = configoptional.Some(WALConfig{Directory: t.TempDir()})
+	}
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	assert.Nil(t, prwe.wal)
+	require.NoError(t, prwe.Start(t.Context(), componenttest.NewNopHost()))
+	return prwe
+}
+
+func TestTenantsExport(t *testing.T) {
+	tests := []struct {
+		name string
+		wal  bool
+	}{
+		{name: "without wal"},
+		{name: "with wal", wal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := make(map[string]int)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				requests[r.Header.Get("X-Scope-OrgID")]++
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			prwe := newTenantsTestExporter(t, server.URL, TenantsConfig{Enabled: true, FromAttribute: "tenant"}, tt.wal)
+
+			require.NoError(t, prwe.PushMetrics(t.Context(), tenantMetrics("a", "b")))
+			require.NoError(t, prwe.PushMetrics(t.Context(), tenantMetrics("a")))
+
+			if !tt.wal {
+				// The metrics are sent before PushMetrics returns
+				mu.Lock()
+				assert.Equal(t, map[string]int{"a": 2, "b": 1}, requests)
+				mu.Unlock()
+				require.NoError(t, prwe.Shutdown(t.Context()))
+				return
+			}
+
+			assert.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return requests["a"] >= 2 && requests["b"] >= 1
+			}, 10*time.Second, 10*time.Millisecond)
+			require.NoError(t, prwe.Shutdown(t.Context()))
+		})
+	}
+}
+
+func TestTenantsExportFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Scope-OrgID") == "b" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTenantsTestExporter(t, server.URL, TenantsConfig{Enabled: true, FromAttribute: "tenant"}, false)
+
+	// The delivery error of a tenant is returned rather than only logged
+	err := prwe.PushMetrics(t.Context(), tenantMetrics("a", "b"))
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `failed to export the metrics of tenant "b"`)
+	assert.NotContains(t, err.Error(), `tenant "a"`)
+
+	require.NoError(t, prwe.Shutdown(t.Context()))
+}
+
+func TestTenantsLimits(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tenants = TenantsConfig{Enabled: true, FromAttribute: "tenant", MaxTenants: 1}
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+
+	tq := prwe.tenants
+	queue, err := tq.acquire("a")
+	require.NoError(t, err)
+
+	_, err = tq.acquire("b")
+	assert.ErrorIs(t, err, errTooManyTenants)
+
+	// The metrics of the extra tenant are dropped
+	err = tq.push(t.Context(), tenantMetrics("b"))
+	assert.ErrorIs(t, err, errTooManyTenants)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	// The tenant slot is released once the tenant is idle
+	tq.release(queue)
+	tq.evictIdle(time.Now())
+	assert.Contains(t, tq.queues, "a")
+	tq.evictIdle(time.Now().Add(tq.cfg.idleTimeout()))
+	assert.NotContains(t, tq.queues, "a")
+
+	queue, err = tq.acquire("b")
+	require.NoError(t, err)
+	// The tenants with exports in progress are not evicted
+	tq.evictIdle(time.Now().Add(time.Hour))
+	assert.Contains(t, tq.queues, "b")
+	tq.release(queue)
+
+	require.NoError(t, prwe.Shutdown(t.Context()))
+}
+
+func TestTenantsEvictAndAcquire(t *testing.T) {
+	prwe := newTenantsTestExporter(t, "http://localhost:1", TenantsConfig{Enabled: true, FromAttribute: "tenant"}, true)
+	tq := prwe.tenants
+
+	for i := 0; i < 20; i++ {
+		queue, err := tq.acquire("a")
+		require.NoError(t, err)
+		tq.release(queue)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tq.evictIdle(time.Now().Add(time.Hour))
+		}()
+
+		// The write-ahead-log of the tenant is only opened again once the evicted one is stopped
+		acquired, err := tq.acquire("a")
+		require.NoError(t, err)
+		if acquired != queue {
+			select {
+			case <-queue.stopped:
+			default:
+				assert.Fail(t, "the queue of the tenant was created before the evicted one was stopped")
+			}
+		}
+		tq.release(acquired)
+		wg.Wait()
+	}
+
+	require.NoError(t, prwe.Shutdown(t.Context()))
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/pprof"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+// maxMetricsBytes is the maximum size of the internal metrics snapshot.
+const maxMetricsBytes = 64 << 20
+
+var errNoConfig = errors.New("the effective config has not been notified yet")
+
+// manifest describes the content of a bundle.
+type manifest struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Collector collectorInfo     `json:"collector"`
+	Files     []string          `json:"files"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+type collectorInfo struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// bundleFile is a file of a bundle, and the function collecting its content.
+type bundleFile struct {
+	name    string
+	collect func(ctx context.Context) ([]byte, error)
+}
+
+func bundleFileName(t time.Time) string {
+	return "diagnostics-" + t.UTC().Format("20060102T150405Z") + ".tar.gz"
+}
+
+// files returns the files of a bundle. The failure to collect a file is recorded in the
+// manifest rather than failing the whole bundle.
+func (e *diagnosticsExtension) files() []bundleFile {
+	files := []bundleFile{
+		{name: "config.yaml", collect: e.collectConfig},
+		{name: "status.json", collect: e.collectStatus},
+	}
+	for _, name := range e.config.Profiles {
+		files = append(files, bundleFile{
+			name: "profiles/" + name + ".pprof",
+			collect: func(context.Context) ([]byte, error) {
+				return collectProfile(name)
+			},
+		})
+	}
+	if e.metricsClient != nil {
+		files = append(files, bundleFile{name: "metrics.txt", collect: e.collectInternalMetrics})
+	}
+	files = append(files,
+		bundleFile{name: "logs.txt", collect: e.collectLogs},
+		bundleFile{name: "storage.json", collect: e.collectStorageUsage},
+	)
+	return files
+}
+
+// writeBundle writes a gzipped tar archive of the bundle to w.
+func (e *diagnosticsExtension) writeBundle(ctx context.Context, w io.Writer) error {
+	now := time.Now()
+	m := manifest{
+		CreatedAt: now,
+		Collector: collectorInfo{
+			Command:     e.buildInfo.Command,
+			Description: e.buildInfo.Description,
+			Version:     e.buildInfo.Version,
+		},
+		Files:  []string{},
+		Errors: map[string]string{},
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, f := range e.files() {
+		data, err := f.collect(ctx)
+		if err != nil {
+			m.Errors[f.name] = err.Error()
+			continue
+		}
+		if err = writeTarFile(tw, f.name, now, data); err != nil {
+			return err
+		}
+		m.Files = append(m.Files, f.name)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writeTarFile(tw, "manifest.json", now, data); err != nil {
+		return err
+	}
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, modTime time.Time, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func (e *diagnosticsExtension) collectConfig(context.Context) ([]byte, error) {
+	conf := e.conf.Load()
+	if conf == nil {
+		return nil, errNoConfig
+	}
+	return yaml.Marshal(redactConfig(conf.ToStringMap(), e.config.UnredactedKeys))
+}
+
+func (e *diagnosticsExtension) collectStatus(context.Context) ([]byte, error) {
+	st, _ := e.aggregator.AggregateStatus(status.ScopeAll, status.Verbose)
+	return json.MarshalIndent(toSerializableStatus(st), "", "  ")
+}
+
+func (e *diagnosticsExtension) collectInternalMetrics(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.config.InternalMetrics.Endpoint, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+
+	resp, err := e.metricsClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxMetricsBytes))
+}
+
+// collectLogs returns the recent logs from the log file, or from memory when the path of the
+// file is not set.
+func (e *diagnosticsExtension) collectLogs(context.Context) ([]byte, error) {
+	if e.config.Logs.Path != "" {
+		return readRecentLogs(e.config.Logs.Path, e.config.Logs.Level, e.config.Logs.MaxEntries)
+	}
+	return memoryLogs.recentLogs(e.config.Logs.Level, e.config.Logs.MaxEntries)
+}
+
+func (e *diagnosticsExtension) collectStorageUsage(context.Context) ([]byte, error) {
+	conf := e.conf.Load()
+	if conf == nil {
+		return nil, errNoConfig
+	}
+	return json.MarshalIndent(storageUsages(conf), "", "  ")
+}
+
+// collectProfile returns the runtime profile with the given name in the protobuf format
+// read by `go tool pprof`.
+func collectProfile(name string) ([]byte, error) {
+	p := pprof.Lookup(name)
+	if p == nil {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	var buf bytes.Buffer
+	if err := p.WriteTo(&buf, 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
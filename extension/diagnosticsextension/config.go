@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	errNoOutput          = errors.New("either endpoint or output_dir must be set")
+	errInvalidPath       = errors.New("path must start with /")
+	errInvalidMaxEntries = errors.New("logs::max_entries must be positive")
+)
+
+// profileNames are the names of the runtime profiles which can be included in the bundle.
+var profileNames = []string{"goroutine", "heap", "allocs", "threadcreate", "block", "mutex"}
+
+// Config has the configuration for the diagnostics extension.
+type Config struct {
+	// ServerConfig configures the HTTP server serving the bundle. The server is disabled
+	// when the endpoint is empty.
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Path is the path of the endpoint serving the bundle.
+	Path string `mapstructure:"path"`
+
+	// OutputDir is the directory the bundle is written to when the collector receives
+	// SIGUSR1. Writing bundles on signal is disabled when it is empty.
+	OutputDir string `mapstructure:"output_dir"`
+
+	// InternalMetrics configures the client scraping the internal metrics of the collector,
+	// exposed in the Prometheus text format. The snapshot is skipped when the endpoint is empty.
+	InternalMetrics confighttp.ClientConfig `mapstructure:"internal_metrics"`
+
+	// Profiles are the names of the runtime profiles included in the bundle.
+	Profiles []string `mapstructure:"profiles"`
+
+	// Logs configures the recent logs included in the bundle.
+	Logs LogsConfig `mapstructure:"logs"`
+
+	// UnredactedKeys are the case-insensitive keys whose values are kept in the effective config.
+	// The other scalar values, except for booleans, are redacted.
+	UnredactedKeys []string `mapstructure:"unredacted_keys"`
+}
+
+// LogsConfig configures the recent logs included in the bundle.
+type LogsConfig struct {
+	// Path is the file the collector writes its logs to, as set in the output_paths of
+	// the service::telemetry::logs settings. When it is empty, the logs are read from memory,
+	// if the diagnostics:// output path is set.
+	Path string `mapstructure:"path"`
+
+	// Level is the minimum level of the included log entries.
+	Level zapcore.Level `mapstructure:"level"`
+
+	// MaxEntries is the maximum number of the most recent log entries included.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" && cfg.OutputDir == "" {
+		return errNoOutput
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errInvalidPath
+	}
+	for _, name := range cfg.Profiles {
+		if !slices.Contains(profileNames, name) {
+			return fmt.Errorf("invalid profile %q, must be one of %v", name, profileNames)
+		}
+	}
+	if cfg.Logs.MaxEntries <= 0 {
+		return errInvalidMaxEntries
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id             component.ID
+		expectedConfig func() *Config
+		expectedErr    string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expectedConfig: func() *Config {
+				return createDefaultConfig().(*Config)
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expectedConfig: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "localhost:13141"
+				cfg.Path = "/bundle"
+				cfg.OutputDir = "/var/lib/otelcol/diagnostics"
+				cfg.InternalMetrics.Endpoint = "http://localhost:9999/metrics"
+				cfg.InternalMetrics.Timeout = 10 * time.Second
+				cfg.Profiles = []string{"goroutine", "heap", "mutex"}
+				cfg.Logs = LogsConfig{
+					Path:       "/var/log/otelcol/otelcol.log",
+					Level:      zapcore.WarnLevel,
+					MaxEntries: 100,
+				}
+				cfg.UnredactedKeys = []string{"endpoint", "username"}
+				return cfg
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "output_dir_only"),
+			expectedConfig: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = ""
+				cfg.OutputDir = "/var/lib/otelcol/diagnostics"
+				return cfg
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_output"),
+			expectedErr: errNoOutput.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_path"),
+			expectedErr: errInvalidPath.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_profile"),
+			expectedErr: `invalid profile "cpu"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_max_entries"),
+			expectedErr: errInvalidMaxEntries.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.expectedErr)
+				return
+			}
+			require.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expectedConfig(), cfg)
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package diagnosticsextension serves a diagnostics bundle of the collector, a single archive
+// containing its redacted effective config, component statuses, profiles, internal metrics,
+// recent error logs and storage usage.
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+type diagnosticsExtension struct {
+	config        *Config
+	telemetry     component.TelemetrySettings
+	buildInfo     component.BuildInfo
+	aggregator    *status.Aggregator
+	conf          atomic.Pointer[confmap.Conf]
+	metricsClient *http.Client
+	server        *http.Server
+	signalCh      chan os.Signal
+	stopCh        chan struct{}
+	doneWg        sync.WaitGroup
+}
+
+var (
+	_ extension.Extension                 = (*diagnosticsExtension)(nil)
+	_ extensioncapabilities.ConfigWatcher = (*diagnosticsExtension)(nil)
+	_ componentstatus.Watcher             = (*diagnosticsExtension)(nil)
+)
+
+func newDiagnosticsExtension(config *Config, set extension.Settings) *diagnosticsExtension {
+	return &diagnosticsExtension{
+		config:     config,
+		telemetry:  set.TelemetrySettings,
+		buildInfo:  set.BuildInfo,
+		aggregator: status.NewAggregator(status.PriorityPermanent),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start implements the component.Component interface.
+func (e *diagnosticsExtension) Start(ctx context.Context, host component.Host) error {
+	var err error
+	if e.config.InternalMetrics.Endpoint != "" {
+		if e.metricsClient, err = e.config.InternalMetrics.ToClient(ctx, host, e.telemetry); err != nil {
+			return err
+		}
+	}
+
+	if e.config.Endpoint != "" {
+		mux := http.NewServeMux()
+		mux.Handle(e.config.Path, e.bundleHandler())
+		if e.server, err = e.config.ToServer(ctx, host, e.telemetry, mux); err != nil {
+			return err
+		}
+
+		ln, err := e.config.ToListener(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to bind to address %s: %w", e.config.Endpoint, err)
+		}
+
+		e.doneWg.Add(1)
+		go func() {
+			defer e.doneWg.Done()
+
+			if err := e.server.Serve(ln); !errors.Is(err, http.ErrServerClosed) && err != nil {
+				componentstatus.ReportStatus(host, componentstatus.NewPermanentErrorEvent(err))
+			}
+		}()
+	}
+
+	if e.config.OutputDir != "" {
+		if len(bundleSignals) == 0 {
+			e.telemetry.Logger.Warn("Writing diagnostics bundles on signal is not supported on this platform")
+			return nil
+		}
+
+		e.signalCh = make(chan os.Signal, 1)
+		signal.Notify(e.signalCh, bundleSignals...)
+
+		e.doneWg.Add(1)
+		go e.handleSignals()
+	}
+
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (e *diagnosticsExtension) Shutdown(context.Context) error {
+	if e.server != nil {
+		e.server.Close()
+	}
+	if e.signalCh != nil {
+		signal.Stop(e.signalCh)
+	}
+	close(e.stopCh)
+	e.doneWg.Wait()
+	e.aggregator.Close()
+	return nil
+}
+
+// NotifyConfig implements the extensioncapabilities.ConfigWatcher interface.
+func (e *diagnosticsExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	e.conf.Store(conf)
+	return nil
+}
+
+// ComponentStatusChanged implements the componentstatus.Watcher interface.
+func (e *diagnosticsExtension) ComponentStatusChanged(
+	source *componentstatus.InstanceID,
+	event *componentstatus.Event,
+) {
+	e.aggregator.RecordStatus(source, event)
+}
+
+func (e *diagnosticsExtension) handleSignals() {
+	defer e.doneWg.Done()
+
+	for {
+		select {
+		case <-e.signalCh:
+			path, err := e.writeBundleFile(context.Background())
+			if err != nil {
+				e.telemetry.Logger.Error("Failed to write the diagnostics bundle", zap.Error(err))
+				continue
+			}
+			e.telemetry.Logger.Info("Wrote the diagnostics bundle", zap.String("path", path))
+		case <-e.stopCh:
+			return
+		}
+	}
+}
+
+func (e *diagnosticsExtension) bundleHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bundleFileName(time.Now())))
+		if err := e.writeBundle(r.Context(), w); err != nil {
+			e.telemetry.Logger.Warn("Failed to serve the diagnostics bundle", zap.Error(err))
+		}
+	})
+}
+
+// writeBundleFile writes a bundle to the output directory, and returns its path.
+func (e *diagnosticsExtension) writeBundleFile(ctx context.Context) (string, error) {
+	if err := os.MkdirAll(e.config.OutputDir, 0o700); err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first, so that partial bundles are never picked up
+	f, err := os.CreateTemp(e.config.OutputDir, ".diagnostics-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	err = e.writeBundle(ctx, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(e.config.OutputDir, bundleFileName(time.Now()))
+	if err := os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
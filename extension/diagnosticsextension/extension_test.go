@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+// readBundle returns the content of the files of a bundle by name.
+func readBundle(t *testing.T, r io.Reader) map[string][]byte {
+	gr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files[hdr.Name], err = io.ReadAll(tr)
+		require.NoError(t, err)
+	}
+	return files
+}
+
+func newTestExtension(t *testing.T, cfg *Config) *diagnosticsExtension {
+	storageDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(storageDir, "receiver_filelog_"), []byte("data"), 0o600))
+
+	ext := newDiagnosticsExtension(cfg, extensiontest.NewNopSettings(metadata.Type))
+	require.NoError(t, ext.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": "backend:4317",
+				"headers":  map[string]any{"Authorization": "Bearer secret-token"},
+			},
+		},
+		"extensions": map[string]any{
+			"file_storage": map[string]any{"directory": storageDir},
+			"diagnostics":  nil,
+		},
+		"service": map[string]any{
+			"extensions": []any{"file_storage", "diagnostics"},
+		},
+	})))
+	return ext
+}
+
+func TestBundleHandler(t *testing.T) {
+	metrics := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("otelcol_process_uptime 12.5\n"))
+	}))
+	defer metrics.Close()
+
+	logPath := filepath.Join(t.TempDir(), "otelcol.log")
+	require.NoError(t, os.WriteFile(logPath, []byte(`2024-01-01T00:00:00.000Z	info	service@v0.100.0/service.go:10	Starting
+2024-01-01T00:00:01.000Z	error	exporterhelper/queue_sender.go:20	Exporting failed	{"error": "connection refused"}
+`), 0o600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.InternalMetrics.Endpoint = metrics.URL + "/metrics"
+	cfg.Logs.Path = logPath
+
+	ext := newTestExtension(t, cfg)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+	ext.ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter).
+			WithPipelines(pipeline.NewID(pipeline.SignalTraces)),
+		componentstatus.NewEvent(componentstatus.StatusOK),
+	)
+
+	resp, err := http.Get("http://" + cfg.Endpoint + cfg.Path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/gzip", resp.Header.Get("Content-Type"))
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment; filename=\"diagnostics-")
+
+	files := readBundle(t, resp.Body)
+
+	var m manifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &m))
+	assert.ElementsMatch(t, []string{
+		"config.yaml",
+		"status.json",
+		"profiles/goroutine.pprof",
+		"profiles/heap.pprof",
+		"metrics.txt",
+		"logs.txt",
+		"storage.json",
+	}, m.Files)
+	assert.Empty(t, m.Errors)
+
+	assert.Contains(t, string(files["config.yaml"]), "endpoint: backend:4317")
+	assert.Contains(t, string(files["config.yaml"]), redactedValue)
+	assert.NotContains(t, string(files["config.yaml"]), "secret-token")
+
+	var st serializableStatus
+	require.NoError(t, json.Unmarshal(files["status.json"], &st))
+	require.Contains(t, st.Components, "pipeline:traces")
+	assert.Equal(t, "StatusOK", st.Components["pipeline:traces"].Components["exporter:otlp"].Status)
+
+	assert.NotEmpty(t, files["profiles/goroutine.pprof"])
+	assert.NotEmpty(t, files["profiles/heap.pprof"])
+	assert.Equal(t, "otelcol_process_uptime 12.5\n", string(files["metrics.txt"]))
+	assert.Contains(t, string(files["logs.txt"]), "Exporting failed")
+	assert.NotContains(t, string(files["logs.txt"]), "Starting")
+
+	var usages []storageUsage
+	require.NoError(t, json.Unmarshal(files["storage.json"], &usages))
+	require.Len(t, usages, 1)
+	assert.Equal(t, "file_storage", usages[0].Extension)
+	assert.Equal(t, int64(4), usages[0].TotalSize)
+}
+
+func TestBundleHandlerMethodNotAllowed(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+
+	rec := httptest.NewRecorder()
+	ext.bundleHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, defaultPath, http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestWriteBundleCollectErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Path = filepath.Join(t.TempDir(), "missing.log")
+	ext := newDiagnosticsExtension(cfg, extensiontest.NewNopSettings(metadata.Type))
+
+	rec := httptest.NewRecorder()
+	ext.bundleHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultPath, http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	files := readBundle(t, rec.Body)
+	var m manifest
+	require.NoError(t, json.Unmarshal(files["manifest.json"], &m))
+	assert.Equal(t, errNoConfig.Error(), m.Errors["config.yaml"])
+	assert.Equal(t, errNoConfig.Error(), m.Errors["storage.json"])
+	assert.Contains(t, m.Errors, "logs.txt")
+	assert.Contains(t, m.Files, "status.json")
+	assert.NotContains(t, files, "config.yaml")
+}
+
+func TestWriteBundleFile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.OutputDir = filepath.Join(t.TempDir(), "bundles")
+	ext := newTestExtension(t, cfg)
+
+	path, err := ext.writeBundleFile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, cfg.OutputDir, filepath.Dir(path))
+
+	entries, err := os.ReadDir(cfg.OutputDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	assert.Contains(t, readBundle(t, f), "config.yaml")
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension/internal/metadata"
+)
+
+const (
+	defaultEndpoint                = "localhost:13140"
+	defaultPath                    = "/diagnostics/bundle"
+	defaultInternalMetricsEndpoint = "http://localhost:8888/metrics"
+	defaultInternalMetricsTimeout  = 5 * time.Second
+	defaultLogsMaxEntries          = 1000
+)
+
+// NewFactory creates a factory for the diagnostics extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	serverConfig := confighttp.NewDefaultServerConfig()
+	serverConfig.Endpoint = defaultEndpoint
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = defaultInternalMetricsEndpoint
+	clientConfig.Timeout = defaultInternalMetricsTimeout
+
+	return &Config{
+		ServerConfig:    serverConfig,
+		Path:            defaultPath,
+		InternalMetrics: clientConfig,
+		Profiles:        []string{"goroutine", "heap"},
+		Logs: LogsConfig{
+			Level:      zapcore.ErrorLevel,
+			MaxEntries: defaultLogsMaxEntries,
+		},
+		UnredactedKeys: []string{
+			"endpoint",
+			"timeout",
+			"interval",
+			"collection_interval",
+			"initial_delay",
+			"level",
+			"verbosity",
+			"encoding",
+			"compression",
+			"receivers",
+			"processors",
+			"exporters",
+			"connectors",
+			"extensions",
+			"job_name",
+			"directory",
+			"storage",
+			"send_batch_size",
+			"send_batch_max_size",
+			"queue_size",
+			"num_consumers",
+			"check_interval",
+			"limit_mib",
+			"spike_limit_mib",
+		},
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newDiagnosticsExtension(cfg.(*Config), set), nil
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension/internal/metadata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultEndpoint, cfg.Endpoint)
+	assert.Equal(t, defaultPath, cfg.Path)
+	assert.Equal(t, defaultInternalMetricsEndpoint, cfg.InternalMetrics.Endpoint)
+	assert.Equal(t, []string{"goroutine", "heap"}, cfg.Profiles)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestCreateExtension(t *testing.T) {
+	ext, err := createExtension(context.Background(), extensiontest.NewNopSettings(metadata.Type), createDefaultConfig())
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// maxLogsBytes is the size of the end of the log file which is searched for recent entries.
+	maxLogsBytes = 16 << 20
+
+	// logsSinkScheme is the scheme of the output path of the collector logs kept in memory,
+	// e.g. `diagnostics://` in the output_paths of the service::telemetry::logs settings.
+	logsSinkScheme = "diagnostics"
+	// maxMemoryLogEntries is the maximum number of the most recent entries kept in memory.
+	maxMemoryLogEntries = 10000
+)
+
+var errNoLogsSink = fmt.Errorf("neither logs::path nor the %s:// logs output path is set", logsSinkScheme)
+
+// memoryLogs keeps the most recent entries written to the logs output path of the extension.
+// The output path is registered globally, as the logger of the collector is created before
+// the extensions.
+var memoryLogs = newLogsBuffer(maxMemoryLogEntries)
+
+func init() {
+	if err := zap.RegisterSink(logsSinkScheme, func(*url.URL) (zap.Sink, error) {
+		return memoryLogs.open(), nil
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// logsBuffer is a zap.Sink keeping the most recent encoded log entries.
+type logsBuffer struct {
+	mu         sync.Mutex
+	opened     bool
+	maxEntries int
+	entries    []string
+}
+
+var _ zap.Sink = (*logsBuffer)(nil)
+
+func newLogsBuffer(maxEntries int) *logsBuffer {
+	return &logsBuffer{maxEntries: maxEntries}
+}
+
+func (b *logsBuffer) open() *logsBuffer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.opened = true
+	return b
+}
+
+// Write implements zapcore.WriteSyncer. Each write is a single entry.
+func (b *logsBuffer) Write(p []byte) (int, error) {
+	entry := strings.TrimRight(string(p), "\n")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, entry)
+	if len(b.entries) > b.maxEntries {
+		b.entries = b.entries[1:]
+	}
+	return len(p), nil
+}
+
+// Sync implements zapcore.WriteSyncer.
+func (*logsBuffer) Sync() error {
+	return nil
+}
+
+// Close implements io.Closer. The entries are kept, as the output path is opened again when
+// the collector reloads its config.
+func (*logsBuffer) Close() error {
+	return nil
+}
+
+// recentLogs returns the most recent entries with at least the given level.
+func (b *logsBuffer) recentLogs(minLevel zapcore.Level, maxEntries int) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.opened {
+		return nil, errNoLogsSink
+	}
+
+	var entries []string
+	for i := len(b.entries) - 1; i >= 0 && len(entries) < maxEntries; i-- {
+		firstLine, _, _ := strings.Cut(b.entries[i], "\n")
+		if level, ok := logLevel(firstLine); ok && level >= minLevel {
+			entries = append(entries, b.entries[i])
+		}
+	}
+	slices.Reverse(entries)
+	return joinLogEntries(entries), nil
+}
+
+// readRecentLogs returns the most recent entries of the log file with at least the given level.
+// Both the json and console encodings of the collector logs are supported. The lines following
+// an entry without a level, such as the stacktrace of the console encoding, belong to the entry.
+func readRecentLogs(path string, minLevel zapcore.Level, maxEntries int) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	partial := false
+	if info.Size() > maxLogsBytes {
+		if _, err = f.Seek(info.Size()-maxLogsBytes, io.SeekStart); err != nil {
+			return nil, err
+		}
+		partial = true
+	}
+
+	var entries []string
+	keep := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if partial {
+			// The first line is likely cut by the seek
+			partial = false
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		level, ok := logLevel(line)
+		if !ok {
+			if keep {
+				entries[len(entries)-1] += "\n" + line
+			}
+			continue
+		}
+
+		keep = level >= minLevel
+		if keep {
+			entries = append(entries, line)
+			if len(entries) > maxEntries {
+				entries = entries[1:]
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return joinLogEntries(entries), nil
+}
+
+func joinLogEntries(entries []string) []byte {
+	if len(entries) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(entries, "\n") + "\n")
+}
+
+// logLevel returns the level of a log entry encoded in json or console, and false if the line
+// is not the first line of an entry.
+func logLevel(line string) (zapcore.Level, bool) {
+	var levelText string
+	if strings.HasPrefix(line, "{") {
+		var entry struct {
+			Level string `json:"level"`
+		}
+		if json.Unmarshal([]byte(line), &entry) != nil {
+			return zapcore.InvalidLevel, false
+		}
+		levelText = entry.Level
+	} else {
+		// The console encoding starts with the timestamp and the level separated by tabs
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 2 {
+			return zapcore.InvalidLevel, false
+		}
+		levelText = fields[1]
+	}
+
+	level, err := zapcore.ParseLevel(strings.ToLower(levelText))
+	if err != nil {
+		return zapcore.InvalidLevel, false
+	}
+	return level, true
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func writeLogFile(t *testing.T, lines ...string) string {
+	path := filepath.Join(t.TempDir(), "otelcol.log")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	return path
+}
+
+func TestReadRecentLogs(t *testing.T) {
+	path := writeLogFile(t,
+		"2024-01-01T00:00:00.000Z\tinfo\tservice.go:10\tStarting",
+		"2024-01-01T00:00:01.000Z\terror\tqueue_sender.go:20\tExporting failed",
+		"go.opentelemetry.io/collector/exporter/exporterhelper.(*retrySender).send",
+		"\t/go/pkg/mod/retry_sender.go:100",
+		"2024-01-01T00:00:02.000Z\twarn\tbatch.go:30\tBatch too large",
+		`{"level":"error","ts":"2024-01-01T00:00:03.000Z","msg":"Scrape failed"}`,
+		`{"level":"debug","ts":"2024-01-01T00:00:04.000Z","msg":"Scraping"}`,
+		"",
+		"2024-01-01T00:00:05.000Z\tERROR\tconsumer.go:40\tDropping data",
+	)
+
+	data, err := readRecentLogs(path, zapcore.ErrorLevel, 10)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"2024-01-01T00:00:01.000Z\terror\tqueue_sender.go:20\tExporting failed",
+		"go.opentelemetry.io/collector/exporter/exporterhelper.(*retrySender).send",
+		"\t/go/pkg/mod/retry_sender.go:100",
+		`{"level":"error","ts":"2024-01-01T00:00:03.000Z","msg":"Scrape failed"}`,
+		"2024-01-01T00:00:05.000Z\tERROR\tconsumer.go:40\tDropping data",
+	}, "\n")+"\n", string(data))
+
+	data, err = readRecentLogs(path, zapcore.WarnLevel, 2)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		`{"level":"error","ts":"2024-01-01T00:00:03.000Z","msg":"Scrape failed"}`,
+		"2024-01-01T00:00:05.000Z\tERROR\tconsumer.go:40\tDropping data",
+	}, "\n")+"\n", string(data))
+
+	data, err = readRecentLogs(path, zapcore.FatalLevel, 10)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = readRecentLogs(filepath.Join(t.TempDir(), "missing.log"), zapcore.ErrorLevel, 10)
+	assert.Error(t, err)
+}
+
+func TestLogsBuffer(t *testing.T) {
+	b := newLogsBuffer(3)
+	_, err := b.recentLogs(zapcore.ErrorLevel, 10)
+	require.ErrorIs(t, err, errNoLogsSink)
+
+	b.open()
+	for _, entry := range []string{
+		"2024-01-01T00:00:00.000Z\terror\tservice.go:10\tDropped\n",
+		"2024-01-01T00:00:01.000Z\terror\tqueue_sender.go:20\tExporting failed\n\t/go/pkg/mod/retry_sender.go:100\n",
+		"2024-01-01T00:00:02.000Z\tinfo\tservice.go:30\tEverything is ready\n",
+		`{"level":"warn","ts":"2024-01-01T00:00:03.000Z","msg":"Batch too large"}` + "\n",
+	} {
+		_, err = b.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+
+	data, err := b.recentLogs(zapcore.WarnLevel, 10)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"2024-01-01T00:00:01.000Z\terror\tqueue_sender.go:20\tExporting failed",
+		"\t/go/pkg/mod/retry_sender.go:100",
+		`{"level":"warn","ts":"2024-01-01T00:00:03.000Z","msg":"Batch too large"}`,
+	}, "\n")+"\n", string(data))
+
+	data, err = b.recentLogs(zapcore.InfoLevel, 1)
+	require.NoError(t, err)
+	assert.Equal(t, `{"level":"warn","ts":"2024-01-01T00:00:03.000Z","msg":"Batch too large"}`+"\n", string(data))
+}
+
+func TestMemoryLogsSink(t *testing.T) {
+	cfg := zap.NewProductionConfig()
+	cfg.OutputPaths = []string{logsSinkScheme + "://"}
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+
+	logger.Error("Exporting failed", zap.String("error", "connection refused"))
+	require.NoError(t, logger.Sync())
+
+	data, err := memoryLogs.recentLogs(zapcore.ErrorLevel, 1)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Exporting failed")
+	assert.Contains(t, string(data), "connection refused")
+}
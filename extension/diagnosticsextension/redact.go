@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"net/url"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// redactConfig returns a copy of conf where the scalar values are replaced, except for booleans,
+// empty values and the values of the unredacted keys. Sensitive values cannot be told apart by the
+// name of their key, so that only the values known to be safe are kept. Maps are never redacted as
+// a whole, so that the IDs of the components and the structure of the config remain visible.
+func redactConfig(conf map[string]any, unredactedKeys []string) map[string]any {
+	keys := make(map[string]struct{}, len(unredactedKeys))
+	for _, k := range unredactedKeys {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+	return redactMap(conf, keys)
+}
+
+func redactMap(m map[string]any, keys map[string]struct{}) map[string]any {
+	redacted := make(map[string]any, len(m))
+	for k, v := range m {
+		_, keep := keys[strings.ToLower(k)]
+		redacted[k] = redactValue(v, keep, keys)
+	}
+	return redacted
+}
+
+// redactValue redacts a value of the config. The items of a list are kept if the key of the
+// list is unredacted.
+func redactValue(v any, keep bool, keys map[string]struct{}) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactMap(v, keys)
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item, keep, keys)
+		}
+		return redacted
+	case nil, bool:
+		return v
+	case string:
+		if v == "" {
+			return v
+		}
+		if keep {
+			return redactURLPassword(v)
+		}
+		return redactedValue
+	default:
+		if keep {
+			return v
+		}
+		return redactedValue
+	}
+}
+
+// redactURLPassword redacts the password of a URL, such as an endpoint with credentials.
+func redactURLPassword(v string) string {
+	if !strings.Contains(v, "://") {
+		return v
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.User == nil {
+		return v
+	}
+	return u.Redacted()
+}
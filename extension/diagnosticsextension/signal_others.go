@@ -0,0 +1,14 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"os"
+	"syscall"
+)
+
+// bundleSignals are the signals triggering the write of a bundle to the output directory.
+var bundleSignals = []os.Signal{syscall.SIGUSR1}
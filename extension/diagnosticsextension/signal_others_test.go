@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package diagnosticsextension
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestWriteBundleOnSignal(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ""
+	cfg.InternalMetrics.Endpoint = ""
+	cfg.OutputDir = t.TempDir()
+
+	ext := newTestExtension(t, cfg)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		entries, err := os.ReadDir(cfg.OutputDir)
+		assert.NoError(tt, err)
+		if assert.Len(tt, entries, 1) {
+			assert.Regexp(tt, `^diagnostics-\d{8}T\d{6}Z\.tar\.gz$`, entries[0].Name())
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import "os"
+
+// bundleSignals are the signals triggering the write of a bundle to the output directory.
+// Windows has no user-defined signals.
+var bundleSignals []os.Signal
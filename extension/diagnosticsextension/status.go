@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+// serializableStatus is the status of the collector, an extension or a pipeline, or a component,
+// in the component status graph of a bundle.
+type serializableStatus struct {
+	Status     string                         `json:"status"`
+	Error      string                         `json:"error,omitempty"`
+	Timestamp  time.Time                      `json:"status_time"`
+	Components map[string]*serializableStatus `json:"components,omitempty"`
+}
+
+func toSerializableStatus(st *status.AggregateStatus) *serializableStatus {
+	s := &serializableStatus{
+		Status:    st.Status().String(),
+		Timestamp: st.Timestamp(),
+	}
+	if st.Err() != nil {
+		s.Error = st.Err().Error()
+	}
+
+	if len(st.ComponentStatusMap) > 0 {
+		s.Components = make(map[string]*serializableStatus, len(st.ComponentStatusMap))
+		for k, cs := range st.ComponentStatusMap {
+			s.Components[k] = toSerializableStatus(cs)
+		}
+	}
+	return s
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// fileStorageType is the type of the file storage extension, the storage extension keeping
+// its data on the local disk.
+const fileStorageType = "file_storage"
+
+// storageUsage is the disk usage of a storage extension.
+type storageUsage struct {
+	Extension string        `json:"extension"`
+	Directory string        `json:"directory"`
+	TotalSize int64         `json:"total_size"`
+	Files     []storageFile `json:"files"`
+	Error     string        `json:"error,omitempty"`
+}
+
+type storageFile struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// storageUsages returns the disk usage of the file storage extensions enabled in the service.
+func storageUsages(conf *confmap.Conf) []storageUsage {
+	ids, _ := conf.Get("service::extensions").([]any)
+
+	usages := []storageUsage{}
+	for _, v := range ids {
+		id, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if typ, _, _ := strings.Cut(id, "/"); typ != fileStorageType {
+			continue
+		}
+
+		dir, _ := conf.Get("extensions::" + id + "::directory").(string)
+		if dir == "" {
+			dir = defaultFileStorageDirectory()
+		}
+		usages = append(usages, directoryUsage(id, dir))
+	}
+	return usages
+}
+
+func directoryUsage(id, dir string) storageUsage {
+	usage := storageUsage{
+		Extension: id,
+		Directory: dir,
+		Files:     []storageFile{},
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		usage.Files = append(usage.Files, storageFile{
+			Name:    name,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+		usage.TotalSize += info.Size()
+		return nil
+	})
+	if err != nil {
+		usage.Error = err.Error()
+	}
+	return usage
+}
@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package diagnosticsextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/diagnosticsextension"
+
+// defaultFileStorageDirectory returns the default directory of the file storage extension.
+func defaultFileStorageDirectory() string {
+	return "/var/lib/otelcol/file_storage"
+}
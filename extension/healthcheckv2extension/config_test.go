@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/pipeline"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckv2extension/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
@@ -170,6 +171,52 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "v2noprotocols"),
 			expectedErr: healthcheck.ErrMissingProtocol,
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "v2dataflow"),
+			expected: &Config{
+				LegacyConfig: healthcheck.HTTPLegacyConfig{
+					UseV2: true,
+					ServerConfig: confighttp.ServerConfig{
+						Endpoint: testutil.EndpointForPort(healthcheck.DefaultHTTPPort),
+					},
+					Path: "/",
+				},
+				HTTPConfig: &healthcheck.HTTPConfig{
+					ServerConfig: confighttp.ServerConfig{
+						Endpoint: testutil.EndpointForPort(healthcheck.DefaultHTTPPort),
+					},
+					Status: healthcheck.PathConfig{
+						Enabled: true,
+						Path:    "/status",
+					},
+					Config: healthcheck.PathConfig{
+						Enabled: false,
+						Path:    "/config",
+					},
+				},
+				DataFlowConfig: func() *healthcheck.DataFlowConfig {
+					cfg := &healthcheck.DataFlowConfig{
+						ClientConfig: confighttp.NewDefaultClientConfig(),
+						Interval:     time.Minute,
+						Pipelines: map[pipeline.ID]healthcheck.PipelineDataFlowConfig{
+							pipeline.NewID(pipeline.SignalTraces): {
+								MinThroughput:     10,
+								MaxStaleness:      10 * time.Minute,
+								MaxQueueFillRatio: 0.8,
+								MaxFailureRatio:   0.05,
+							},
+						},
+					}
+					cfg.Endpoint = "http://localhost:9999/metrics"
+					cfg.Timeout = 5 * time.Second
+					return cfg
+				}(),
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "v2dataflowinvalidinterval"),
+			expectedErr: healthcheck.ErrInvalidDataFlowInterval,
+		},
 	}
 
 	for _, tt := range tests {
@@ -6,6 +6,7 @@ package healthcheckv2extension
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,10 @@ import (
 )
 
 func TestCreateDefaultConfig(t *testing.T) {
+	dataFlowClientConfig := confighttp.NewDefaultClientConfig()
+	dataFlowClientConfig.Endpoint = "http://localhost:8888/metrics"
+	dataFlowClientConfig.Timeout = 5 * time.Second
+
 	cfg := createDefaultConfig()
 	assert.Equal(t, &Config{
 		LegacyConfig: healthcheck.HTTPLegacyConfig{
@@ -49,6 +54,10 @@ func TestCreateDefaultConfig(t *testing.T) {
 				},
 			},
 		},
+		DataFlowConfig: &healthcheck.DataFlowConfig{
+			ClientConfig: dataFlowClientConfig,
+			Interval:     30 * time.Second,
+		},
 	}, cfg)
 
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
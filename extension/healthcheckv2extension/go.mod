@@ -17,6 +17,7 @@ require (
 	go.opentelemetry.io/collector/confmap/xconfmap v0.134.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/extension v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/extension/extensiontest v0.134.1-0.20250908133507-3166bac6544f
+	go.opentelemetry.io/collector/pipeline v1.40.1-0.20250908133507-3166bac6544f
 	go.uber.org/goleak v1.3.0
 )
 
@@ -61,7 +62,6 @@ require (
 	go.opentelemetry.io/collector/featuregate v1.40.1-0.20250908133507-3166bac6544f // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.134.1-0.20250908133507-3166bac6544f // indirect
 	go.opentelemetry.io/collector/pdata v1.40.1-0.20250908133507-3166bac6544f // indirect
-	go.opentelemetry.io/collector/pipeline v1.40.1-0.20250908133507-3166bac6544f // indirect
 	go.opentelemetry.io/contrib/bridges/otelzap v0.12.0 // indirect
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.62.0 // indirect
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.62.0 // indirect
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+// Config is the configuration for the k8s metadata extension.
+type Config struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// ResyncPeriod is the period at which the informers replay the cached objects
+	// to the event handlers of the components. Zero disables the resyncs.
+	ResyncPeriod time.Duration `mapstructure:"resync_period"`
+
+	makeClient        func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error)
+	makeDynamicClient func(apiConf k8sconfig.APIConfig) (dynamic.Interface, error)
+}
+
+func (cfg *Config) getK8sClients() (kubernetes.Interface, dynamic.Interface, error) {
+	if cfg.makeClient == nil {
+		cfg.makeClient = k8sconfig.MakeClient
+	}
+	if cfg.makeDynamicClient == nil {
+		cfg.makeDynamicClient = k8sconfig.MakeDynamicClient
+	}
+	client, err := cfg.makeClient(cfg.APIConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	dynamicClient, err := cfg.makeDynamicClient(cfg.APIConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, dynamicClient, nil
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.ResyncPeriod < 0 {
+		return errors.New("resync_period must not be negative")
+	}
+	return cfg.APIConfig.Validate()
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package k8smetadataextension provides informers watching the kubernetes API which are
+// shared by the components of a collector, so that each resource is watched and cached once.
+package k8smetadataextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	errNotStarted = errors.New("the k8s_metadata extension isn't started")
+	errShutdown   = errors.New("the k8s_metadata extension is shut down")
+)
+
+// InformerKey identifies a shared informer: the components watching the same resource
+// with the same namespace and selectors share the same informer and cache.
+type InformerKey struct {
+	// Resource is the watched resource, e.g. the "pods" resource of the "v1" version.
+	Resource schema.GroupVersionResource
+	// Namespace restricts the watched objects to a namespace. Empty means all namespaces.
+	Namespace string
+	// LabelSelector restricts the watched objects to the ones matching the label selector.
+	LabelSelector string
+	// FieldSelector restricts the watched objects to the ones matching the field selector.
+	FieldSelector string
+}
+
+// InformerProvider is implemented by the extension, and allows the components to get
+// informers shared with the other components of the collector.
+//
+// The shared informers are run by the extension. The informers returned to a component
+// must still be run by the component once its event handlers are added: running them
+// doesn't run the shared informer again, but waits for the given stop channel to be
+// closed, and then removes the event handlers of the component. The objects of the shared
+// informers are shared with the other components, and must not be modified: the transform
+// set by a component is applied to the objects delivered to its event handlers, and must
+// return new objects rather than modify them, while the cache holds the objects slimmed by
+// the extension.
+type InformerProvider interface {
+	extension.Extension
+	// Informer returns an informer of the given resource, which must be a built-in
+	// kubernetes resource, with typed objects such as *v1.Pod.
+	Informer(key InformerKey) (cache.SharedIndexInformer, error)
+	// DynamicInformer returns an informer of the given resource, with objects
+	// of type *unstructured.Unstructured.
+	DynamicInformer(key InformerKey) (cache.SharedIndexInformer, error)
+}
+
+type informerKey struct {
+	InformerKey
+	dynamic bool
+}
+
+// k8sMetadataExtension is the main struct implementing the extension's behavior.
+type k8sMetadataExtension struct {
+	logger        *zap.Logger
+	resyncPeriod  time.Duration
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+
+	mu        sync.Mutex
+	started   bool
+	shutdown  bool
+	stopCh    chan struct{}
+	informers map[informerKey]cache.SharedIndexInformer
+	waitGroup sync.WaitGroup
+}
+
+var _ InformerProvider = (*k8sMetadataExtension)(nil)
+
+func newK8sMetadataExtension(logger *zap.Logger, cfg *Config, client kubernetes.Interface, dynamicClient dynamic.Interface) *k8sMetadataExtension {
+	return &k8sMetadataExtension{
+		logger:        logger,
+		resyncPeriod:  cfg.ResyncPeriod,
+		client:        client,
+		dynamicClient: dynamicClient,
+		stopCh:        make(chan struct{}),
+		informers:     map[informerKey]cache.SharedIndexInformer{},
+	}
+}
+
+// Start begins the extension's processing.
+func (e *k8sMetadataExtension) Start(context.Context, component.Host) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.started = true
+	return nil
+}
+
+// Shutdown stops the shared informers.
+func (e *k8sMetadataExtension) Shutdown(context.Context) error {
+	e.mu.Lock()
+	if !e.shutdown {
+		e.shutdown = true
+		close(e.stopCh)
+	}
+	e.mu.Unlock()
+	e.waitGroup.Wait()
+	return nil
+}
+
+// Informer returns an informer of the given built-in resource, with typed objects.
+func (e *k8sMetadataExtension) Informer(key InformerKey) (cache.SharedIndexInformer, error) {
+	return e.informer(informerKey{InformerKey: key})
+}
+
+// DynamicInformer returns an informer of the given resource, with unstructured objects.
+func (e *k8sMetadataExtension) DynamicInformer(key InformerKey) (cache.SharedIndexInformer, error) {
+	return e.informer(informerKey{InformerKey: key, dynamic: true})
+}
+
+func (e *k8sMetadataExtension) informer(key informerKey) (cache.SharedIndexInformer, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.shutdown {
+		return nil, errShutdown
+	}
+	if !e.started {
+		return nil, errNotStarted
+	}
+
+	informer, ok := e.informers[key]
+	if !ok {
+		var err error
+		if informer, err = e.newInformer(key); err != nil {
+			return nil, err
+		}
+		// The objects are slimmed down for all the components sharing the informer.
+		if err = informer.SetTransform(slimObject); err != nil {
+			return nil, err
+		}
+		e.informers[key] = informer
+
+		e.logger.Info("Starting shared informer",
+			zap.String("resource", key.Resource.String()),
+			zap.String("namespace", key.Namespace),
+			zap.String("label_selector", key.LabelSelector),
+			zap.String("field_selector", key.FieldSelector),
+			zap.Bool("dynamic", key.dynamic))
+		e.waitGroup.Add(1)
+		go func() {
+			defer e.waitGroup.Done()
+			informer.Run(e.stopCh)
+		}()
+	}
+	return &componentInformer{SharedIndexInformer: informer}, nil
+}
+
+func (e *k8sMetadataExtension) newInformer(key informerKey) (cache.SharedIndexInformer, error) {
+	tweakListOptions := func(opts *metav1.ListOptions) {
+		opts.LabelSelector = key.LabelSelector
+		opts.FieldSelector = key.FieldSelector
+	}
+	if key.dynamic {
+		return dynamicinformer.NewFilteredDynamicInformer(
+			e.dynamicClient,
+			key.Resource,
+			key.Namespace,
+			e.resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+			tweakListOptions,
+		).Informer(), nil
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		e.client,
+		e.resyncPeriod,
+		informers.WithNamespace(key.Namespace),
+		informers.WithTweakListOptions(tweakListOptions),
+	)
+	genericInformer, err := factory.ForResource(key.Resource)
+	if err != nil {
+		return nil, err
+	}
+	return genericInformer.Informer(), nil
+}
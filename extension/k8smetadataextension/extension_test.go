@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	api_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	podsGVR     = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	rolloutsGVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+)
+
+func newTestExtension(t *testing.T, objects ...runtime.Object) (*k8sMetadataExtension, *fake.Clientset) {
+	rollout := &unstructured.Unstructured{Object: map[string]any{}}
+	rollout.SetAPIVersion("argoproj.io/v1alpha1")
+	rollout.SetKind("Rollout")
+	rollout.SetName("checkout")
+	rollout.SetNamespace("shop")
+
+	client := fake.NewClientset(objects...)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rolloutsGVR: "RolloutList"}, rollout)
+	e := newK8sMetadataExtension(zap.NewNop(), createDefaultConfig().(*Config), client, dynamicClient)
+	require.NoError(t, e.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, e.Shutdown(t.Context()))
+	})
+	return e, client
+}
+
+func newPod(name string) *api_v1.Pod {
+	return &api_v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          name,
+			Namespace:     "shop",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			Annotations: map[string]string{
+				"team":                      "payments",
+				lastAppliedConfigAnnotation: `{"kind":"Pod"}`,
+			},
+		},
+	}
+}
+
+func TestInformerIsShared(t *testing.T) {
+	e, client := newTestExtension(t, newPod("checkout-1"))
+
+	key := InformerKey{Resource: podsGVR, Namespace: "shop"}
+	first, err := e.Informer(key)
+	require.NoError(t, err)
+	second, err := e.Informer(key)
+	require.NoError(t, err)
+	other, err := e.Informer(InformerKey{Resource: podsGVR})
+	require.NoError(t, err)
+	assert.Len(t, e.informers, 2)
+	assert.Same(t, first.(*componentInformer).SharedIndexInformer, second.(*componentInformer).SharedIndexInformer)
+	assert.NotSame(t, first.(*componentInformer).SharedIndexInformer, other.(*componentInformer).SharedIndexInformer)
+
+	var firstAdds, secondAdds atomic.Int32
+	firstStopCh := make(chan struct{})
+	// The transform of a component is applied to the objects delivered to its handlers only.
+	require.NoError(t, first.SetTransform(func(obj any) (any, error) {
+		if pod, ok := obj.(*api_v1.Pod); ok {
+			return &api_v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}, nil
+		}
+		return obj, nil
+	}))
+	_, err = first.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if obj.(*api_v1.Pod).Annotations == nil {
+				firstAdds.Add(1)
+			}
+		},
+	})
+	require.NoError(t, err)
+	go first.Run(firstStopCh)
+
+	secondStopCh := make(chan struct{})
+	defer close(secondStopCh)
+	reg, err := second.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(any) { secondAdds.Add(1) },
+	})
+	require.NoError(t, err)
+	go second.Run(secondStopCh)
+
+	require.Eventually(t, func() bool {
+		return reg.HasSynced() && firstAdds.Load() == 1 && secondAdds.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The managed fields and last applied configuration of the cached objects are removed.
+	obj, exists, err := second.GetStore().GetByKey("shop/checkout-1")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Nil(t, obj.(*api_v1.Pod).ManagedFields)
+	assert.Equal(t, map[string]string{"team": "payments"}, obj.(*api_v1.Pod).Annotations)
+
+	// The handlers of a component are removed once it stops running the informer.
+	close(firstStopCh)
+	require.Eventually(t, func() bool {
+		first.(*componentInformer).mu.Lock()
+		defer first.(*componentInformer).mu.Unlock()
+		return first.(*componentInformer).registrations == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = client.CoreV1().Pods("shop").Create(t.Context(), newPod("checkout-2"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return secondAdds.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), firstAdds.Load())
+}
+
+func TestDynamicInformer(t *testing.T) {
+	e, _ := newTestExtension(t)
+
+	informer, err := e.DynamicInformer(InformerKey{Resource: rolloutsGVR})
+	require.NoError(t, err)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+
+	require.Eventually(t, informer.HasSynced, 5*time.Second, 10*time.Millisecond)
+	obj, exists, err := informer.GetStore().GetByKey("shop/checkout")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.IsType(t, &unstructured.Unstructured{}, obj)
+}
+
+func TestInformerErrors(t *testing.T) {
+	e := newK8sMetadataExtension(zap.NewNop(), createDefaultConfig().(*Config), fake.NewClientset(), nil)
+
+	_, err := e.Informer(InformerKey{Resource: podsGVR})
+	require.ErrorIs(t, err, errNotStarted)
+
+	require.NoError(t, e.Start(t.Context(), componenttest.NewNopHost()))
+	_, err = e.Informer(InformerKey{Resource: rolloutsGVR})
+	require.ErrorContains(t, err, "no informer found")
+
+	require.NoError(t, e.Shutdown(t.Context()))
+	_, err = e.Informer(InformerKey{Resource: podsGVR})
+	require.ErrorIs(t, err, errShutdown)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const defaultResyncPeriod = 5 * time.Minute
+
+// createDefaultConfig returns the default configuration for the extension.
+func createDefaultConfig() component.Config {
+	return &Config{
+		APIConfig: k8sconfig.APIConfig{
+			AuthType: k8sconfig.AuthTypeServiceAccount,
+		},
+		ResyncPeriod: defaultResyncPeriod,
+	}
+}
+
+// createExtension creates the extension instance based on the configuration.
+func createExtension(
+	_ context.Context,
+	set extension.Settings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	baseCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, errors.New("invalid config, cannot create extension k8s_metadata")
+	}
+
+	// Initialize the k8s clients in the factory as doing it in extension.Start()
+	// should cause race condition as http Proxy gets shared.
+	client, dynamicClient, err := baseCfg.getK8sClients()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create k8s clients: %w", err)
+	}
+
+	return newK8sMetadataExtension(set.Logger, baseCfg, client, dynamicClient), nil
+}
+
+// NewFactory creates a new factory for the k8s metadata extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := NewFactory()
+	require.Equal(t, metadata.Type, factory.Type())
+
+	expectedCfg := &Config{
+		APIConfig: k8sconfig.APIConfig{
+			AuthType: k8sconfig.AuthTypeServiceAccount,
+		},
+		ResyncPeriod: 5 * time.Minute,
+	}
+	require.Equal(t, expectedCfg, factory.CreateDefaultConfig())
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.makeClient = func(k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return fake.NewClientset(), nil
+	}
+	cfg.makeDynamicClient = func(k8sconfig.APIConfig) (dynamic.Interface, error) {
+		return dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil
+	}
+
+	f := NewFactory()
+	ext, err := f.Create(t.Context(), extensiontest.NewNopSettings(f.Type()), cfg)
+	require.NoError(t, err)
+	assert.Implements(t, (*InformerProvider)(nil), ext)
+}
+
+func TestCreateExtensionClientError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.makeClient = func(k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return nil, errors.New("no cluster")
+	}
+
+	f := NewFactory()
+	_, err := f.Create(t.Context(), extensiontest.NewNopSettings(f.Type()), cfg)
+	require.ErrorContains(t, err, "no cluster")
+}
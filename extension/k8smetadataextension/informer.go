@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// componentInformer is the view of a shared informer handed to a component. It keeps
+// track of the event handlers added by the component, so that they are removed when
+// the component stops running the informer, and applies the transform of the component
+// to the objects delivered to its event handlers.
+type componentInformer struct {
+	cache.SharedIndexInformer
+
+	mu            sync.Mutex
+	registrations []cache.ResourceEventHandlerRegistration
+	transform     cache.TransformFunc
+}
+
+// AddEventHandler adds an event handler to the shared informer.
+func (i *componentInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	reg, err := i.SharedIndexInformer.AddEventHandler(i.transformingHandler(handler))
+	if err != nil {
+		return nil, err
+	}
+	i.addRegistration(reg)
+	return reg, nil
+}
+
+// AddEventHandlerWithResyncPeriod adds an event handler to the shared informer.
+func (i *componentInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	reg, err := i.SharedIndexInformer.AddEventHandlerWithResyncPeriod(i.transformingHandler(handler), resyncPeriod)
+	if err != nil {
+		return nil, err
+	}
+	i.addRegistration(reg)
+	return reg, nil
+}
+
+// RemoveEventHandler removes an event handler added by the component.
+func (i *componentInformer) RemoveEventHandler(reg cache.ResourceEventHandlerRegistration) error {
+	i.mu.Lock()
+	i.registrations = slices.DeleteFunc(i.registrations, func(r cache.ResourceEventHandlerRegistration) bool {
+		return r == reg
+	})
+	i.mu.Unlock()
+	return i.SharedIndexInformer.RemoveEventHandler(reg)
+}
+
+// Run doesn't run the shared informer, which is run by the extension. It waits for the
+// given channel to be closed, and then removes the event handlers of the component.
+func (i *componentInformer) Run(stopCh <-chan struct{}) {
+	<-stopCh
+
+	i.mu.Lock()
+	registrations := i.registrations
+	i.registrations = nil
+	i.mu.Unlock()
+	for _, reg := range registrations {
+		_ = i.SharedIndexInformer.RemoveEventHandler(reg)
+	}
+}
+
+// SetTransform sets the transform applied to the objects delivered to the event handlers
+// added afterwards. The objects of the shared informer are shared with the other components,
+// so the transform must not modify them, and the cache keeps the objects as slimmed by the
+// extension.
+func (i *componentInformer) SetTransform(transform cache.TransformFunc) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.transform = transform
+	return nil
+}
+
+// SetWatchErrorHandler is a noop: the watch errors of the shared informer are handled
+// by the extension.
+func (*componentInformer) SetWatchErrorHandler(cache.WatchErrorHandler) error {
+	return nil
+}
+
+func (i *componentInformer) addRegistration(reg cache.ResourceEventHandlerRegistration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.registrations = append(i.registrations, reg)
+}
+
+func (i *componentInformer) transformingHandler(handler cache.ResourceEventHandler) cache.ResourceEventHandler {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.transform == nil {
+		return handler
+	}
+	return &transformingHandler{handler: handler, transform: i.transform}
+}
+
+// transformingHandler delivers the objects transformed by the transform of a component
+// to its event handler. The objects the transform fails on aren't delivered.
+type transformingHandler struct {
+	handler   cache.ResourceEventHandler
+	transform cache.TransformFunc
+}
+
+func (h *transformingHandler) OnAdd(obj any, isInInitialList bool) {
+	if obj, ok := h.apply(obj); ok {
+		h.handler.OnAdd(obj, isInInitialList)
+	}
+}
+
+func (h *transformingHandler) OnUpdate(oldObj, newObj any) {
+	oldObj, ok := h.apply(oldObj)
+	if !ok {
+		return
+	}
+	if newObj, ok := h.apply(newObj); ok {
+		h.handler.OnUpdate(oldObj, newObj)
+	}
+}
+
+func (h *transformingHandler) OnDelete(obj any) {
+	if obj, ok := h.apply(obj); ok {
+		h.handler.OnDelete(obj)
+	}
+}
+
+func (h *transformingHandler) apply(obj any) (any, bool) {
+	obj, err := h.transform(obj)
+	return obj, err == nil
+}
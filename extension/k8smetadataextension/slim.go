@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
+
+import (
+	apps_v1 "k8s.io/api/apps/v1"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// lastAppliedConfigAnnotation holds a copy of the whole object as last applied by kubectl.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// slimObject slims down the objects for all the components sharing an informer. The pods and
+// replica sets, which make up most of the cached objects, only keep the union of the fields used
+// by the components supporting the extension. The fields read by a new component must be added
+// here. The other objects only lose their managed fields and last applied configuration, which
+// are of no use to the components, and make up a large part of the size of the objects.
+func slimObject(obj any) (any, error) {
+	switch o := obj.(type) {
+	case *api_v1.Pod:
+		return slimPod(o), nil
+	case *apps_v1.ReplicaSet:
+		return slimReplicaSet(o), nil
+	}
+
+	// cache.DeletedFinalStateUnknown objects don't have an accessor, and are left as is.
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return obj, nil
+	}
+	if accessor.GetManagedFields() != nil {
+		accessor.SetManagedFields(nil)
+	}
+	if annotations := accessor.GetAnnotations(); annotations[lastAppliedConfigAnnotation] != "" {
+		delete(annotations, lastAppliedConfigAnnotation)
+		accessor.SetAnnotations(annotations)
+	}
+	return obj, nil
+}
+
+// slimObjectMeta keeps the identity, the labels, the annotations and the owners of an object.
+func slimObjectMeta(m metav1.ObjectMeta) metav1.ObjectMeta {
+	slim := metav1.ObjectMeta{
+		Name:              m.Name,
+		Namespace:         m.Namespace,
+		UID:               m.UID,
+		ResourceVersion:   m.ResourceVersion,
+		CreationTimestamp: m.CreationTimestamp,
+		DeletionTimestamp: m.DeletionTimestamp,
+		Labels:            m.Labels,
+		OwnerReferences:   m.OwnerReferences,
+	}
+	if len(m.Annotations) > 0 {
+		slim.Annotations = make(map[string]string, len(m.Annotations))
+		for k, v := range m.Annotations {
+			if k != lastAppliedConfigAnnotation {
+				slim.Annotations[k] = v
+			}
+		}
+	}
+	return slim
+}
+
+// slimPod keeps the fields of the pods used by the k8sattributes processor.
+func slimPod(pod *api_v1.Pod) *api_v1.Pod {
+	return &api_v1.Pod{
+		TypeMeta:   pod.TypeMeta,
+		ObjectMeta: slimObjectMeta(pod.ObjectMeta),
+		Spec: api_v1.PodSpec{
+			NodeName:       pod.Spec.NodeName,
+			Hostname:       pod.Spec.Hostname,
+			HostNetwork:    pod.Spec.HostNetwork,
+			Containers:     slimContainers(pod.Spec.Containers),
+			InitContainers: slimContainers(pod.Spec.InitContainers),
+		},
+		Status: api_v1.PodStatus{
+			PodIP:                 pod.Status.PodIP,
+			StartTime:             pod.Status.StartTime,
+			ContainerStatuses:     slimContainerStatuses(pod.Status.ContainerStatuses),
+			InitContainerStatuses: slimContainerStatuses(pod.Status.InitContainerStatuses),
+		},
+	}
+}
+
+func slimContainers(containers []api_v1.Container) []api_v1.Container {
+	if containers == nil {
+		return nil
+	}
+	slim := make([]api_v1.Container, len(containers))
+	for i, c := range containers {
+		slim[i] = api_v1.Container{Name: c.Name, Image: c.Image}
+	}
+	return slim
+}
+
+func slimContainerStatuses(statuses []api_v1.ContainerStatus) []api_v1.ContainerStatus {
+	if statuses == nil {
+		return nil
+	}
+	slim := make([]api_v1.ContainerStatus, len(statuses))
+	for i, s := range statuses {
+		slim[i] = api_v1.ContainerStatus{
+			Name:         s.Name,
+			ContainerID:  s.ContainerID,
+			RestartCount: s.RestartCount,
+			Image:        s.Image,
+			ImageID:      s.ImageID,
+		}
+	}
+	return slim
+}
+
+// slimReplicaSet keeps the fields of the replica sets used by the k8sattributes processor to
+// find the deployment of the pods.
+func slimReplicaSet(replicaSet *apps_v1.ReplicaSet) *apps_v1.ReplicaSet {
+	return &apps_v1.ReplicaSet{
+		TypeMeta:   replicaSet.TypeMeta,
+		ObjectMeta: slimObjectMeta(replicaSet.ObjectMeta),
+	}
+}
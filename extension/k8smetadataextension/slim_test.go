@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8smetadataextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	api_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSlimObject(t *testing.T) {
+	pod := newPod("checkout-1")
+	pod.Labels = map[string]string{"app": "checkout"}
+	pod.Spec = api_v1.PodSpec{
+		NodeName: "node-1",
+		Containers: []api_v1.Container{{
+			Name:  "checkout",
+			Image: "checkout:1.0",
+			Env:   []api_v1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+		}},
+		Volumes: []api_v1.Volume{{Name: "data"}},
+	}
+	pod.Status = api_v1.PodStatus{
+		PodIP:      "10.0.0.1",
+		Conditions: []api_v1.PodCondition{{Type: api_v1.PodReady}},
+		ContainerStatuses: []api_v1.ContainerStatus{{
+			Name:        "checkout",
+			ContainerID: "containerd://abc",
+			ImageID:     "checkout@sha256:abc",
+			Ready:       true,
+		}},
+	}
+
+	obj, err := slimObject(pod)
+	require.NoError(t, err)
+	assert.Equal(t, &api_v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "checkout-1",
+			Namespace:   "shop",
+			Labels:      map[string]string{"app": "checkout"},
+			Annotations: map[string]string{"team": "payments"},
+		},
+		Spec: api_v1.PodSpec{
+			NodeName:   "node-1",
+			Containers: []api_v1.Container{{Name: "checkout", Image: "checkout:1.0"}},
+		},
+		Status: api_v1.PodStatus{
+			PodIP: "10.0.0.1",
+			ContainerStatuses: []api_v1.ContainerStatus{{
+				Name:        "checkout",
+				ContainerID: "containerd://abc",
+				ImageID:     "checkout@sha256:abc",
+			}},
+		},
+	}, obj)
+	// The original object is left untouched.
+	assert.Contains(t, pod.Annotations, lastAppliedConfigAnnotation)
+
+	replicaSet := &apps_v1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-6d4b",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "checkout"}},
+		},
+		Spec: apps_v1.ReplicaSetSpec{Template: api_v1.PodTemplateSpec{Spec: pod.Spec}},
+	}
+	obj, err = slimObject(replicaSet)
+	require.NoError(t, err)
+	assert.Equal(t, &apps_v1.ReplicaSet{ObjectMeta: replicaSet.ObjectMeta}, obj)
+
+	node := &api_v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:          "node-1",
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+	}}
+	obj, err = slimObject(node)
+	require.NoError(t, err)
+	assert.Equal(t, &api_v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}, obj)
+
+	tombstone := cache.DeletedFinalStateUnknown{Key: "shop/checkout-1", Obj: pod}
+	obj, err = slimObject(tombstone)
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, obj)
+}
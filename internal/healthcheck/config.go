@@ -15,6 +15,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/common"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/dataflow"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/grpc"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/http"
 )
@@ -27,13 +28,16 @@ type (
 	GRPCConfig                   = grpc.Config
 	ComponentHealthConfig        = common.ComponentHealthConfig
 	CheckCollectorPipelineConfig = http.CheckCollectorPipelineConfig
+	DataFlowConfig               = dataflow.Config
+	PipelineDataFlowConfig       = dataflow.PipelineConfig
 )
 
 const (
-	httpConfigKey   = "http"
-	grpcConfigKey   = "grpc"
-	DefaultGRPCPort = 13132
-	DefaultHTTPPort = 13133
+	httpConfigKey     = "http"
+	grpcConfigKey     = "grpc"
+	dataFlowConfigKey = "data_flow"
+	DefaultGRPCPort   = 13132
+	DefaultHTTPPort   = 13133
 )
 
 var (
@@ -41,6 +45,9 @@ var (
 	ErrGRPCEndpointRequired = errors.New("grpc endpoint required")
 	ErrHTTPEndpointRequired = errors.New("http endpoint required")
 	ErrInvalidPath          = errors.New("path must start with /")
+
+	ErrDataFlowEndpointRequired = dataflow.ErrEndpointRequired
+	ErrInvalidDataFlowInterval  = dataflow.ErrInvalidInterval
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -57,6 +64,10 @@ type Config struct {
 
 	// ComponentHealthConfig is v2 config shared between http and grpc services
 	ComponentHealthConfig *common.ComponentHealthConfig `mapstructure:"component_health"`
+
+	// DataFlowConfig is v2 config for the data flow health checks of pipelines, shared
+	// between http and grpc services
+	DataFlowConfig *dataflow.Config `mapstructure:"data_flow"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -111,6 +122,10 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 		c.GRPCConfig = nil
 	}
 
+	if !conf.IsSet(dataFlowConfigKey) {
+		c.DataFlowConfig = nil
+	}
+
 	return nil
 }
 
@@ -143,5 +158,6 @@ func NewDefaultConfig() component.Config {
 				},
 			},
 		},
+		DataFlowConfig: dataflow.NewDefaultConfig(),
 	}
 }
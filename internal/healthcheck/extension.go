@@ -14,6 +14,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/dataflow"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/grpc"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/http"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
@@ -29,6 +30,7 @@ type HealthCheckExtension struct {
 	telemetry     component.TelemetrySettings
 	aggregator    *status.Aggregator
 	subcomponents []component.Component
+	dataFlow      *dataflow.Checker
 	eventCh       chan *eventSourcePair
 	readyCh       chan struct{}
 	host          component.Host
@@ -86,6 +88,10 @@ func NewHealthCheckExtension(
 		readyCh:       make(chan struct{}),
 	}
 
+	if config.UseV2 && config.DataFlowConfig != nil {
+		hc.dataFlow = dataflow.NewChecker(config.DataFlowConfig, set.TelemetrySettings, aggregator)
+	}
+
 	// Start processing events in the background so that our status watcher doesn't
 	// block others before the extension starts.
 	go hc.eventLoop(ctx)
@@ -105,6 +111,10 @@ func (hc *HealthCheckExtension) Start(ctx context.Context, host component.Host)
 		}
 	}
 
+	if hc.dataFlow != nil {
+		return hc.dataFlow.Start(ctx, host)
+	}
+
 	return nil
 }
 
@@ -113,10 +123,15 @@ func (hc *HealthCheckExtension) Shutdown(ctx context.Context) error {
 	// Preemptively send the stopped event, so it can be exported before shutdown
 	componentstatus.ReportStatus(hc.host, componentstatus.NewEvent(componentstatus.StatusStopped))
 
+	// The data flow checker records statuses, so it is shut down before the aggregator.
+	var err error
+	if hc.dataFlow != nil {
+		err = hc.dataFlow.Shutdown(ctx)
+	}
+
 	close(hc.eventCh)
 	hc.aggregator.Close()
 
-	var err error
 	for _, comp := range hc.subcomponents {
 		err = multierr.Append(err, comp.Shutdown(ctx))
 	}
@@ -145,6 +160,15 @@ func (hc *HealthCheckExtension) ComponentStatusChanged(
 	hc.eventCh <- &eventSourcePair{source: source, event: event}
 }
 
+// Subscribe allows other components, such as the failover connector, to subscribe to the
+// aggregated status of a scope. See status.Aggregator.Subscribe for details.
+func (hc *HealthCheckExtension) Subscribe(
+	scope status.Scope,
+	verbosity status.Verbosity,
+) (<-chan *status.AggregateStatus, status.UnsubscribeFunc) {
+	return hc.aggregator.Subscribe(scope, verbosity)
+}
+
 // NotifyConfig implements the extensioncapabilities.ConfigWatcher interface.
 func (hc *HealthCheckExtension) NotifyConfig(ctx context.Context, conf *confmap.Conf) error {
 	var err error
@@ -153,6 +177,9 @@ func (hc *HealthCheckExtension) NotifyConfig(ctx context.Context, conf *confmap.
 			err = multierr.Append(err, cw.NotifyConfig(ctx, conf))
 		}
 	}
+	if hc.dataFlow != nil {
+		err = multierr.Append(err, hc.dataFlow.NotifyConfig(ctx, conf))
+	}
 	return err
 }
 
@@ -92,6 +92,35 @@ func TestComponentStatus(t *testing.T) {
 	assert.Equal(t, componentstatus.StatusStopping, st.Status())
 }
 
+func TestSubscribe(t *testing.T) {
+	cfg := NewDefaultConfig().(*Config)
+	cfg.HTTPConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.GRPCConfig.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.UseV2 = true
+	ext := NewHealthCheckExtension(t.Context(), *cfg, extensiontest.NewNopSettings(extensiontest.NopType))
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+
+	traces := testhelpers.NewPipelineMetadata(pipeline.SignalTraces)
+	statusCh, unsubscribe := ext.Subscribe(status.Scope(traces.PipelineID.String()), status.Verbose)
+	defer unsubscribe()
+
+	// The pipeline has not reported yet
+	assert.Nil(t, <-statusCh)
+
+	require.NoError(t, ext.Ready())
+	ext.ComponentStatusChanged(
+		traces.ExporterID,
+		componentstatus.NewRecoverableErrorEvent(assert.AnError),
+	)
+
+	st := <-statusCh
+	require.NotNil(t, st)
+	assert.Equal(t, componentstatus.StatusRecoverableError, st.Status())
+	assert.Contains(t, st.ComponentStatusMap, "exporter:traces/out")
+
+	require.NoError(t, ext.Shutdown(t.Context()))
+}
+
 func TestNotifyConfig(t *testing.T) {
 	confMap, err := confmaptest.LoadConf(
 		filepath.Join("internal", "http", "testdata", "config.yaml"),
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dataflow derives the health of the data flow of pipelines from the internal
+// telemetry of the collector.
+package dataflow // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/dataflow"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+// StatusKey is the key of the data flow status in the component statuses of a pipeline.
+const StatusKey = "dataflow"
+
+// itemNames are the names of the items of each signal in the internal metrics.
+var itemNames = map[pipeline.Signal]string{
+	pipeline.SignalTraces:  "spans",
+	pipeline.SignalMetrics: "metric_points",
+	pipeline.SignalLogs:    "log_records",
+}
+
+type pipelineComponents struct {
+	Receivers []string `mapstructure:"receivers"`
+	Exporters []string `mapstructure:"exporters"`
+}
+
+// counters are the cumulative item counts of the components of a pipeline.
+type counters struct {
+	accepted      float64
+	refused       float64
+	failed        float64
+	sent          float64
+	sendFailed    float64
+	enqueueFailed float64
+}
+
+type pipelineState struct {
+	previous     *counters
+	previousTime time.Time
+	// lastAccepted is the last time the receivers were seen accepting items.
+	lastAccepted time.Time
+	recorded     bool
+	healthy      bool
+}
+
+// Checker periodically scrapes the internal metrics of the collector, and records the data
+// flow status of the configured pipelines in the aggregator.
+type Checker struct {
+	config     *Config
+	telemetry  component.TelemetrySettings
+	aggregator *status.Aggregator
+	client     *http.Client
+	pipelines  atomic.Pointer[map[pipeline.ID]pipelineComponents]
+	states     map[pipeline.ID]*pipelineState
+	cancel     context.CancelFunc
+	doneCh     chan struct{}
+}
+
+var (
+	_ component.Component                 = (*Checker)(nil)
+	_ extensioncapabilities.ConfigWatcher = (*Checker)(nil)
+)
+
+func NewChecker(
+	config *Config,
+	telemetry component.TelemetrySettings,
+	aggregator *status.Aggregator,
+) *Checker {
+	return &Checker{
+		config:     config,
+		telemetry:  telemetry,
+		aggregator: aggregator,
+		states:     make(map[pipeline.ID]*pipelineState),
+	}
+}
+
+// Start implements the component.Component interface.
+func (c *Checker) Start(ctx context.Context, host component.Host) error {
+	var err error
+	c.client, err = c.config.ToClient(ctx, host, c.telemetry)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for id := range c.config.Pipelines {
+		c.states[id] = &pipelineState{lastAccepted: now}
+	}
+
+	var runCtx context.Context
+	runCtx, c.cancel = context.WithCancel(context.Background())
+	c.doneCh = make(chan struct{})
+	go c.run(runCtx)
+
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (c *Checker) Shutdown(context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+	<-c.doneCh
+
+	for id, state := range c.states {
+		if state.recorded {
+			c.aggregator.RecordPipelineStatus(id, StatusKey, componentstatus.NewEvent(componentstatus.StatusStopped))
+		}
+	}
+	return nil
+}
+
+// NotifyConfig implements the extensioncapabilities.ConfigWatcher interface.
+func (c *Checker) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	var cfg struct {
+		Pipelines map[pipeline.ID]pipelineComponents `mapstructure:"pipelines"`
+	}
+	service, err := conf.Sub("service")
+	if err != nil {
+		return err
+	}
+	if err = service.Unmarshal(&cfg, confmap.WithIgnoreUnused()); err != nil {
+		return err
+	}
+
+	for id := range c.config.Pipelines {
+		if _, ok := cfg.Pipelines[id]; !ok {
+			c.telemetry.Logger.Warn("data flow health checks configured for an unknown pipeline", zap.String("pipeline", id.String()))
+		}
+	}
+	c.pipelines.Store(&cfg.Pipelines)
+	return nil
+}
+
+func (c *Checker) run(ctx context.Context) {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			samples, err := c.scrape(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					c.telemetry.Logger.Warn("failed to scrape the internal metrics of the collector", zap.Error(err))
+				}
+				continue
+			}
+			c.check(time.Now(), samples)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Checker) scrape(ctx context.Context) ([]sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.Endpoint, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return parseSamples(resp.Body)
+}
+
+// check evaluates the health checks of the pipelines with the given samples, and records
+// their status when it changes.
+func (c *Checker) check(now time.Time, samples []sample) {
+	pipelines := c.pipelines.Load()
+	if pipelines == nil {
+		// The pipelines are not known until the config is notified
+		return
+	}
+
+	for id, pc := range c.config.Pipelines {
+		components, ok := (*pipelines)[id]
+		if !ok {
+			continue
+		}
+		if _, ok = itemNames[id.Signal()]; !ok {
+			continue
+		}
+
+		state := c.states[id]
+		err := state.check(now, pc, id.Signal(), components, samples)
+		healthy := err == nil
+		if state.recorded && state.healthy == healthy {
+			// Keep the first event of a failure for the recovery duration
+			continue
+		}
+		state.recorded = true
+		state.healthy = healthy
+
+		if healthy {
+			c.telemetry.Logger.Info("pipeline data flow is healthy", zap.String("pipeline", id.String()))
+			c.aggregator.RecordPipelineStatus(id, StatusKey, componentstatus.NewEvent(componentstatus.StatusOK))
+		} else {
+			c.telemetry.Logger.Warn("pipeline data flow is unhealthy", zap.String("pipeline", id.String()), zap.Error(err))
+			c.aggregator.RecordPipelineStatus(id, StatusKey, componentstatus.NewRecoverableErrorEvent(err))
+		}
+	}
+}
+
+func (s *pipelineState) check(
+	now time.Time,
+	config PipelineConfig,
+	signal pipeline.Signal,
+	components pipelineComponents,
+	samples []sample,
+) error {
+	current := sumCounters(signal, components, samples)
+	previous, previousTime := s.previous, s.previousTime
+	s.previous, s.previousTime = current, now
+
+	var errs []error
+	if previous != nil {
+		accepted := delta(previous.accepted, current.accepted)
+		if accepted > 0 {
+			s.lastAccepted = now
+		}
+
+		if config.MinThroughput > 0 {
+			throughput := accepted / now.Sub(previousTime).Seconds()
+			if throughput < config.MinThroughput {
+				errs = append(errs, fmt.Errorf("throughput of %.2f items/s is below the minimum of %.2f items/s", throughput, config.MinThroughput))
+			}
+		}
+
+		if config.MaxFailureRatio > 0 {
+			refused := delta(previous.refused, current.refused) + delta(previous.failed, current.failed)
+			receiverRatio := ratio(refused, accepted+refused)
+			sendFailed := delta(previous.sendFailed, current.sendFailed) + delta(previous.enqueueFailed, current.enqueueFailed)
+			exporterRatio := ratio(sendFailed, delta(previous.sent, current.sent)+sendFailed)
+			if failureRatio := max(receiverRatio, exporterRatio); failureRatio > config.MaxFailureRatio {
+				errs = append(errs, fmt.Errorf("failure ratio of %.2f is above the maximum of %.2f", failureRatio, config.MaxFailureRatio))
+			}
+		}
+	}
+
+	if config.MaxStaleness > 0 {
+		if staleness := now.Sub(s.lastAccepted); staleness > config.MaxStaleness {
+			errs = append(errs, fmt.Errorf("no items accepted for %s, above the maximum staleness of %s", staleness.Round(time.Second), config.MaxStaleness))
+		}
+	}
+
+	if config.MaxQueueFillRatio > 0 {
+		for _, exporter := range components.Exporters {
+			fillRatio, ok := queueFillRatio(signal, exporter, samples)
+			if ok && fillRatio > config.MaxQueueFillRatio {
+				errs = append(errs, fmt.Errorf("queue of exporter %q is %.2f full, above the maximum of %.2f", exporter, fillRatio, config.MaxQueueFillRatio))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sumCounters sums the item counts of the receivers and exporters of a pipeline. The counts
+// of a component shared by several pipelines of the same signal are counted in each of them.
+func sumCounters(signal pipeline.Signal, components pipelineComponents, samples []sample) *counters {
+	items := itemNames[signal]
+	receivers := toSet(components.Receivers)
+	exporters := toSet(components.Exporters)
+
+	c := &counters{}
+	for _, s := range samples {
+		if _, ok := receivers[s.labels["receiver"]]; ok {
+			switch {
+			case isCounter(s.name, "otelcol_receiver_accepted_"+items):
+				c.accepted += s.value
+			case isCounter(s.name, "otelcol_receiver_refused_"+items):
+				c.refused += s.value
+			case isCounter(s.name, "otelcol_receiver_failed_"+items):
+				c.failed += s.value
+			}
+		}
+		if _, ok := exporters[s.labels["exporter"]]; ok {
+			switch {
+			case isCounter(s.name, "otelcol_exporter_sent_"+items):
+				c.sent += s.value
+			case isCounter(s.name, "otelcol_exporter_send_failed_"+items):
+				c.sendFailed += s.value
+			case isCounter(s.name, "otelcol_exporter_enqueue_failed_"+items):
+				c.enqueueFailed += s.value
+			}
+		}
+	}
+	return c
+}
+
+// queueFillRatio returns the ratio of the size of the sending queue of an exporter to its
+// capacity, and false if the exporter has no sending queue.
+func queueFillRatio(signal pipeline.Signal, exporter string, samples []sample) (float64, bool) {
+	var size, capacity float64
+	var found bool
+	for _, s := range samples {
+		if s.labels["exporter"] != exporter {
+			continue
+		}
+		if dataType, ok := s.labels["data_type"]; ok && dataType != signal.String() {
+			continue
+		}
+		switch s.name {
+		case "otelcol_exporter_queue_size":
+			size += s.value
+		case "otelcol_exporter_queue_capacity":
+			capacity += s.value
+			found = true
+		}
+	}
+	if !found || capacity <= 0 {
+		return 0, false
+	}
+	return size / capacity, true
+}
+
+// isCounter returns whether name is the name of the given counter, which the Prometheus
+// exporter suffixes with _total.
+func isCounter(name, counter string) bool {
+	return name == counter || name == counter+"_total"
+}
+
+// delta returns the increase of a counter, which may have been reset.
+func delta(previous, current float64) float64 {
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+func ratio(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return part / total
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataflow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/status"
+)
+
+var tracesID = pipeline.NewID(pipeline.SignalTraces)
+
+func parseTestSamples(t *testing.T, text string) []sample {
+	samples, err := parseSamples(strings.NewReader(text))
+	require.NoError(t, err)
+	return samples
+}
+
+func notifyTestConfig(t *testing.T, c *Checker) {
+	require.NoError(t, c.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers": []any{"otlp"},
+					"exporters": []any{"otlp/backend"},
+				},
+			},
+		},
+	})))
+}
+
+func dataFlowEvent(t *testing.T, aggregator *status.Aggregator) status.Event {
+	st, ok := aggregator.AggregateStatus(status.Scope(tracesID.String()), status.Verbose)
+	require.True(t, ok)
+	require.Contains(t, st.ComponentStatusMap, StatusKey)
+	return st.ComponentStatusMap[StatusKey].Event
+}
+
+func TestPipelineStateCheck(t *testing.T) {
+	components := pipelineComponents{
+		Receivers: []string{"otlp"},
+		Exporters: []string{"otlp/backend"},
+	}
+
+	tests := []struct {
+		name     string
+		config   PipelineConfig
+		previous string
+		current  string
+		elapsed  time.Duration
+		// expected are the substrings of the expected error, nil if healthy
+		expected []string
+	}{
+		{
+			name:     "healthy",
+			config:   PipelineConfig{MinThroughput: 5, MaxFailureRatio: 0.1, MaxStaleness: time.Minute, MaxQueueFillRatio: 0.8},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			current: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 200
+otelcol_exporter_queue_size{exporter="otlp/backend",data_type="traces"} 1
+otelcol_exporter_queue_capacity{exporter="otlp/backend",data_type="traces"} 10`,
+			elapsed: 10 * time.Second,
+		},
+		{
+			name:     "low throughput",
+			config:   PipelineConfig{MinThroughput: 5},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			current:  `otelcol_receiver_accepted_spans_total{receiver="otlp"} 120`,
+			elapsed:  10 * time.Second,
+			expected: []string{"throughput of 2.00 items/s is below the minimum of 5.00 items/s"},
+		},
+		{
+			name:     "counter reset",
+			config:   PipelineConfig{MinThroughput: 5},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			current:  `otelcol_receiver_accepted_spans_total{receiver="otlp"} 50`,
+			elapsed:  10 * time.Second,
+		},
+		{
+			name:     "other components ignored",
+			config:   PipelineConfig{MinThroughput: 5},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			current: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100
+otelcol_receiver_accepted_spans_total{receiver="zipkin"} 1000
+otelcol_receiver_accepted_log_records_total{receiver="otlp"} 1000`,
+			elapsed:  10 * time.Second,
+			expected: []string{"throughput of 0.00 items/s"},
+		},
+		{
+			name:   "receiver failure ratio",
+			config: PipelineConfig{MaxFailureRatio: 0.5},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100
+otelcol_receiver_refused_spans_total{receiver="otlp"} 0`,
+			current: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 150
+otelcol_receiver_refused_spans_total{receiver="otlp"} 40
+otelcol_receiver_failed_spans_total{receiver="otlp"} 20`,
+			elapsed:  10 * time.Second,
+			expected: []string{"failure ratio of 0.55 is above the maximum of 0.50"},
+		},
+		{
+			name:   "exporter failure ratio",
+			config: PipelineConfig{MaxFailureRatio: 0.5},
+			previous: `otelcol_exporter_sent_spans_total{exporter="otlp/backend"} 100
+otelcol_exporter_send_failed_spans_total{exporter="otlp/backend"} 10`,
+			current: `otelcol_exporter_sent_spans_total{exporter="otlp/backend"} 150
+otelcol_exporter_send_failed_spans_total{exporter="otlp/backend"} 60
+otelcol_exporter_enqueue_failed_spans_total{exporter="otlp/backend"} 50`,
+			elapsed:  10 * time.Second,
+			expected: []string{"failure ratio of 0.67 is above the maximum of 0.50"},
+		},
+		{
+			name:     "stale",
+			config:   PipelineConfig{MaxStaleness: time.Minute},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			current:  `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			elapsed:  2 * time.Minute,
+			expected: []string{"no items accepted for 2m0s, above the maximum staleness of 1m0s"},
+		},
+		{
+			name:   "queue fill ratio",
+			config: PipelineConfig{MaxQueueFillRatio: 0.8},
+			current: `otelcol_exporter_queue_size{exporter="otlp/backend",data_type="traces"} 9
+otelcol_exporter_queue_capacity{exporter="otlp/backend",data_type="traces"} 10
+otelcol_exporter_queue_size{exporter="otlp/backend",data_type="logs"} 10
+otelcol_exporter_queue_capacity{exporter="otlp/backend",data_type="logs"} 10
+otelcol_exporter_queue_size{exporter="otlp/other",data_type="traces"} 10
+otelcol_exporter_queue_capacity{exporter="otlp/other",data_type="traces"} 10`,
+			elapsed:  10 * time.Second,
+			expected: []string{`queue of exporter "otlp/backend" is 0.90 full, above the maximum of 0.80`},
+		},
+		{
+			name:     "multiple failures",
+			config:   PipelineConfig{MinThroughput: 5, MaxStaleness: time.Minute},
+			previous: `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			current:  `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`,
+			elapsed:  2 * time.Minute,
+			expected: []string{"throughput of 0.00 items/s", "no items accepted for 2m0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			state := &pipelineState{lastAccepted: start}
+			_ = state.check(start, tt.config, pipeline.SignalTraces, components, parseTestSamples(t, tt.previous))
+
+			err := state.check(start.Add(tt.elapsed), tt.config, pipeline.SignalTraces, components, parseTestSamples(t, tt.current))
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, expected := range tt.expected {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestCheckerCheck(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Interval = time.Hour
+	config.Pipelines = map[pipeline.ID]PipelineConfig{
+		tracesID:                            {MinThroughput: 5},
+		pipeline.NewID(pipeline.SignalLogs): {MinThroughput: 5},
+	}
+	aggregator := status.NewAggregator(status.PriorityPermanent)
+	defer aggregator.Close()
+
+	c := NewChecker(config, componenttest.NewNopTelemetrySettings(), aggregator)
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+
+	now := time.Now()
+	// No status is recorded until the pipelines are known
+	c.check(now, parseTestSamples(t, `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`))
+	_, ok := aggregator.AggregateStatus(status.Scope(tracesID.String()), status.Verbose)
+	assert.False(t, ok)
+
+	notifyTestConfig(t, c)
+
+	c.check(now, parseTestSamples(t, `otelcol_receiver_accepted_spans_total{receiver="otlp"} 100`))
+	assert.Equal(t, componentstatus.StatusOK, dataFlowEvent(t, aggregator).Status())
+
+	// Pipelines missing from the service config are not checked
+	_, ok = aggregator.AggregateStatus(status.Scope(pipeline.NewID(pipeline.SignalLogs).String()), status.Verbose)
+	assert.False(t, ok)
+
+	now = now.Add(10 * time.Second)
+	c.check(now, parseTestSamples(t, `otelcol_receiver_accepted_spans_total{receiver="otlp"} 110`))
+	failure := dataFlowEvent(t, aggregator)
+	assert.Equal(t, componentstatus.StatusRecoverableError, failure.Status())
+	require.Error(t, failure.Err())
+	assert.Contains(t, failure.Err().Error(), "throughput of 1.00 items/s")
+
+	// The first event of a failure is kept while it persists
+	now = now.Add(10 * time.Second)
+	c.check(now, parseTestSamples(t, `otelcol_receiver_accepted_spans_total{receiver="otlp"} 110`))
+	assert.Equal(t, failure.Timestamp(), dataFlowEvent(t, aggregator).Timestamp())
+
+	now = now.Add(10 * time.Second)
+	c.check(now, parseTestSamples(t, `otelcol_receiver_accepted_spans_total{receiver="otlp"} 210`))
+	assert.Equal(t, componentstatus.StatusOK, dataFlowEvent(t, aggregator).Status())
+
+	require.NoError(t, c.Shutdown(context.Background()))
+	assert.Equal(t, componentstatus.StatusStopped, dataFlowEvent(t, aggregator).Status())
+}
+
+func TestCheckerScrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/metrics", r.URL.Path)
+		_, _ = w.Write([]byte(`# TYPE otelcol_receiver_accepted_spans_total counter
+otelcol_receiver_accepted_spans_total{receiver="otlp",transport="grpc"} 100
+`))
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.Endpoint = server.URL + "/metrics"
+	config.Interval = 10 * time.Millisecond
+	config.Pipelines = map[pipeline.ID]PipelineConfig{
+		tracesID: {MaxStaleness: time.Minute},
+	}
+	aggregator := status.NewAggregator(status.PriorityPermanent)
+	defer aggregator.Close()
+
+	c := NewChecker(config, componenttest.NewNopTelemetrySettings(), aggregator)
+	notifyTestConfig(t, c)
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		st, ok := aggregator.AggregateStatus(status.Scope(tracesID.String()), status.Verbose)
+		if assert.True(tt, ok) && assert.Contains(tt, st.ComponentStatusMap, StatusKey) {
+			assert.Equal(tt, componentstatus.StatusOK, st.ComponentStatusMap[StatusKey].Status())
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, c.Shutdown(context.Background()))
+}
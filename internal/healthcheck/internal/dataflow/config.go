@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataflow // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/dataflow"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var (
+	ErrEndpointRequired = errors.New("data_flow endpoint required")
+	ErrInvalidInterval  = errors.New("data_flow interval must be positive")
+)
+
+// Config contains the config for the data flow health checks, which are derived from the
+// internal telemetry of the collector.
+type Config struct {
+	// ClientConfig configures the client scraping the internal metrics of the collector,
+	// exposed in the Prometheus text format.
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// Interval is the time between two scrapes of the internal metrics. The rates are
+	// computed over this interval.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Pipelines contains the health checks of each pipeline.
+	Pipelines map[pipeline.ID]PipelineConfig `mapstructure:"pipelines"`
+}
+
+// PipelineConfig contains the data flow health checks of a pipeline. A zero value disables
+// the corresponding check.
+type PipelineConfig struct {
+	// MinThroughput is the minimum number of items per second accepted by the receivers
+	// of the pipeline.
+	MinThroughput float64 `mapstructure:"min_throughput"`
+
+	// MaxStaleness is the maximum time since the receivers of the pipeline last accepted
+	// an item.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+
+	// MaxQueueFillRatio is the maximum ratio of the size of the sending queue of any exporter
+	// of the pipeline to its capacity.
+	MaxQueueFillRatio float64 `mapstructure:"max_queue_fill_ratio"`
+
+	// MaxFailureRatio is the maximum ratio of the items refused or failed by the receivers of
+	// the pipeline, or failed to be sent or enqueued by its exporters.
+	MaxFailureRatio float64 `mapstructure:"max_failure_ratio"`
+}
+
+// NewDefaultConfig returns the default config for the data flow health checks.
+func NewDefaultConfig() *Config {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = "http://localhost:8888/metrics"
+	clientConfig.Timeout = 5 * time.Second
+	return &Config{
+		ClientConfig: clientConfig,
+		Interval:     30 * time.Second,
+	}
+}
+
+// Validate checks if the data flow health checks configuration is valid.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return ErrEndpointRequired
+	}
+	if c.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	for id, pc := range c.Pipelines {
+		if err := pc.validate(); err != nil {
+			return fmt.Errorf("data_flow pipeline %q: %w", id.String(), err)
+		}
+	}
+	return nil
+}
+
+func (c PipelineConfig) validate() error {
+	if c.MinThroughput < 0 {
+		return errors.New("min_throughput must not be negative")
+	}
+	if c.MaxStaleness < 0 {
+		return errors.New("max_staleness must not be negative")
+	}
+	if c.MaxQueueFillRatio < 0 || c.MaxQueueFillRatio > 1 {
+		return errors.New("max_queue_fill_ratio must be between 0 and 1")
+	}
+	if c.MaxFailureRatio < 0 || c.MaxFailureRatio > 1 {
+		return errors.New("max_failure_ratio must be between 0 and 1")
+	}
+	return nil
+}
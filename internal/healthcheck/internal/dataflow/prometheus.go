@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataflow // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/healthcheck/internal/dataflow"
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sample is a sample of a metric exposed in the Prometheus text format.
+type sample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// parseSamples parses the samples of the metrics exposed in the Prometheus text format.
+// Comments, including the HELP and TYPE metadata, are ignored.
+func parseSamples(r io.Reader) ([]sample, error) {
+	var samples []sample
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		s, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		samples = append(samples, s)
+	}
+
+	return samples, scanner.Err()
+}
+
+func parseSample(line string) (sample, error) {
+	s := sample{labels: map[string]string{}}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return s, errors.New("invalid sample")
+	}
+	s.name = line[:end]
+
+	rest := line[end:]
+	if rest[0] == '{' {
+		var err error
+		if rest, err = parseLabels(rest[1:], s.labels); err != nil {
+			return s, err
+		}
+	}
+
+	// The value may be followed by a timestamp
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return s, fmt.Errorf("missing value of %q", s.name)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return s, fmt.Errorf("invalid value of %q: %w", s.name, err)
+	}
+	s.value = value
+
+	return s, nil
+}
+
+// parseLabels parses the labels following the opening brace into labels, and returns the
+// rest of the line after the closing brace.
+func parseLabels(line string, labels map[string]string) (string, error) {
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return "", errors.New("unterminated labels")
+		}
+		if line[0] == '}' {
+			return line[1:], nil
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 {
+			return "", errors.New("invalid label")
+		}
+		name := strings.TrimSpace(line[:eq])
+		line = strings.TrimLeft(line[eq+1:], " \t")
+		if line == "" || line[0] != '"' {
+			return "", fmt.Errorf("invalid value of label %q", name)
+		}
+
+		var value strings.Builder
+		i := 1
+		for ; i < len(line) && line[i] != '"'; i++ {
+			if line[i] == '\\' && i+1 < len(line) {
+				i++
+				if line[i] == 'n' {
+					value.WriteByte('\n')
+				} else {
+					value.WriteByte(line[i])
+				}
+				continue
+			}
+			value.WriteByte(line[i])
+		}
+		if i == len(line) {
+			return "", fmt.Errorf("unterminated value of label %q", name)
+		}
+		labels[name] = value.String()
+
+		line = strings.TrimLeft(line[i+1:], " \t")
+		if line != "" && line[0] == ',' {
+			line = line[1:]
+		}
+	}
+}
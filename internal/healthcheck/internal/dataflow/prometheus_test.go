@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dataflow
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSamples(t *testing.T) {
+	samples, err := parseSamples(strings.NewReader(`# HELP otelcol_receiver_accepted_spans_total Number of spans successfully pushed into the pipeline.
+# TYPE otelcol_receiver_accepted_spans_total counter
+otelcol_receiver_accepted_spans_total{receiver="otlp",service_name="otelcol",transport="grpc"} 42
+otelcol_exporter_queue_size{data_type="traces", exporter="otlp/backend" } 3 1700000000000
+otelcol_process_uptime 12.5
+
+escaped{label="a \"quoted\\ value\nwith a newline",empty=""} +Inf
+`))
+	require.NoError(t, err)
+	require.Len(t, samples, 4)
+
+	assert.Equal(t, sample{
+		name:   "otelcol_receiver_accepted_spans_total",
+		labels: map[string]string{"receiver": "otlp", "service_name": "otelcol", "transport": "grpc"},
+		value:  42,
+	}, samples[0])
+	assert.Equal(t, sample{
+		name:   "otelcol_exporter_queue_size",
+		labels: map[string]string{"data_type": "traces", "exporter": "otlp/backend"},
+		value:  3,
+	}, samples[1])
+	assert.Equal(t, sample{
+		name:   "otelcol_process_uptime",
+		labels: map[string]string{},
+		value:  12.5,
+	}, samples[2])
+	assert.Equal(t, map[string]string{"label": "a \"quoted\\ value\nwith a newline", "empty": ""}, samples[3].labels)
+	assert.True(t, math.IsInf(samples[3].value, 1))
+}
+
+func TestParseSamplesInvalid(t *testing.T) {
+	for _, text := range []string{
+		"missing_value",
+		"missing_value{label=\"value\"}",
+		"unterminated{label=\"value\" 1",
+		"unquoted{label=value} 1",
+		"invalid_value NotANumber",
+	} {
+		_, err := parseSamples(strings.NewReader(text))
+		assert.Error(t, err, text)
+	}
+}
@@ -23,6 +23,11 @@ const (
 	semconvOtelEntityInterval   = "otel.entity.interval"
 	semconvOtelEntityAttributes = "otel.entity.attributes"
 
+	semconvOtelEntityRelationships          = "otel.entity.relationships"
+	semconvOtelEntityRelationshipType       = "relationship.type"
+	semconvOtelEntityRelationshipEntityType = "entity.type"
+	semconvOtelEntityRelationshipEntityID   = "entity.id"
+
 	semconvOtelEntityEventAsScope = "otel.entity.event_as_log"
 )
 
@@ -191,6 +196,79 @@ func (s EntityStateDetails) Interval() time.Duration {
 	return time.Duration(t.Int()) * time.Millisecond
 }
 
+// Relationships returns the relationships of the entity to other entities.
+func (s EntityStateDetails) Relationships() EntityRelationshipSlice {
+	r, ok := s.orig.Attributes().Get(semconvOtelEntityRelationships)
+	if !ok {
+		return EntityRelationshipSlice{orig: s.orig.Attributes().PutEmptySlice(semconvOtelEntityRelationships)}
+	}
+	return EntityRelationshipSlice{orig: r.Slice()}
+}
+
+// EntityRelationshipSlice is a slice of EntityRelationship.
+type EntityRelationshipSlice struct {
+	orig pcommon.Slice
+}
+
+// AppendEmpty will append to the end of the slice an empty EntityRelationship.
+// It returns the newly added EntityRelationship.
+func (s EntityRelationshipSlice) AppendEmpty() EntityRelationship {
+	return EntityRelationship{orig: s.orig.AppendEmpty().SetEmptyMap()}
+}
+
+// Len returns the number of elements in the slice.
+func (s EntityRelationshipSlice) Len() int {
+	return s.orig.Len()
+}
+
+// At returns the element at the given index.
+func (s EntityRelationshipSlice) At(i int) EntityRelationship {
+	return EntityRelationship{orig: s.orig.At(i).Map()}
+}
+
+// EntityRelationship describes a relationship of an entity to a target entity,
+// e.g. a k8s.pod that is "owned_by" a k8s.replicaset.
+type EntityRelationship struct {
+	orig pcommon.Map
+}
+
+// Type returns the type of the relationship.
+func (r EntityRelationship) Type() string {
+	t, ok := r.orig.Get(semconvOtelEntityRelationshipType)
+	if !ok {
+		return ""
+	}
+	return t.Str()
+}
+
+// SetType sets the type of the relationship.
+func (r EntityRelationship) SetType(t string) {
+	r.orig.PutStr(semconvOtelEntityRelationshipType, t)
+}
+
+// EntityType returns the type of the target entity.
+func (r EntityRelationship) EntityType() string {
+	t, ok := r.orig.Get(semconvOtelEntityRelationshipEntityType)
+	if !ok {
+		return ""
+	}
+	return t.Str()
+}
+
+// SetEntityType sets the type of the target entity.
+func (r EntityRelationship) SetEntityType(t string) {
+	r.orig.PutStr(semconvOtelEntityRelationshipEntityType, t)
+}
+
+// EntityID returns the identifying attributes of the target entity.
+func (r EntityRelationship) EntityID() pcommon.Map {
+	m, ok := r.orig.Get(semconvOtelEntityRelationshipEntityID)
+	if !ok {
+		return r.orig.PutEmptyMap(semconvOtelEntityRelationshipEntityID)
+	}
+	return m.Map()
+}
+
 // EntityDeleteDetails represents the details of an EntityDelete event.
 type EntityDeleteDetails struct {
 	orig plog.LogRecord
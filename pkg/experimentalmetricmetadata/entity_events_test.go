@@ -39,6 +39,37 @@ func Test_Entity_State(t *testing.T) {
 	assert.Equal(t, 1*time.Hour, actual.EntityStateDetails().Interval())
 }
 
+func Test_Entity_State_Relationships(t *testing.T) {
+	slice := NewEntityEventsSlice()
+	event := slice.AppendEmpty()
+
+	event.ID().PutStr("k8s.pod.uid", "123")
+	state := event.SetEntityState()
+	assert.Equal(t, 0, state.Relationships().Len())
+
+	relationship := state.Relationships().AppendEmpty()
+	relationship.SetType("owned_by")
+	relationship.SetEntityType("k8s.replicaset")
+	relationship.EntityID().PutStr("k8s.replicaset.uid", "456")
+	relationship = state.Relationships().AppendEmpty()
+	relationship.SetType("scheduled_on")
+	relationship.SetEntityType("k8s.node")
+	relationship.EntityID().PutStr("k8s.node.uid", "789")
+
+	actual := slice.At(0).EntityStateDetails().Relationships()
+	assert.Equal(t, 2, actual.Len())
+	assert.Equal(t, "owned_by", actual.At(0).Type())
+	assert.Equal(t, "k8s.replicaset", actual.At(0).EntityType())
+	assert.Equal(t, map[string]any{"k8s.replicaset.uid": "456"}, actual.At(0).EntityID().AsRaw())
+	assert.Equal(t, "scheduled_on", actual.At(1).Type())
+	assert.Equal(t, "k8s.node", actual.At(1).EntityType())
+	assert.Equal(t, map[string]any{"k8s.node.uid": "789"}, actual.At(1).EntityID().AsRaw())
+
+	empty := EntityRelationshipSlice{orig: pcommon.NewSlice()}.AppendEmpty()
+	assert.Empty(t, empty.Type())
+	assert.Empty(t, empty.EntityType())
+}
+
 func Test_Entity_Delete(t *testing.T) {
 	slice := NewEntityEventsSlice()
 
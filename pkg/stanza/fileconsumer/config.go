@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadata"
@@ -59,6 +60,7 @@ func NewConfig() *Config {
 		MaxConcurrentFiles: defaultMaxConcurrentFiles,
 		StartAt:            "end",
 		FingerprintSize:    fingerprint.DefaultSize,
+		FileIdentity:       fingerprint.StrategyFingerprint,
 		InitialBufferSize:  scanner.DefaultBufferSize,
 		MaxLogSize:         reader.DefaultMaxLogSize,
 		Encoding:           defaultEncoding,
@@ -78,6 +80,8 @@ type Config struct {
 	MaxBatches              int             `mapstructure:"max_batches,omitempty"`
 	StartAt                 string          `mapstructure:"start_at,omitempty"`
 	FingerprintSize         helper.ByteSize `mapstructure:"fingerprint_size,omitempty"`
+	FingerprintSkipLines    int             `mapstructure:"fingerprint_skip_lines,omitempty"`
+	FileIdentity            string          `mapstructure:"file_identity,omitempty"`
 	InitialBufferSize       helper.ByteSize `mapstructure:"initial_buffer_size,omitempty"`
 	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size,omitempty"`
 	Encoding                string          `mapstructure:"encoding,omitempty"`
@@ -89,6 +93,7 @@ type Config struct {
 	IncludeFileRecordNumber bool            `mapstructure:"include_file_record_number,omitempty"`
 	IncludeFileRecordOffset bool            `mapstructure:"include_file_record_offset,omitempty"`
 	Compression             string          `mapstructure:"compression,omitempty"`
+	Format                  string          `mapstructure:"format,omitempty"`
 	PollsToArchive          int             `mapstructure:"polls_to_archive,omitempty"`
 	AcquireFSLock           bool            `mapstructure:"acquire_fs_lock,omitempty"`
 }
@@ -116,6 +121,22 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		return nil, fmt.Errorf("failed to find encoding: %w", err)
 	}
 
+	var recordReader format.RecordReader
+	switch c.Format {
+	case "", format.Text, format.NDJSON:
+	default:
+		var ok bool
+		if recordReader, ok = o.recordReaders[c.Format]; !ok {
+			return nil, fmt.Errorf("invalid 'format' %q", c.Format)
+		}
+		if o.recordCallback == nil {
+			return nil, fmt.Errorf("format '%s' requires a record callback", c.Format)
+		}
+		if r, ok := recordReader.(format.FileIDRequirer); ok && r.RequiresFileID() && c.FileIdentity != fingerprint.StrategyFileID {
+			return nil, fmt.Errorf("format '%s' requires 'file_identity: %s', as its files can't be told apart by their first bytes", c.Format, fingerprint.StrategyFileID)
+		}
+	}
+
 	splitFunc := o.splitFunc
 	if splitFunc == nil {
 		splitFunc, err = c.SplitConfig.Func(enc, false, int(c.MaxLogSize))
@@ -157,6 +178,8 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		TelemetrySettings:       set,
 		FromBeginning:           startAtBeginning,
 		FingerprintSize:         int(c.FingerprintSize),
+		FingerprintSkipLines:    c.FingerprintSkipLines,
+		IdentityStrategy:        c.FileIdentity,
 		InitialBufferSize:       int(c.InitialBufferSize),
 		MaxLogSize:              int(c.MaxLogSize),
 		Encoding:                enc,
@@ -164,6 +187,8 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		TrimFunc:                trimFunc,
 		FlushTimeout:            c.FlushPeriod,
 		EmitFunc:                emit,
+		RecordReader:            recordReader,
+		RecordFunc:              o.recordCallback,
 		Attributes:              c.Resolver,
 		HeaderConfig:            hCfg,
 		DeleteAtEOF:             c.DeleteAfterRead,
@@ -204,6 +229,21 @@ func (c Config) validate() error {
 		return fmt.Errorf("'fingerprint_size' must be at least %d bytes", fingerprint.MinSize)
 	}
 
+	if c.FingerprintSkipLines < 0 {
+		return errors.New("'fingerprint_skip_lines' must not be negative")
+	}
+
+	switch c.FileIdentity {
+	case "", fingerprint.StrategyFingerprint, fingerprint.StrategyHybrid:
+	case fingerprint.StrategyFileID:
+		if c.FingerprintSkipLines > 0 {
+			return fmt.Errorf("'fingerprint_skip_lines' cannot be used with 'file_identity: %s'", fingerprint.StrategyFileID)
+		}
+	default:
+		return fmt.Errorf("invalid 'file_identity' %q, must be one of %q, %q or %q",
+			c.FileIdentity, fingerprint.StrategyFingerprint, fingerprint.StrategyFileID, fingerprint.StrategyHybrid)
+	}
+
 	if c.MaxLogSize <= 0 {
 		return errors.New("'max_log_size' must be positive")
 	}
@@ -243,6 +283,24 @@ func (c Config) validate() error {
 		}
 	}
 
+	switch c.Compression {
+	case "", "gzip", "zstd", "bzip2", "auto":
+	default:
+		return fmt.Errorf("invalid 'compression' %q, must be one of \"gzip\", \"zstd\", \"bzip2\" or \"auto\"", c.Compression)
+	}
+
+	switch c.Format {
+	case "", format.Text, format.NDJSON:
+	default:
+		// The formats read by record readers are checked once the record readers are known
+		if c.Compression != "" {
+			return fmt.Errorf("'compression' cannot be used with 'format: %s'", c.Format)
+		}
+		if c.Header != nil {
+			return fmt.Errorf("'header' cannot be used with 'format: %s'", c.Format)
+		}
+	}
+
 	if runtime.GOOS == "windows" && (c.IncludeFileOwnerName || c.IncludeFileOwnerGroupName) {
 		return fmt.Errorf("'include_file_owner_name' or 'include_file_owner_group_name' it's not supported for windows: %w", err)
 	}
@@ -251,8 +309,10 @@ func (c Config) validate() error {
 }
 
 type options struct {
-	splitFunc  bufio.SplitFunc
-	noTracking bool
+	splitFunc      bufio.SplitFunc
+	noTracking     bool
+	recordCallback emit.RecordCallback
+	recordReaders  map[string]format.RecordReader
 }
 
 type Option func(*options)
@@ -271,3 +331,18 @@ func WithNoTracking() Option {
 		o.noTracking = true
 	}
 }
+
+// WithRecordCallback sets the function called with the records of structured files, which are read
+// by the record reader of the configured format. It is required by such formats.
+func WithRecordCallback(f emit.RecordCallback) Option {
+	return func(o *options) {
+		o.recordCallback = f
+	}
+}
+
+// WithRecordReaders sets the record readers of the structured formats which can be configured, by format
+func WithRecordReaders(readers map[string]format.RecordReader) Option {
+	return func(o *options) {
+		o.recordReaders = readers
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/emittest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
@@ -30,6 +31,8 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, defaultMaxConcurrentFiles, cfg.MaxConcurrentFiles)
 	assert.Equal(t, "end", cfg.StartAt)
 	assert.Equal(t, fingerprint.DefaultSize, int(cfg.FingerprintSize))
+	assert.Equal(t, fingerprint.StrategyFingerprint, cfg.FileIdentity)
+	assert.Zero(t, cfg.FingerprintSkipLines)
 	assert.Equal(t, defaultEncoding, cfg.Encoding)
 	assert.Equal(t, reader.DefaultMaxLogSize, int(cfg.MaxLogSize))
 	assert.Equal(t, reader.DefaultFlushPeriod, cfg.FlushPeriod)
@@ -316,6 +319,24 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "file_identity_hybrid",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.FileIdentity = fingerprint.StrategyHybrid
+					cfg.FingerprintSkipLines = 1
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "format_ndjson_zstd",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Format = format.NDJSON
+					cfg.Compression = "zstd"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "multiline_line_start_string",
 				Expect: func() *mockOperatorConfig {
@@ -582,6 +603,99 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, 6, m.maxBatches)
 			},
 		},
+		{
+			"FileIdentityFileID",
+			func(cfg *Config) {
+				cfg.FileIdentity = fingerprint.StrategyFileID
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, fingerprint.StrategyFileID, m.readerFactory.IdentityStrategy)
+			},
+		},
+		{
+			"FileIdentityHybridSkipLines",
+			func(cfg *Config) {
+				cfg.FileIdentity = fingerprint.StrategyHybrid
+				cfg.FingerprintSkipLines = 2
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, fingerprint.StrategyHybrid, m.readerFactory.IdentityStrategy)
+				require.Equal(t, 2, m.readerFactory.FingerprintSkipLines)
+			},
+		},
+		{
+			"InvalidFileIdentity",
+			func(cfg *Config) {
+				cfg.FileIdentity = "inode"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidFingerprintSkipLines",
+			func(cfg *Config) {
+				cfg.FingerprintSkipLines = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"FileIDSkipLines",
+			func(cfg *Config) {
+				cfg.FileIdentity = fingerprint.StrategyFileID
+				cfg.FingerprintSkipLines = 1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ZstdCompression",
+			func(cfg *Config) {
+				cfg.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "zstd", m.readerFactory.Compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"NDJSONFormat",
+			func(cfg *Config) {
+				cfg.Format = format.NDJSON
+				cfg.Compression = "gzip"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Nil(t, m.readerFactory.RecordReader)
+			},
+		},
+		{
+			"InvalidFormat",
+			func(cfg *Config) {
+				cfg.Format = "orc"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"RecordFormatWithCompression",
+			func(cfg *Config) {
+				cfg.Format = testRecordFormat
+				cfg.Compression = "gzip"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"HeaderConfigNoFlag",
 			func(cfg *Config) {
@@ -20,3 +20,6 @@ func NewToken(body []byte, attrs map[string]any) Token {
 		Attributes: attrs,
 	}
 }
+
+// RecordCallback is called with the records read from structured files
+type RecordCallback func(ctx context.Context, records []map[string]any, attributes map[string]any, lastRecordNumber int64) error
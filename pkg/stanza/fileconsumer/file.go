@@ -248,6 +248,18 @@ func (m *Manager) handleUnmatchedFiles(ctx context.Context) {
 		file := files[i]
 		fp := fps[i]
 
+		// Exclude files with the same content as a file that was matched later in this poll.
+		// With the hybrid identity, this is the copy of a file being rotated with copy/truncate.
+		if r := m.tracker.GetCurrentFile(fp); r != nil {
+			m.set.Logger.Debug("Skipping duplicate file", zap.String("path", file.Name()))
+			// re-add the reader as Match() removes duplicates
+			m.tracker.Add(r)
+			if err := file.Close(); err != nil {
+				m.set.Logger.Debug("problem closing file", zap.Error(err))
+			}
+			continue
+		}
+
 		var reader *reader.Reader
 		var err error
 
@@ -7,21 +7,27 @@ import (
 	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/featuregate"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/emittest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
@@ -1607,6 +1613,159 @@ func TestReadGzipCompressedLogsFromEnd(t *testing.T) {
 	sink.ExpectToken(t, []byte("testlog4"))
 }
 
+// TestReadZstdCompressedLogsFromEnd tests that zstd compressed files are detected by their extension,
+// and that the frames appended to a file are read
+func TestReadZstdCompressedLogsFromEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "auto"
+	cfg.StartAt = "end"
+	operator, sink := testManager(t, cfg)
+
+	temp := filetest.OpenTempWithPattern(t, tempDir, "*.zst")
+
+	appendToLog := func(t *testing.T, content string) {
+		writer, err := zstd.NewWriter(temp)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+	}
+
+	appendToLog(t, "testlog1\ntestlog2\n")
+
+	// poll for the first time - this should not lead to emitted
+	// logs as those were already in the existing file
+	operator.poll(t.Context())
+
+	appendToLog(t, "testlog3\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog3"))
+
+	appendToLog(t, "testlog4\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog4"))
+}
+
+// TestReadBzip2CompressedLogsFromBeginning tests that bzip2 compressed files are read
+func TestReadBzip2CompressedLogsFromBeginning(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "bzip2"
+	cfg.StartAt = "beginning"
+	operator, sink := testManager(t, cfg)
+
+	compressed, err := os.ReadFile(filepath.Join("testdata", "compressed.log.bz2"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "compressed.log.bz2"), compressed, 0o600))
+
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog1"))
+	sink.ExpectToken(t, []byte("testlog2"))
+}
+
+const testRecordFormat = "test_records"
+
+// testRecordReader reads files holding one record per line, and uses line numbers as positions
+type testRecordReader struct{}
+
+func (testRecordReader) Read(ctx context.Context, file *os.File, position int64, emit format.EmitFunc) error {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(content), "\n")
+	// the last line is incomplete
+	for i := position; i < int64(len(lines)-1); i++ {
+		if err = emit(ctx, []map[string]any{{"line": lines[i]}}, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testFileIDRecordReader is a testRecordReader whose files must be identified by their file ID
+type testFileIDRecordReader struct {
+	testRecordReader
+}
+
+func (testFileIDRecordReader) RequiresFileID() bool {
+	return true
+}
+
+var testRecordReaders = map[string]format.RecordReader{
+	testRecordFormat:         testRecordReader{},
+	testRecordFormat + "_id": testFileIDRecordReader{},
+}
+
+// TestReadRecords tests that the records of structured files are read from the last emitted position
+func TestReadRecords(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Format = testRecordFormat
+
+	records := make(chan map[string]any, 10)
+	recordNumbers := make(chan int64, 10)
+	callback := func(_ context.Context, batch []map[string]any, _ map[string]any, lastRecordNumber int64) error {
+		for _, record := range batch {
+			records <- record
+		}
+		recordNumbers <- lastRecordNumber
+		return nil
+	}
+	operator, sink := testManager(t, cfg, WithRecordReaders(testRecordReaders), WithRecordCallback(callback))
+
+	temp := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp, "record1\nrecord2\nrecord3")
+
+	operator.poll(t.Context())
+	require.Equal(t, map[string]any{"line": "record1"}, <-records)
+	require.Equal(t, map[string]any{"line": "record2"}, <-records)
+	require.Equal(t, int64(1), <-recordNumbers)
+	require.Equal(t, int64(2), <-recordNumbers)
+
+	filetest.WriteString(t, temp, "\nrecord4\n")
+	operator.poll(t.Context())
+	require.Equal(t, map[string]any{"line": "record3"}, <-records)
+	require.Equal(t, map[string]any{"line": "record4"}, <-records)
+	require.Equal(t, int64(3), <-recordNumbers)
+	require.Equal(t, int64(4), <-recordNumbers)
+
+	operator.poll(t.Context())
+	require.Empty(t, records)
+	sink.ExpectNoCalls(t)
+}
+
+func TestRecordFormatRequiresCallback(t *testing.T) {
+	cfg := NewConfig().includeDir(t.TempDir())
+	cfg.Format = testRecordFormat
+	_, err := cfg.Build(componenttest.NewNopTelemetrySettings(), emittest.Nop, WithRecordReaders(testRecordReaders))
+	require.ErrorContains(t, err, "requires a record callback")
+
+	// The format is unknown without its record reader
+	_, err = cfg.Build(componenttest.NewNopTelemetrySettings(), emittest.Nop)
+	require.ErrorContains(t, err, "invalid 'format'")
+}
+
+func TestRecordFormatRequiresFileID(t *testing.T) {
+	callback := func(context.Context, []map[string]any, map[string]any, int64) error { return nil }
+	cfg := NewConfig().includeDir(t.TempDir())
+	cfg.Format = testRecordFormat + "_id"
+	_, err := cfg.Build(componenttest.NewNopTelemetrySettings(), emittest.Nop, WithRecordReaders(testRecordReaders), WithRecordCallback(callback))
+	require.ErrorContains(t, err, "requires 'file_identity: file_id'")
+
+	cfg.FileIdentity = fingerprint.StrategyFileID
+	_, err = cfg.Build(componenttest.NewNopTelemetrySettings(), emittest.Nop, WithRecordReaders(testRecordReaders), WithRecordCallback(callback))
+	require.NoError(t, err)
+}
+
 func TestArchive(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Time sensitive tests disabled for now on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/32715#issuecomment-2107737828")
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package format defines the formats of the files read by the file consumer.
+// Text files are split into tokens, while the records of structured files,
+// such as columnar files or object container files, are read by the RecordReader
+// of their format, provided by the component building the file consumer.
+package format // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
+
+import (
+	"context"
+	"os"
+)
+
+const (
+	// Text files are split into tokens. This is the default format.
+	Text = "text"
+	// NDJSON files are text files holding one JSON object per line. Each object is decoded into a map.
+	NDJSON = "ndjson"
+)
+
+// EmitFunc is called with a batch of records, and the position following the batch in the file.
+// The position is saved in the checkpoints once the records are emitted. The batch may be empty
+// to only move the position, e.g. past a part of the file which holds no records.
+type EmitFunc func(ctx context.Context, records []map[string]any, next int64) error
+
+// RecordReader reads the records of a structured file
+type RecordReader interface {
+	// Read reads the records of the file from the given position and calls emit with each batch of records.
+	// The position is specific to the format, e.g. the index of a row group or the offset of a block,
+	// and is zero for files which have not been read yet. Read must return without error when the end
+	// of the file is incomplete, e.g. when the file is still being written, so that it is read again
+	// from the last emitted position. Reading stops at the first error returned by emit.
+	Read(ctx context.Context, file *os.File, position int64, emit EmitFunc) error
+}
+
+// FileIDRequirer is implemented by the record readers of formats whose files can't be told apart by
+// their first bytes, e.g. as they start with a header which is the same for all the files of a producer.
+// The files of such formats must be identified with 'file_identity: file_id'.
+type FileIDRequirer interface {
+	RequiresFileID() bool
+}
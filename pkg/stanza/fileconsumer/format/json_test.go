@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSONRecord(t *testing.T) {
+	record, err := DecodeJSONRecord([]byte(`{"int":3,"float":0.5,"big":1e20,"list":["a",1],"map":{"ok":true,"null":null}}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"int":   int64(3),
+		"float": 0.5,
+		"big":   1e20,
+		"list":  []any{"a", int64(1)},
+		"map":   map[string]any{"ok": true, "null": nil},
+	}, record)
+
+	_, err = DecodeJSONRecord([]byte(`not json`))
+	require.Error(t, err)
+
+	_, err = DecodeJSONRecord([]byte(`["not", "an", "object"]`))
+	require.Error(t, err)
+
+	_, err = DecodeJSONRecord([]byte(`{"a":1} {"b":2}`))
+	require.Error(t, err)
+}
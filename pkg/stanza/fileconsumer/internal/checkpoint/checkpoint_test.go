@@ -142,6 +142,52 @@ func TestMigrateHeaderAttributes(t *testing.T) {
 	}, reloaded)
 }
 
+func TestFileIDRoundTrip(t *testing.T) {
+	p := testutil.NewUnscopedMockPersister()
+	id := &fingerprint.FileID{Device: 1, Inode: 2, CreationTime: 3}
+	require.NoError(t, Save(t.Context(), p, []*reader.Metadata{
+		{
+			Fingerprint:    fingerprint.New([]byte("foo")).WithFileID(fingerprint.StrategyHybrid, id),
+			Offset:         3,
+			FileAttributes: map[string]any{},
+		},
+		{
+			Fingerprint:    fingerprint.New([]byte("bar")),
+			Offset:         5,
+			FileAttributes: map[string]any{},
+		},
+	}))
+
+	reloaded, err := Load(t.Context(), p)
+	require.NoError(t, err)
+	require.Len(t, reloaded, 2)
+	assert.Equal(t, id, reloaded[0].Fingerprint.FileID())
+	assert.True(t, fingerprint.New([]byte("foo")).Equal(reloaded[0].Fingerprint))
+	assert.Nil(t, reloaded[1].Fingerprint.FileID())
+	assert.True(t, fingerprint.New([]byte("bar")).Equal(reloaded[1].Fingerprint))
+}
+
+func TestLoadWithoutFileID(t *testing.T) {
+	// Checkpoint saved by a version without file identity strategies
+	p := testutil.NewUnscopedMockPersister()
+	require.NoError(t, p.Set(t.Context(), knownFilesKey, []byte(`1
+{"Fingerprint":{"first_bytes":"Zm9v"},"Offset":3,"RecordNum":0,"FileAttributes":{},"HeaderFinalized":false,"FlushState":{"LastDataChange":"0001-01-01T00:00:00Z","LastDataLength":0},"TokenLenState":{"MinimumLength":0},"FileType":""}
+`)))
+
+	reloaded, err := Load(t.Context(), p)
+	require.NoError(t, err)
+	require.Len(t, reloaded, 1)
+	assert.Nil(t, reloaded[0].Fingerprint.FileID())
+	assert.Equal(t, int64(3), reloaded[0].Offset)
+
+	// The fingerprint is matched by content, regardless of the identity strategy
+	id := &fingerprint.FileID{Device: 1, Inode: 2}
+	for _, strategy := range []string{fingerprint.StrategyFingerprint, fingerprint.StrategyFileID, fingerprint.StrategyHybrid} {
+		assert.True(t, fingerprint.New([]byte("foobar")).WithFileID(strategy, id).StartsWith(reloaded[0].Fingerprint))
+		assert.False(t, fingerprint.New([]byte("bar")).WithFileID(strategy, id).StartsWith(reloaded[0].Fingerprint))
+	}
+}
+
 func saveDeprecated(t *testing.T, persister operator.Persister, dep *deprecatedMetadata) {
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
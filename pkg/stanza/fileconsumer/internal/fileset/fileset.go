@@ -55,9 +55,16 @@ func (set *Fileset[T]) Add(readers ...T) {
 }
 
 func (set *Fileset[T]) Match(fp *fingerprint.Fingerprint, cmp func(a, b *fingerprint.Fingerprint) bool) T {
+	return set.MatchFunc(func(r T) bool {
+		return cmp(fp, r.GetFingerprint())
+	})
+}
+
+// MatchFunc removes and returns the first item accepted by the match function
+func (set *Fileset[T]) MatchFunc(match func(T) bool) T {
 	var val T
 	for idx, r := range set.readers {
-		if cmp(fp, r.GetFingerprint()) {
+		if match(r) {
 			set.readers = append(set.readers[:idx], set.readers[idx+1:]...)
 			return r
 		}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+// FileID identifies a file on its filesystem, independently of its path and content
+type FileID struct {
+	Device uint64 `json:"device"`
+	Inode  uint64 `json:"inode"`
+	// CreationTime is the creation time of the file in nanoseconds since the epoch,
+	// or zero if the filesystem does not record it
+	CreationTime int64 `json:"creation_time,omitempty"`
+}
+
+// Equal returns true if the IDs refer to the same file. The creation times are only
+// compared when both are known, in which case they detect reused inodes.
+func (id *FileID) Equal(other *FileID) bool {
+	if id.Device != other.Device || id.Inode != other.Inode {
+		return false
+	}
+	if id.CreationTime == 0 || other.CreationTime == 0 {
+		return true
+	}
+	return id.CreationTime == other.CreationTime
+}
@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix && !windows
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"errors"
+	"os"
+)
+
+func NewFileID(*os.File) (*FileID, error) {
+	return nil, errors.New("file IDs are not supported on this platform")
+}
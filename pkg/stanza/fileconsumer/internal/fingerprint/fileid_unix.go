@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// NewFileID returns the device, inode and creation time of the file
+func NewFileID(file *os.File) (*FileID, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil, fmt.Errorf("unexpected stat type %T", info.Sys())
+	}
+	return &FileID{
+		Device:       uint64(stat.Dev), //nolint:unconvert // the type of Dev depends on the platform
+		Inode:        stat.Ino,
+		CreationTime: creationTime(file),
+	}, nil
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"os"
+	"syscall"
+)
+
+// NewFileID returns the volume serial number, file index and creation time of the file
+func NewFileID(file *os.File) (*FileID, error) {
+	var info syscall.ByHandleFileInformation
+	if err := syscall.GetFileInformationByHandle(syscall.Handle(file.Fd()), &info); err != nil {
+		return nil, err
+	}
+	return &FileID{
+		Device:       uint64(info.VolumeSerialNumber),
+		Inode:        uint64(info.FileIndexHigh)<<32 | uint64(info.FileIndexLow),
+		CreationTime: info.CreationTime.Nanoseconds(),
+	}, nil
+}
@@ -4,12 +4,14 @@
 package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 
@@ -28,19 +30,75 @@ var DecompressedFingerprintFeatureGate = featuregate.GlobalRegistry().MustRegist
 	featuregate.WithRegisterReferenceURL("https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/40256"),
 )
 
+const (
+	// StrategyFingerprint identifies files by the first bytes of their content
+	StrategyFingerprint = "fingerprint"
+	// StrategyFileID identifies files by their device and inode, and their creation time where available
+	StrategyFileID = "file_id"
+	// StrategyHybrid identifies files by both their file ID and the first bytes of their content
+	StrategyHybrid = "hybrid"
+)
+
 // Fingerprint is used to identify a file
-// A file's fingerprint is the first N bytes of the file
+// A file's fingerprint is the first N bytes of the file,
+// and the ID of the file when the identity strategy relies on it
 type Fingerprint struct {
 	firstBytes []byte
+	fileID     *FileID
+	strategy   string
 }
 
 func New(first []byte) *Fingerprint {
 	return &Fingerprint{firstBytes: first}
 }
 
+// Option configures how a fingerprint is computed from a file
+type Option func(*options)
+
+type options struct {
+	strategy  string
+	skipLines int
+}
+
+// WithStrategy sets the identity strategy of the fingerprint.
+// The ID of the file is recorded unless the strategy is StrategyFingerprint.
+func WithStrategy(strategy string) Option {
+	return func(o *options) {
+		o.strategy = strategy
+	}
+}
+
+// WithSkipLines excludes the first lines of the file from the fingerprint bytes.
+// This allows to distinguish files which all start with the same header.
+func WithSkipLines(skipLines int) Option {
+	return func(o *options) {
+		o.skipLines = skipLines
+	}
+}
+
 // NewFromFile computes fingerprint of the given file using first 'N' bytes
 // Set decompressData to true to compute fingerprint of compressed files by decompressing its data first
-func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, error) {
+func NewFromFile(file *os.File, size int, decompressData bool, opts ...Option) (*Fingerprint, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	firstBytes, err := readFirstBytes(file, size, decompressData, o.skipLines)
+	if err != nil {
+		return nil, err
+	}
+	fp := New(firstBytes)
+	if usesFileID(o.strategy) {
+		fp.strategy = o.strategy
+		if fp.fileID, err = NewFileID(file); err != nil {
+			return nil, fmt.Errorf("reading file id: %w", err)
+		}
+	}
+	return fp, nil
+}
+
+func readFirstBytes(file *os.File, size int, decompressData bool, skipLines int) ([]byte, error) {
 	buf := make([]byte, size)
 	if DecompressedFingerprintFeatureGate.IsEnabled() {
 		if decompressData {
@@ -52,20 +110,55 @@ func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, er
 				}
 				defer uncompressedData.Close()
 
+				if skipLines > 0 {
+					return readAfterLines(uncompressedData, buf, skipLines)
+				}
 				n, err := uncompressedData.Read(buf)
 				if err != nil && !errors.Is(err, io.EOF) {
 					return nil, fmt.Errorf("error reading fingerprint bytes: %w", err)
 				}
-				return New(buf[:n]), nil
+				return buf[:n], nil
 			}
 		}
 	}
 
+	if skipLines > 0 {
+		return readAfterLines(io.NewSectionReader(file, 0, math.MaxInt64), buf, skipLines)
+	}
 	n, err := file.ReadAt(buf, 0)
 	if err != nil && !errors.Is(err, io.EOF) {
 		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
 	}
-	return New(buf[:n]), nil
+	return buf[:n], nil
+}
+
+// readAfterLines fills buf with the data following the first skipLines lines.
+// No data is returned until all the skipped lines are complete, so that the
+// fingerprint of a file does not change once its header has been written.
+func readAfterLines(r io.Reader, buf []byte, skipLines int) ([]byte, error) {
+	br := bufio.NewReader(r)
+	for skipped := 0; skipped < skipLines; {
+		_, err := br.ReadSlice('\n')
+		switch {
+		case err == nil:
+			skipped++
+		case errors.Is(err, bufio.ErrBufferFull):
+			// The line is longer than the buffer, keep reading it
+		case errors.Is(err, io.EOF):
+			return buf[:0], nil
+		default:
+			return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+		}
+	}
+	n, err := io.ReadFull(br, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	return buf[:n], nil
+}
+
+func usesFileID(strategy string) bool {
+	return strategy == StrategyFileID || strategy == StrategyHybrid
 }
 
 func hasGzipExtension(filename string) bool {
@@ -76,29 +169,52 @@ func hasGzipExtension(filename string) bool {
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.firstBytes), cap(f.firstBytes))
 	n := copy(buf, f.firstBytes)
-	return New(buf[:n])
+	cp := &Fingerprint{firstBytes: buf[:n], strategy: f.strategy}
+	if f.fileID != nil {
+		id := *f.fileID
+		cp.fileID = &id
+	}
+	return cp
+}
+
+// WithFileID returns a copy of the fingerprint identified with the given strategy and file ID.
+// This is used to attach a file ID to fingerprints that were recorded without one.
+func (f Fingerprint) WithFileID(strategy string, id *FileID) *Fingerprint {
+	cp := f.Copy()
+	cp.strategy = strategy
+	cp.fileID = id
+	return cp
+}
+
+// FileID returns the ID of the file, or nil if it was not recorded
+func (f *Fingerprint) FileID() *FileID {
+	return f.fileID
+}
+
+// Strategy returns the identity strategy of the fingerprint
+func (f *Fingerprint) Strategy() string {
+	if f.strategy == "" {
+		return StrategyFingerprint
+	}
+	return f.strategy
 }
 
 func (f *Fingerprint) Len() int {
 	return len(f.firstBytes)
 }
 
-// Equal returns true if the fingerprints have the same FirstBytes,
-// false otherwise. This does not compare other aspects of the fingerprints
-// because the primary purpose of a fingerprint is to convey a unique
-// identity, and only the FirstBytes field contributes to this goal.
+// Equal returns true if the fingerprints identify the same file in the same state.
+// This is used to exclude duplicate files within a single poll.
+// With the fingerprint and hybrid strategies, only the FirstBytes are compared,
+// because the primary purpose of a fingerprint is to convey a unique identity,
+// and because the copy of a file which is being rotated with copy/truncate
+// must not be read before the original is truncated. With the file_id strategy,
+// only the file IDs are compared. The strategy of f is used.
 func (f Fingerprint) Equal(other *Fingerprint) bool {
-	l0 := len(other.firstBytes)
-	l1 := len(f.firstBytes)
-	if l0 != l1 {
-		return false
-	}
-	for i := 0; i < l0; i++ {
-		if other.firstBytes[i] != f.firstBytes[i] {
-			return false
-		}
+	if f.strategy == StrategyFileID && f.bothHaveFileID(other) {
+		return f.fileID.Equal(other.fileID)
 	}
-	return true
+	return bytes.Equal(other.firstBytes, f.firstBytes)
 }
 
 // StartsWith returns true if the fingerprints are the same
@@ -107,7 +223,26 @@ func (f Fingerprint) Equal(other *Fingerprint) bool {
 // since their initial size is typically less than that of
 // a fingerprint. As the file grows, its fingerprint is updated
 // until it reaches a maximum size, as configured on the operator
+// With the file_id strategy, only the file IDs are compared, and with the
+// hybrid strategy both must match. The strategy of f is used, and old
+// fingerprints which were recorded without a file ID are compared by content.
 func (f Fingerprint) StartsWith(old *Fingerprint) bool {
+	if f.bothHaveFileID(old) {
+		switch f.strategy {
+		case StrategyFileID:
+			return f.fileID.Equal(old.fileID)
+		case StrategyHybrid:
+			if !f.fileID.Equal(old.fileID) {
+				return false
+			}
+		}
+	}
+	return f.ContentStartsWith(old)
+}
+
+// ContentStartsWith returns true if the first bytes of the new fingerprint
+// start with those of the old one, regardless of the file IDs
+func (f Fingerprint) ContentStartsWith(old *Fingerprint) bool {
 	l0 := len(old.firstBytes)
 	if l0 == 0 {
 		return false
@@ -119,8 +254,12 @@ func (f Fingerprint) StartsWith(old *Fingerprint) bool {
 	return bytes.Equal(old.firstBytes[:l0], f.firstBytes[:l0])
 }
 
+func (f Fingerprint) bothHaveFileID(other *Fingerprint) bool {
+	return f.fileID != nil && other.fileID != nil
+}
+
 func (f *Fingerprint) MarshalJSON() ([]byte, error) {
-	m := marshal{FirstBytes: f.firstBytes}
+	m := marshal{FirstBytes: f.firstBytes, FileID: f.fileID}
 	return json.Marshal(&m)
 }
 
@@ -130,9 +269,12 @@ func (f *Fingerprint) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	f.firstBytes = m.FirstBytes
+	f.fileID = m.FileID
 	return nil
 }
 
 type marshal struct {
 	FirstBytes []byte `json:"first_bytes"`
+	// FileID is omitted for fingerprints without file ID, so that checkpoints remain readable by older versions
+	FileID *FileID `json:"file_id,omitempty"`
 }
@@ -10,6 +10,7 @@ import (
 	"io"
 	"math/rand/v2"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -313,6 +314,123 @@ func TestMarshalUnmarshal(t *testing.T) {
 	require.Equal(t, fp, fp2)
 }
 
+func TestMarshalUnmarshalFileID(t *testing.T) {
+	fp := New([]byte("hello")).WithFileID(StrategyHybrid, &FileID{Device: 1, Inode: 2, CreationTime: 3})
+	b, err := fp.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"first_bytes":"aGVsbG8=","file_id":{"device":1,"inode":2,"creation_time":3}}`, string(b))
+
+	fp2 := new(Fingerprint)
+	require.NoError(t, fp2.UnmarshalJSON(b))
+	require.Equal(t, fp.firstBytes, fp2.firstBytes)
+	require.Equal(t, fp.fileID, fp2.fileID)
+
+	// Fingerprints without file ID are encoded as before
+	b, err = New([]byte("hello")).MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"first_bytes":"aGVsbG8="}`, string(b))
+}
+
+func TestNewFromFileSkipLines(t *testing.T) {
+	cases := []struct {
+		name      string
+		contents  string
+		size      int
+		skipLines int
+		expected  []byte
+	}{
+		{"NoSkip", "header\nline1\n", 10, 0, []byte("header\nlin")},
+		{"SkipOne", "header\nline1\n", 10, 1, []byte("line1\n")},
+		{"SkipTwo", "header\nline1\nline2\nline3\n", 8, 2, []byte("line2\nli")},
+		{"IncompleteHeader", "header", 10, 1, []byte{}},
+		{"OnlyHeader", "header\n", 10, 1, []byte{}},
+		{"LongHeader", strings.Repeat("h", 10000) + "\nline1\n", 10, 1, []byte("line1\n")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			temp := filetest.OpenTemp(t, t.TempDir())
+			filetest.WriteString(t, temp, tc.contents)
+
+			fp, err := NewFromFile(temp, tc.size, false, WithSkipLines(tc.skipLines))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, fp.firstBytes)
+		})
+	}
+}
+
+func TestNewFromFileWithFileID(t *testing.T) {
+	tempDir := t.TempDir()
+	temp1 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp1, "same content\n")
+	temp2 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp2, "same content\n")
+
+	fp, err := NewFromFile(temp1, DefaultSize, false)
+	require.NoError(t, err)
+	require.Nil(t, fp.FileID())
+	require.Equal(t, StrategyFingerprint, fp.Strategy())
+
+	for _, strategy := range []string{StrategyFileID, StrategyHybrid} {
+		fp1, err := NewFromFile(temp1, DefaultSize, false, WithStrategy(strategy))
+		require.NoError(t, err)
+		require.NotNil(t, fp1.FileID())
+		require.Equal(t, strategy, fp1.Strategy())
+
+		reopened := filetest.OpenFile(t, temp1.Name())
+		fp1Reopened, err := NewFromFile(reopened, DefaultSize, false, WithStrategy(strategy))
+		require.NoError(t, err)
+		require.Equal(t, fp1.FileID(), fp1Reopened.FileID())
+		require.True(t, fp1Reopened.StartsWith(fp1))
+
+		fp2, err := NewFromFile(temp2, DefaultSize, false, WithStrategy(strategy))
+		require.NoError(t, err)
+		require.NotEqual(t, fp1.FileID(), fp2.FileID())
+		require.False(t, fp2.StartsWith(fp1))
+	}
+}
+
+func TestFileIDStrategies(t *testing.T) {
+	id1 := &FileID{Device: 1, Inode: 1}
+	id2 := &FileID{Device: 1, Inode: 2}
+
+	cases := []struct {
+		name       string
+		strategy   string
+		new        *Fingerprint
+		old        *Fingerprint
+		equal      bool
+		startsWith bool
+	}{
+		{"FingerprintIgnoresFileID", StrategyFingerprint, New([]byte("abc")).WithFileID("", id1), New([]byte("abc")).WithFileID("", id2), true, true},
+		{"FileIDSameFile", StrategyFileID, New([]byte("abcdef")), New([]byte("abc")).WithFileID("", id1), true, true},
+		{"FileIDTruncated", StrategyFileID, New([]byte("xyz")), New([]byte("abc")).WithFileID("", id1), true, true},
+		{"FileIDOtherFile", StrategyFileID, New([]byte("abc")), New([]byte("abc")).WithFileID("", id2), false, false},
+		{"FileIDNotRecorded", StrategyFileID, New([]byte("abcdef")), New([]byte("abc")), false, true},
+		{"HybridSameFile", StrategyHybrid, New([]byte("abcdef")), New([]byte("abc")).WithFileID("", id1), false, true},
+		{"HybridTruncated", StrategyHybrid, New([]byte("xyz")), New([]byte("abc")).WithFileID("", id1), false, false},
+		{"HybridOtherFile", StrategyHybrid, New([]byte("abc")), New([]byte("abc")).WithFileID("", id2), true, false},
+		{"HybridNotRecorded", StrategyHybrid, New([]byte("abcdef")), New([]byte("abc")), false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fp := tc.new.WithFileID(tc.strategy, id1)
+			require.Equal(t, tc.equal, fp.Equal(tc.old))
+			require.Equal(t, tc.startsWith, fp.StartsWith(tc.old))
+		})
+	}
+}
+
+func TestFileIDEqual(t *testing.T) {
+	id := &FileID{Device: 1, Inode: 2, CreationTime: 3}
+	require.True(t, id.Equal(&FileID{Device: 1, Inode: 2, CreationTime: 3}))
+	require.True(t, id.Equal(&FileID{Device: 1, Inode: 2}))
+	require.False(t, id.Equal(&FileID{Device: 1, Inode: 2, CreationTime: 4}))
+	require.False(t, id.Equal(&FileID{Device: 2, Inode: 2, CreationTime: 3}))
+	require.False(t, id.Equal(&FileID{Device: 1, Inode: 3, CreationTime: 3}))
+}
+
 // Test compressed and uncompressed file with same content have equal fingerprint
 func TestCompressionFingerprint(t *testing.T) {
 	tmp := t.TempDir()
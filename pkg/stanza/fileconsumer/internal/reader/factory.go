@@ -18,6 +18,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/flush"
@@ -36,6 +37,8 @@ type Factory struct {
 	HeaderConfig            *header.Config
 	FromBeginning           bool
 	FingerprintSize         int
+	FingerprintSkipLines    int
+	IdentityStrategy        string
 	BufPool                 sync.Pool
 	InitialBufferSize       int
 	MaxLogSize              int
@@ -44,6 +47,8 @@ type Factory struct {
 	TrimFunc                trim.Func
 	FlushTimeout            time.Duration
 	EmitFunc                emit.Callback
+	RecordReader            format.RecordReader
+	RecordFunc              emit.RecordCallback
 	Attributes              attrs.Resolver
 	DeleteAtEOF             bool
 	IncludeFileRecordNumber bool
@@ -53,7 +58,8 @@ type Factory struct {
 }
 
 func (f *Factory) NewFingerprint(file *os.File) (*fingerprint.Fingerprint, error) {
-	return fingerprint.NewFromFile(file, f.FingerprintSize, f.Compression != "")
+	return fingerprint.NewFromFile(file, f.FingerprintSize, f.Compression != "",
+		fingerprint.WithStrategy(f.IdentityStrategy), fingerprint.WithSkipLines(f.FingerprintSkipLines))
 }
 
 func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader, error) {
@@ -62,8 +68,9 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 		return nil, err
 	}
 	var filetype string
-	if filepath.Ext(file.Name()) == gzipExtension {
-		filetype = gzipExtension
+	switch ext := filepath.Ext(file.Name()); ext {
+	case gzipExtension, zstdExtension, bzip2Extension:
+		filetype = ext
 	}
 
 	m := &Metadata{
@@ -80,26 +87,30 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 
 func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader, err error) {
 	r = &Reader{
-		Metadata:          m,
-		set:               f.TelemetrySettings,
-		file:              file,
-		fileName:          file.Name(),
-		fingerprintSize:   f.FingerprintSize,
-		bufPool:           &f.BufPool,
-		initialBufferSize: f.InitialBufferSize,
-		maxLogSize:        f.MaxLogSize,
-		decoder:           f.Encoding.NewDecoder(),
-		deleteAtEOF:       f.DeleteAtEOF,
-		compression:       f.Compression,
-		acquireFSLock:     f.AcquireFSLock,
-		maxBatchSize:      DefaultMaxBatchSize,
-		emitFunc:          f.EmitFunc,
+		Metadata:             m,
+		set:                  f.TelemetrySettings,
+		file:                 file,
+		fileName:             file.Name(),
+		fingerprintSize:      f.FingerprintSize,
+		fingerprintSkipLines: f.FingerprintSkipLines,
+		identityStrategy:     f.IdentityStrategy,
+		bufPool:              &f.BufPool,
+		initialBufferSize:    f.InitialBufferSize,
+		maxLogSize:           f.MaxLogSize,
+		decoder:              f.Encoding.NewDecoder(),
+		deleteAtEOF:          f.DeleteAtEOF,
+		compression:          f.Compression,
+		acquireFSLock:        f.AcquireFSLock,
+		maxBatchSize:         DefaultMaxBatchSize,
+		emitFunc:             f.EmitFunc,
+		recordReader:         f.RecordReader,
+		recordFunc:           f.RecordFunc,
 	}
 	r.set.Logger = r.set.Logger.With(zap.String("path", r.fileName))
 
 	if r.Fingerprint.Len() > r.fingerprintSize {
 		// User has reconfigured fingerprint_size
-		shorter, rereadErr := fingerprint.NewFromFile(file, r.fingerprintSize, r.compression != "")
+		shorter, rereadErr := r.newFingerprint()
 		if rereadErr != nil {
 			return nil, fmt.Errorf("reread fingerprint: %w", rereadErr)
 		}
@@ -109,6 +120,17 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		m.Fingerprint = shorter
 	}
 
+	if f.IdentityStrategy == fingerprint.StrategyFileID || f.IdentityStrategy == fingerprint.StrategyHybrid {
+		// Files recorded without file ID, e.g. in checkpoints of previous versions or with another
+		// strategy, and files that were copied before being truncated, are identified by the ID of
+		// the file which is now read.
+		id, idErr := fingerprint.NewFileID(file)
+		if idErr != nil {
+			return nil, fmt.Errorf("file id: %w", idErr)
+		}
+		m.Fingerprint = m.Fingerprint.WithFileID(f.IdentityStrategy, id)
+	}
+
 	if !f.FromBeginning {
 		var info os.FileInfo
 		if info, err = r.file.Stat(); err != nil {
@@ -5,6 +5,7 @@ package reader // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
+	"compress/bzip2"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -12,12 +13,14 @@ import (
 	"os"
 	"sync"
 
+	"github.com/klauspost/compress/zstd"
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 	"golang.org/x/text/encoding"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
@@ -25,7 +28,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/tokenlen"
 )
 
-const gzipExtension = ".gz"
+const (
+	gzipExtension  = ".gz"
+	zstdExtension  = ".zst"
+	bzip2Extension = ".bz2"
+)
 
 type Metadata struct {
 	Fingerprint     *fingerprint.Fingerprint
@@ -46,6 +53,8 @@ type Reader struct {
 	file                   *os.File
 	reader                 io.Reader
 	fingerprintSize        int
+	fingerprintSkipLines   int
+	identityStrategy       string
 	bufPool                *sync.Pool
 	initialBufferSize      int
 	maxLogSize             int
@@ -54,6 +63,8 @@ type Reader struct {
 	decoder                *encoding.Decoder
 	headerReader           *header.Reader
 	emitFunc               emit.Callback
+	recordReader           format.RecordReader
+	recordFunc             emit.RecordCallback
 	deleteAtEOF            bool
 	needsUpdateFingerprint bool
 	compression            string
@@ -70,33 +81,31 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		defer r.unlockFile()
 	}
 
-	switch r.compression {
-	case "gzip":
-		currentEOF, err := r.createGzipReader()
+	if r.recordReader != nil {
+		r.readRecords(ctx)
+		return
+	}
+
+	if r.identityStrategy == fingerprint.StrategyFileID {
+		r.resetIfTruncated()
+	}
+
+	if compression := r.compressionFormat(); compression != "" {
+		currentEOF, err := r.createDecompressionReader(compression)
 		if err != nil {
 			return
 		}
 		// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
 		// we need to set the offset to the end of the file.
 		defer func() {
+			if closer, ok := r.reader.(io.Closer); ok {
+				if err := closer.Close(); err != nil {
+					r.set.Logger.Debug("problem closing decompression reader", zap.Error(err))
+				}
+			}
 			r.Offset = currentEOF
 		}()
-	case "auto":
-		// Identifying a filename by its extension may not always be correct. We could have a compressed file without the .gz extension
-		if r.FileType == gzipExtension {
-			currentEOF, err := r.createGzipReader()
-			if err != nil {
-				return
-			}
-			// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
-			// we need to set the offset to the end of the file.
-			defer func() {
-				r.Offset = currentEOF
-			}()
-		} else {
-			r.reader = r.file
-		}
-	default:
+	} else {
 		r.reader = r.file
 	}
 
@@ -120,9 +129,28 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	r.readContents(ctx)
 }
 
-// createGzipReader creates gzip reader and returns the file offset
-func (r *Reader) createGzipReader() (int64, error) {
-	// We need to create a gzip reader each time ReadToEnd is called because the underlying
+// compressionFormat returns the compression format of the file, or an empty string if it is not compressed
+func (r *Reader) compressionFormat() string {
+	switch r.compression {
+	case "gzip", "zstd", "bzip2":
+		return r.compression
+	case "auto":
+		// Identifying a filename by its extension may not always be correct. We could have a compressed file without the extension
+		switch r.FileType {
+		case gzipExtension:
+			return "gzip"
+		case zstdExtension:
+			return "zstd"
+		case bzip2Extension:
+			return "bzip2"
+		}
+	}
+	return ""
+}
+
+// createDecompressionReader creates a reader of the compressed file and returns the file offset
+func (r *Reader) createDecompressionReader(compression string) (int64, error) {
+	// We need to create a decompression reader each time ReadToEnd is called because the underlying
 	// SectionReader can only read a fixed window (from previous offset to EOF).
 	info, err := r.file.Stat()
 	if err != nil {
@@ -130,19 +158,70 @@ func (r *Reader) createGzipReader() (int64, error) {
 		return 0, err
 	}
 	currentEOF := info.Size()
-	// use a gzip Reader with an underlying SectionReader to pick up at the last
-	// offset of a gzip compressed file
-	gzipReader, err := gzip.NewReader(io.NewSectionReader(r.file, r.Offset, currentEOF))
-	if err != nil {
-		if !errors.Is(err, io.EOF) {
-			r.set.Logger.Error("failed to create gzip reader", zap.Error(err))
+	// use a decompression Reader with an underlying SectionReader to pick up at the last
+	// offset of a compressed file. This works for files made of concatenated streams or frames.
+	section := io.NewSectionReader(r.file, r.Offset, currentEOF)
+	switch compression {
+	case "zstd":
+		zstdReader, zstdErr := zstd.NewReader(section, zstd.WithDecoderConcurrency(1))
+		if zstdErr != nil {
+			r.set.Logger.Error("failed to create zstd reader", zap.Error(zstdErr))
+			return 0, zstdErr
 		}
-		return 0, err
+		r.reader = zstdReader.IOReadCloser()
+	case "bzip2":
+		r.reader = bzip2.NewReader(section)
+	default:
+		gzipReader, gzipErr := gzip.NewReader(section)
+		if gzipErr != nil {
+			if !errors.Is(gzipErr, io.EOF) {
+				r.set.Logger.Error("failed to create gzip reader", zap.Error(gzipErr))
+			}
+			return 0, gzipErr
+		}
+		r.reader = gzipReader
 	}
-	r.reader = gzipReader
 	return currentEOF, nil
 }
 
+// readRecords reads the records of a structured file. The offset holds the position of the
+// next records in the file, as defined by the format of the file.
+func (r *Reader) readRecords(ctx context.Context) {
+	defer func() {
+		if r.needsUpdateFingerprint {
+			r.updateFingerprint()
+		}
+	}()
+
+	err := r.recordReader.Read(ctx, r.file, r.Offset, func(ctx context.Context, records []map[string]any, next int64) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			r.RecordNum += int64(len(records))
+			if err := r.recordFunc(ctx, records, r.FileAttributes, r.RecordNum); err != nil {
+				r.set.Logger.Error("failed to emit records", zap.Error(err))
+			}
+		}
+		r.Offset = next
+		if r.Fingerprint.Len() < r.fingerprintSize {
+			r.needsUpdateFingerprint = true
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			r.set.Logger.Error("failed to read records", zap.Error(err))
+		}
+		return
+	}
+
+	r.set.Logger.Debug("end of file reached", zap.Bool("delete_at_eof", r.deleteAtEOF))
+	if r.deleteAtEOF {
+		r.delete()
+	}
+}
+
 func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
 	bufPtr := r.getBufPtrFromPool()
 	defer r.bufPool.Put(bufPtr)
@@ -324,7 +403,7 @@ func (r *Reader) Validate() bool {
 	if r.file == nil {
 		return false
 	}
-	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "")
+	refreshedFingerprint, err := r.newFingerprint()
 	if err != nil {
 		return false
 	}
@@ -347,7 +426,7 @@ func (r *Reader) updateFingerprint() {
 	if r.file == nil {
 		return
 	}
-	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "")
+	refreshedFingerprint, err := r.newFingerprint()
 	if err != nil {
 		return
 	}
@@ -357,6 +436,25 @@ func (r *Reader) updateFingerprint() {
 	r.Fingerprint = refreshedFingerprint
 }
 
+func (r *Reader) newFingerprint() (*fingerprint.Fingerprint, error) {
+	return fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "",
+		fingerprint.WithStrategy(r.identityStrategy), fingerprint.WithSkipLines(r.fingerprintSkipLines))
+}
+
+// resetIfTruncated starts reading the file from the beginning again if it was truncated in place.
+// This is only needed when files are identified by their file ID, since a truncated file
+// would otherwise no longer match its fingerprint and be read as a new file.
+func (r *Reader) resetIfTruncated() {
+	info, err := r.file.Stat()
+	if err != nil || info.Size() >= r.Offset {
+		return
+	}
+	r.set.Logger.Info("File was truncated, reading from the beginning", zap.Int64("offset", r.Offset), zap.Int64("size", info.Size()))
+	r.Offset = 0
+	r.RecordNum = 0
+	r.needsUpdateFingerprint = true
+}
+
 func (r *Reader) getBufPtrFromPool() *[]byte {
 	bufP := r.bufPool.Get()
 	if bufP == nil {
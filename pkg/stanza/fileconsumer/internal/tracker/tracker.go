@@ -89,7 +89,15 @@ func (t *fileTracker) GetCurrentFile(fp *fingerprint.Fingerprint) *reader.Reader
 }
 
 func (t *fileTracker) GetOpenFile(fp *fingerprint.Fingerprint) *reader.Reader {
-	return t.previousPollFiles.Match(fp, fileset.StartsWith)
+	if r := t.previousPollFiles.Match(fp, fileset.StartsWith); r != nil || fp.Strategy() != fingerprint.StrategyHybrid {
+		return r
+	}
+	// A file which was copied before being truncated in place has another file ID than the file
+	// that was read, but the same content. The content is only matched when the file that was
+	// read no longer holds it, so that distinct files with identical content are never mixed up.
+	return t.previousPollFiles.MatchFunc(func(r *reader.Reader) bool {
+		return fp.ContentStartsWith(r.Fingerprint) && !r.Validate()
+	})
 }
 
 func (t *fileTracker) GetClosedFile(fp *fingerprint.Fingerprint) *reader.Metadata {
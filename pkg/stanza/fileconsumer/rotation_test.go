@@ -18,6 +18,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
@@ -453,6 +454,112 @@ func TestCopyTruncateWriteBoth(t *testing.T) {
 	sink.ExpectTokens(t, []byte("testlog3"), []byte("testlog4"))
 }
 
+// CopyTruncateWriteBothHybrid tests that the hybrid file identity resumes
+// reading the copy of a file that was truncated in place, although the
+// copy has another file ID than the file that was read
+func TestCopyTruncateWriteBothHybrid(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.FileIdentity = fingerprint.StrategyHybrid
+	operator, sink := testManager(t, cfg)
+	operator.persister = testutil.NewUnscopedMockPersister()
+
+	temp1 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp1, "testlog1\ntestlog2\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+	operator.wg.Wait() // wait for all goroutines to finish
+
+	// Copy the first file to a new file, and add another log
+	temp2 := filetest.OpenTemp(t, tempDir)
+	_, err := io.Copy(temp2, temp1)
+	require.NoError(t, err)
+
+	// Truncate original file
+	require.NoError(t, temp1.Truncate(0))
+	_, err = temp1.Seek(0, 0)
+	require.NoError(t, err)
+
+	// Write to original and new file
+	filetest.WriteString(t, temp2, "testlog3\n")
+	filetest.WriteString(t, temp1, "testlog4\n")
+
+	// Expect both messages to come through, without reading the copy again
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("testlog3"), []byte("testlog4"))
+	sink.ExpectNoCalls(t)
+}
+
+// TruncateThenWriteFileID tests that a file identified by its file ID
+// is read from the beginning again after it was truncated in place
+func TestTruncateThenWriteFileID(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.FileIdentity = fingerprint.StrategyFileID
+	operator, sink := testManager(t, cfg)
+	operator.persister = testutil.NewUnscopedMockPersister()
+
+	temp1 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp1, "testlog1\ntestlog2\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+	operator.wg.Wait() // wait for all goroutines to finish
+
+	require.NoError(t, temp1.Truncate(0))
+	_, err := temp1.Seek(0, 0)
+	require.NoError(t, err)
+
+	filetest.WriteString(t, temp1, "testlog3\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog3"))
+	sink.ExpectNoCalls(t)
+}
+
+// IdenticalContentFileIdentity tests that files whose content starts with the
+// content of another file are not mistaken for it when file IDs are used
+func TestIdenticalContentFileIdentity(t *testing.T) {
+	for _, strategy := range []string{fingerprint.StrategyFileID, fingerprint.StrategyHybrid} {
+		t.Run(strategy, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.FileIdentity = strategy
+			operator, sink := testManager(t, cfg)
+			operator.persister = testutil.NewUnscopedMockPersister()
+
+			temp1 := filetest.OpenTemp(t, tempDir)
+			filetest.WriteString(t, temp1, "header\nline1\n")
+
+			operator.poll(t.Context())
+			sink.ExpectTokens(t, []byte("header"), []byte("line1"))
+			operator.wg.Wait() // wait for all goroutines to finish
+
+			temp2 := filetest.OpenTemp(t, tempDir)
+			filetest.WriteString(t, temp2, "header\nline1\nline2\n")
+
+			operator.poll(t.Context())
+			sink.ExpectTokens(t, []byte("header"), []byte("line1"), []byte("line2"))
+			sink.ExpectNoCalls(t)
+		})
+	}
+}
+
 func TestFileMovedWhileOff_BigFiles(t *testing.T) {
 	if runtime.GOOS == windowsOS {
 		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
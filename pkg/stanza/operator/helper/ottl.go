@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+)
+
+// OTTLCondition is an OTTL condition evaluated against entries using the OTTL log context
+type OTTLCondition struct {
+	condition *ottl.Condition[ottllog.TransformContext]
+}
+
+// NewOTTLCondition parses an OTTL condition. Only the standard OTTL converters can be used in the condition.
+func NewOTTLCondition(condition string, set component.TelemetrySettings) (*OTTLCondition, error) {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parser.ParseCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	return &OTTLCondition{condition: parsed}, nil
+}
+
+// Match returns whether the entry matches the condition
+func (c *OTTLCondition) Match(ctx context.Context, e *entry.Entry) (bool, error) {
+	return c.condition.Eval(ctx, newOTTLTransformContext(e))
+}
+
+// OTTLStatements are OTTL statements executed against entries using the OTTL log context
+type OTTLStatements struct {
+	statements ottl.StatementSequence[ottllog.TransformContext]
+}
+
+// NewOTTLStatements parses OTTL statements. The standard OTTL editors and converters can be used in the statements.
+func NewOTTLStatements(statements []string, set component.TelemetrySettings) (*OTTLStatements, error) {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parser.ParseStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+	return &OTTLStatements{
+		statements: ottllog.NewStatementSequence(parsed, set, ottllog.WithStatementSequenceErrorMode(ottl.PropagateError)),
+	}, nil
+}
+
+// Execute executes the statements against the entry, and updates the entry with the result.
+// The entry is left unchanged when a statement fails.
+func (s *OTTLStatements) Execute(ctx context.Context, e *entry.Entry) error {
+	tCtx := newOTTLTransformContext(e)
+	if err := s.statements.Execute(ctx, tCtx); err != nil {
+		return err
+	}
+	readOTTLTransformContext(tCtx, e)
+	return nil
+}
+
+// newOTTLTransformContext returns an OTTL log transform context holding a copy of the entry.
+// The resource attributes and scope name of the entry are exposed as the resource and scope of the log record.
+func newOTTLTransformContext(e *entry.Entry) ottllog.TransformContext {
+	resourceLogs := plog.NewResourceLogs()
+	putOTTLMap(e.Resource, resourceLogs.Resource().Attributes())
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(e.ScopeName)
+	record := scopeLogs.LogRecords().AppendEmpty()
+
+	if !e.Timestamp.IsZero() {
+		record.SetTimestamp(pcommon.NewTimestampFromTime(e.Timestamp))
+	}
+	if !e.ObservedTimestamp.IsZero() {
+		record.SetObservedTimestamp(pcommon.NewTimestampFromTime(e.ObservedTimestamp))
+	}
+	// The entry severities are defined in the same order as the OTLP severity numbers
+	record.SetSeverityNumber(plog.SeverityNumber(e.Severity))
+	record.SetSeverityText(e.SeverityText)
+	putOTTLMap(e.Attributes, record.Attributes())
+	putOTTLValue(e.Body, record.Body())
+	if len(e.TraceID) > 0 {
+		var traceID [16]byte
+		copy(traceID[:], e.TraceID)
+		record.SetTraceID(traceID)
+	}
+	if len(e.SpanID) > 0 {
+		var spanID [8]byte
+		copy(spanID[:], e.SpanID)
+		record.SetSpanID(spanID)
+	}
+	if len(e.TraceFlags) > 0 {
+		record.SetFlags(plog.LogRecordFlags(e.TraceFlags[0]))
+	}
+
+	return ottllog.NewTransformContext(record, scopeLogs.Scope(), resourceLogs.Resource(), scopeLogs, resourceLogs)
+}
+
+// readOTTLTransformContext updates the entry with the content of the transform context.
+func readOTTLTransformContext(tCtx ottllog.TransformContext, e *entry.Entry) {
+	record := tCtx.GetLogRecord()
+
+	e.Timestamp = ottlTime(record.Timestamp())
+	e.ObservedTimestamp = ottlTime(record.ObservedTimestamp())
+	e.Severity = entry.Severity(record.SeverityNumber())
+	e.SeverityText = record.SeverityText()
+	e.Attributes = rawOTTLMap(record.Attributes())
+	e.Body = record.Body().AsRaw()
+	e.Resource = rawOTTLMap(tCtx.GetResource().Attributes())
+	e.ScopeName = tCtx.GetInstrumentationScope().Name()
+
+	e.TraceID = nil
+	if traceID := record.TraceID(); !traceID.IsEmpty() {
+		e.TraceID = traceID[:]
+	}
+	e.SpanID = nil
+	if spanID := record.SpanID(); !spanID.IsEmpty() {
+		e.SpanID = spanID[:]
+	}
+	e.TraceFlags = nil
+	if flags := record.Flags(); flags != 0 {
+		e.TraceFlags = []byte{byte(flags)}
+	}
+}
+
+func putOTTLMap(m map[string]any, dest pcommon.Map) {
+	dest.EnsureCapacity(len(m))
+	for k, v := range m {
+		putOTTLValue(v, dest.PutEmpty(k))
+	}
+}
+
+func putOTTLValue(value any, dest pcommon.Value) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]any:
+		putOTTLMap(v, dest.SetEmptyMap())
+	case []any:
+		slice := dest.SetEmptySlice()
+		slice.EnsureCapacity(len(v))
+		for _, item := range v {
+			putOTTLValue(item, slice.AppendEmpty())
+		}
+	case []string:
+		slice := dest.SetEmptySlice()
+		slice.EnsureCapacity(len(v))
+		for _, item := range v {
+			slice.AppendEmpty().SetStr(item)
+		}
+	default:
+		if err := dest.FromRaw(v); err != nil {
+			dest.SetStr(fmt.Sprintf("%v", v))
+		}
+	}
+}
+
+// rawOTTLMap returns the raw map, or nil when the map is empty.
+func rawOTTLMap(m pcommon.Map) map[string]any {
+	if m.Len() == 0 {
+		return nil
+	}
+	return m.AsRaw()
+}
+
+// ottlTime returns the time of the timestamp, or the zero time when the timestamp is not set.
+func ottlTime(ts pcommon.Timestamp) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
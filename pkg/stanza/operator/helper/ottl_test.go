@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+)
+
+func ottlTestEntry() *entry.Entry {
+	e := entry.New()
+	e.ObservedTimestamp = time.Date(2020, time.April, 11, 21, 34, 1, 0, time.UTC)
+	e.Timestamp = time.Date(2020, time.April, 11, 21, 34, 0, 0, time.UTC)
+	e.Body = map[string]any{
+		"message": "test",
+		"nested":  map[string]any{"list": []any{"a", int64(1)}},
+	}
+	e.Attributes = map[string]any{
+		"key":   "value",
+		"bytes": []byte("raw"),
+	}
+	e.Resource = map[string]any{
+		"host.name": "host1",
+	}
+	e.Severity = entry.Warn2
+	e.SeverityText = "WARNING"
+	e.TraceID = []byte{0x48, 0x01, 0x40, 0xf3, 0xd7, 0x70, 0xa5, 0xae, 0x32, 0xf0, 0xa2, 0x2b, 0x6a, 0x81, 0x2c, 0xff}
+	e.SpanID = []byte{0x92, 0xd1, 0xd6, 0xe1, 0xa2, 0xa3, 0xb4, 0xc5}
+	e.TraceFlags = []byte{0x01}
+	e.ScopeName = "scope"
+	return e
+}
+
+func TestOTTLTransformContext(t *testing.T) {
+	e := ottlTestEntry()
+	tCtx := newOTTLTransformContext(e)
+
+	record := tCtx.GetLogRecord()
+	require.Equal(t, e.Timestamp, record.Timestamp().AsTime())
+	require.Equal(t, e.ObservedTimestamp, record.ObservedTimestamp().AsTime())
+	require.Equal(t, plog.SeverityNumberWarn2, record.SeverityNumber())
+	require.Equal(t, "WARNING", record.SeverityText())
+	require.Equal(t, e.Body, record.Body().AsRaw())
+	require.Equal(t, e.Attributes, record.Attributes().AsRaw())
+	require.Equal(t, e.Resource, tCtx.GetResource().Attributes().AsRaw())
+	require.Equal(t, "scope", tCtx.GetInstrumentationScope().Name())
+	require.Equal(t, "480140f3d770a5ae32f0a22b6a812cff", record.TraceID().String())
+	require.Equal(t, "92d1d6e1a2a3b4c5", record.SpanID().String())
+	require.Equal(t, plog.LogRecordFlags(1), record.Flags())
+
+	converted := &entry.Entry{}
+	readOTTLTransformContext(tCtx, converted)
+	require.Equal(t, e, converted)
+}
+
+func TestOTTLTransformContextEmptyEntry(t *testing.T) {
+	tCtx := newOTTLTransformContext(&entry.Entry{})
+
+	converted := &entry.Entry{}
+	readOTTLTransformContext(tCtx, converted)
+	require.Equal(t, &entry.Entry{}, converted)
+}
+
+func TestPutOTTLValue(t *testing.T) {
+	e := &entry.Entry{
+		Body: map[string]any{
+			"int":     42,
+			"uint8":   uint8(7),
+			"float32": float32(1.5),
+			"bool":    true,
+			"strings": []string{"a", "b"},
+			"time":    time.Date(2020, time.April, 11, 21, 34, 0, 0, time.UTC),
+		},
+	}
+	record := newOTTLTransformContext(e).GetLogRecord()
+	require.Equal(t, map[string]any{
+		"int":     int64(42),
+		"uint8":   int64(7),
+		"float32": float64(1.5),
+		"bool":    true,
+		"strings": []any{"a", "b"},
+		"time":    "2020-04-11 21:34:00 +0000 UTC",
+	}, record.Body().AsRaw())
+}
+
+func TestOTTLCondition(t *testing.T) {
+	set := componenttest.NewNopTelemetrySettings()
+
+	cases := []struct {
+		name      string
+		condition string
+		expected  bool
+	}{
+		{"body", `body["message"] == "test"`, true},
+		{"attributes", `attributes["key"] == "other"`, false},
+		{"resource", `resource.attributes["host.name"] == "host1"`, true},
+		{"scope", `instrumentation_scope.name == "scope"`, true},
+		{"severity", `severity_number >= SEVERITY_NUMBER_WARN`, true},
+		{"converter", `IsMatch(body["message"], "^te")`, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition, err := NewOTTLCondition(tc.condition, set)
+			require.NoError(t, err)
+			matches, err := condition.Match(t.Context(), ottlTestEntry())
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, matches)
+		})
+	}
+
+	// Editors are not available in conditions
+	_, err := NewOTTLCondition(`set(attributes["key"], "value")`, set)
+	require.Error(t, err)
+}
+
+func TestOTTLStatements(t *testing.T) {
+	set := componenttest.NewNopTelemetrySettings()
+
+	statements, err := NewOTTLStatements([]string{
+		`set(attributes["new"], body["message"])`,
+		`delete_key(attributes, "bytes")`,
+		`set(resource.attributes["service.name"], "svc")`,
+		`set(severity_number, SEVERITY_NUMBER_ERROR)`,
+	}, set)
+	require.NoError(t, err)
+
+	e := ottlTestEntry()
+	require.NoError(t, statements.Execute(t.Context(), e))
+
+	expected := ottlTestEntry()
+	expected.Attributes = map[string]any{
+		"key": "value",
+		"new": "test",
+	}
+	expected.Resource["service.name"] = "svc"
+	expected.Severity = entry.Error
+	require.Equal(t, expected, e)
+}
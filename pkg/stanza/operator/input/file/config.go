@@ -8,6 +8,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
@@ -37,19 +38,43 @@ type Config struct {
 	fileconsumer.Config `mapstructure:",squash"`
 }
 
+// WithRecordReaders returns a builder of the operator reading the structured formats
+// with the given record readers, by format
+func (c *Config) WithRecordReaders(readers map[string]format.RecordReader) operator.Builder {
+	return &recordReadersBuilder{Config: c, readers: readers}
+}
+
+type recordReadersBuilder struct {
+	*Config
+	readers map[string]format.RecordReader
+}
+
+func (b *recordReadersBuilder) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	return b.build(set, b.readers)
+}
+
 // Build will build a file input operator from the supplied configuration
 func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	return c.build(set, nil)
+}
+
+func (c Config) build(set component.TelemetrySettings, readers map[string]format.RecordReader) (operator.Operator, error) {
 	inputOperator, err := c.InputConfig.Build(set)
 	if err != nil {
 		return nil, err
 	}
 
-	var toBody toBodyFunc = func(token []byte) any {
-		return textutils.UnsafeBytesAsString(token)
+	var toBody toBodyFunc = func(token []byte) (any, error) {
+		return textutils.UnsafeBytesAsString(token), nil
 	}
-	if textutils.IsNop(c.Encoding) {
-		toBody = func(token []byte) any {
-			return token
+	switch {
+	case c.Format == format.NDJSON:
+		toBody = func(token []byte) (any, error) {
+			return format.DecodeJSONRecord(token)
+		}
+	case textutils.IsNop(c.Encoding):
+		toBody = func(token []byte) (any, error) {
+			return token, nil
 		}
 	}
 
@@ -60,7 +85,8 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 		includeFileRecordOffset: c.IncludeFileRecordOffset,
 	}
 
-	input.fileConsumer, err = c.Config.Build(set, input.emitBatch)
+	input.fileConsumer, err = c.Config.Build(set, input.emitBatch,
+		fileconsumer.WithRecordReaders(readers), fileconsumer.WithRecordCallback(input.emitRecords))
 	if err != nil {
 		return nil, err
 	}
@@ -17,7 +17,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
-type toBodyFunc func([]byte) any
+type toBodyFunc func([]byte) (any, error)
 
 // Input is an operator that monitors files for entries
 type Input struct {
@@ -63,7 +63,13 @@ func (i *Input) convertTokens(tokens [][]byte, attributes map[string]any, lastRe
 			continue
 		}
 
-		ent, err := i.NewEntry(i.toBody(token))
+		body, err := i.toBody(token)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("convert token: %w", err))
+			continue
+		}
+
+		ent, err := i.NewEntry(body)
 		if err != nil {
 			errs = multierr.Append(errs, fmt.Errorf("create entry: %w", err))
 			continue
@@ -91,3 +97,35 @@ func (i *Input) convertTokens(tokens [][]byte, attributes map[string]any, lastRe
 	}
 	return entries, errs
 }
+
+func (i *Input) emitRecords(ctx context.Context, records []map[string]any, attributes map[string]any, lastRecordNumber int64) error {
+	var errs error
+	entries := make([]*entry.Entry, 0, len(records))
+	for recordIndex, record := range records {
+		ent, err := i.NewEntry(record)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("create entry: %w", err))
+			continue
+		}
+
+		for k, v := range attributes {
+			if err = ent.Set(entry.NewAttributeField(k), v); err != nil {
+				i.Logger().Error("set attribute", zap.Error(err))
+			}
+		}
+
+		if i.includeFileRecordNumber {
+			if err = ent.Set(entry.NewAttributeField(attrs.LogFileRecordNumber), lastRecordNumber-int64(len(records))+int64(recordIndex)+1); err != nil {
+				i.Logger().Error("set record number attribute", zap.Error(err))
+			}
+		}
+
+		entries = append(entries, ent)
+	}
+
+	if err := i.WriteBatch(ctx, entries); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("consume entries: %w", err))
+	}
+
+	return errs
+}
@@ -4,6 +4,8 @@
 package file
 
 import (
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -12,9 +14,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -370,3 +375,84 @@ func TestSkipEmpty(t *testing.T) {
 	waitForMessage(t, logReceived, "testlog1")
 	waitForMessage(t, logReceived, "testlog2")
 }
+
+// TestNDJSONFormat tests that the lines of newline delimited JSON files are decoded into maps
+func TestNDJSONFormat(t *testing.T) {
+	t.Parallel()
+	operator, logReceived, tempDir := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Format = "ndjson"
+		cfg.IncludeFileRecordNumber = true
+	})
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, `{"message":"first","count":3,"ratio":0.5,"tags":["a",1],"nested":{"ok":true}}`+"\n")
+	writeString(t, temp, "not json\n")
+	writeString(t, temp, `{"message":"second"}`+"\n")
+
+	require.NoError(t, operator.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, map[string]any{
+		"message": "first",
+		"count":   int64(3),
+		"ratio":   0.5,
+		"tags":    []any{"a", int64(1)},
+		"nested":  map[string]any{"ok": true},
+	}, e.Body)
+	require.Equal(t, int64(1), e.Attributes[attrs.LogFileRecordNumber])
+
+	// the line which is not a JSON object is dropped
+	e = waitForOne(t, logReceived)
+	require.Equal(t, map[string]any{"message": "second"}, e.Body)
+	require.Equal(t, int64(3), e.Attributes[attrs.LogFileRecordNumber])
+}
+
+// lineRecordReader reads the complete lines of files as records
+type lineRecordReader struct{}
+
+func (lineRecordReader) Read(ctx context.Context, file *os.File, position int64, emit format.EmitFunc) error {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(content), "\n")
+	for i := position; i < int64(len(lines)-1); i++ {
+		if err = emit(ctx, []map[string]any{{"line": lines[i]}}, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestWithRecordReaders tests that the formats are read by the record readers given to the builder
+func TestWithRecordReaders(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := newDefaultConfig(tempDir)
+	cfg.Format = "lines"
+
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "invalid 'format'")
+
+	op, err := cfg.WithRecordReaders(map[string]format.RecordReader{"lines": lineRecordReader{}}).Build(set)
+	require.NoError(t, err)
+	fakeOutput := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fakeOutput}))
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "first\nsecond\n")
+
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	e := waitForOne(t, fakeOutput.Received)
+	require.Equal(t, map[string]any{"line": "first"}, e.Body)
+	e = waitForOne(t, fakeOutput.Received)
+	require.Equal(t, map[string]any{"line": "second"}, e.Body)
+}
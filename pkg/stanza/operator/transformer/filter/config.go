@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/expr-lang/expr/vm"
 	"go.opentelemetry.io/collector/component"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -43,6 +44,7 @@ func NewConfigWithID(operatorID string) *Config {
 type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
 	Expression               string  `mapstructure:"expr"`
+	Condition                string  `mapstructure:"condition"`
 	DropRatio                float64 `mapstructure:"drop_ratio"`
 }
 
@@ -53,9 +55,22 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 		return nil, err
 	}
 
-	compiledExpression, err := helper.ExprCompileBool(c.Expression)
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile expression '%s': %w", c.Expression, err)
+	if c.Expression != "" && c.Condition != "" {
+		return nil, errors.New("only one of expr and condition can be set")
+	}
+
+	var compiledExpression *vm.Program
+	var condition *helper.OTTLCondition
+	if c.Condition != "" {
+		condition, err = helper.NewOTTLCondition(c.Condition, set)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse condition '%s': %w", c.Condition, err)
+		}
+	} else {
+		compiledExpression, err = helper.ExprCompileBool(c.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile expression '%s': %w", c.Expression, err)
+		}
 	}
 
 	if c.DropRatio < 0.0 || c.DropRatio > 1.0 {
@@ -65,6 +80,7 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 	return &Transformer{
 		TransformerOperator: transformer,
 		expression:          compiledExpression,
+		condition:           condition,
 		dropCutoff:          big.NewInt(int64(c.DropRatio * 1000)),
 	}, nil
 }
@@ -52,6 +52,14 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name: "condition",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Condition = `body == "value"`
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -6,6 +6,7 @@ package filter // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"math/big"
 
 	"github.com/expr-lang/expr/vm"
@@ -19,6 +20,7 @@ import (
 type Transformer struct {
 	helper.TransformerOperator
 	expression *vm.Program
+	condition  *helper.OTTLCondition
 	dropCutoff *big.Int // [0..1000)
 }
 
@@ -28,21 +30,12 @@ func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry)
 
 // Process will drop incoming entries that match the filter expression
 func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
-	env := helper.GetExprEnv(entry)
-	defer helper.PutExprEnv(env)
-
-	matches, err := vm.Run(t.expression, env)
+	filtered, err := t.matches(ctx, entry)
 	if err != nil {
 		t.Logger().Error("Running expressing returned an error", zap.Error(err))
 		return nil
 	}
 
-	filtered, ok := matches.(bool)
-	if !ok {
-		t.Logger().Error("Expression did not compile as a boolean")
-		return nil
-	}
-
 	if !filtered {
 		return t.Write(ctx, entry)
 	}
@@ -61,3 +54,24 @@ func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
 
 	return nil
 }
+
+// matches returns whether the entry matches the filter expression or condition
+func (t *Transformer) matches(ctx context.Context, entry *entry.Entry) (bool, error) {
+	if t.condition != nil {
+		return t.condition.Match(ctx, entry)
+	}
+
+	env := helper.GetExprEnv(entry)
+	defer helper.PutExprEnv(env)
+
+	matches, err := vm.Run(t.expression, env)
+	if err != nil {
+		return false, err
+	}
+
+	filtered, ok := matches.(bool)
+	if !ok {
+		return false, errors.New("expression did not compile as a boolean")
+	}
+	return filtered, nil
+}
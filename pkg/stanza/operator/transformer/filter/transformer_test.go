@@ -243,3 +243,106 @@ func TestFilterDropRatio(t *testing.T) {
 
 	require.Equal(t, 10, processedEntries)
 }
+
+func TestTransformerOTTLCondition(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     *entry.Entry
+		condition string
+		filtered  bool
+	}{
+		{
+			"BodyMatch",
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "test_message",
+				},
+			},
+			`body["message"] == "test_message"`,
+			true,
+		},
+		{
+			"NoMatchBody",
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "invalid",
+				},
+			},
+			`body["message"] == "test_message"`,
+			false,
+		},
+		{
+			"FilterOutRegexp",
+			&entry.Entry{
+				Body: "INFO: this is an info message",
+			},
+			`IsMatch(body, "^INFO:")`,
+			true,
+		},
+		{
+			"MatchAttributeAndResource",
+			&entry.Entry{
+				Attributes: map[string]any{
+					"key": "value",
+				},
+				Resource: map[string]any{
+					"host.name": "host1",
+				},
+			},
+			`attributes["key"] == "value" and resource.attributes["host.name"] == "host1"`,
+			true,
+		},
+		{
+			"MatchSeverity",
+			&entry.Entry{
+				Severity: entry.Debug,
+			},
+			`severity_number < SEVERITY_NUMBER_INFO`,
+			true,
+		},
+		{
+			"MissingAttribute",
+			&entry.Entry{
+				Body: "test_message",
+			},
+			`attributes["key"] == "value"`,
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Condition = tc.condition
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			filtered := true
+			mockOutput := testutil.NewMockOperator("output")
+			mockOutput.On("Process", mock.Anything, mock.Anything).Return(nil).Run(func(_ mock.Arguments) {
+				filtered = false
+			})
+
+			op.(*Transformer).OutputOperators = []operator.Operator{mockOutput}
+			err = op.ProcessBatch(t.Context(), []*entry.Entry{tc.input})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.filtered, filtered)
+		})
+	}
+}
+
+func TestBuildInvalidCondition(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Condition = `body ==`
+	_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "failed to parse condition")
+
+	cfg = NewConfigWithID("test")
+	cfg.Expression = `body == "value"`
+	cfg.Condition = `body == "value"`
+	_, err = cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "only one of expr and condition can be set")
+}
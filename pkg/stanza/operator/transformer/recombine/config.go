@@ -52,6 +52,8 @@ type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
 	IsFirstEntry             string          `mapstructure:"is_first_entry"`
 	IsLastEntry              string          `mapstructure:"is_last_entry"`
+	IsFirstEntryCondition    string          `mapstructure:"is_first_entry_condition"`
+	IsLastEntryCondition     string          `mapstructure:"is_last_entry_condition"`
 	MaxBatchSize             int             `mapstructure:"max_batch_size"`
 	MaxUnmatchedBatchSize    int             `mapstructure:"max_unmatched_batch_size"`
 	CombineField             entry.Field     `mapstructure:"combine_field"`
@@ -70,28 +72,47 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		return nil, fmt.Errorf("failed to build transformer config: %w", err)
 	}
 
-	if c.IsLastEntry != "" && c.IsFirstEntry != "" {
-		return nil, errors.New("only one of is_first_entry and is_last_entry can be set")
+	numSet := 0
+	for _, matcher := range []string{c.IsFirstEntry, c.IsLastEntry, c.IsFirstEntryCondition, c.IsLastEntryCondition} {
+		if matcher != "" {
+			numSet++
+		}
 	}
-
-	if c.IsLastEntry == "" && c.IsFirstEntry == "" {
-		return nil, errors.New("one of is_first_entry and is_last_entry must be set")
+	if numSet > 1 {
+		return nil, errors.New("only one of is_first_entry, is_last_entry, is_first_entry_condition and is_last_entry_condition can be set")
+	}
+	if numSet == 0 {
+		return nil, errors.New("one of is_first_entry, is_last_entry, is_first_entry_condition and is_last_entry_condition must be set")
 	}
 
 	var matchesFirst bool
 	var prog *vm.Program
-	if c.IsFirstEntry != "" {
+	var condition *helper.OTTLCondition
+	switch {
+	case c.IsFirstEntry != "":
 		matchesFirst = true
 		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
 		}
-	} else {
+	case c.IsLastEntry != "":
 		matchesFirst = false
 		prog, err = helper.ExprCompileBool(c.IsLastEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_last_entry: %w", err)
 		}
+	case c.IsFirstEntryCondition != "":
+		matchesFirst = true
+		condition, err = helper.NewOTTLCondition(c.IsFirstEntryCondition, set)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse is_first_entry_condition: %w", err)
+		}
+	default:
+		matchesFirst = false
+		condition, err = helper.NewOTTLCondition(c.IsLastEntryCondition, set)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse is_last_entry_condition: %w", err)
+		}
 	}
 
 	if c.CombineField.FieldInterface == nil {
@@ -112,6 +133,7 @@ func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, erro
 		TransformerOperator:   transformer,
 		matchFirstLine:        matchesFirst,
 		prog:                  prog,
+		condition:             condition,
 		maxBatchSize:          c.MaxBatchSize,
 		maxUnmatchedBatchSize: c.MaxUnmatchedBatchSize,
 		maxSources:            c.MaxSources,
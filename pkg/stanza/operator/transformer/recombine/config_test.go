@@ -93,6 +93,15 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:               "is_first_entry_condition",
+				ExpectUnmarshalErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.IsFirstEntryCondition = `IsMatch(body, "^start")`
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
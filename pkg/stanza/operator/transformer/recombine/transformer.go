@@ -26,6 +26,7 @@ type Transformer struct {
 	helper.TransformerOperator
 	matchFirstLine        bool
 	prog                  *vm.Program
+	condition             *helper.OTTLCondition
 	maxBatchSize          int
 	maxUnmatchedBatchSize int
 	maxSources            int
@@ -103,20 +104,15 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	t.Lock()
 	defer t.Unlock()
 
-	// Get the environment for executing the expression.
+	// Only the current entry is available to the expression or condition.
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
 	// rather than just use absolute rules.
-	env := helper.GetExprEnv(e)
-	defer helper.PutExprEnv(env)
-
-	m, err := expr.Run(t.prog, env)
+	matches, err := t.matches(ctx, e)
 	if err != nil {
 		return t.HandleEntryError(ctx, e, err)
 	}
 
-	// this is guaranteed to be a boolean because of expr.AsBool
-	matches := m.(bool)
 	var s string
 	err = e.Read(t.sourceIdentifier, &s)
 	if err != nil {
@@ -151,6 +147,24 @@ func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	return nil
 }
 
+// matches returns whether the entry matches the is_first_entry or is_last_entry expression or condition
+func (t *Transformer) matches(ctx context.Context, e *entry.Entry) (bool, error) {
+	if t.condition != nil {
+		return t.condition.Match(ctx, e)
+	}
+
+	env := helper.GetExprEnv(e)
+	defer helper.PutExprEnv(env)
+
+	m, err := expr.Run(t.prog, env)
+	if err != nil {
+		return false, err
+	}
+
+	// this is guaranteed to be a boolean because of expr.AsBool
+	return m.(bool), nil
+}
+
 // addToBatch adds the current entry to the current batch of entries that will be combined
 func (t *Transformer) addToBatch(ctx context.Context, e *entry.Entry, source string, matches bool) {
 	batch, ok := t.batchMap[source]
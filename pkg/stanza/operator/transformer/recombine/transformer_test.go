@@ -114,6 +114,37 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*entry.Entry{entryWithBody(t1, "test1\ntest2")},
 		},
+		{
+			"TwoEntriesLastCondition",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.IsLastEntryCondition = `body == "test2"`
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "test1"),
+				entryWithBody(t2, "test2"),
+			},
+			[]*entry.Entry{entryWithBody(t1, "test1\ntest2")},
+		},
+		{
+			"ThreeEntriesFirstCondition",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.IsFirstEntryCondition = `IsMatch(body, "^start")`
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "start1"),
+				entryWithBody(t2, "next"),
+				entryWithBody(t2, "start2"),
+			},
+			[]*entry.Entry{entryWithBody(t1, "start1\nnext")},
+		},
 		{
 			"ThreeEntriesFirstNewest",
 			func() *Config {
@@ -994,3 +1025,25 @@ func TestSourceBatchDelete(t *testing.T) {
 	fake.ExpectEntry(t, expect)
 	require.NoError(t, op.Stop())
 }
+
+func TestBuildMatchers(t *testing.T) {
+	set := componenttest.NewNopTelemetrySettings()
+
+	cfg := NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	_, err := cfg.Build(set)
+	require.ErrorContains(t, err, "one of is_first_entry, is_last_entry, is_first_entry_condition and is_last_entry_condition must be set")
+
+	cfg = NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.IsFirstEntry = "body == 'start'"
+	cfg.IsLastEntryCondition = `body == "end"`
+	_, err = cfg.Build(set)
+	require.ErrorContains(t, err, "only one of is_first_entry, is_last_entry, is_first_entry_condition and is_last_entry_condition can be set")
+
+	cfg = NewConfig()
+	cfg.CombineField = entry.NewBodyField()
+	cfg.IsLastEntryCondition = `body ==`
+	_, err = cfg.Build(set)
+	require.ErrorContains(t, err, "failed to parse is_last_entry_condition")
+}
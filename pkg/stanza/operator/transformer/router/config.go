@@ -4,6 +4,7 @@
 package router // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/router"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -41,6 +42,7 @@ type Config struct {
 type RouteConfig struct {
 	helper.AttributerConfig `mapstructure:",squash"`
 	Expression              string   `mapstructure:"expr"`
+	Condition               string   `mapstructure:"condition"`
 	OutputIDs               []string `mapstructure:"output"`
 }
 
@@ -61,20 +63,29 @@ func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error
 
 	routes := make([]*Route, 0, len(c.Routes))
 	for _, routeConfig := range c.Routes {
-		compiled, err := helper.ExprCompileBool(routeConfig.Expression)
-		if err != nil {
-			return nil, fmt.Errorf("failed to compile expression '%s': %w", routeConfig.Expression, err)
+		route := Route{
+			OutputIDs: routeConfig.OutputIDs,
 		}
-
-		attributer, err := routeConfig.Build()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build attributer for route '%s': %w", routeConfig.Expression, err)
+		routeName := routeConfig.Expression
+		switch {
+		case routeConfig.Expression != "" && routeConfig.Condition != "":
+			return nil, errors.New("only one of expr and condition can be set on a route")
+		case routeConfig.Condition != "":
+			routeName = routeConfig.Condition
+			route.Condition, err = helper.NewOTTLCondition(routeConfig.Condition, set)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse condition '%s': %w", routeConfig.Condition, err)
+			}
+		default:
+			route.Expression, err = helper.ExprCompileBool(routeConfig.Expression)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile expression '%s': %w", routeConfig.Expression, err)
+			}
 		}
 
-		route := Route{
-			Attributer: attributer,
-			Expression: compiled,
-			OutputIDs:  routeConfig.OutputIDs,
+		route.Attributer, err = routeConfig.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build attributer for route '%s': %w", routeName, err)
 		}
 		routes = append(routes, &route)
 	}
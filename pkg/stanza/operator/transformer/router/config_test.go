@@ -86,6 +86,18 @@ func TestRouterGoldenConfig(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name: "routes_condition",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					newRoute := &RouteConfig{
+						Condition: `body["format"] == "json"`,
+						OutputIDs: []string{"my_json_parser"},
+					}
+					cfg.Routes = append(cfg.Routes, newRoute)
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -27,6 +27,7 @@ type Transformer struct {
 type Route struct {
 	helper.Attributer
 	Expression      *vm.Program
+	Condition       *helper.OTTLCondition
 	OutputIDs       []string
 	OutputOperators []operator.Operator
 }
@@ -54,14 +55,13 @@ func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
 	defer helper.PutExprEnv(env)
 
 	for _, route := range t.routes {
-		matches, err := vm.Run(route.Expression, env)
+		matches, err := route.matches(ctx, entry, env)
 		if err != nil {
 			t.Logger().Warn("Running expression returned an error", zapAttributes(entry, err)...)
 			continue
 		}
 
-		// we compile the expression with "AsBool", so this should be safe
-		if matches.(bool) {
+		if matches {
 			if err = route.Attribute(entry); err != nil {
 				t.Logger().Error("Failed to label entry", zapAttributes(entry, err)...)
 				return err
@@ -79,6 +79,20 @@ func (t *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
 	return nil
 }
 
+// matches returns whether the entry matches the route expression or condition
+func (r *Route) matches(ctx context.Context, entry *entry.Entry, env map[string]any) (bool, error) {
+	if r.Condition != nil {
+		return r.Condition.Match(ctx, entry)
+	}
+
+	matches, err := vm.Run(r.Expression, env)
+	if err != nil {
+		return false, err
+	}
+	// we compile the expression with "AsBool", so this should be safe
+	return matches.(bool), nil
+}
+
 // CanOutput will always return true for a router operator
 func (*Transformer) CanOutput() bool {
 	return true
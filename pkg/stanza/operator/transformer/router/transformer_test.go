@@ -42,9 +42,9 @@ func TestTransformer(t *testing.T) {
 			entry.New(),
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					"true",
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       "true",
+					OutputIDs:        []string{"output1"},
 				},
 			},
 			nil,
@@ -56,9 +56,9 @@ func TestTransformer(t *testing.T) {
 			entry.New(),
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					`false`,
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `false`,
+					OutputIDs:        []string{"output1"},
 				},
 			},
 			nil,
@@ -74,14 +74,14 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					`body.message == "non_match"`,
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `body.message == "non_match"`,
+					OutputIDs:        []string{"output1"},
 				},
 				{
-					helper.NewAttributerConfig(),
-					`body.message == "test_message"`,
-					[]string{"output2"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `body.message == "test_message"`,
+					OutputIDs:        []string{"output2"},
 				},
 			},
 			nil,
@@ -97,18 +97,18 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					`body.message == "non_match"`,
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `body.message == "non_match"`,
+					OutputIDs:        []string{"output1"},
 				},
 				{
-					helper.AttributerConfig{
+					AttributerConfig: helper.AttributerConfig{
 						Attributes: map[string]helper.ExprStringConfig{
 							"label-key": "label-value",
 						},
 					},
-					`body.message == "test_message"`,
-					[]string{"output2"},
+					Expression: `body.message == "test_message"`,
+					OutputIDs:  []string{"output2"},
 				},
 			},
 			nil,
@@ -126,14 +126,14 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					`env("TEST_ROUTER_OPERATOR_ENV") == "foo"`,
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `env("TEST_ROUTER_OPERATOR_ENV") == "foo"`,
+					OutputIDs:        []string{"output1"},
 				},
 				{
-					helper.NewAttributerConfig(),
-					`true`,
-					[]string{"output2"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `true`,
+					OutputIDs:        []string{"output2"},
 				},
 			},
 			nil,
@@ -149,9 +149,9 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					`false`,
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `false`,
+					OutputIDs:        []string{"output1"},
 				},
 			},
 			[]string{"output2"},
@@ -167,15 +167,84 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*RouteConfig{
 				{
-					helper.NewAttributerConfig(),
-					`true`,
-					[]string{"output1"},
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `true`,
+					OutputIDs:        []string{"output1"},
 				},
 			},
 			[]string{"output2"},
 			map[string]int{"output1": 1},
 			nil,
 		},
+		{
+			"ConditionMatch",
+			&entry.Entry{
+				Body: map[string]any{
+					"message": "test_message",
+				},
+			},
+			[]*RouteConfig{
+				{
+					AttributerConfig: helper.NewAttributerConfig(),
+					Condition:        `body["message"] == "non_match"`,
+					OutputIDs:        []string{"output1"},
+				},
+				{
+					AttributerConfig: helper.NewAttributerConfig(),
+					Condition:        `body["message"] == "test_message"`,
+					OutputIDs:        []string{"output2"},
+				},
+			},
+			nil,
+			map[string]int{"output2": 1},
+			nil,
+		},
+		{
+			"ConditionAndExpression",
+			&entry.Entry{
+				Attributes: map[string]any{
+					"key": "value",
+				},
+			},
+			[]*RouteConfig{
+				{
+					AttributerConfig: helper.NewAttributerConfig(),
+					Expression:       `attributes.key == "other"`,
+					OutputIDs:        []string{"output1"},
+				},
+				{
+					AttributerConfig: helper.AttributerConfig{
+						Attributes: map[string]helper.ExprStringConfig{
+							"label-key": "label-value",
+						},
+					},
+					Condition: `attributes["key"] == "value"`,
+					OutputIDs: []string{"output2"},
+				},
+			},
+			nil,
+			map[string]int{"output2": 1},
+			map[string]any{
+				"key":       "value",
+				"label-key": "label-value",
+			},
+		},
+		{
+			"ConditionUseDefault",
+			&entry.Entry{
+				Body: "test_message",
+			},
+			[]*RouteConfig{
+				{
+					AttributerConfig: helper.NewAttributerConfig(),
+					Condition:        `IsMatch(body, "^other")`,
+					OutputIDs:        []string{"output1"},
+				},
+			},
+			[]string{"output2"},
+			map[string]int{"output2": 1},
+			nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -222,3 +291,17 @@ func TestTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRouteWithExpressionAndCondition(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Routes = []*RouteConfig{
+		{
+			AttributerConfig: helper.NewAttributerConfig(),
+			Expression:       `body == "value"`,
+			Condition:        `body == "value"`,
+			OutputIDs:        []string{"output1"},
+		},
+	}
+	_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.ErrorContains(t, err, "only one of expr and condition can be set on a route")
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "statements",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Statements = []string{
+						`set(attributes["env"], "prod")`,
+						`set(severity_number, SEVERITY_NUMBER_ERROR) where IsMatch(body, "ERROR")`,
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "if",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.IfExpr = `body.level == "error"`
+					cfg.Statements = []string{
+						`delete_key(attributes, "password")`,
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
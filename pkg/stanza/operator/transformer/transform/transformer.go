@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transform // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/transform"
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Transformer is an operator that modifies entries with OTTL statements
+type Transformer struct {
+	helper.TransformerOperator
+	statements *helper.OTTLStatements
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return t.ProcessBatchWithTransform(ctx, entries, func(e *entry.Entry) error {
+		return t.statements.Execute(ctx, e)
+	})
+}
+
+// Process will process an entry with the OTTL statements.
+func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	return t.ProcessWith(ctx, e, func(e *entry.Entry) error {
+		return t.statements.Execute(ctx, e)
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestProcessAndBuild(t *testing.T) {
+	observed := time.Date(2020, time.April, 11, 21, 34, 1, 0, time.UTC)
+	newTestEntry := func() *entry.Entry {
+		e := entry.New()
+		e.ObservedTimestamp = observed
+		e.Timestamp = time.Date(2020, time.April, 11, 21, 34, 0, 0, time.UTC)
+		e.Body = map[string]any{
+			"message": "ERROR: something failed",
+			"level":   "error",
+		}
+		e.Attributes = map[string]any{
+			"password": "secret",
+			"user":     "alice",
+		}
+		e.Resource = map[string]any{
+			"host.name": "host1",
+		}
+		return e
+	}
+
+	cases := []struct {
+		name       string
+		statements []string
+		ifExpr     string
+		output     func() *entry.Entry
+	}{
+		{
+			"set_attribute",
+			[]string{`set(attributes["env"], "prod")`},
+			"",
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Attributes["env"] = "prod"
+				return e
+			},
+		},
+		{
+			"delete_attribute",
+			[]string{`delete_key(attributes, "password")`},
+			"",
+			func() *entry.Entry {
+				e := newTestEntry()
+				delete(e.Attributes, "password")
+				return e
+			},
+		},
+		{
+			"body_to_attributes",
+			[]string{
+				`set(attributes["message"], body["message"])`,
+				`set(body, body["level"])`,
+			},
+			"",
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Attributes["message"] = "ERROR: something failed"
+				e.Body = "error"
+				return e
+			},
+		},
+		{
+			"severity_with_condition",
+			[]string{
+				`set(severity_number, SEVERITY_NUMBER_ERROR) where IsMatch(body["message"], "^ERROR")`,
+				`set(severity_text, "ERROR") where IsMatch(body["message"], "^ERROR")`,
+				`set(severity_text, "WARN") where IsMatch(body["message"], "^WARN")`,
+			},
+			"",
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Severity = entry.Error
+				e.SeverityText = "ERROR"
+				return e
+			},
+		},
+		{
+			"resource_and_scope",
+			[]string{
+				`set(resource.attributes["service.name"], "checkout")`,
+				`set(instrumentation_scope.name, "payments")`,
+			},
+			"",
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Resource["service.name"] = "checkout"
+				e.ScopeName = "payments"
+				return e
+			},
+		},
+		{
+			"trace_context",
+			[]string{
+				`set(trace_id.string, "480140f3d770a5ae32f0a22b6a812cff")`,
+				`set(span_id.string, "92d1d6e1a2a3b4c5")`,
+				`set(flags, 1)`,
+			},
+			"",
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.TraceID = []byte{0x48, 0x01, 0x40, 0xf3, 0xd7, 0x70, 0xa5, 0xae, 0x32, 0xf0, 0xa2, 0x2b, 0x6a, 0x81, 0x2c, 0xff}
+				e.SpanID = []byte{0x92, 0xd1, 0xd6, 0xe1, 0xa2, 0xa3, 0xb4, 0xc5}
+				e.TraceFlags = []byte{0x01}
+				return e
+			},
+		},
+		{
+			"if_not_matched",
+			[]string{`set(attributes["env"], "prod")`},
+			`body.level == "info"`,
+			newTestEntry,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run("BuildandProcess/"+tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.Statements = tc.statements
+			cfg.IfExpr = tc.ifExpr
+			cfg.OutputIDs = []string{"fake"}
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			transform := op.(*Transformer)
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, transform.SetOutputs([]operator.Operator{fake}))
+			require.NoError(t, transform.Process(t.Context(), newTestEntry()))
+			fake.ExpectEntry(t, tc.output())
+
+			require.NoError(t, transform.ProcessBatch(t.Context(), []*entry.Entry{newTestEntry()}))
+			fake.ExpectEntry(t, tc.output())
+		})
+	}
+}
+
+func TestProcessError(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Statements = []string{`set(attributes["env"], "prod")`, `merge_maps(attributes, ParseJSON(body["message"]), "upsert")`}
+	cfg.OutputIDs = []string{"fake"}
+	cfg.OnError = "send_quiet"
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := entry.New()
+	e.Body = map[string]any{"message": "not json"}
+	expected := e.Copy()
+	require.Error(t, op.Process(t.Context(), e))
+	// The entry is sent unchanged when a statement fails
+	fake.ExpectEntry(t, expected)
+}
+
+func TestBuildInvalid(t *testing.T) {
+	set := componenttest.NewNopTelemetrySettings()
+
+	_, err := NewConfig().Build(set)
+	require.ErrorContains(t, err, "missing required field 'statements'")
+
+	cfg := NewConfig()
+	cfg.Statements = []string{`set(attributes["env"]`}
+	_, err = cfg.Build(set)
+	require.ErrorContains(t, err, "failed to parse statements")
+
+	cfg = NewConfig()
+	cfg.Statements = []string{`unknown_function(attributes)`}
+	_, err = cfg.Build(set)
+	require.ErrorContains(t, err, "failed to parse statements")
+}
@@ -129,12 +129,14 @@ func (a *Aggregator) RecordStatus(source *componentstatus.InstanceID, event *com
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	componentKey := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
+
 	// extensions are treated as a pseudo-pipeline
 	if source.Kind() == component.KindExtension {
-		a.updateStatus(ScopeExtensions, source, event)
+		a.updateStatus(ScopeExtensions, componentKey, event)
 	} else {
 		source.AllPipelineIDs(func(id pipeline.ID) bool {
-			a.updateStatus(Scope(id.String()), source, event)
+			a.updateStatus(Scope(id.String()), componentKey, event)
 			return true
 		})
 	}
@@ -143,7 +145,20 @@ func (a *Aggregator) RecordStatus(source *componentstatus.InstanceID, event *com
 	a.notifySubscribers(ScopeAll, a.aggregateStatus)
 }
 
-func (a *Aggregator) updateStatus(pipelineScope Scope, source *componentstatus.InstanceID, event *componentstatus.Event) {
+// RecordPipelineStatus stores and aggregates a StatusEvent about a pipeline as a whole rather
+// than one of its components, such as the health of its data flow. The event is stored under
+// the given key in the ComponentStatusMap of the pipeline.
+func (a *Aggregator) RecordPipelineStatus(pipelineID pipeline.ID, key string, event *componentstatus.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.updateStatus(Scope(pipelineID.String()), key, event)
+
+	a.aggregateStatus.Event = a.aggregationFunc(a.aggregateStatus)
+	a.notifySubscribers(ScopeAll, a.aggregateStatus)
+}
+
+func (a *Aggregator) updateStatus(pipelineScope Scope, componentKey string, event *componentstatus.Event) {
 	pipelineKey := pipelineScope.toKey()
 	pipelineStatus, ok := a.aggregateStatus.ComponentStatusMap[pipelineKey]
 	if !ok {
@@ -153,7 +168,6 @@ func (a *Aggregator) updateStatus(pipelineScope Scope, source *componentstatus.I
 		a.aggregateStatus.ComponentStatusMap[pipelineKey] = pipelineStatus
 	}
 
-	componentKey := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
 	pipelineStatus.ComponentStatusMap[componentKey] = &AggregateStatus{
 		Event: event,
 	}
@@ -253,6 +253,29 @@ func TestPipelineAggregateStatusVerbose(t *testing.T) {
 	})
 }
 
+func TestRecordPipelineStatus(t *testing.T) {
+	agg := status.NewAggregator(status.PriorityPermanent)
+	traces := testhelpers.NewPipelineMetadata(pipeline.SignalTraces)
+
+	testhelpers.SeedAggregator(agg, traces.InstanceIDs(), componentstatus.StatusOK)
+	agg.RecordPipelineStatus(traces.PipelineID, "dataflow", componentstatus.NewRecoverableErrorEvent(assert.AnError))
+
+	st, ok := agg.AggregateStatus(status.Scope(traces.PipelineID.String()), status.Verbose)
+	require.True(t, ok)
+	assertErrorEventsMatch(t, componentstatus.StatusRecoverableError, assert.AnError, st, st.ComponentStatusMap["dataflow"])
+	assertEventsMatch(t, componentstatus.StatusOK, collectStatuses(st, traces.InstanceIDs()...)...)
+
+	st, ok = agg.AggregateStatus(status.ScopeAll, status.Concise)
+	require.True(t, ok)
+	assertErrorEventsMatch(t, componentstatus.StatusRecoverableError, assert.AnError, st)
+
+	agg.RecordPipelineStatus(traces.PipelineID, "dataflow", componentstatus.NewEvent(componentstatus.StatusOK))
+
+	st, ok = agg.AggregateStatus(status.ScopeAll, status.Concise)
+	require.True(t, ok)
+	assertEventsMatch(t, componentstatus.StatusOK, st)
+}
+
 func TestAggregateStatusExtensions(t *testing.T) {
 	agg := status.NewAggregator(status.PriorityPermanent)
 
@@ -107,3 +107,48 @@ func (f *fakeClient) Start() error {
 func (f *fakeClient) Stop() {
 	close(f.StopCh)
 }
+
+// fakeObjectClient is used as a replacement for ObjectWatchClient in test cases.
+type fakeObjectClient struct {
+	Rules   []kube.ResourceRule
+	Objects []*kube.Object
+	StopCh  chan struct{}
+}
+
+// newFakeObjectClient instantiates a new fakeObjectClient and satisfies the ObjectClientProvider type
+func newFakeObjectClient(_ component.TelemetrySettings, _ k8sconfig.APIConfig, rules []kube.ResourceRule, _ kube.Filters, _ kube.DynamicClientProvider, _ bool, _ time.Duration) (kube.ObjectClient, error) {
+	return &fakeObjectClient{
+		Rules:  rules,
+		StopCh: make(chan struct{}),
+	}, nil
+}
+
+// GetObject looks up fakeObjectClient.Objects by kind, namespace and name.
+func (f *fakeObjectClient) GetObject(kind, namespace, name string) (*kube.Object, bool) {
+	for _, o := range f.Objects {
+		if o.Kind == kind && o.Namespace == namespace && o.Name == name {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+// GetObjectByUID looks up fakeObjectClient.Objects by UID.
+func (f *fakeObjectClient) GetObjectByUID(uid string) (*kube.Object, bool) {
+	for _, o := range f.Objects {
+		if o.UID == uid {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+// Start is a noop for fakeObjectClient.
+func (*fakeObjectClient) Start() error {
+	return nil
+}
+
+// Stop is a noop for fakeObjectClient.
+func (f *fakeObjectClient) Stop() {
+	close(f.StopCh)
+}
@@ -4,11 +4,13 @@
 package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
 
@@ -56,6 +58,17 @@ type Config struct {
 
 	// WaitForMetadataTimeout is the maximum time the processor will wait for the k8s metadata to be synced.
 	WaitForMetadataTimeout time.Duration `mapstructure:"wait_for_metadata_timeout"`
+
+	// Resources section allows enriching telemetry referring to other resources than pods,
+	// such as nodes, services or custom resources, which are watched with dynamic informers.
+	Resources []ResourceConfig `mapstructure:"resources"`
+
+	// OwnerChain section allows resolving the chain of owners of the pods and resources.
+	OwnerChain OwnerChainConfig `mapstructure:"owner_chain"`
+
+	// K8sMetadata is the ID of the k8s_metadata extension providing the informers shared with the
+	// other components of the collector. The processor creates its own informers when it's not set.
+	K8sMetadata *component.ID `mapstructure:"k8s_metadata"`
 }
 
 func (cfg *Config) Validate() error {
@@ -109,6 +122,21 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	kinds := map[string]bool{}
+	for i, r := range cfg.Resources {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("resources[%d]: %w", i, err)
+		}
+		if kinds[r.Kind] {
+			return fmt.Errorf("resources[%d]: duplicate kind %q", i, r.Kind)
+		}
+		kinds[r.Kind] = true
+	}
+
+	if cfg.OwnerChain.MaxDepth < 0 {
+		return errors.New("owner_chain::max_depth must not be negative")
+	}
+
 	for _, f := range cfg.Filter.Labels {
 		switch f.Op {
 		case "", filterOPEquals, filterOPNotEquals, filterOPExists, filterOPDoesNotExist:
@@ -327,3 +355,73 @@ type PodAssociationSourceConfig struct {
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
+
+// ResourceConfig specifies a resource watched with a dynamic informer, how telemetry
+// refers to its objects, and the labels and annotations extracted from them.
+type ResourceConfig struct {
+	// Group, Version and Resource identify the watched resource,
+	// e.g. group "argoproj.io", version "v1alpha1" and resource "rollouts".
+	Group    string `mapstructure:"group"`
+	Version  string `mapstructure:"version"`
+	Resource string `mapstructure:"resource"`
+
+	// Kind is the kind of the objects of the resource, as found in owner references,
+	// e.g. "Rollout". The resource attributes of the objects are named after it.
+	Kind string `mapstructure:"kind"`
+
+	// NameAttribute is the resource attribute holding the name of the object the telemetry
+	// refers to. The default is k8s.<kind>.name, e.g. k8s.rollout.name.
+	NameAttribute string `mapstructure:"name_attribute"`
+
+	// UIDAttribute is the resource attribute holding the UID of the object the telemetry
+	// refers to. The default is k8s.<kind>.uid, e.g. k8s.rollout.uid.
+	UIDAttribute string `mapstructure:"uid_attribute"`
+
+	// Labels allows extracting data from the labels of the objects.
+	// The `from` setting isn't supported.
+	Labels []FieldExtractConfig `mapstructure:"labels"`
+
+	// Annotations allows extracting data from the annotations of the objects.
+	// The `from` setting isn't supported.
+	Annotations []FieldExtractConfig `mapstructure:"annotations"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *ResourceConfig) validate() error {
+	if cfg.Version == "" || cfg.Resource == "" {
+		return errors.New("version and resource must be set")
+	}
+	if cfg.Kind == "" {
+		return errors.New("kind must be set")
+	}
+	for _, f := range append(cfg.Labels, cfg.Annotations...) {
+		if f.Key != "" && f.KeyRegex != "" {
+			return fmt.Errorf("Out of Key or KeyRegex only one option is expected to be configured at a time, currently Key:%s and KeyRegex:%s", f.Key, f.KeyRegex)
+		}
+		if f.From != "" {
+			return fmt.Errorf("from is not supported for the labels and annotations of %s", cfg.Kind)
+		}
+		if f.KeyRegex != "" {
+			if _, err := regexp.Compile("^(?:" + f.KeyRegex + ")$"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// OwnerChainConfig allows resolving the chain of owners of the pods, and of the objects of
+// the resources the telemetry refers to, by following their owner references through the
+// watched resources.
+type OwnerChainConfig struct {
+	// Enabled enables the resolution of the owner chain.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxDepth is the maximum number of owners followed.
+	MaxDepth int `mapstructure:"max_depth"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
@@ -33,6 +33,7 @@ func TestLoadConfig(t *testing.T) {
 					Metadata: enabledAttributes(),
 				},
 				WaitForMetadataTimeout: 10 * time.Second,
+				OwnerChain:             OwnerChainConfig{MaxDepth: 10},
 			},
 		},
 		{
@@ -105,6 +106,7 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				WaitForMetadataTimeout: 10 * time.Second,
+				OwnerChain:             OwnerChainConfig{MaxDepth: 10},
 			},
 		},
 		{
@@ -128,6 +130,52 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				WaitForMetadataTimeout: 10 * time.Second,
+				OwnerChain:             OwnerChainConfig{MaxDepth: 10},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "resources"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Exclude:   ExcludeConfig{Pods: []ExcludePodConfig{{Name: "jaeger-agent"}, {Name: "jaeger-collector"}}},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+				},
+				WaitForMetadataTimeout: 10 * time.Second,
+				Resources: []ResourceConfig{
+					{
+						Version:  "v1",
+						Resource: "services",
+						Kind:     "Service",
+						Labels: []FieldExtractConfig{
+							{Key: "team"},
+						},
+					},
+					{
+						Group:        "argoproj.io",
+						Version:      "v1alpha1",
+						Resource:     "rollouts",
+						Kind:         "Rollout",
+						UIDAttribute: "argo.rollout.uid",
+						Annotations: []FieldExtractConfig{
+							{TagName: "rollout.revision", Key: "rollout.argoproj.io/revision"},
+						},
+					},
+				},
+				OwnerChain: OwnerChainConfig{Enabled: true, MaxDepth: 5},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "k8s_metadata"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Exclude:   ExcludeConfig{Pods: []ExcludePodConfig{{Name: "jaeger-agent"}, {Name: "jaeger-collector"}}},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+				},
+				WaitForMetadataTimeout: 10 * time.Second,
+				OwnerChain:             OwnerChainConfig{MaxDepth: 10},
+				K8sMetadata:            func() *component.ID { id := component.MustNewIDWithName("k8s_metadata", "shared"); return &id }(),
 			},
 		},
 		{
@@ -157,6 +205,18 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_filter_field_op"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_resource_kind"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "duplicate_resource_kind"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_from_resource_labels"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_owner_chain_max_depth"),
+		},
 	}
 
 	for _, tt := range tests {
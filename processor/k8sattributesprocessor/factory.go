@@ -22,6 +22,7 @@ import (
 
 var (
 	kubeClientProvider   = kube.ClientProvider(nil)
+	objectClientProvider = kube.ObjectClientProvider(nil)
 	consumerCapabilities = consumer.Capabilities{MutatesData: true}
 	defaultExcludes      = ExcludeConfig{Pods: []ExcludePodConfig{{Name: "jaeger-agent"}, {Name: "jaeger-collector"}}}
 )
@@ -46,6 +47,9 @@ func createDefaultConfig() component.Config {
 			Metadata: enabledAttributes(),
 		},
 		WaitForMetadataTimeout: 10 * time.Second,
+		OwnerChain: OwnerChainConfig{
+			MaxDepth: 10,
+		},
 	}
 }
 
@@ -202,7 +206,9 @@ func createProcessorOpts(cfg component.Config) []option {
 		withAPIConfig(oCfg.APIConfig),
 		withExtractPodAssociations(oCfg.Association...),
 		withExcludes(oCfg.Exclude),
-		withWaitForMetadataTimeout(oCfg.WaitForMetadataTimeout))
+		withWaitForMetadataTimeout(oCfg.WaitForMetadataTimeout),
+		withResources(oCfg.Resources...),
+		withOwnerChain(oCfg.OwnerChain.Enabled, oCfg.OwnerChain.MaxDepth))
 
 	if oCfg.WaitForMetadata {
 		opts = append(opts, withWaitForMetadata(true))
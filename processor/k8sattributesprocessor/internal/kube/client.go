@@ -25,6 +25,7 @@ import (
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -146,6 +147,13 @@ type InformersFactoryList struct {
 	newInformer           InformerProvider
 	newNamespaceInformer  InformerProviderNamespace
 	newReplicaSetInformer InformerProviderWorkload
+	sharedInformer        SharedInformerGetter
+}
+
+// NewSharedInformersFactoryList returns an InformersFactoryList getting the informers
+// shared with the other components of the collector from the given getter.
+func NewSharedInformersFactoryList(getter SharedInformerGetter) InformersFactoryList {
+	return InformersFactoryList{sharedInformer: getter}
 }
 
 // New initializes a new k8s Client.
@@ -211,6 +219,16 @@ func New(
 		informersFactory.newInformer = newSharedInformer
 	}
 
+	// newInformer returns the informer shared with the other components of the collector
+	// when a getter is configured, or the informer created with the given func otherwise.
+	newInformer := func(gvr schema.GroupVersionResource, namespace string, ls labels.Selector, fs fields.Selector, create func() cache.SharedInformer) (cache.SharedInformer, error) {
+		if informersFactory.sharedInformer == nil {
+			return create(), nil
+		}
+		return informersFactory.sharedInformer(gvr, namespace, ls, fs)
+	}
+
+	namespaceFieldSelector := fields.Everything()
 	if informersFactory.newNamespaceInformer == nil {
 		switch {
 		case c.extractNamespaceLabelsAnnotations():
@@ -221,12 +239,18 @@ func New(
 			// use kube-system shared informer to only watch kube-system namespace
 			// reducing overhead of watching all the namespaces
 			informersFactory.newNamespaceInformer = newKubeSystemSharedInformer
+			namespaceFieldSelector = fields.OneTermEqualSelector("metadata.name", kubeSystemNamespace)
 		default:
 			informersFactory.newNamespaceInformer = NewNoOpInformer
 		}
 	}
 
-	c.informer = informersFactory.newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+	c.informer, err = newInformer(podsGVR, c.Filters.Namespace, labelSelector, fieldSelector, func() cache.SharedInformer {
+		return informersFactory.newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+	})
+	if err != nil {
+		return nil, err
+	}
 	err = c.informer.SetTransform(
 		func(object any) (any, error) {
 			originalPod, success := object.(*api_v1.Pod)
@@ -241,13 +265,28 @@ func New(
 		return nil, err
 	}
 
-	c.namespaceInformer = informersFactory.newNamespaceInformer(c.kc)
+	if c.extractNamespaceLabelsAnnotations() || rules.ClusterUID {
+		c.namespaceInformer, err = newInformer(namespacesGVR, "", labels.Everything(), namespaceFieldSelector, func() cache.SharedInformer {
+			return informersFactory.newNamespaceInformer(c.kc)
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.namespaceInformer = informersFactory.newNamespaceInformer(c.kc)
+	}
 
-	if rules.DeploymentName || rules.DeploymentUID {
+	// The owner chain falls back to the deployments of the replica sets when they aren't watched
+	if rules.DeploymentName || rules.DeploymentUID || rules.OwnerChain {
 		if informersFactory.newReplicaSetInformer == nil {
 			informersFactory.newReplicaSetInformer = newReplicaSetSharedInformer
 		}
-		c.replicasetInformer = informersFactory.newReplicaSetInformer(c.kc, c.Filters.Namespace)
+		c.replicasetInformer, err = newInformer(replicaSetsGVR, c.Filters.Namespace, labels.Everything(), fields.Everything(), func() cache.SharedInformer {
+			return informersFactory.newReplicaSetInformer(c.kc, c.Filters.Namespace)
+		})
+		if err != nil {
+			return nil, err
+		}
 		err = c.replicasetInformer.SetTransform(
 			func(object any) (any, error) {
 				originalReplicaset, success := object.(*apps_v1.ReplicaSet)
@@ -264,23 +303,52 @@ func New(
 	}
 
 	if c.extractNodeLabelsAnnotations() || c.extractNodeUID() {
-		c.nodeInformer = k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
+		nodeFieldSelector := fields.Everything()
+		if c.Filters.Node != "" {
+			nodeFieldSelector = fields.OneTermEqualSelector("metadata.name", c.Filters.Node)
+		}
+		c.nodeInformer, err = newInformer(nodesGVR, "", labels.Everything(), nodeFieldSelector, func() cache.SharedInformer {
+			return k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.extractDeploymentLabelsAnnotations() {
-		c.deploymentInformer = newDeploymentSharedInformer(c.kc, c.Filters.Namespace)
+		c.deploymentInformer, err = newInformer(deploymentsGVR, c.Filters.Namespace, labels.Everything(), fields.Everything(), func() cache.SharedInformer {
+			return newDeploymentSharedInformer(c.kc, c.Filters.Namespace)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.extractStatefulSetLabelsAnnotations() {
-		c.statefulsetInformer = newStatefulSetSharedInformer(c.kc, c.Filters.Namespace)
+		c.statefulsetInformer, err = newInformer(statefulSetsGVR, c.Filters.Namespace, labels.Everything(), fields.Everything(), func() cache.SharedInformer {
+			return newStatefulSetSharedInformer(c.kc, c.Filters.Namespace)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.extractDaemonSetLabelsAnnotations() {
-		c.daemonsetInformer = newDaemonSetSharedInformer(c.kc, c.Filters.Namespace)
+		c.daemonsetInformer, err = newInformer(daemonSetsGVR, c.Filters.Namespace, labels.Everything(), fields.Everything(), func() cache.SharedInformer {
+			return newDaemonSetSharedInformer(c.kc, c.Filters.Namespace)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.extractJobLabelsAnnotations() {
-		c.jobInformer = newJobSharedInformer(c.kc, c.Filters.Namespace)
+		c.jobInformer, err = newInformer(jobsGVR, c.Filters.Namespace, labels.Everything(), fields.Everything(), func() cache.SharedInformer {
+			return newJobSharedInformer(c.kc, c.Filters.Namespace)
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return c, err
@@ -291,7 +359,7 @@ func (c *WatchClient) Start() error {
 	synced := make([]cache.InformerSynced, 0)
 	// start the replicaSet informer first, as the replica sets need to be
 	// present at the time the pods are handled, to correctly establish the connection between pods and deployments
-	if c.Rules.DeploymentName || c.Rules.DeploymentUID {
+	if c.Rules.DeploymentName || c.Rules.DeploymentUID || c.Rules.OwnerChain {
 		reg, err := c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleReplicaSetAdd,
 			UpdateFunc: c.handleReplicaSetUpdate,
@@ -1262,6 +1330,13 @@ func (c *WatchClient) podFromAPI(pod *api_v1.Pod) *Pod {
 		newPod.JobUID = job.UID
 	}
 
+	if c.Rules.OwnerChain {
+		newPod.OwnerReferences = pod.OwnerReferences
+		if replicaset, ok := c.getReplicaSet(getPodReplicaSetUID(pod)); ok {
+			newPod.DeploymentName = replicaset.Deployment.Name
+		}
+	}
+
 	if c.shouldIgnorePod(pod) {
 		newPod.Ignore = true
 	} else {
@@ -23,6 +23,7 @@ import (
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
@@ -3129,3 +3130,36 @@ func TestGetIdentifiersFromAssoc(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWithSharedInformers(t *testing.T) {
+	type informerRequest struct {
+		gvr           schema.GroupVersionResource
+		namespace     string
+		labelSelector string
+		fieldSelector string
+	}
+	var requests []informerRequest
+	getter := func(gvr schema.GroupVersionResource, namespace string, ls labels.Selector, fs fields.Selector) (cache.SharedInformer, error) {
+		requests = append(requests, informerRequest{gvr, namespace, ls.String(), fs.String()})
+		return NewFakeInformer(fake.NewSimpleClientset(), namespace, ls, fs), nil
+	}
+
+	rules := ExtractionRules{DeploymentName: true, ClusterUID: true, NodeUID: true}
+	filters := Filters{Namespace: "shop", Node: "node-1"}
+	c, err := New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, filters, []Association{}, Excludes{}, newFakeAPIClientset, NewSharedInformersFactoryList(getter), false, 10*time.Second)
+	require.NoError(t, err)
+	c.Stop()
+
+	assert.Equal(t, []informerRequest{
+		{gvr: podsGVR, namespace: "shop", fieldSelector: "spec.nodeName=node-1"},
+		{gvr: namespacesGVR, fieldSelector: "metadata.name=kube-system"},
+		{gvr: replicaSetsGVR, namespace: "shop"},
+		{gvr: nodesGVR, fieldSelector: "metadata.name=node-1"},
+	}, requests)
+
+	getter = func(schema.GroupVersionResource, string, labels.Selector, fields.Selector) (cache.SharedInformer, error) {
+		return nil, errors.New("extension shut down")
+	}
+	_, err = New(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, filters, []Association{}, Excludes{}, newFakeAPIClientset, NewSharedInformersFactoryList(getter), false, 10*time.Second)
+	require.EqualError(t, err, "extension shut down")
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -20,6 +21,17 @@ import (
 
 const kubeSystemNamespace = "kube-system"
 
+var (
+	podsGVR         = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	namespacesGVR   = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+	nodesGVR        = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+	replicaSetsGVR  = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	deploymentsGVR  = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	statefulSetsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+	daemonSetsGVR   = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}
+	jobsGVR         = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+)
+
 // InformerProvider defines a function type that returns a new SharedInformer. It is used to
 // allow passing custom shared informers to the watch client.
 type InformerProvider func(
@@ -29,6 +41,17 @@ type InformerProvider func(
 	fieldSelector fields.Selector,
 ) cache.SharedInformer
 
+// SharedInformerGetter defines a function type that returns an informer shared with the other
+// components of the collector, which watches the given resource with the given namespace and
+// selectors. Running the informer doesn't run the shared informer, but removes the event handlers
+// added to it once the given channel is closed, and setting a transform on it has no effect.
+type SharedInformerGetter func(
+	gvr schema.GroupVersionResource,
+	namespace string,
+	labelSelector labels.Selector,
+	fieldSelector fields.Selector,
+) (cache.SharedInformer, error)
+
 // InformerProviderNamespace defines a function type that returns a new SharedInformer. It is used to
 // allow passing custom shared informers to the watch client for fetching namespace objects.
 type InformerProviderNamespace func(
@@ -130,6 +130,12 @@ type Pod struct {
 	JobUID         string
 	HostNetwork    bool
 
+	// OwnerReferences are the owner references of the pod, only set if the owner chain is resolved.
+	OwnerReferences []metav1.OwnerReference
+	// DeploymentName is the name of the deployment of the replica set of the pod, only set if the
+	// owner chain is resolved.
+	DeploymentName string
+
 	// Containers specifies all containers in this pod.
 	Containers PodContainers
 
@@ -260,6 +266,7 @@ type ExtractionRules struct {
 	ServiceName               bool
 	ServiceVersion            bool
 	ServiceInstanceID         bool
+	OwnerChain                bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
@@ -279,6 +286,7 @@ func (rules *ExtractionRules) IncludesOwnerMetadata() bool {
 		rules.ReplicaSetName,
 		rules.StatefulSetUID,
 		rules.StatefulSetName,
+		rules.OwnerChain,
 	}
 	for _, ruleEnabled := range rulesNeedingOwnerMetadata {
 		if ruleEnabled {
@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// K8sResourceLabel is the default attribute name of the labels extracted from a resource.
+	K8sResourceLabel = "k8s.%s.label.%%s"
+	// K8sResourceAnnotation is the default attribute name of the annotations extracted from a resource.
+	K8sResourceAnnotation = "k8s.%s.annotation.%%s"
+
+	// K8sWorkloadKind is the attribute holding the kind of the root owner of the owner chain.
+	K8sWorkloadKind = "k8s.workload.kind"
+	// K8sWorkloadName is the attribute holding the name of the root owner of the owner chain.
+	K8sWorkloadName = "k8s.workload.name"
+	// K8sWorkloadUID is the attribute holding the UID of the root owner of the owner chain.
+	K8sWorkloadUID = "k8s.workload.uid"
+)
+
+// ResourceRule specifies a resource watched with a dynamic informer, how telemetry
+// refers to its objects, and the attributes extracted from them.
+type ResourceRule struct {
+	GVR schema.GroupVersionResource
+	// Kind is the kind of the objects of the resource, as found in owner references.
+	Kind string
+	// NameAttribute is the resource attribute holding the name of the object.
+	NameAttribute string
+	// UIDAttribute is the resource attribute holding the UID of the object.
+	UIDAttribute string
+	Labels       []FieldExtractionRule
+	Annotations  []FieldExtractionRule
+}
+
+// KindAttributeName returns the name of the resource attribute of the given field for
+// objects of the given kind, for example k8s.rollout.name.
+func KindAttributeName(kind, field string) string {
+	return fmt.Sprintf("k8s.%s.%s", strings.ToLower(kind), field)
+}
+
+// Object represents a kubernetes object watched with a dynamic informer.
+type Object struct {
+	Kind            string
+	Name            string
+	Namespace       string
+	UID             string
+	OwnerReferences []meta_v1.OwnerReference
+	// Attributes holds the labels and annotations extracted from the object.
+	Attributes map[string]string
+}
+
+// ObjectClient allows querying the objects of the resources watched with dynamic informers.
+type ObjectClient interface {
+	GetObject(kind, namespace, name string) (*Object, bool)
+	GetObjectByUID(uid string) (*Object, bool)
+	Start() error
+	Stop()
+}
+
+// ObjectClientProvider defines a func type that returns a new ObjectClient.
+type ObjectClientProvider func(component.TelemetrySettings, k8sconfig.APIConfig, []ResourceRule, Filters, DynamicClientProvider, bool, time.Duration) (ObjectClient, error)
+
+// DynamicClientProvider defines a func type that initializes and return a new kubernetes
+// dynamic client.
+type DynamicClientProvider func(config k8sconfig.APIConfig) (dynamic.Interface, error)
+
+type objectKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// ObjectWatchClient watches the objects of the configured resources with dynamic informers.
+type ObjectWatchClient struct {
+	m         sync.RWMutex
+	logger    *zap.Logger
+	rules     []ResourceRule
+	informers []cache.SharedIndexInformer
+	stopCh    chan struct{}
+
+	waitForMetadata        bool
+	waitForMetadataTimeout time.Duration
+
+	// A map containing the watched objects by UID.
+	objects map[string]*Object
+	// A map containing the watched objects by kind, namespace and name.
+	objectsByName map[objectKey]*Object
+}
+
+// NewObjectClient initializes a new ObjectClient watching the resources of the given rules,
+// in the namespace of the filters if set.
+func NewObjectClient(
+	set component.TelemetrySettings,
+	apiCfg k8sconfig.APIConfig,
+	rules []ResourceRule,
+	filters Filters,
+	newDynamicClient DynamicClientProvider,
+	waitForMetadata bool,
+	waitForMetadataTimeout time.Duration,
+) (ObjectClient, error) {
+	if newDynamicClient == nil {
+		newDynamicClient = k8sconfig.MakeDynamicClient
+	}
+	dc, err := newDynamicClient(apiCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ObjectWatchClient{
+		logger:                 set.Logger,
+		rules:                  rules,
+		stopCh:                 make(chan struct{}),
+		waitForMetadata:        waitForMetadata,
+		waitForMetadataTimeout: waitForMetadataTimeout,
+		objects:                map[string]*Object{},
+		objectsByName:          map[objectKey]*Object{},
+	}
+	for _, rule := range rules {
+		informer := dynamicinformer.NewFilteredDynamicInformer(dc, rule.GVR, filters.Namespace, watchSyncPeriod, cache.Indexers{}, nil).Informer()
+		err = informer.SetTransform(
+			func(object any) (any, error) {
+				originalObject, success := object.(*unstructured.Unstructured)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
+
+				return removeUnnecessaryObjectData(originalObject), nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		c.informers = append(c.informers, informer)
+	}
+	return c, nil
+}
+
+// Start registers the event handlers and starts watching the resources.
+func (c *ObjectWatchClient) Start() error {
+	synced := make([]cache.InformerSynced, 0, len(c.informers))
+	for i, informer := range c.informers {
+		rule := &c.rules[i]
+		reg, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj any) {
+				c.handleObjectAdd(rule, obj)
+			},
+			UpdateFunc: func(_, newObj any) {
+				c.handleObjectAdd(rule, newObj)
+			},
+			DeleteFunc: func(obj any) {
+				c.handleObjectDelete(rule, obj)
+			},
+		})
+		if err != nil {
+			return err
+		}
+		synced = append(synced, reg.HasSynced)
+		go informer.Run(c.stopCh)
+	}
+
+	if c.waitForMetadata {
+		timeoutCh := make(chan struct{})
+		t := time.AfterFunc(c.waitForMetadataTimeout, func() {
+			close(timeoutCh)
+		})
+		defer t.Stop()
+		if !cache.WaitForCacheSync(timeoutCh, synced...) {
+			return errors.New("failed to wait for caches to sync")
+		}
+	}
+	return nil
+}
+
+// Stop signals the informers to stop watching the resources.
+func (c *ObjectWatchClient) Stop() {
+	close(c.stopCh)
+}
+
+// GetObject returns the object of the given kind with the given namespace and name.
+// Cluster-scoped objects have an empty namespace.
+func (c *ObjectWatchClient) GetObject(kind, namespace, name string) (*Object, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	object, ok := c.objectsByName[objectKey{kind: kind, namespace: namespace, name: name}]
+	return object, ok
+}
+
+// GetObjectByUID returns the object with the given UID.
+func (c *ObjectWatchClient) GetObjectByUID(uid string) (*Object, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	object, ok := c.objects[uid]
+	return object, ok
+}
+
+func (c *ObjectWatchClient) handleObjectAdd(rule *ResourceRule, obj any) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		object := objectFromAPI(rule, u)
+		c.m.Lock()
+		c.objects[object.UID] = object
+		c.objectsByName[objectKey{kind: object.Kind, namespace: object.Namespace, name: object.Name}] = object
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received of an unexpected type", zap.Any("received", obj))
+	}
+}
+
+func (c *ObjectWatchClient) handleObjectDelete(rule *ResourceRule, obj any) {
+	if u, ok := ignoreDeletedFinalStateUnknown(obj).(*unstructured.Unstructured); ok {
+		c.m.Lock()
+		delete(c.objects, string(u.GetUID()))
+		delete(c.objectsByName, objectKey{kind: rule.Kind, namespace: u.GetNamespace(), name: u.GetName()})
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received of an unexpected type", zap.Any("received", obj))
+	}
+}
+
+// removeUnnecessaryObjectData keeps the metadata of the object needed for the enrichment only.
+func removeUnnecessaryObjectData(object *unstructured.Unstructured) *unstructured.Unstructured {
+	transformedObject := &unstructured.Unstructured{Object: map[string]any{}}
+	transformedObject.SetAPIVersion(object.GetAPIVersion())
+	transformedObject.SetKind(object.GetKind())
+	transformedObject.SetName(object.GetName())
+	transformedObject.SetNamespace(object.GetNamespace())
+	transformedObject.SetUID(object.GetUID())
+	transformedObject.SetResourceVersion(object.GetResourceVersion())
+	transformedObject.SetLabels(object.GetLabels())
+	transformedObject.SetAnnotations(object.GetAnnotations())
+	transformedObject.SetOwnerReferences(object.GetOwnerReferences())
+	return transformedObject
+}
+
+func objectFromAPI(rule *ResourceRule, u *unstructured.Unstructured) *Object {
+	object := &Object{
+		Kind:            rule.Kind,
+		Name:            u.GetName(),
+		Namespace:       u.GetNamespace(),
+		UID:             string(u.GetUID()),
+		OwnerReferences: u.GetOwnerReferences(),
+		Attributes:      map[string]string{},
+	}
+	kind := strings.ToLower(rule.Kind)
+	for _, r := range rule.Labels {
+		r.extractFromMetadata(u.GetLabels(), object.Attributes, fmt.Sprintf(K8sResourceLabel, kind))
+	}
+	for _, r := range rule.Annotations {
+		r.extractFromMetadata(u.GetAnnotations(), object.Attributes, fmt.Sprintf(K8sResourceAnnotation, kind))
+	}
+	return object
+}
+
+// OwnerChain follows the owner references from the given ones up to the root owner,
+// and returns the owners from the closest to the root. The controller reference is
+// followed when there are several owners. The owners that aren't watched by the
+// client end the chain, and are only described by their reference.
+func OwnerChain(c ObjectClient, refs []meta_v1.OwnerReference, maxDepth int) []*Object {
+	var chain []*Object
+	visited := map[string]bool{}
+	for len(chain) < maxDepth {
+		ref, ok := controllerReference(refs)
+		if !ok || visited[string(ref.UID)] {
+			break
+		}
+		visited[string(ref.UID)] = true
+
+		owner, ok := c.GetObjectByUID(string(ref.UID))
+		if !ok {
+			chain = append(chain, &Object{Kind: ref.Kind, Name: ref.Name, UID: string(ref.UID)})
+			break
+		}
+		chain = append(chain, owner)
+		refs = owner.OwnerReferences
+	}
+	return chain
+}
+
+func controllerReference(refs []meta_v1.OwnerReference) (meta_v1.OwnerReference, bool) {
+	if len(refs) == 0 {
+		return meta_v1.OwnerReference{}, false
+	}
+	for _, ref := range refs {
+		if ref.Controller != nil && *ref.Controller {
+			return ref, true
+		}
+	}
+	return refs[0], true
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+var rolloutGVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+
+func newRollout(name, uid string, labels map[string]string, owners ...meta_v1.OwnerReference) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{}}
+	u.SetAPIVersion("argoproj.io/v1alpha1")
+	u.SetKind("Rollout")
+	u.SetName(name)
+	u.SetNamespace("shop")
+	u.SetUID(types.UID(uid))
+	u.SetLabels(labels)
+	u.SetOwnerReferences(owners)
+	u.Object["spec"] = map[string]any{"replicas": int64(3)}
+	return u
+}
+
+func newTestObjectClient(t *testing.T, objects ...runtime.Object) *ObjectWatchClient {
+	rules := []ResourceRule{
+		{
+			GVR:  rolloutGVR,
+			Kind: "Rollout",
+			Labels: []FieldExtractionRule{
+				{Name: "team", Key: "team"},
+				{KeyRegex: regexp.MustCompile("^(?:app.*)$")},
+			},
+		},
+	}
+	dynamicClient := func(k8sconfig.APIConfig) (dynamic.Interface, error) {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{rolloutGVR: "RolloutList"}, objects...), nil
+	}
+	c, err := NewObjectClient(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, rules, Filters{}, dynamicClient, true, 5*time.Second)
+	require.NoError(t, err)
+	return c.(*ObjectWatchClient)
+}
+
+func TestObjectClientConstructorError(t *testing.T) {
+	dynamicClient := func(k8sconfig.APIConfig) (dynamic.Interface, error) {
+		return nil, errors.New("error creating dynamic client")
+	}
+	c, err := NewObjectClient(componenttest.NewNopTelemetrySettings(), k8sconfig.APIConfig{}, nil, Filters{}, dynamicClient, false, 0)
+	assert.Nil(t, c)
+	require.EqualError(t, err, "error creating dynamic client")
+}
+
+func TestObjectClientStartStop(t *testing.T) {
+	c := newTestObjectClient(t, newRollout("checkout", "rollout-uid", map[string]string{"team": "payments", "app.kubernetes.io/name": "checkout"}))
+	// The client waits for the informers to be synced
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	_, ok := c.GetObjectByUID("rollout-uid")
+	require.True(t, ok)
+
+	object, ok := c.GetObject("Rollout", "shop", "checkout")
+	require.True(t, ok)
+	assert.Equal(t, &Object{
+		Kind:      "Rollout",
+		Name:      "checkout",
+		Namespace: "shop",
+		UID:       "rollout-uid",
+		Attributes: map[string]string{
+			"team": "payments",
+			"k8s.rollout.label.app.kubernetes.io/name": "checkout",
+		},
+	}, object)
+}
+
+func TestObjectAddDelete(t *testing.T) {
+	c := newTestObjectClient(t)
+	rule := &c.rules[0]
+
+	c.handleObjectAdd(rule, removeUnnecessaryObjectData(newRollout("checkout", "rollout-uid", nil)))
+	_, ok := c.GetObject("Rollout", "shop", "checkout")
+	assert.True(t, ok)
+
+	c.handleObjectDelete(rule, cache.DeletedFinalStateUnknown{
+		Key: "shop/checkout",
+		Obj: newRollout("checkout", "rollout-uid", nil),
+	})
+	_, ok = c.GetObjectByUID("rollout-uid")
+	assert.False(t, ok)
+	_, ok = c.GetObject("Rollout", "shop", "checkout")
+	assert.False(t, ok)
+
+	// Objects of unexpected types are ignored.
+	c.handleObjectAdd(rule, &Pod{})
+	c.handleObjectDelete(rule, &Pod{})
+	assert.Empty(t, c.objects)
+}
+
+func TestRemoveUnnecessaryObjectData(t *testing.T) {
+	isController := true
+	owner := meta_v1.OwnerReference{Kind: "ScaledJob", Name: "checkout", UID: "scaledjob-uid", Controller: &isController}
+	u := removeUnnecessaryObjectData(newRollout("checkout", "rollout-uid", map[string]string{"team": "payments"}, owner))
+
+	assert.NotContains(t, u.Object, "spec")
+	assert.Equal(t, "checkout", u.GetName())
+	assert.Equal(t, "shop", u.GetNamespace())
+	assert.Equal(t, types.UID("rollout-uid"), u.GetUID())
+	assert.Equal(t, map[string]string{"team": "payments"}, u.GetLabels())
+	assert.Equal(t, []meta_v1.OwnerReference{owner}, u.GetOwnerReferences())
+}
+
+func TestOwnerChain(t *testing.T) {
+	isController := true
+	c := newTestObjectClient(t)
+	c.objects = map[string]*Object{
+		"replicaset-uid": {
+			Kind: "ReplicaSet", Name: "checkout-5d8f", UID: "replicaset-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "Rollout", Name: "checkout", UID: "rollout-uid", Controller: &isController},
+			},
+		},
+		"rollout-uid": {
+			Kind: "Rollout", Name: "checkout", UID: "rollout-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				{Kind: "Application", Name: "shop", UID: "application-uid"},
+			},
+		},
+		"cycle-a": {
+			Kind: "Custom", Name: "a", UID: "cycle-a",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Custom", Name: "b", UID: "cycle-b"}},
+		},
+		"cycle-b": {
+			Kind: "Custom", Name: "b", UID: "cycle-b",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Custom", Name: "a", UID: "cycle-a"}},
+		},
+	}
+
+	podRefs := []meta_v1.OwnerReference{
+		{Kind: "Other", Name: "other", UID: "other-uid"},
+		{Kind: "ReplicaSet", Name: "checkout-5d8f", UID: "replicaset-uid", Controller: &isController},
+	}
+
+	tests := []struct {
+		name     string
+		refs     []meta_v1.OwnerReference
+		maxDepth int
+		want     []string
+	}{
+		{
+			name:     "no owners",
+			maxDepth: 10,
+		},
+		{
+			name:     "follows the controller up to an owner that isn't watched",
+			refs:     podRefs,
+			maxDepth: 10,
+			want:     []string{"replicaset-uid", "rollout-uid", "application-uid"},
+		},
+		{
+			name:     "max depth",
+			refs:     podRefs,
+			maxDepth: 2,
+			want:     []string{"replicaset-uid", "rollout-uid"},
+		},
+		{
+			name:     "cycle",
+			refs:     []meta_v1.OwnerReference{{Kind: "Custom", Name: "a", UID: "cycle-a"}},
+			maxDepth: 10,
+			want:     []string{"cycle-a", "cycle-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, owner := range OwnerChain(c, tt.refs, tt.maxDepth) {
+				got = append(got, owner.UID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -418,3 +420,63 @@ func withWaitForMetadataTimeout(timeout time.Duration) option {
 		return nil
 	}
 }
+
+// withResources allows specifying the resources watched to enrich the telemetry referring
+// to their objects, and the labels and annotations extracted from them.
+func withResources(resources ...ResourceConfig) option {
+	return func(p *kubernetesprocessor) error {
+		rules := make([]kube.ResourceRule, 0, len(resources))
+		for _, r := range resources {
+			rule := kube.ResourceRule{
+				GVR:           schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource},
+				Kind:          r.Kind,
+				NameAttribute: r.NameAttribute,
+				UIDAttribute:  r.UIDAttribute,
+			}
+			if rule.NameAttribute == "" {
+				rule.NameAttribute = kube.KindAttributeName(r.Kind, "name")
+			}
+			if rule.UIDAttribute == "" {
+				rule.UIDAttribute = kube.KindAttributeName(r.Kind, "uid")
+			}
+
+			var err error
+			if rule.Labels, err = extractResourceFieldRules(r.Kind, "label", r.Labels...); err != nil {
+				return err
+			}
+			if rule.Annotations, err = extractResourceFieldRules(r.Kind, "annotation", r.Annotations...); err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+		}
+		p.resourceRules = rules
+		return nil
+	}
+}
+
+// extractResourceFieldRules builds the extraction rules of the labels or annotations of the
+// objects of the given kind, named k8s.<kind>.<fieldType>.<key> by default.
+func extractResourceFieldRules(kind, fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
+	rules, err := extractFieldRules(fieldType, fields...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		rules[i].From = strings.ToLower(kind)
+		if fields[i].TagName == "" && fields[i].Key != "" {
+			rules[i].Name = kube.KindAttributeName(kind, fieldType+"."+fields[i].Key)
+		}
+	}
+	return rules, nil
+}
+
+// withOwnerChain allows resolving the chain of owners of the pods and of the objects of the
+// watched resources, following up to maxDepth owner references.
+func withOwnerChain(enabled bool, maxDepth int) option {
+	return func(p *kubernetesprocessor) error {
+		p.ownerChain = enabled
+		p.ownerChainMaxDepth = maxDepth
+		p.rules.OwnerChain = enabled
+		return nil
+	}
+}
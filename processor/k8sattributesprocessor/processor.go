@@ -18,7 +18,13 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/otel/semconv/v1.8.0"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
 )
@@ -41,6 +47,11 @@ type kubernetesprocessor struct {
 	podIgnore              kube.Excludes
 	waitForMetadata        bool
 	waitForMetadataTimeout time.Duration
+	oc                     kube.ObjectClient
+	sharedInformers        kube.SharedInformerGetter
+	resourceRules          []kube.ResourceRule
+	ownerChain             bool
+	ownerChainMaxDepth     int
 }
 
 func (kp *kubernetesprocessor) initKubeClient(set component.TelemetrySettings, kubeClient kube.ClientProvider) error {
@@ -48,7 +59,11 @@ func (kp *kubernetesprocessor) initKubeClient(set component.TelemetrySettings, k
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(set, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, kube.InformersFactoryList{}, kp.waitForMetadata, kp.waitForMetadataTimeout)
+		informersFactory := kube.InformersFactoryList{}
+		if kp.sharedInformers != nil {
+			informersFactory = kube.NewSharedInformersFactoryList(kp.sharedInformers)
+		}
+		kc, err := kubeClient(set, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, informersFactory, kp.waitForMetadata, kp.waitForMetadataTimeout)
 		if err != nil {
 			return err
 		}
@@ -57,7 +72,31 @@ func (kp *kubernetesprocessor) initKubeClient(set component.TelemetrySettings, k
 	return nil
 }
 
+func (kp *kubernetesprocessor) initObjectClient(set component.TelemetrySettings, objectClient kube.ObjectClientProvider) error {
+	if objectClient == nil {
+		objectClient = kube.NewObjectClient
+	}
+	if !kp.passthroughMode && (len(kp.resourceRules) > 0 || kp.ownerChain) {
+		oc, err := objectClient(set, kp.apiConfig, kp.resourceRules, kp.filters, nil, kp.waitForMetadata, kp.waitForMetadataTimeout)
+		if err != nil {
+			return err
+		}
+		kp.oc = oc
+	}
+	return nil
+}
+
 func (kp *kubernetesprocessor) Start(_ context.Context, host component.Host) error {
+	if id := kp.cfg.(*Config).K8sMetadata; id != nil && !kp.cfg.(*Config).Passthrough {
+		sharedInformers, err := getSharedInformers(host, *id)
+		if err != nil {
+			kp.logger.Error("Could not get the k8s metadata extension", zap.Error(err))
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
+			return err
+		}
+		kp.sharedInformers = sharedInformers
+	}
+
 	allOptions := append(createProcessorOpts(kp.cfg), kp.options...)
 
 	for _, opt := range allOptions {
@@ -77,12 +116,26 @@ func (kp *kubernetesprocessor) Start(_ context.Context, host component.Host) err
 			return err
 		}
 	}
+	if kp.oc == nil {
+		err := kp.initObjectClient(kp.telemetrySettings, objectClientProvider)
+		if err != nil {
+			kp.logger.Error("Could not initialize object client", zap.Error(err))
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
+			return err
+		}
+	}
 	if !kp.passthroughMode {
 		err := kp.kc.Start()
 		if err != nil {
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
 			return err
 		}
+		if kp.oc != nil {
+			if err := kp.oc.Start(); err != nil {
+				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -93,10 +146,33 @@ func (kp *kubernetesprocessor) Shutdown(context.Context) error {
 	}
 	if !kp.passthroughMode {
 		kp.kc.Stop()
+		if kp.oc != nil {
+			kp.oc.Stop()
+		}
 	}
 	return nil
 }
 
+// getSharedInformers returns the getter of the informers shared by the given k8s_metadata extension.
+func getSharedInformers(host component.Host, id component.ID) (kube.SharedInformerGetter, error) {
+	ext := host.GetExtensions()[id]
+	if ext == nil {
+		return nil, fmt.Errorf("unknown k8s metadata extension %q", id)
+	}
+	provider, ok := ext.(k8smetadataextension.InformerProvider)
+	if !ok {
+		return nil, fmt.Errorf("the extension %T does not implement k8smetadataextension.InformerProvider", ext)
+	}
+	return func(gvr schema.GroupVersionResource, namespace string, ls labels.Selector, fs fields.Selector) (cache.SharedInformer, error) {
+		return provider.Informer(k8smetadataextension.InformerKey{
+			Resource:      gvr,
+			Namespace:     namespace,
+			LabelSelector: ls.String(),
+			FieldSelector: fs.String(),
+		})
+	}, nil
+}
+
 // processTraces process traces and add k8s metadata using resource IP or incoming IP as pod origin.
 func (kp *kubernetesprocessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	rss := td.ResourceSpans()
@@ -220,6 +296,77 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 			setResourceAttribute(resource.Attributes(), key, val)
 		}
 	}
+
+	if kp.oc != nil {
+		kp.addObjectAttributes(resource.Attributes(), pod)
+	}
+}
+
+// addObjectAttributes adds the metadata of the objects of the watched resources the resource
+// refers to, and of the owners of these objects and of the pod when the owner chain is enabled.
+func (kp *kubernetesprocessor) addObjectAttributes(attrs pcommon.Map, pod *kube.Pod) {
+	for i := range kp.resourceRules {
+		object, found := kp.getObject(&kp.resourceRules[i], pod, attrs)
+		if !found {
+			continue
+		}
+		addObjectMetadata(attrs, object)
+		if kp.ownerChain {
+			kp.addOwnerChainAttributes(attrs, object.OwnerReferences, nil)
+		}
+	}
+
+	if kp.ownerChain && pod != nil {
+		kp.addOwnerChainAttributes(attrs, pod.OwnerReferences, pod)
+	}
+}
+
+// getObject returns the object of the rule the resource refers to by UID, or by name
+// in the namespace of the resource. Cluster-scoped objects are looked up without namespace.
+func (kp *kubernetesprocessor) getObject(rule *kube.ResourceRule, pod *kube.Pod, attrs pcommon.Map) (*kube.Object, bool) {
+	if uid := stringAttributeFromMap(attrs, rule.UIDAttribute); uid != "" {
+		if object, found := kp.oc.GetObjectByUID(uid); found {
+			return object, true
+		}
+	}
+	name := stringAttributeFromMap(attrs, rule.NameAttribute)
+	if name == "" {
+		return nil, false
+	}
+	if namespace := getNamespace(pod, attrs); namespace != "" {
+		if object, found := kp.oc.GetObject(rule.Kind, namespace, name); found {
+			return object, true
+		}
+	}
+	return kp.oc.GetObject(rule.Kind, "", name)
+}
+
+// addOwnerChainAttributes adds the metadata of the owners followed from the given owner
+// references, and describes the root owner as the workload. The chain of a pod whose
+// replica set isn't watched is completed with the deployment of the replica set.
+func (kp *kubernetesprocessor) addOwnerChainAttributes(attrs pcommon.Map, refs []metav1.OwnerReference, pod *kube.Pod) {
+	chain := kube.OwnerChain(kp.oc, refs, kp.ownerChainMaxDepth)
+	if len(chain) == 0 {
+		return
+	}
+	if pod != nil && pod.DeploymentUID != "" && len(chain) == 1 && kp.ownerChainMaxDepth > 1 && chain[0].Kind == "ReplicaSet" {
+		chain = append(chain, &kube.Object{Kind: "Deployment", Name: pod.DeploymentName, UID: pod.DeploymentUID})
+	}
+	for _, owner := range chain {
+		addObjectMetadata(attrs, owner)
+	}
+	root := chain[len(chain)-1]
+	setResourceAttribute(attrs, kube.K8sWorkloadKind, root.Kind)
+	setResourceAttribute(attrs, kube.K8sWorkloadName, root.Name)
+	setResourceAttribute(attrs, kube.K8sWorkloadUID, root.UID)
+}
+
+func addObjectMetadata(attrs pcommon.Map, object *kube.Object) {
+	setResourceAttribute(attrs, kube.KindAttributeName(object.Kind, "name"), object.Name)
+	setResourceAttribute(attrs, kube.KindAttributeName(object.Kind, "uid"), object.UID)
+	for key, val := range object.Attributes {
+		setResourceAttribute(attrs, key, val)
+	}
 }
 
 func setResourceAttribute(attributes pcommon.Map, key, val string) {
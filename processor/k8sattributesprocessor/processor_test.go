@@ -28,7 +28,13 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/processor/xprocessor"
 	conventions "go.opentelemetry.io/otel/semconv/v1.8.0"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/k8smetadataextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/metadata"
@@ -105,6 +111,13 @@ func withKubeClientProvider(kcp kube.ClientProvider) option {
 	}
 }
 
+// withObjectClientProvider sets the specific implementation for getting ObjectClient instances
+func withObjectClientProvider(ocp kube.ObjectClientProvider) option {
+	return func(p *kubernetesprocessor) error {
+		return p.initObjectClient(p.telemetrySettings, ocp)
+	}
+}
+
 // withExtractKubernetesProcessorInto allows to pull the internal model easily even when processorhelper factory is used
 func withExtractKubernetesProcessorInto(kp **kubernetesprocessor) option {
 	return func(p *kubernetesprocessor) error {
@@ -276,6 +289,68 @@ func TestProcessorBadClientProvider(t *testing.T) {
 	}, withKubeClientProvider(clientProvider))
 }
 
+func TestProcessorUnknownK8sMetadataExtension(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	id := component.MustNewID("k8s_metadata")
+	cfg.K8sMetadata = &id
+
+	newMultiTest(t, cfg, func(err error) {
+		require.EqualError(t, err, `unknown k8s metadata extension "k8s_metadata"`)
+	})
+}
+
+type fakeInformerProvider struct {
+	component.StartFunc
+	component.ShutdownFunc
+	keys []k8smetadataextension.InformerKey
+}
+
+func (f *fakeInformerProvider) Informer(key k8smetadataextension.InformerKey) (cache.SharedIndexInformer, error) {
+	f.keys = append(f.keys, key)
+	return nil, errors.New("not implemented")
+}
+
+func (*fakeInformerProvider) DynamicInformer(k8smetadataextension.InformerKey) (cache.SharedIndexInformer, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestGetSharedInformers(t *testing.T) {
+	id := component.MustNewID("k8s_metadata")
+	provider := &fakeInformerProvider{}
+	host := &extensionsHost{extensions: map[component.ID]component.Component{
+		id: provider,
+		component.MustNewID("other"): struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{},
+	}}
+
+	_, err := getSharedInformers(host, component.MustNewID("unknown"))
+	require.EqualError(t, err, `unknown k8s metadata extension "unknown"`)
+	_, err = getSharedInformers(host, component.MustNewID("other"))
+	require.ErrorContains(t, err, "does not implement k8smetadataextension.InformerProvider")
+
+	getter, err := getSharedInformers(host, id)
+	require.NoError(t, err)
+	_, err = getter(schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "shop", labels.Everything(), fields.OneTermEqualSelector("spec.nodeName", "node-1"))
+	require.EqualError(t, err, "not implemented")
+	assert.Equal(t, []k8smetadataextension.InformerKey{
+		{
+			Resource:      schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Namespace:     "shop",
+			FieldSelector: "spec.nodeName=node-1",
+		},
+	}, provider.keys)
+}
+
+type extensionsHost struct {
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
 type generateResourceFunc func(res pcommon.Resource)
 
 func generateTraces(resourceFunc ...generateResourceFunc) ptrace.Traces {
@@ -360,6 +435,13 @@ func withContainerRunID(containerRunID string) generateResourceFunc {
 	}
 }
 
+func withK8sServiceName(namespace, name string) generateResourceFunc {
+	return func(res pcommon.Resource) {
+		res.Attributes().PutStr("k8s.namespace.name", namespace)
+		res.Attributes().PutStr("k8s.service.name", name)
+	}
+}
+
 type strAddr string
 
 func (strAddr) String() string {
@@ -1011,6 +1093,152 @@ func TestAddNodeUID(t *testing.T) {
 	})
 }
 
+func TestAddResourceAttributes(t *testing.T) {
+	isController := true
+	m := newMultiTest(
+		t,
+		func() component.Config {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Extract.Metadata = []string{}
+			cfg.Resources = []ResourceConfig{
+				{Version: "v1", Resource: "services", Kind: "Service"},
+				{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts", Kind: "Rollout"},
+			}
+			cfg.OwnerChain.Enabled = true
+			return cfg
+		}(),
+		nil,
+		withObjectClientProvider(newFakeObjectClient),
+	)
+
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.oc.(*fakeObjectClient).Objects = []*kube.Object{
+			{
+				Kind:       "Service",
+				Name:       "checkout",
+				Namespace:  "shop",
+				UID:        "svc-uid",
+				Attributes: map[string]string{"k8s.service.label.team": "payments"},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "Rollout", Name: "checkout", UID: "rollout-uid", Controller: &isController},
+				},
+			},
+			{
+				Kind:       "Rollout",
+				Name:       "checkout",
+				Namespace:  "shop",
+				UID:        "rollout-uid",
+				Attributes: map[string]string{"k8s.rollout.label.tier": "backend"},
+			},
+		}
+	})
+
+	m.testConsume(
+		t.Context(),
+		generateTraces(withK8sServiceName("shop", "checkout")),
+		generateMetrics(withK8sServiceName("shop", "checkout")),
+		generateLogs(withK8sServiceName("shop", "checkout")),
+		generateProfiles(withK8sServiceName("shop", "checkout")),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assert.Equal(t, 10, res.Attributes().Len())
+		assertResourceHasStringAttribute(t, res, "k8s.service.uid", "svc-uid")
+		assertResourceHasStringAttribute(t, res, "k8s.service.label.team", "payments")
+		assertResourceHasStringAttribute(t, res, "k8s.rollout.name", "checkout")
+		assertResourceHasStringAttribute(t, res, "k8s.rollout.uid", "rollout-uid")
+		assertResourceHasStringAttribute(t, res, "k8s.rollout.label.tier", "backend")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.kind", "Rollout")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.name", "checkout")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.uid", "rollout-uid")
+	})
+}
+
+func TestAddPodOwnerChain(t *testing.T) {
+	isController := true
+	m := newMultiTest(
+		t,
+		func() component.Config {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Extract.Metadata = []string{}
+			cfg.Resources = []ResourceConfig{
+				{Version: "v1", Group: "batch", Resource: "jobs", Kind: "Job"},
+			}
+			cfg.OwnerChain.Enabled = true
+			return cfg
+		}(),
+		nil,
+		withObjectClientProvider(newFakeObjectClient),
+	)
+
+	podIP := "1.1.1.1"
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		pi := kube.PodIdentifier{
+			kube.PodIdentifierAttributeFromConnection(podIP),
+		}
+		kp.kc.(*fakeClient).Pods[pi] = &kube.Pod{
+			Name:      "report-28012345-abcde",
+			Namespace: "batch",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Job", Name: "report-28012345", UID: "job-uid", Controller: &isController},
+			},
+		}
+		kp.oc.(*fakeObjectClient).Objects = []*kube.Object{
+			{
+				Kind:      "Job",
+				Name:      "report-28012345",
+				Namespace: "batch",
+				UID:       "job-uid",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "CronJob", Name: "report", UID: "cronjob-uid", Controller: &isController},
+				},
+			},
+		}
+	})
+
+	ctx := client.NewContext(t.Context(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP(podIP),
+		},
+	})
+	m.testConsume(
+		ctx,
+		generateTraces(),
+		generateMetrics(),
+		generateLogs(),
+		generateProfiles(),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assert.Equal(t, 8, res.Attributes().Len())
+		assertResourceHasStringAttribute(t, res, "k8s.pod.ip", podIP)
+		assertResourceHasStringAttribute(t, res, "k8s.job.name", "report-28012345")
+		assertResourceHasStringAttribute(t, res, "k8s.job.uid", "job-uid")
+		assertResourceHasStringAttribute(t, res, "k8s.cronjob.name", "report")
+		assertResourceHasStringAttribute(t, res, "k8s.cronjob.uid", "cronjob-uid")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.kind", "CronJob")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.name", "report")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.uid", "cronjob-uid")
+	})
+}
+
 func TestProcessorAddContainerAttributes(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1832,3 +2060,73 @@ func Test_setResourceAttribute(t *testing.T) {
 		})
 	}
 }
+
+func TestAddPodOwnerChainReplicaSetDeployment(t *testing.T) {
+	isController := true
+	m := newMultiTest(
+		t,
+		func() component.Config {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Extract.Metadata = []string{}
+			cfg.OwnerChain.Enabled = true
+			return cfg
+		}(),
+		nil,
+		withObjectClientProvider(newFakeObjectClient),
+	)
+
+	podIP := "1.1.1.1"
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "connection",
+					},
+				},
+			},
+		}
+		pi := kube.PodIdentifier{
+			kube.PodIdentifierAttributeFromConnection(podIP),
+		}
+		kp.kc.(*fakeClient).Pods[pi] = &kube.Pod{
+			Name:           "checkout-5d8f7c9b4-abcde",
+			Namespace:      "shop",
+			DeploymentUID:  "deployment-uid",
+			DeploymentName: "checkout",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "checkout-5d8f7c9b4", UID: "replicaset-uid", Controller: &isController},
+			},
+		}
+	})
+
+	ctx := client.NewContext(t.Context(), client.Info{
+		Addr: &net.IPAddr{
+			IP: net.ParseIP(podIP),
+		},
+	})
+	m.testConsume(
+		ctx,
+		generateTraces(),
+		generateMetrics(),
+		generateLogs(),
+		generateProfiles(),
+		func(err error) {
+			assert.NoError(t, err)
+		})
+
+	// The replica set isn't watched, the chain ends at the deployment of the replica set
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	m.assertResource(0, func(res pcommon.Resource) {
+		assert.Equal(t, 8, res.Attributes().Len())
+		assertResourceHasStringAttribute(t, res, "k8s.pod.ip", podIP)
+		assertResourceHasStringAttribute(t, res, "k8s.replicaset.name", "checkout-5d8f7c9b4")
+		assertResourceHasStringAttribute(t, res, "k8s.replicaset.uid", "replicaset-uid")
+		assertResourceHasStringAttribute(t, res, "k8s.deployment.name", "checkout")
+		assertResourceHasStringAttribute(t, res, "k8s.deployment.uid", "deployment-uid")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.kind", "Deployment")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.name", "checkout")
+		assertResourceHasStringAttribute(t, res, "k8s.workload.uid", "deployment-uid")
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrulesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// EvaluationInterval is the interval at which the rules are evaluated.
+	EvaluationInterval time.Duration `mapstructure:"evaluation_interval"`
+
+	// Window is how long the samples are kept in memory. It must cover the largest
+	// range and offset of the rule expressions plus the lookback delta.
+	Window time.Duration `mapstructure:"window"`
+
+	// LookbackDelta is the maximum age of the latest sample of a series for it to be
+	// selected by an instant vector selector.
+	LookbackDelta time.Duration `mapstructure:"lookback_delta"`
+
+	// MaxSeries is the maximum number of series kept in memory. The samples of
+	// additional series are not used in the rule evaluations.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// MaxSamples is the maximum number of samples loaded by a single rule evaluation.
+	MaxSamples int `mapstructure:"max_samples"`
+
+	// AddMetricSuffixes controls whether the type and unit suffixes are added to the
+	// Prometheus names of the metrics, as done by the Prometheus exporters.
+	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
+
+	// DropInputMetrics drops the incoming metrics after they were added to the window,
+	// so that only the results of the rules are sent to the next consumer.
+	DropInputMetrics bool `mapstructure:"drop_input_metrics"`
+
+	// Rules are the recording rules, evaluated in order at each evaluation interval.
+	// The results of a rule can be used by the next rules.
+	Rules []RuleConfig `mapstructure:"rules"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// RuleConfig defines a recording rule.
+type RuleConfig struct {
+	// Record is the name of the metric the results are recorded as.
+	Record string `mapstructure:"record"`
+
+	// Expr is the PromQL expression evaluated, it must return an instant vector.
+	Expr string `mapstructure:"expr"`
+
+	// Labels are added to the results, overwriting the labels of the expression.
+	Labels map[string]string `mapstructure:"labels"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks that the rules are valid and only use supported PromQL features.
+func (cfg *Config) Validate() error {
+	if cfg.EvaluationInterval <= 0 {
+		return errors.New("evaluation_interval must be greater than 0")
+	}
+	if cfg.LookbackDelta <= 0 {
+		return errors.New("lookback_delta must be greater than 0")
+	}
+	if cfg.MaxSeries <= 0 || cfg.MaxSamples <= 0 {
+		return errors.New("max_series and max_samples must be greater than 0")
+	}
+	if len(cfg.Rules) == 0 {
+		return errors.New("at least one rule must be configured")
+	}
+
+	records := make(map[string]struct{}, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		if !isValidMetricName(rule.Record) {
+			return fmt.Errorf("rules[%d]: invalid record name %q", i, rule.Record)
+		}
+		for name := range rule.Labels {
+			if !isValidLabelName(name) || name == metricNameLabel {
+				return fmt.Errorf("rules[%d]: invalid label name %q", i, name)
+			}
+		}
+		if _, ok := records[rule.Record]; ok {
+			return fmt.Errorf("rules[%d]: duplicate record name %q", i, rule.Record)
+		}
+		records[rule.Record] = struct{}{}
+
+		_, maxRange, err := parseRuleExpr(rule.Expr)
+		if err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if required := maxRange + cfg.LookbackDelta; cfg.Window < required {
+			return fmt.Errorf("rules[%d]: window must be at least %s to evaluate %q", i, required, rule.Expr)
+		}
+	}
+	return nil
+}
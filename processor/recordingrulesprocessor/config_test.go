@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrulesprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: &Config{
+				EvaluationInterval: 30 * time.Second,
+				Window:             15 * time.Minute,
+				LookbackDelta:      2 * time.Minute,
+				MaxSeries:          1000,
+				MaxSamples:         10000,
+				AddMetricSuffixes:  false,
+				DropInputMetrics:   true,
+				Rules: []RuleConfig{
+					{
+						Record: "job:http_requests:rate5m",
+						Expr:   "sum by (job) (rate(http_requests_total[5m]))",
+					},
+					{
+						Record: "job:http_request_duration_seconds:p99",
+						Expr:   "histogram_quantile(0.99, sum by (job, le) (rate(http_request_duration_seconds_bucket[10m])))",
+						Labels: map[string]string{"quantile": "0.99"},
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_rules"),
+			errorMessage: "at least one rule must be configured",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unsupported_function"),
+			errorMessage: `rules[0]: unsupported function "sort" in expression "sort(http_requests_total)"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "subquery"),
+			errorMessage: `rules[0]: subqueries are not supported in expression "max_over_time(rate(http_requests_total[1m])[5m:1m])"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "small_window"),
+			errorMessage: `rules[0]: window must be at least 15m0s to evaluate "sum by (job) (rate(http_requests_total[10m]))"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_record"),
+			errorMessage: `rules[0]: invalid record name "job-http-requests"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_record"),
+			errorMessage: `rules[1]: duplicate record name "job:http_requests:sum"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "scalar_expr"),
+			errorMessage: `rules[0]: expression "1" must return an instant vector, got scalar`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.errorMessage != "" {
+				assert.EqualError(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestParseRuleExpr(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		maxRange time.Duration
+		err      string
+	}{
+		{
+			name: "instant selector",
+			expr: `up{job="api"}`,
+		},
+		{
+			name:     "largest range and offset",
+			expr:     `rate(a_total[5m]) / rate(b_total[1m] offset 10m)`,
+			maxRange: 11 * time.Minute,
+		},
+		{
+			name:     "offset on instant selector",
+			expr:     `up offset 3m`,
+			maxRange: 3 * time.Minute,
+		},
+		{
+			name: "at modifier",
+			expr: `up @ 100`,
+			err:  `the @ modifier is not supported in expression "up @ 100"`,
+		},
+		{
+			name: "negative offset",
+			expr: `up offset -1m`,
+			err:  `negative offsets are not supported in expression "up offset -1m"`,
+		},
+		{
+			name: "range vector",
+			expr: `up[5m]`,
+			err:  `expression "up[5m]" must return an instant vector, got matrix`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, maxRange, err := parseRuleExpr(tt.expr)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.maxRange, maxRange)
+		})
+	}
+}
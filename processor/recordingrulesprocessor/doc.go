@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// package recordingrulesprocessor implements a processor which evaluates Prometheus
+// recording rules over a sliding window of the incoming metrics, and emits their
+// results as new metrics
+package recordingrulesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor"
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package window implements an in-memory Prometheus storage keeping the samples
+// received over a sliding time window, so that PromQL expressions can be evaluated
+// on the metrics flowing through the collector.
+package window // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor/internal/window"
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+// ErrTooManySeries is returned when a sample of a new series is appended while the
+// storage already holds the maximum number of series.
+var ErrTooManySeries = errors.New("too many series")
+
+var _ storage.Queryable = (*Storage)(nil)
+
+// Storage holds the float samples of the series received since the last truncation.
+// It is safe for concurrent use.
+type Storage struct {
+	maxSeries int
+
+	mu        sync.RWMutex
+	series    map[uint64][]*memSeries
+	numSeries int
+}
+
+type memSeries struct {
+	lset    labels.Labels
+	samples []sample
+}
+
+// New returns an empty storage holding at most maxSeries series.
+func New(maxSeries int) *Storage {
+	return &Storage{
+		maxSeries: maxSeries,
+		series:    make(map[uint64][]*memSeries),
+	}
+}
+
+// Append adds a sample to a series. Samples must be appended in timestamp order:
+// a sample older than the latest sample of its series is rejected, a sample with
+// the same timestamp replaces it.
+func (s *Storage) Append(lset labels.Labels, t int64, v float64) error {
+	hash := lset.Hash()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	series := s.getLocked(hash, lset)
+	if series == nil {
+		if s.numSeries >= s.maxSeries {
+			return ErrTooManySeries
+		}
+		series = &memSeries{lset: lset}
+		s.series[hash] = append(s.series[hash], series)
+		s.numSeries++
+	}
+
+	if n := len(series.samples); n > 0 {
+		last := &series.samples[n-1]
+		switch {
+		case t < last.t:
+			return storage.ErrOutOfOrderSample
+		case t == last.t:
+			last.f = v
+			return nil
+		}
+	}
+	series.samples = append(series.samples, sample{t: t, f: v})
+	return nil
+}
+
+func (s *Storage) getLocked(hash uint64, lset labels.Labels) *memSeries {
+	for _, series := range s.series[hash] {
+		if labels.Equal(series.lset, lset) {
+			return series
+		}
+	}
+	return nil
+}
+
+// Truncate removes the samples older than mint, and the series left without samples.
+func (s *Storage) Truncate(mint int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for hash, collisions := range s.series {
+		kept := collisions[:0]
+		for _, series := range collisions {
+			i := sort.Search(len(series.samples), func(i int) bool { return series.samples[i].t >= mint })
+			if i == len(series.samples) {
+				s.numSeries--
+				continue
+			}
+			if i > 0 {
+				series.samples = slices.Clone(series.samples[i:])
+			}
+			kept = append(kept, series)
+		}
+		if len(kept) == 0 {
+			delete(s.series, hash)
+			continue
+		}
+		s.series[hash] = kept
+	}
+}
+
+// NumSeries returns the number of series in the storage.
+func (s *Storage) NumSeries() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.numSeries
+}
+
+// Querier returns a querier over the samples between mint and maxt.
+func (s *Storage) Querier(mint, maxt int64) (storage.Querier, error) {
+	return &querier{storage: s, mint: mint, maxt: maxt}, nil
+}
+
+type querier struct {
+	storage    *Storage
+	mint, maxt int64
+}
+
+// Select returns a copy of the samples of the matching series, so that the storage
+// can be appended to while the query is evaluated.
+func (q *querier) Select(_ context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	mint, maxt := q.mint, q.maxt
+	if hints != nil {
+		mint, maxt = max(mint, hints.Start), min(maxt, hints.End)
+	}
+
+	q.storage.mu.RLock()
+	var result []storage.Series
+	for _, collisions := range q.storage.series {
+		for _, series := range collisions {
+			if !matches(series.lset, matchers) {
+				continue
+			}
+			start := sort.Search(len(series.samples), func(i int) bool { return series.samples[i].t >= mint })
+			end := sort.Search(len(series.samples), func(i int) bool { return series.samples[i].t > maxt })
+			if start >= end {
+				continue
+			}
+			samples := make([]chunks.Sample, 0, end-start)
+			for _, s := range series.samples[start:end] {
+				samples = append(samples, s)
+			}
+			result = append(result, storage.NewListSeries(series.lset, samples))
+		}
+	}
+	q.storage.mu.RUnlock()
+
+	if sortSeries {
+		slices.SortFunc(result, func(a, b storage.Series) int { return labels.Compare(a.Labels(), b.Labels()) })
+	}
+	return &seriesSet{series: result, i: -1}
+}
+
+func (q *querier) LabelValues(_ context.Context, name string, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	values := make(map[string]struct{})
+	q.forEachMatching(matchers, func(lset labels.Labels) {
+		if v := lset.Get(name); v != "" {
+			values[v] = struct{}{}
+		}
+	})
+	return sortedKeys(values), nil, nil
+}
+
+func (q *querier) LabelNames(_ context.Context, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	names := make(map[string]struct{})
+	q.forEachMatching(matchers, func(lset labels.Labels) {
+		lset.Range(func(l labels.Label) { names[l.Name] = struct{}{} })
+	})
+	return sortedKeys(names), nil, nil
+}
+
+func (*querier) Close() error { return nil }
+
+func (q *querier) forEachMatching(matchers []*labels.Matcher, f func(labels.Labels)) {
+	q.storage.mu.RLock()
+	defer q.storage.mu.RUnlock()
+	for _, collisions := range q.storage.series {
+		for _, series := range collisions {
+			if matches(series.lset, matchers) {
+				f(series.lset)
+			}
+		}
+	}
+}
+
+func matches(lset labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(lset.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type seriesSet struct {
+	series []storage.Series
+	i      int
+}
+
+func (s *seriesSet) Next() bool {
+	s.i++
+	return s.i < len(s.series)
+}
+
+func (s *seriesSet) At() storage.Series              { return s.series[s.i] }
+func (*seriesSet) Err() error                        { return nil }
+func (*seriesSet) Warnings() annotations.Annotations { return nil }
+
+// sample is a float sample, it implements chunks.Sample.
+type sample struct {
+	t int64
+	f float64
+}
+
+func (s sample) T() int64                    { return s.t }
+func (s sample) F() float64                  { return s.f }
+func (sample) H() *histogram.Histogram       { return nil }
+func (sample) FH() *histogram.FloatHistogram { return nil }
+func (sample) Type() chunkenc.ValueType      { return chunkenc.ValFloat }
+func (s sample) Copy() chunks.Sample         { return s }
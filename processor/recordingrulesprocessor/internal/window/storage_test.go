@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package window
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type point struct {
+	t int64
+	f float64
+}
+
+func selectAll(t *testing.T, s *Storage, mint, maxt int64, matchers ...*labels.Matcher) map[string][]point {
+	q, err := s.Querier(mint, maxt)
+	require.NoError(t, err)
+	defer q.Close()
+
+	result := make(map[string][]point)
+	set := q.Select(context.Background(), true, nil, matchers...)
+	for set.Next() {
+		series := set.At()
+		var points []point
+		it := series.Iterator(nil)
+		for it.Next() == chunkenc.ValFloat {
+			ts, f := it.At()
+			points = append(points, point{t: ts, f: f})
+		}
+		require.NoError(t, it.Err())
+		result[series.Labels().String()] = points
+	}
+	require.NoError(t, set.Err())
+	return result
+}
+
+func TestStorageAppend(t *testing.T) {
+	s := New(10)
+	up := labels.FromStrings("__name__", "up", "job", "api")
+
+	require.NoError(t, s.Append(up, 1000, 1))
+	require.NoError(t, s.Append(up, 2000, 0))
+	// The same timestamp replaces the value.
+	require.NoError(t, s.Append(up, 2000, 1))
+	assert.ErrorIs(t, s.Append(up, 1500, 1), storage.ErrOutOfOrderSample)
+
+	assert.Equal(t, map[string][]point{
+		up.String(): {{t: 1000, f: 1}, {t: 2000, f: 1}},
+	}, selectAll(t, s, 0, 3000))
+}
+
+func TestStorageMaxSeries(t *testing.T) {
+	s := New(2)
+	require.NoError(t, s.Append(labels.FromStrings("__name__", "a"), 1000, 1))
+	require.NoError(t, s.Append(labels.FromStrings("__name__", "b"), 1000, 1))
+	assert.ErrorIs(t, s.Append(labels.FromStrings("__name__", "c"), 1000, 1), ErrTooManySeries)
+	// Existing series can still be appended to.
+	require.NoError(t, s.Append(labels.FromStrings("__name__", "a"), 2000, 1))
+	assert.Equal(t, 2, s.NumSeries())
+}
+
+func TestStorageTruncate(t *testing.T) {
+	s := New(10)
+	a := labels.FromStrings("__name__", "a")
+	b := labels.FromStrings("__name__", "b")
+	require.NoError(t, s.Append(a, 1000, 1))
+	require.NoError(t, s.Append(a, 3000, 3))
+	require.NoError(t, s.Append(b, 1000, 1))
+
+	s.Truncate(2000)
+
+	assert.Equal(t, 1, s.NumSeries())
+	assert.Equal(t, map[string][]point{
+		a.String(): {{t: 3000, f: 3}},
+	}, selectAll(t, s, 0, 5000))
+
+	// A truncated series can be created again.
+	require.NoError(t, s.Append(b, 4000, 4))
+	assert.Equal(t, 2, s.NumSeries())
+}
+
+func TestQuerier(t *testing.T) {
+	s := New(10)
+	a := labels.FromStrings("__name__", "requests_total", "job", "api")
+	b := labels.FromStrings("__name__", "requests_total", "job", "db")
+	c := labels.FromStrings("__name__", "up", "job", "api")
+	for _, lset := range []labels.Labels{a, b, c} {
+		for ts := int64(1000); ts <= 5000; ts += 1000 {
+			require.NoError(t, s.Append(lset, ts, float64(ts)))
+		}
+	}
+
+	t.Run("select", func(t *testing.T) {
+		result := selectAll(t, s, 2000, 3000, labels.MustNewMatcher(labels.MatchEqual, "__name__", "requests_total"))
+		assert.Equal(t, map[string][]point{
+			a.String(): {{t: 2000, f: 2000}, {t: 3000, f: 3000}},
+			b.String(): {{t: 2000, f: 2000}, {t: 3000, f: 3000}},
+		}, result)
+	})
+
+	t.Run("select outside of the range", func(t *testing.T) {
+		assert.Empty(t, selectAll(t, s, 6000, 7000))
+	})
+
+	t.Run("label values", func(t *testing.T) {
+		q, err := s.Querier(0, 5000)
+		require.NoError(t, err)
+		values, _, err := q.LabelValues(context.Background(), "job", nil, labels.MustNewMatcher(labels.MatchEqual, "__name__", "requests_total"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api", "db"}, values)
+	})
+
+	t.Run("label names", func(t *testing.T) {
+		q, err := s.Querier(0, 5000)
+		require.NoError(t, err)
+		names, _, err := q.LabelNames(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"__name__", "job"}, names)
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrulesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor/internal/window"
+)
+
+var _ processor.Metrics = (*recordingRulesProcessor)(nil)
+
+type rule struct {
+	record string
+	expr   string
+	labels map[string]string
+}
+
+// recordingRulesProcessor keeps the samples of the incoming metrics over a sliding window,
+// and periodically evaluates the recording rules on them with the Prometheus engine.
+type recordingRulesProcessor struct {
+	config       *Config
+	logger       *zap.Logger
+	nextConsumer consumer.Metrics
+
+	rules  []rule
+	window *window.Storage
+	engine *promql.Engine
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newProcessor(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) *recordingRulesProcessor {
+	rules := make([]rule, 0, len(config.Rules))
+	for _, r := range config.Rules {
+		rules = append(rules, rule{record: r.Record, expr: r.Expr, labels: r.Labels})
+	}
+
+	return &recordingRulesProcessor{
+		config:       config,
+		logger:       logger,
+		nextConsumer: nextConsumer,
+		rules:        rules,
+		window:       window.New(config.MaxSeries),
+		engine: promql.NewEngine(promql.EngineOpts{
+			MaxSamples:    config.MaxSamples,
+			Timeout:       config.EvaluationInterval,
+			LookbackDelta: config.LookbackDelta,
+		}),
+	}
+}
+
+func (p *recordingRulesProcessor) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.config.EvaluationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				p.evaluate(ctx, now)
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *recordingRulesProcessor) Shutdown(_ context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+func (p *recordingRulesProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (p *recordingRulesProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var tooManySeries int
+	appendMetrics(md, p.config.AddMetricSuffixes, func(lset labels.Labels, t int64, v float64) {
+		if err := p.window.Append(lset, t, v); err != nil {
+			if errors.Is(err, window.ErrTooManySeries) {
+				tooManySeries++
+				return
+			}
+			p.logger.Debug("Sample not added to the window", zap.Stringer("series", lset), zap.Error(err))
+		}
+	})
+	if tooManySeries > 0 {
+		p.logger.Warn("Samples not added to the window, max_series reached",
+			zap.Int("max_series", p.config.MaxSeries), zap.Int("dropped_samples", tooManySeries))
+	}
+
+	if p.config.DropInputMetrics {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+// evaluate removes the samples older than the window, evaluates the rules in order and
+// sends their results to the next consumer.
+func (p *recordingRulesProcessor) evaluate(ctx context.Context, ts time.Time) {
+	p.window.Truncate(ts.Add(-p.config.Window).UnixMilli())
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(metadata.ScopeName)
+
+	for _, r := range p.rules {
+		vector, err := p.evaluateRule(ctx, r, ts)
+		if err != nil {
+			p.logger.Warn("Failed to evaluate recording rule", zap.String("record", r.record), zap.Error(err))
+			continue
+		}
+		if len(vector) == 0 {
+			continue
+		}
+
+		metric := sm.Metrics().AppendEmpty()
+		metric.SetName(r.record)
+		dps := metric.SetEmptyGauge().DataPoints()
+		seen := make(map[uint64]struct{}, len(vector))
+		for _, sample := range vector {
+			if sample.H != nil {
+				continue
+			}
+			builder := labels.NewBuilder(sample.Metric)
+			for name, value := range r.labels {
+				builder.Set(name, value)
+			}
+			builder.Set(metricNameLabel, r.record)
+			lset := builder.Labels()
+
+			hash := lset.Hash()
+			if _, ok := seen[hash]; ok {
+				p.logger.Warn("Recording rule result contains series with the same labels after applying the rule labels", zap.String("record", r.record))
+				continue
+			}
+			seen[hash] = struct{}{}
+
+			// The results are added to the window so that the next rules can use them.
+			_ = p.window.Append(lset, sample.T, sample.F)
+
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(sample.T)))
+			dp.SetDoubleValue(sample.F)
+			lset.Range(func(l labels.Label) {
+				if l.Name != metricNameLabel {
+					dp.Attributes().PutStr(l.Name, l.Value)
+				}
+			})
+		}
+	}
+
+	if sm.Metrics().Len() == 0 {
+		return
+	}
+	if err := p.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Error("Failed to send the results of the recording rules", zap.Error(err))
+	}
+}
+
+func (p *recordingRulesProcessor) evaluateRule(ctx context.Context, r rule, ts time.Time) (promql.Vector, error) {
+	query, err := p.engine.NewInstantQuery(ctx, p.window, nil, r.expr, ts)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+
+	result := query.Exec(ctx)
+	vector, err := result.Vector()
+	if err != nil {
+		return nil, err
+	}
+	// The memory of the result is reused once the query is closed.
+	out := make(promql.Vector, len(vector))
+	copy(out, vector)
+	return out, nil
+}
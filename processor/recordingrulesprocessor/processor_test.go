@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrulesprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor/internal/metadata"
+)
+
+var testStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestProcessor(t *testing.T, dropInputMetrics bool, rules ...RuleConfig) (*recordingRulesProcessor, *consumertest.MetricsSink) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DropInputMetrics = dropInputMetrics
+	cfg.Rules = rules
+	require.NoError(t, cfg.Validate())
+
+	sink := &consumertest.MetricsSink{}
+	return newProcessor(cfg, zap.NewNop(), sink), sink
+}
+
+// counterMetrics returns a cumulative sum with one datapoint per minute for each service,
+// increasing by 60 every minute.
+func counterMetrics(minutes int, services ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, service := range services {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("http_requests")
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for i := 0; i <= minutes; i++ {
+			for _, method := range []string{"GET", "POST"} {
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(testStart.Add(time.Duration(i) * time.Minute)))
+				dp.SetIntValue(int64(60 * (i + 1)))
+				dp.Attributes().PutStr("http.method", method)
+			}
+		}
+	}
+	return md
+}
+
+// resultValues returns the values of the datapoints of a recorded metric by their job attribute.
+func resultValues(t *testing.T, md pmetric.Metrics, name string) map[string]float64 {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	sm := md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, metadata.ScopeName, sm.Scope().Name())
+
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		m := sm.Metrics().At(i)
+		if m.Name() != name {
+			continue
+		}
+		values := make(map[string]float64)
+		for j := 0; j < m.Gauge().DataPoints().Len(); j++ {
+			dp := m.Gauge().DataPoints().At(j)
+			job, ok := dp.Attributes().Get("job")
+			require.True(t, ok)
+			values[job.Str()] = dp.DoubleValue()
+		}
+		return values
+	}
+	t.Fatalf("metric %q not found", name)
+	return nil
+}
+
+func TestEvaluateRate(t *testing.T) {
+	p, sink := newTestProcessor(t, false, RuleConfig{
+		Record: "job:http_requests:rate5m",
+		Expr:   "sum by (job) (rate(http_requests_total[5m]))",
+	})
+
+	require.NoError(t, p.ConsumeMetrics(t.Context(), counterMetrics(4, "api", "db")))
+	require.Len(t, sink.AllMetrics(), 1)
+	sink.Reset()
+
+	ts := testStart.Add(4 * time.Minute)
+	p.evaluate(t.Context(), ts)
+	require.Len(t, sink.AllMetrics(), 1)
+
+	values := resultValues(t, sink.AllMetrics()[0], "job:http_requests:rate5m")
+	require.Len(t, values, 2)
+	// Each method increases by 1 per second.
+	assert.InDelta(t, 2, values["api"], 1e-9)
+	assert.InDelta(t, 2, values["db"], 1e-9)
+
+	dp := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(ts), dp.Timestamp())
+}
+
+func TestEvaluateChainedRules(t *testing.T) {
+	p, sink := newTestProcessor(t, true,
+		RuleConfig{
+			Record: "job:http_requests:sum",
+			Expr:   "sum by (job) (http_requests_total)",
+			Labels: map[string]string{"env": "prod"},
+		},
+		RuleConfig{
+			Record: "job:http_requests:double",
+			Expr:   `job:http_requests:sum{env="prod"} * 2`,
+		},
+	)
+
+	require.NoError(t, p.ConsumeMetrics(t.Context(), counterMetrics(1, "api")))
+	assert.Empty(t, sink.AllMetrics())
+
+	p.evaluate(t.Context(), testStart.Add(time.Minute))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+
+	assert.Equal(t, map[string]float64{"api": 240}, resultValues(t, md, "job:http_requests:sum"))
+	assert.Equal(t, map[string]float64{"api": 480}, resultValues(t, md, "job:http_requests:double"))
+
+	env, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().Get("env")
+	require.True(t, ok)
+	assert.Equal(t, "prod", env.Str())
+}
+
+func TestEvaluateHistogramQuantile(t *testing.T) {
+	p, sink := newTestProcessor(t, true, RuleConfig{
+		Record: "job:http_duration:p50",
+		Expr:   "histogram_quantile(0.5, sum by (job, le) (http_duration_bucket))",
+	})
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "api")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("http_duration")
+	histogram := m.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := histogram.DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(testStart))
+	dp.ExplicitBounds().FromRaw([]float64{1, 2})
+	dp.BucketCounts().FromRaw([]uint64{0, 10, 0})
+	dp.SetCount(10)
+	dp.SetSum(15)
+
+	require.NoError(t, p.ConsumeMetrics(t.Context(), md))
+	p.evaluate(t.Context(), testStart.Add(time.Minute))
+	require.Len(t, sink.AllMetrics(), 1)
+
+	assert.Equal(t, map[string]float64{"api": 1.5}, resultValues(t, sink.AllMetrics()[0], "job:http_duration:p50"))
+}
+
+func TestEvaluateNoResults(t *testing.T) {
+	p, sink := newTestProcessor(t, true, RuleConfig{
+		Record: "job:http_requests:sum",
+		Expr:   "sum by (job) (http_requests_total)",
+	})
+
+	p.evaluate(t.Context(), testStart)
+	assert.Empty(t, sink.AllMetrics())
+
+	// The samples older than the window and the lookback delta aren't selected.
+	require.NoError(t, p.ConsumeMetrics(t.Context(), counterMetrics(0, "api")))
+	p.evaluate(t.Context(), testStart.Add(time.Hour))
+	assert.Empty(t, sink.AllMetrics())
+	assert.Zero(t, p.window.NumSeries())
+}
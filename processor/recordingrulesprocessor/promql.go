@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrulesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor"
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const metricNameLabel = "__name__"
+
+var (
+	metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegexp  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// supportedFunctions are the PromQL functions that can be used in the rules. The functions
+// working on native histograms aren't supported as the window only holds float samples,
+// and the functions that don't make sense in a recording rule, like sort, are excluded.
+var supportedFunctions = map[string]struct{}{
+	// Range vector functions
+	"rate":               {},
+	"irate":              {},
+	"increase":           {},
+	"delta":              {},
+	"idelta":             {},
+	"deriv":              {},
+	"changes":            {},
+	"resets":             {},
+	"predict_linear":     {},
+	"avg_over_time":      {},
+	"min_over_time":      {},
+	"max_over_time":      {},
+	"sum_over_time":      {},
+	"count_over_time":    {},
+	"last_over_time":     {},
+	"quantile_over_time": {},
+	"stddev_over_time":   {},
+	"stdvar_over_time":   {},
+	"present_over_time":  {},
+	"absent_over_time":   {},
+	// Classic histograms
+	"histogram_quantile": {},
+	// Math functions
+	"abs":       {},
+	"ceil":      {},
+	"floor":     {},
+	"round":     {},
+	"sqrt":      {},
+	"exp":       {},
+	"ln":        {},
+	"log2":      {},
+	"log10":     {},
+	"sgn":       {},
+	"clamp":     {},
+	"clamp_min": {},
+	"clamp_max": {},
+	// Labels and types
+	"label_replace": {},
+	"label_join":    {},
+	"absent":        {},
+	"scalar":        {},
+	"vector":        {},
+	"time":          {},
+	"timestamp":     {},
+}
+
+// supportedAggregations are the PromQL aggregation operators that can be used in the rules.
+var supportedAggregations = map[parser.ItemType]struct{}{
+	parser.SUM:          {},
+	parser.AVG:          {},
+	parser.MIN:          {},
+	parser.MAX:          {},
+	parser.COUNT:        {},
+	parser.GROUP:        {},
+	parser.STDDEV:       {},
+	parser.STDVAR:       {},
+	parser.TOPK:         {},
+	parser.BOTTOMK:      {},
+	parser.QUANTILE:     {},
+	parser.COUNT_VALUES: {},
+}
+
+// parseRuleExpr parses the expression of a rule, checks that it only uses supported
+// features, and returns the largest time range it reads, including offsets.
+func parseRuleExpr(input string) (parser.Expr, time.Duration, error) {
+	expr, err := parser.ParseExpr(input)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid expression %q: %w", input, err)
+	}
+	if expr.Type() != parser.ValueTypeVector {
+		return nil, 0, fmt.Errorf("expression %q must return an instant vector, got %s", input, expr.Type())
+	}
+
+	var maxRange time.Duration
+	var unsupported error
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		if unsupported != nil {
+			return unsupported
+		}
+		switch n := node.(type) {
+		case *parser.Call:
+			if _, ok := supportedFunctions[n.Func.Name]; !ok {
+				unsupported = fmt.Errorf("unsupported function %q in expression %q", n.Func.Name, input)
+			}
+		case *parser.AggregateExpr:
+			if _, ok := supportedAggregations[n.Op]; !ok {
+				unsupported = fmt.Errorf("unsupported aggregation %q in expression %q", n.Op, input)
+			}
+		case *parser.SubqueryExpr:
+			unsupported = fmt.Errorf("subqueries are not supported in expression %q", input)
+		case *parser.MatrixSelector:
+			if n.RangeExpr != nil {
+				unsupported = fmt.Errorf("duration expressions are not supported in expression %q", input)
+				break
+			}
+			if vs, ok := n.VectorSelector.(*parser.VectorSelector); ok {
+				maxRange = max(maxRange, n.Range+vs.OriginalOffset)
+			}
+		case *parser.VectorSelector:
+			switch {
+			case n.Timestamp != nil || n.StartOrEnd != 0:
+				unsupported = fmt.Errorf("the @ modifier is not supported in expression %q", input)
+			case n.OriginalOffsetExpr != nil:
+				unsupported = fmt.Errorf("duration expressions are not supported in expression %q", input)
+			case n.OriginalOffset < 0:
+				unsupported = fmt.Errorf("negative offsets are not supported in expression %q", input)
+			default:
+				maxRange = max(maxRange, n.OriginalOffset)
+			}
+		}
+		return unsupported
+	})
+	if unsupported != nil {
+		return nil, 0, unsupported
+	}
+	return expr, maxRange, nil
+}
+
+func isValidMetricName(name string) bool {
+	return metricNameRegexp.MatchString(name)
+}
+
+func isValidLabelName(name string) bool {
+	return labelNameRegexp.MatchString(name)
+}
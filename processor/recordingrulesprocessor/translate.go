@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recordingrulesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/recordingrulesprocessor"
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/otel/semconv/v1.25.0"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	jobLabel      = "job"
+	instanceLabel = "instance"
+	bucketLabel   = "le"
+	quantileLabel = "quantile"
+)
+
+// appender adds the samples of a series to the window.
+type appender func(lset labels.Labels, t int64, v float64)
+
+// appendMetrics translates the metrics into Prometheus series, the same way as the Prometheus
+// exporters do, and appends their samples. Delta sums, delta histograms and exponential
+// histograms are not translated.
+func appendMetrics(md pmetric.Metrics, addMetricSuffixes bool, appendSample appender) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resourceLabels := resourceLabels(rm.Resource())
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			metrics := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				name := prometheustranslator.BuildCompliantName(metric, "", addMetricSuffixes)
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					appendNumberDataPoints(metric.Gauge().DataPoints(), name, resourceLabels, appendSample)
+				case pmetric.MetricTypeSum:
+					if metric.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative {
+						appendNumberDataPoints(metric.Sum().DataPoints(), name, resourceLabels, appendSample)
+					}
+				case pmetric.MetricTypeHistogram:
+					if metric.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative {
+						appendHistogramDataPoints(metric.Histogram().DataPoints(), name, resourceLabels, appendSample)
+					}
+				case pmetric.MetricTypeSummary:
+					appendSummaryDataPoints(metric.Summary().DataPoints(), name, resourceLabels, appendSample)
+				default:
+					// Exponential histograms would be translated into native histograms, which aren't supported.
+				}
+			}
+		}
+	}
+}
+
+// resourceLabels returns the job and instance labels of a resource.
+func resourceLabels(resource pcommon.Resource) map[string]string {
+	result := make(map[string]string, 2)
+	attrs := resource.Attributes()
+	if serviceName, ok := attrs.Get(string(conventions.ServiceNameKey)); ok {
+		job := serviceName.AsString()
+		if serviceNamespace, ok := attrs.Get(string(conventions.ServiceNamespaceKey)); ok {
+			job = serviceNamespace.AsString() + "/" + job
+		}
+		result[jobLabel] = job
+	}
+	if instance, ok := attrs.Get(string(conventions.ServiceInstanceIDKey)); ok {
+		result[instanceLabel] = instance.AsString()
+	}
+	return result
+}
+
+// seriesLabels returns the labels of a series from the attributes of its datapoint. The attribute
+// names are normalized, and the values of attributes colliding after normalization are joined.
+func seriesLabels(name string, resourceLabels map[string]string, attrs pcommon.Map, extra ...string) labels.Labels {
+	keys := make([]string, 0, attrs.Len())
+	for k := range attrs.All() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make(map[string]string, attrs.Len()+len(resourceLabels)+len(extra)/2+1)
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		normalized := prometheustranslator.NormalizeLabel(k)
+		if existing, ok := values[normalized]; ok && existing != v.AsString() {
+			values[normalized] = existing + ";" + v.AsString()
+			continue
+		}
+		values[normalized] = v.AsString()
+	}
+	for k, v := range resourceLabels {
+		values[k] = v
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		values[extra[i]] = extra[i+1]
+	}
+	values[metricNameLabel] = name
+
+	builder := labels.NewScratchBuilder(len(values))
+	for k, v := range values {
+		builder.Add(k, v)
+	}
+	builder.Sort()
+	return builder.Labels()
+}
+
+func appendNumberDataPoints(dps pmetric.NumberDataPointSlice, name string, resourceLabels map[string]string, appendSample appender) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		var v float64
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			v = float64(dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			v = dp.DoubleValue()
+		default:
+			continue
+		}
+		appendSample(seriesLabels(name, resourceLabels, dp.Attributes()), timestampMillis(dp.Timestamp()), sampleValue(v, dp.Flags()))
+	}
+}
+
+func appendHistogramDataPoints(dps pmetric.HistogramDataPointSlice, name string, resourceLabels map[string]string, appendSample appender) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		t := timestampMillis(dp.Timestamp())
+
+		if dp.HasSum() {
+			appendSample(seriesLabels(name+"_sum", resourceLabels, dp.Attributes()), t, sampleValue(dp.Sum(), dp.Flags()))
+		}
+		appendSample(seriesLabels(name+"_count", resourceLabels, dp.Attributes()), t, sampleValue(float64(dp.Count()), dp.Flags()))
+
+		var cumulative uint64
+		for j := 0; j < dp.BucketCounts().Len(); j++ {
+			cumulative += dp.BucketCounts().At(j)
+			le := "+Inf"
+			if j < dp.ExplicitBounds().Len() {
+				le = strconv.FormatFloat(dp.ExplicitBounds().At(j), 'f', -1, 64)
+			}
+			appendSample(seriesLabels(name+"_bucket", resourceLabels, dp.Attributes(), bucketLabel, le), t, sampleValue(float64(cumulative), dp.Flags()))
+		}
+		if dp.BucketCounts().Len() == 0 {
+			// The +Inf bucket is required by histogram_quantile
+			appendSample(seriesLabels(name+"_bucket", resourceLabels, dp.Attributes(), bucketLabel, "+Inf"), t, sampleValue(float64(dp.Count()), dp.Flags()))
+		}
+	}
+}
+
+func appendSummaryDataPoints(dps pmetric.SummaryDataPointSlice, name string, resourceLabels map[string]string, appendSample appender) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		t := timestampMillis(dp.Timestamp())
+
+		appendSample(seriesLabels(name+"_sum", resourceLabels, dp.Attributes()), t, sampleValue(dp.Sum(), dp.Flags()))
+		appendSample(seriesLabels(name+"_count", resourceLabels, dp.Attributes()), t, sampleValue(float64(dp.Count()), dp.Flags()))
+		for j := 0; j < dp.QuantileValues().Len(); j++ {
+			q := dp.QuantileValues().At(j)
+			quantile := strconv.FormatFloat(q.Quantile(), 'f', -1, 64)
+			appendSample(seriesLabels(name, resourceLabels, dp.Attributes(), quantileLabel, quantile), t, sampleValue(q.Value(), dp.Flags()))
+		}
+	}
+}
+
+// sampleValue returns a staleness marker for the datapoints without recorded value.
+func sampleValue(v float64, flags pmetric.DataPointFlags) float64 {
+	if flags.NoRecordedValue() {
+		return math.Float64frombits(value.StaleNaN)
+	}
+	return v
+}
+
+func timestampMillis(ts pcommon.Timestamp) int64 {
+	return ts.AsTime().UnixNano() / int64(time.Millisecond)
+}
@@ -9,11 +9,20 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/avro"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/parquet"
 )
 
+// recordReaders are the record readers of the structured formats supported by the receiver
+var recordReaders = map[string]format.RecordReader{
+	parquet.Format: parquet.Reader{},
+	avro.Format:    avro.Reader{},
+}
+
 // NewFactory creates a factory for filelog receiver
 func NewFactory() receiver.Factory {
 	return adapter.NewFactory(ReceiverType{}, metadata.LogsStability)
@@ -59,5 +68,5 @@ type FileLogConfig struct {
 
 // InputConfig unmarshals the input operator
 func (ReceiverType) InputConfig(cfg component.Config) operator.Config {
-	return operator.NewConfig(&cfg.(*FileLogConfig).InputConfig)
+	return operator.NewConfig(cfg.(*FileLogConfig).InputConfig.WithRecordReaders(recordReaders))
 }
@@ -0,0 +1,323 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package avro reads the records of Avro object container files.
+package avro // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/avro"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
+)
+
+// Format is the name of the format in the configuration
+const Format = "avro"
+
+const (
+	syncSize = 16
+
+	codecNull      = "null"
+	codecDeflate   = "deflate"
+	codecSnappy    = "snappy"
+	codecZstandard = "zstandard"
+	codecBzip2     = "bzip2"
+)
+
+var magic = []byte("Obj\x01")
+
+// errIncomplete is returned when the end of the file is reached in the middle of the header or of a block
+var errIncomplete = errors.New("incomplete avro file")
+
+// Reader reads the records of Avro object container files. Records are decoded as JSON objects
+// would be, e.g. the values of unions are not wrapped by their type. The position in a file is
+// the offset of the next block to read.
+type Reader struct{}
+
+// RequiresFileID returns true: the files start with a header holding the schema, which is the same
+// for all the files of a producer and is usually longer than the fingerprint. The sync marker which
+// tells the files apart follows the schema.
+func (Reader) RequiresFileID() bool {
+	return true
+}
+
+func (Reader) Read(ctx context.Context, f *os.File, position int64, emit format.EmitFunc) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+	size := info.Size()
+
+	br := &blockReader{r: bufio.NewReader(io.NewSectionReader(f, 0, size)), remaining: size}
+	h, err := readHeader(br)
+	if err != nil {
+		if errors.Is(err, errIncomplete) {
+			return nil
+		}
+		return err
+	}
+	defer h.close()
+
+	if position < br.offset {
+		position = br.offset
+	}
+	if position > size {
+		// The file is being truncated
+		return nil
+	}
+	br = &blockReader{r: bufio.NewReader(io.NewSectionReader(f, position, size-position)), offset: position, remaining: size - position}
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		records, err := h.readBlock(br)
+		if err != nil {
+			if errors.Is(err, errIncomplete) {
+				return nil
+			}
+			return fmt.Errorf("read block at offset %d: %w", position, err)
+		}
+		if records == nil {
+			return nil
+		}
+		position = br.offset
+		if err = emit(ctx, records, position); err != nil {
+			return err
+		}
+	}
+}
+
+type header struct {
+	codec       *goavro.Codec
+	compression string
+	sync        []byte
+	zstdDecoder *zstd.Decoder
+}
+
+func readHeader(br *blockReader) (*header, error) {
+	fileMagic, err := br.readFull(int64(len(magic)))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(fileMagic, magic) {
+		return nil, errors.New("not an avro object container file")
+	}
+
+	metadata := map[string][]byte{}
+	for {
+		count, err := br.readLong()
+		if err != nil {
+			return nil, incomplete(err)
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			// A negative count is followed by the size of the block of entries
+			count = -count
+			if _, err = br.readLong(); err != nil {
+				return nil, incomplete(err)
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			key, err := br.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			value, err := br.readBytes()
+			if err != nil {
+				return nil, err
+			}
+			metadata[string(key)] = value
+		}
+	}
+
+	h := &header{compression: string(metadata["avro.codec"])}
+	if h.sync, err = br.readFull(syncSize); err != nil {
+		return nil, err
+	}
+
+	switch h.compression {
+	case "", codecNull, codecDeflate, codecSnappy, codecBzip2:
+	case codecZstandard:
+		if h.zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+			return nil, fmt.Errorf("create zstandard decoder: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported avro codec %q", h.compression)
+	}
+
+	if h.codec, err = goavro.NewCodecForStandardJSONFull(string(metadata["avro.schema"])); err != nil {
+		h.close()
+		return nil, fmt.Errorf("parse avro schema: %w", err)
+	}
+	return h, nil
+}
+
+// readBlock returns the records of the next block, or nil at the end of the file
+func (h *header) readBlock(br *blockReader) ([]map[string]any, error) {
+	count, err := br.readLong()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("invalid record count %d", count)
+	}
+	size, err := br.readLong()
+	if err != nil {
+		return nil, incomplete(err)
+	}
+	data, err := br.readFull(size)
+	if err != nil {
+		return nil, err
+	}
+	sync, err := br.readFull(syncSize)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(sync, h.sync) {
+		return nil, errors.New("invalid sync marker")
+	}
+
+	if data, err = h.decompress(data); err != nil {
+		return nil, err
+	}
+
+	// The capacity is bounded by the size of the block, in case the count is corrupted
+	records := make([]map[string]any, 0, min(count, int64(len(data))))
+	for i := int64(0); i < count; i++ {
+		var datum any
+		if datum, data, err = h.codec.NativeFromBinary(data); err != nil {
+			return nil, fmt.Errorf("decode record: %w", err)
+		}
+		textual, err := h.codec.TextualFromNative(nil, datum)
+		if err != nil {
+			return nil, fmt.Errorf("convert record: %w", err)
+		}
+		record, err := format.DecodeJSONRecord(textual)
+		if err != nil {
+			return nil, fmt.Errorf("convert record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (h *header) decompress(data []byte) ([]byte, error) {
+	switch h.compression {
+	case codecDeflate:
+		decompressed, err := io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return nil, fmt.Errorf("decompress deflate block: %w", err)
+		}
+		return decompressed, nil
+	case codecSnappy:
+		// Snappy blocks are followed by the CRC32 checksum of the decompressed data
+		if len(data) < 4 {
+			return nil, errors.New("decompress snappy block: missing checksum")
+		}
+		decompressed, err := snappy.Decode(nil, data[:len(data)-4])
+		if err != nil {
+			return nil, fmt.Errorf("decompress snappy block: %w", err)
+		}
+		if crc32.ChecksumIEEE(decompressed) != binary.BigEndian.Uint32(data[len(data)-4:]) {
+			return nil, errors.New("decompress snappy block: checksum mismatch")
+		}
+		return decompressed, nil
+	case codecZstandard:
+		decompressed, err := h.zstdDecoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompress zstandard block: %w", err)
+		}
+		return decompressed, nil
+	case codecBzip2:
+		decompressed, err := io.ReadAll(bzip2.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return nil, fmt.Errorf("decompress bzip2 block: %w", err)
+		}
+		return decompressed, nil
+	default:
+		return data, nil
+	}
+}
+
+func (h *header) close() {
+	if h.zstdDecoder != nil {
+		h.zstdDecoder.Close()
+	}
+}
+
+// blockReader reads the binary encoding of avro values, and tracks the offset in the file
+type blockReader struct {
+	r         *bufio.Reader
+	offset    int64
+	remaining int64
+}
+
+func (br *blockReader) ReadByte() (byte, error) {
+	b, err := br.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	br.offset++
+	br.remaining--
+	return b, nil
+}
+
+// readLong reads a zig-zag encoded variable length integer. io.EOF is returned only
+// when no byte of the integer is read.
+func (br *blockReader) readLong() (int64, error) {
+	v, err := binary.ReadVarint(br)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, errIncomplete
+	}
+	return v, err
+}
+
+func (br *blockReader) readBytes() ([]byte, error) {
+	size, err := br.readLong()
+	if err != nil {
+		return nil, incomplete(err)
+	}
+	return br.readFull(size)
+}
+
+func (br *blockReader) readFull(size int64) ([]byte, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("invalid size %d", size)
+	}
+	if size > br.remaining {
+		return nil, errIncomplete
+	}
+	buf := make([]byte, size)
+	n, err := io.ReadFull(br.r, buf)
+	br.offset += int64(n)
+	br.remaining -= int64(n)
+	if err != nil {
+		return nil, incomplete(err)
+	}
+	return buf, nil
+}
+
+func incomplete(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errIncomplete
+	}
+	return err
+}
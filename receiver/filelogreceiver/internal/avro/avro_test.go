@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avro
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+  "type": "record",
+  "name": "log",
+  "fields": [
+    {"name": "message", "type": "string"},
+    {"name": "count", "type": "long"},
+    {"name": "host", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+type emitted struct {
+	records []map[string]any
+	next    int64
+}
+
+func read(t *testing.T, path string, position int64) ([]emitted, error) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var calls []emitted
+	err = Reader{}.Read(t.Context(), f, position, func(_ context.Context, records []map[string]any, next int64) error {
+		calls = append(calls, emitted{records: records, next: next})
+		return nil
+	})
+	return calls, err
+}
+
+// writeFile writes two blocks, of two records and one record
+func writeFile(t *testing.T, compression string) string {
+	path := filepath.Join(t.TempDir(), "logs.avro")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	writer, err := goavro.NewOCFWriter(goavro.OCFConfig{W: f, Schema: testSchema, CompressionName: compression})
+	require.NoError(t, err)
+	require.NoError(t, writer.Append([]any{
+		map[string]any{"message": "first", "count": int64(1), "host": goavro.Union("string", "a")},
+		map[string]any{"message": "second", "count": int64(2), "host": goavro.Union("null", nil)},
+	}))
+	require.NoError(t, writer.Append([]any{
+		map[string]any{"message": "third", "count": int64(3), "host": goavro.Union("string", "c")},
+	}))
+	return path
+}
+
+var (
+	firstBlock = []map[string]any{
+		{"message": "first", "count": int64(1), "host": "a"},
+		{"message": "second", "count": int64(2), "host": nil},
+	}
+	secondBlock = []map[string]any{
+		{"message": "third", "count": int64(3), "host": "c"},
+	}
+)
+
+func TestRead(t *testing.T) {
+	for _, compression := range []string{goavro.CompressionNullLabel, goavro.CompressionDeflateLabel, goavro.CompressionSnappyLabel} {
+		t.Run(compression, func(t *testing.T) {
+			path := writeFile(t, compression)
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+
+			calls, err := read(t, path, 0)
+			require.NoError(t, err)
+			require.Len(t, calls, 2)
+			require.Equal(t, firstBlock, calls[0].records)
+			require.Equal(t, secondBlock, calls[1].records)
+			require.Equal(t, info.Size(), calls[1].next)
+
+			// Resume from the second block
+			resumed, err := read(t, path, calls[0].next)
+			require.NoError(t, err)
+			require.Equal(t, calls[1:], resumed)
+
+			resumed, err = read(t, path, info.Size())
+			require.NoError(t, err)
+			require.Empty(t, resumed)
+		})
+	}
+}
+
+func TestReadIncomplete(t *testing.T) {
+	path := writeFile(t, goavro.CompressionNullLabel)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// The last block is being written
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+	calls, err := read(t, path, 0)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Equal(t, firstBlock, calls[0].records)
+
+	// The header is being written
+	require.NoError(t, os.Truncate(path, 10))
+	calls, err = read(t, path, 0)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+}
+
+func TestReadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.avro")
+	require.NoError(t, os.WriteFile(path, []byte("not an avro file"), 0o600))
+	_, err := read(t, path, 0)
+	require.ErrorContains(t, err, "not an avro object container file")
+}
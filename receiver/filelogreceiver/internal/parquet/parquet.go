@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parquet reads the rows of Parquet files.
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/parquet"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/format"
+)
+
+// Format is the name of the format in the configuration
+const Format = "parquet"
+
+// batchSize is the number of rows decoded at once
+const batchSize = 1024
+
+// magic starts and ends Parquet files. The footer, which holds the metadata of the
+// row groups, is written last, so files which do not end with it are incomplete.
+var magic = []byte("PAR1")
+
+// Reader reads the rows of Parquet files. Each row is a record, holding a field per column.
+// The position in a file is the index of the next row to read, so that the rows emitted before
+// a restart are not emitted again.
+type Reader struct{}
+
+func (Reader) Read(ctx context.Context, f *os.File, position int64, emit format.EmitFunc) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+	// The section reader is not closed along with the parquet reader, unlike the file
+	section := io.NewSectionReader(f, 0, info.Size())
+	if complete, err := isComplete(section, info.Size()); err != nil || !complete {
+		return err
+	}
+
+	pf, err := file.NewParquetReader(section)
+	if err != nil {
+		return fmt.Errorf("open parquet file: %w", err)
+	}
+	defer pf.Close()
+
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: batchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return fmt.Errorf("create arrow reader: %w", err)
+	}
+
+	var firstRow int64
+	for rowGroup := 0; rowGroup < pf.NumRowGroups(); rowGroup++ {
+		numRows := pf.MetaData().RowGroup(rowGroup).NumRows()
+		// The row groups which were read entirely are skipped without being decoded
+		if firstRow+numRows > position {
+			if err = readRowGroup(ctx, fr, rowGroup, firstRow, position, emit); err != nil {
+				return err
+			}
+		}
+		firstRow += numRows
+	}
+	return nil
+}
+
+// readRowGroup emits the rows of a row group starting with the given first row, in batches,
+// skipping the rows before the position. The position moves past each emitted batch.
+func readRowGroup(ctx context.Context, fr *pqarrow.FileReader, rowGroup int, firstRow, position int64, emit format.EmitFunc) error {
+	rr, err := fr.GetRecordReader(ctx, nil, []int{rowGroup})
+	if err != nil {
+		return fmt.Errorf("read row group %d: %w", rowGroup, err)
+	}
+	defer rr.Release()
+
+	row := firstRow
+	var records []map[string]any
+	for rr.Next() {
+		if records, err = recordValues(rr.Record()); err != nil {
+			return fmt.Errorf("convert row group %d: %w", rowGroup, err)
+		}
+		if skip := position - row; skip > 0 {
+			records = records[min(skip, int64(len(records))):]
+		}
+		row += rr.Record().NumRows()
+		if len(records) == 0 {
+			continue
+		}
+		if err = emit(ctx, records, row); err != nil {
+			return err
+		}
+	}
+	if err = rr.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read row group %d: %w", rowGroup, err)
+	}
+	return nil
+}
+
+func isComplete(r io.ReaderAt, size int64) (bool, error) {
+	if size < 2*int64(len(magic)) {
+		return false, nil
+	}
+	buf := make([]byte, len(magic))
+	if _, err := r.ReadAt(buf, size-int64(len(magic))); err != nil {
+		return false, fmt.Errorf("read footer: %w", err)
+	}
+	return bytes.Equal(buf, magic), nil
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	arrowparquet "github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/require"
+)
+
+type emitted struct {
+	records []map[string]any
+	next    int64
+}
+
+func read(t *testing.T, path string, position int64) ([]emitted, error) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var calls []emitted
+	err = Reader{}.Read(t.Context(), f, position, func(_ context.Context, records []map[string]any, next int64) error {
+		calls = append(calls, emitted{records: records, next: next})
+		return nil
+	})
+	return calls, err
+}
+
+// writeFile writes three rows, in row groups of two rows
+func writeFile(t *testing.T) string {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "message", Type: arrow.BinaryTypes.String},
+		{Name: "count", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "host", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.StringBuilder).AppendValues([]string{"first", "second", "third"}, nil)
+	builder.Field(1).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	builder.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+	record := builder.NewRecord()
+	defer record.Release()
+	return writeRecord(t, record, arrowparquet.NewWriterProperties(arrowparquet.WithMaxRowGroupLength(2)))
+}
+
+func writeRecord(t *testing.T, record arrow.Record, props *arrowparquet.WriterProperties) string {
+	path := filepath.Join(t.TempDir(), "logs.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	writer, err := pqarrow.NewFileWriter(record.Schema(), f, props, pqarrow.DefaultWriterProps())
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(record))
+	require.NoError(t, writer.Close())
+	return path
+}
+
+func TestRead(t *testing.T) {
+	path := writeFile(t)
+
+	calls, err := read(t, path, 0)
+	require.NoError(t, err)
+	require.Equal(t, []emitted{
+		{
+			records: []map[string]any{
+				{"message": "first", "count": int64(1), "host": "a"},
+				{"message": "second", "count": int64(2), "host": nil},
+			},
+			next: 2,
+		},
+		{
+			records: []map[string]any{
+				{"message": "third", "count": int64(3), "host": "c"},
+			},
+			next: 3,
+		},
+	}, calls)
+
+	// Resume from the middle of the first row group
+	calls, err = read(t, path, 1)
+	require.NoError(t, err)
+	require.Equal(t, []emitted{
+		{
+			records: []map[string]any{
+				{"message": "second", "count": int64(2), "host": nil},
+			},
+			next: 2,
+		},
+		{
+			records: []map[string]any{
+				{"message": "third", "count": int64(3), "host": "c"},
+			},
+			next: 3,
+		},
+	}, calls)
+
+	// Resume from the second row group
+	calls, err = read(t, path, 2)
+	require.NoError(t, err)
+	require.Equal(t, []emitted{
+		{
+			records: []map[string]any{
+				{"message": "third", "count": int64(3), "host": "c"},
+			},
+			next: 3,
+		},
+	}, calls)
+
+	calls, err = read(t, path, 3)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+}
+
+func TestReadTypes(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "ratio", Type: arrow.PrimitiveTypes.Float32},
+		{Name: "ok", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}},
+		{Name: "day", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "price", Type: &arrow.Decimal128Type{Precision: 10, Scale: 2}},
+		{Name: "tags", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+		{Name: "attrs", Type: arrow.StructOf(arrow.Field{Name: "name", Type: arrow.BinaryTypes.String})},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).Append(math.MaxInt64)
+	builder.Field(1).(*array.Float32Builder).Append(0.5)
+	builder.Field(2).(*array.BooleanBuilder).Append(true)
+	builder.Field(3).(*array.TimestampBuilder).Append(arrow.Timestamp(ts.UnixMicro()))
+	builder.Field(4).(*array.Date32Builder).Append(arrow.Date32FromTime(ts))
+	builder.Field(5).(*array.Decimal128Builder).Append(decimal128.FromI64(12345))
+	tags := builder.Field(6).(*array.ListBuilder)
+	tags.Append(true)
+	tags.ValueBuilder().(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	attrs := builder.Field(7).(*array.StructBuilder)
+	attrs.Append(true)
+	attrs.FieldBuilder(0).(*array.StringBuilder).Append("checkout")
+	record := builder.NewRecord()
+	defer record.Release()
+	path := writeRecord(t, record, arrowparquet.NewWriterProperties())
+
+	// The values keep their types and precision, and the values which have no equivalent in log
+	// bodies are converted to strings
+	calls, err := read(t, path, 0)
+	require.NoError(t, err)
+	require.Equal(t, []emitted{
+		{
+			records: []map[string]any{{
+				"id":    int64(math.MaxInt64),
+				"ratio": 0.5,
+				"ok":    true,
+				"time":  "2024-05-01T12:30:00.123456Z",
+				"day":   "2024-05-01",
+				"price": "123.45",
+				"tags":  []any{int64(1), int64(2)},
+				"attrs": map[string]any{"name": "checkout"},
+			}},
+			next: 1,
+		},
+	}, calls)
+}
+
+func TestReadIncomplete(t *testing.T) {
+	path := writeFile(t)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// The footer is not written yet
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+	calls, err := read(t, path, 0)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+
+	require.NoError(t, os.Truncate(path, 0))
+	calls, err = read(t, path, 0)
+	require.NoError(t, err)
+	require.Empty(t, calls)
+}
+
+func TestReadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.parquet")
+	require.NoError(t, os.WriteFile(path, []byte("PAR1 not a parquet file PAR1"), 0o600))
+	_, err := read(t, path, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver/internal/parquet"
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// dateLayout and timeLayout format the dates and the times of day, which have no time zone.
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+// recordValues converts the rows of a record to maps holding a field per column.
+func recordValues(record arrow.Record) ([]map[string]any, error) {
+	rows := make([]map[string]any, record.NumRows())
+	for i := range rows {
+		rows[i] = make(map[string]any, record.NumCols())
+	}
+	for c, column := range record.Columns() {
+		name := record.ColumnName(c)
+		for i := range rows {
+			v, err := value(column, i)
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", name, err)
+			}
+			rows[i][name] = v
+		}
+	}
+	return rows, nil
+}
+
+// value converts the value at the given index of an array to a value of a log body. Integers keep
+// their precision, while timestamps, dates, times, durations and decimals, which have no
+// equivalent in log bodies, are converted to strings.
+func value(arr arrow.Array, i int) (any, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i), nil
+	case *array.Int8:
+		return int64(a.Value(i)), nil
+	case *array.Int16:
+		return int64(a.Value(i)), nil
+	case *array.Int32:
+		return int64(a.Value(i)), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Uint8:
+		return int64(a.Value(i)), nil
+	case *array.Uint16:
+		return int64(a.Value(i)), nil
+	case *array.Uint32:
+		return int64(a.Value(i)), nil
+	case *array.Uint64:
+		if v := a.Value(i); v > math.MaxInt64 {
+			return strconv.FormatUint(v, 10), nil
+		}
+		return int64(a.Value(i)), nil
+	case *array.Float16:
+		return float64(a.Value(i).Float32()), nil
+	case *array.Float32:
+		return float64(a.Value(i)), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.String:
+		return a.Value(i), nil
+	case *array.LargeString:
+		return a.Value(i), nil
+	case *array.Binary:
+		return bytes.Clone(a.Value(i)), nil
+	case *array.LargeBinary:
+		return bytes.Clone(a.Value(i)), nil
+	case *array.FixedSizeBinary:
+		return bytes.Clone(a.Value(i)), nil
+	case *array.Timestamp:
+		toTime, err := a.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return nil, err
+		}
+		return toTime(a.Value(i)).Format(time.RFC3339Nano), nil
+	case *array.Date32:
+		return a.Value(i).ToTime().Format(dateLayout), nil
+	case *array.Date64:
+		return a.Value(i).ToTime().Format(dateLayout), nil
+	case *array.Time32:
+		return a.Value(i).ToTime(a.DataType().(*arrow.Time32Type).Unit).Format(timeLayout), nil
+	case *array.Time64:
+		return a.Value(i).ToTime(a.DataType().(*arrow.Time64Type).Unit).Format(timeLayout), nil
+	case *array.Duration:
+		return (time.Duration(a.Value(i)) * a.DataType().(*arrow.DurationType).Unit.Multiplier()).String(), nil
+	case *array.Decimal128:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal128Type).Scale), nil
+	case *array.Decimal256:
+		return a.Value(i).ToString(a.DataType().(*arrow.Decimal256Type).Scale), nil
+	case *array.Dictionary:
+		return value(a.Dictionary(), a.GetValueIndex(i))
+	case *array.Struct:
+		fields := a.DataType().(*arrow.StructType).Fields()
+		m := make(map[string]any, len(fields))
+		for f, field := range fields {
+			v, err := value(a.Field(f), i)
+			if err != nil {
+				return nil, err
+			}
+			m[field.Name] = v
+		}
+		return m, nil
+	case *array.Map:
+		// The keys are converted to strings, as the keys of the maps of log bodies
+		start, end := a.ValueOffsets(i)
+		m := make(map[string]any, end-start)
+		for j := int(start); j < int(end); j++ {
+			item, err := value(a.Items(), j)
+			if err != nil {
+				return nil, err
+			}
+			m[a.Keys().ValueStr(j)] = item
+		}
+		return m, nil
+	case array.ListLike:
+		start, end := a.ValueOffsets(i)
+		list := make([]any, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			item, err := value(a.ListValues(), j)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, nil
+	default:
+		return arr.ValueStr(i), nil
+	}
+}
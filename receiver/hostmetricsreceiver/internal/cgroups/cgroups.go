@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cgroups parses the cgroup of the processes and the containers the cgroups belong to.
+package cgroups // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/cgroups"
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// cgroupV1Controllers are the cgroup v1 controllers whose hierarchy is used as the cgroup path of
+// a process, in order of preference.
+var cgroupV1Controllers = []string{"cpu", "memory", "pids"}
+
+// containerIDRegexp matches the ID of a container at the end of the last element of a cgroup path,
+// as created by the container runtimes and the kubelet, for instance
+// /system.slice/docker-<id>.scope, /kubepods/besteffort/pod<uid>/<id> or
+// /kubepods.slice/kubepods-pod<uid>.slice/cri-containerd-<id>.scope.
+var containerIDRegexp = regexp.MustCompile(`(?:^|[-:])([0-9a-f]{64})(?:\.scope)?$`)
+
+// ParsePath returns the cgroup path of a process from the content of /proc/<pid>/cgroup.
+// On the cgroup v2 unified hierarchy, the path of the single "0::" entry is returned. On cgroup v1
+// or hybrid hierarchies, the path in the hierarchy of the first of cgroupV1Controllers present is
+// returned, and otherwise the path of the first entry.
+func ParsePath(contents string) string {
+	var unified, first string
+	v1Paths := map[string]string{}
+	for _, line := range strings.Split(contents, "\n") {
+		// Each line has the format hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(strings.TrimSpace(line), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if first == "" {
+			first = fields[2]
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			unified = fields[2]
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			v1Paths[controller] = fields[2]
+		}
+	}
+
+	for _, controller := range cgroupV1Controllers {
+		if p, ok := v1Paths[controller]; ok {
+			return p
+		}
+	}
+	if unified != "" {
+		return unified
+	}
+	return first
+}
+
+// ContainerID returns the ID of the container of a cgroup path, or an empty string if the
+// cgroup does not belong to a container.
+func ContainerID(cgroupPath string) string {
+	matches := containerIDRegexp.FindStringSubmatch(path.Base(cgroupPath))
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
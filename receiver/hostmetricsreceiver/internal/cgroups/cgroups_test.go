@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroups
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testContainerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected string
+	}{
+		{
+			name:     "unified",
+			contents: "0::/system.slice/docker-" + testContainerID + ".scope\n",
+			expected: "/system.slice/docker-" + testContainerID + ".scope",
+		},
+		{
+			name: "v1",
+			contents: `12:pids:/docker/` + testContainerID + `
+11:memory:/docker/` + testContainerID + `
+4:cpu,cpuacct:/docker/` + testContainerID + `
+1:name=systemd:/docker/` + testContainerID + `
+`,
+			expected: "/docker/" + testContainerID,
+		},
+		{
+			name: "hybrid",
+			contents: `5:memory:/user.slice
+4:cpu,cpuacct:/user.slice/user-1000.slice
+0::/user.slice/user-1000.slice/session-1.scope
+`,
+			expected: "/user.slice/user-1000.slice",
+		},
+		{
+			name:     "v1 without preferred controllers",
+			contents: "1:name=systemd:/init.scope\n",
+			expected: "/init.scope",
+		},
+		{
+			name:     "empty",
+			contents: "",
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParsePath(tt.contents))
+		})
+	}
+}
+
+func TestContainerID(t *testing.T) {
+	tests := []struct {
+		name       string
+		cgroupPath string
+		expected   string
+	}{
+		{
+			name:       "docker systemd",
+			cgroupPath: "/system.slice/docker-" + testContainerID + ".scope",
+			expected:   testContainerID,
+		},
+		{
+			name:       "docker cgroupfs",
+			cgroupPath: "/docker/" + testContainerID,
+			expected:   testContainerID,
+		},
+		{
+			name:       "containerd",
+			cgroupPath: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice/cri-containerd-" + testContainerID + ".scope",
+			expected:   testContainerID,
+		},
+		{
+			name:       "cri-o",
+			cgroupPath: "/kubepods.slice/kubepods-pod1234.slice/crio-" + testContainerID + ".scope",
+			expected:   testContainerID,
+		},
+		{
+			name:       "kubepods cgroupfs",
+			cgroupPath: "/kubepods/besteffort/pod1234/" + testContainerID,
+			expected:   testContainerID,
+		},
+		{
+			name:       "podman",
+			cgroupPath: "/user.slice/user-1000.slice/user@1000.service/user.slice/libpod-" + testContainerID + ".scope",
+			expected:   testContainerID,
+		},
+		{
+			name:       "not a container",
+			cgroupPath: "/system.slice/sshd.service",
+			expected:   "",
+		},
+		{
+			name:       "container parent",
+			cgroupPath: "/docker/" + testContainerID + "/child",
+			expected:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ContainerID(tt.cgroupPath))
+		})
+	}
+}
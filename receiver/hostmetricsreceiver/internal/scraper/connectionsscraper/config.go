@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectionsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper/internal/metadata"
+)
+
+// Config relating to Connections Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+
+	// ResolveProcesses specifies whether the sockets are attributed to the processes owning them,
+	// read from /proc/[pid]/fd. Resolving the processes of other users requires root privileges,
+	// and the sockets whose owning process cannot be resolved are reported without process.
+	ResolveProcesses bool `mapstructure:"resolve_processes"`
+
+	// Listeners specifies the listening ports reported by the system.network.listener.* metrics.
+	Listeners ListenersConfig `mapstructure:"listeners"`
+
+	// Peers specifies the remote endpoints reported by the system.network.peer.* metrics.
+	Peers PeersConfig `mapstructure:"peers"`
+}
+
+// ListenersConfig specifies the listening ports reported.
+type ListenersConfig struct {
+	// MaxCount is the maximum number of listening ports reported per scrape. The listening ports
+	// with the most connections are reported first. 0 disables the limit.
+	MaxCount int `mapstructure:"max_count"`
+}
+
+// PeersConfig specifies the remote endpoints reported.
+type PeersConfig struct {
+	// MaxCount is the maximum number of remote endpoints reported per scrape. The remote endpoints
+	// with the most connections are reported first. 0 disables the limit.
+	MaxCount int `mapstructure:"max_count"`
+
+	// Include specifies a filter on the addresses of the remote endpoints that should be reported.
+	// Exclude specifies a filter on the addresses of the remote endpoints that should not be reported.
+	// If neither `include` or `exclude` are set, all the remote endpoints are reported.
+	Include MatchConfig `mapstructure:"include"`
+	Exclude MatchConfig `mapstructure:"exclude"`
+}
+
+type MatchConfig struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Addresses []string `mapstructure:"addresses"`
+}
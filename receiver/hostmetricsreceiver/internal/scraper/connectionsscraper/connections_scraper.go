@@ -0,0 +1,350 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectionsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper"
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/shirou/gopsutil/v4/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper/internal/metadata"
+)
+
+const metricsLen = 4
+
+var (
+	errInvalidMaxListeners = errors.New("listeners::max_count must not be negative")
+	errInvalidMaxPeers     = errors.New("peers::max_count must not be negative")
+)
+
+// connectionsScraper for Connections Metrics
+type connectionsScraper struct {
+	settings  scraper.Settings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+	now      func() time.Time
+}
+
+// endpoint is a listening port or a remote endpoint.
+type endpoint struct {
+	protocol metadata.AttributeProtocol
+	address  string
+	port     int64
+}
+
+// endpointSockets are the numbers of sockets of an endpoint by state, and the sizes of their queues.
+type endpointSockets struct {
+	states  map[string]int64
+	txQueue int64
+	rxQueue int64
+}
+
+func (es *endpointSockets) add(s socket) {
+	es.states[s.state]++
+	// The queues of the listening TCP sockets are their accept queues, counted in connections
+	if s.state != stateListen {
+		es.txQueue += s.txQueue
+		es.rxQueue += s.rxQueue
+	}
+}
+
+func (es *endpointSockets) count() int64 {
+	var n int64
+	for _, c := range es.states {
+		n += c
+	}
+	return n
+}
+
+// processSockets are the sockets owned by a process, by listening port and by remote endpoint.
+type processSockets struct {
+	listeners map[endpoint]*endpointSockets
+	peers     map[endpoint]*endpointSockets
+}
+
+func addSocket(endpoints map[endpoint]*endpointSockets, ep endpoint, s socket) {
+	es, ok := endpoints[ep]
+	if !ok {
+		es = &endpointSockets{states: map[string]int64{}}
+		endpoints[ep] = es
+	}
+	es.add(s)
+}
+
+// listenerKey indexes the listening sockets which may accept a connection.
+type listenerKey struct {
+	protocol metadata.AttributeProtocol
+	ipv4     bool
+	port     uint16
+}
+
+// newConnectionsScraper creates a Connections Scraper
+func newConnectionsScraper(settings scraper.Settings, cfg *Config) (*connectionsScraper, error) {
+	if cfg.Listeners.MaxCount < 0 {
+		return nil, errInvalidMaxListeners
+	}
+	if cfg.Peers.MaxCount < 0 {
+		return nil, errInvalidMaxPeers
+	}
+
+	scraper := &connectionsScraper{
+		settings: settings,
+		config:   cfg,
+		bootTime: host.BootTimeWithContext,
+		now:      time.Now,
+	}
+
+	var err error
+
+	if len(cfg.Peers.Include.Addresses) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Peers.Include.Addresses, &cfg.Peers.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating peer address include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Peers.Exclude.Addresses) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Peers.Exclude.Addresses, &cfg.Peers.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating peer address exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *connectionsScraper) start(ctx context.Context, _ component.Host) error {
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *connectionsScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(s.now())
+	procRoot := gopsutilenv.GetEnvWithContext(ctx, string(common.HostProcEnvKey), "/proc")
+
+	var sockets []socket
+	for _, table := range socketTables {
+		tableSockets, err := readSocketTable(filepath.Join(procRoot, "net", table.name), table.protocol)
+		if table.ipv6 && errors.Is(err, fs.ErrNotExist) {
+			// The IPv6 tables are missing when IPv6 is disabled
+			continue
+		}
+		if err != nil {
+			errs.AddPartial(metricsLen, fmt.Errorf("failed to read the %s sockets: %w", table.name, err))
+			continue
+		}
+		sockets = append(sockets, tableSockets...)
+	}
+
+	// The unconnected UDP sockets on ephemeral ports are client sockets rather than listeners,
+	// e.g. the sockets of DNS resolvers, and are skipped
+	ephemeralPorts, err := readEphemeralPorts(procRoot)
+	if err != nil {
+		s.settings.Logger.Debug("Failed to read the ephemeral port range, using the default one", zap.Error(err))
+		ephemeralPorts = defaultEphemeralPorts
+	}
+	sockets = slices.DeleteFunc(sockets, func(sock socket) bool {
+		return sock.state == stateUnconnected && ephemeralPorts.contains(sock.local.Port())
+	})
+
+	var owners map[uint64]*process
+	if s.config.ResolveProcesses {
+		var err error
+		owners, err = readSocketOwners(procRoot)
+		if err != nil {
+			errs.AddPartial(metricsLen, fmt.Errorf("failed to read the processes owning the sockets: %w", err))
+		}
+	}
+
+	processes := s.groupSockets(sockets, owners)
+	listeners := s.limitEndpoints(processes, func(ps *processSockets) map[endpoint]*endpointSockets { return ps.listeners },
+		s.config.Listeners.MaxCount, "listeners")
+	peers := s.limitEndpoints(processes, func(ps *processSockets) map[endpoint]*endpointSockets { return ps.peers },
+		s.config.Peers.MaxCount, "peers")
+
+	// Emit the resources in a stable order, the sockets without owning process first
+	owned := make([]process, 0, len(processes))
+	for p := range processes {
+		owned = append(owned, p)
+	}
+	slices.SortFunc(owned, func(a, b process) int { return cmp.Compare(a.pid, b.pid) })
+
+	for _, p := range owned {
+		ps := processes[p]
+		for ep, es := range ps.listeners {
+			if _, ok := listeners[ep]; !ok {
+				continue
+			}
+			for state, n := range es.states {
+				s.mb.RecordSystemNetworkListenerConnectionsDataPoint(now, n, ep.protocol, ep.address, ep.port, state)
+			}
+			s.mb.RecordSystemNetworkListenerQueueSizeDataPoint(now, es.rxQueue, ep.protocol, ep.address, ep.port, metadata.AttributeDirectionReceive)
+			s.mb.RecordSystemNetworkListenerQueueSizeDataPoint(now, es.txQueue, ep.protocol, ep.address, ep.port, metadata.AttributeDirectionTransmit)
+		}
+		for ep, es := range ps.peers {
+			if _, ok := peers[ep]; !ok {
+				continue
+			}
+			for state, n := range es.states {
+				s.mb.RecordSystemNetworkPeerConnectionsDataPoint(now, n, ep.protocol, ep.address, ep.port, state)
+			}
+			s.mb.RecordSystemNetworkPeerQueueSizeDataPoint(now, es.rxQueue, ep.protocol, ep.address, ep.port, metadata.AttributeDirectionReceive)
+			s.mb.RecordSystemNetworkPeerQueueSizeDataPoint(now, es.txQueue, ep.protocol, ep.address, ep.port, metadata.AttributeDirectionTransmit)
+		}
+
+		rb := s.mb.NewResourceBuilder()
+		if p.pid != 0 {
+			rb.SetProcessPid(p.pid)
+			rb.SetProcessExecutableName(p.name)
+		}
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+// groupSockets groups the sockets by owning process, and by listening port or remote endpoint.
+// The connections accepted by a listening socket are grouped by the listening port, and the other
+// connections by remote endpoint. The sockets whose owning process is unknown, e.g. the sockets in
+// the TIME_WAIT state which have no inode, are grouped under the zero process.
+func (s *connectionsScraper) groupSockets(sockets []socket, owners map[uint64]*process) map[process]*processSockets {
+	listeners := map[listenerKey][]socket{}
+	for _, sock := range sockets {
+		if sock.isListener() {
+			key := listenerKey{protocol: sock.protocol, ipv4: sock.local.Addr().Is4(), port: sock.local.Port()}
+			listeners[key] = append(listeners[key], sock)
+		}
+	}
+
+	processes := map[process]*processSockets{}
+	for _, sock := range sockets {
+		var p process
+		if owner, ok := owners[sock.inode]; ok {
+			p = *owner
+		}
+		ps, ok := processes[p]
+		if !ok {
+			ps = &processSockets{
+				listeners: map[endpoint]*endpointSockets{},
+				peers:     map[endpoint]*endpointSockets{},
+			}
+			processes[p] = ps
+		}
+
+		if listener, ok := acceptingListener(listeners, sock); ok {
+			addSocket(ps.listeners, endpoint{
+				protocol: listener.protocol,
+				address:  listener.local.Addr().Unmap().String(),
+				port:     int64(listener.local.Port()),
+			}, sock)
+			continue
+		}
+
+		// The sockets neither listening nor connected, e.g. bound before connecting, are skipped
+		if sock.remote.Addr().IsUnspecified() {
+			continue
+		}
+		address := sock.remote.Addr().Unmap().String()
+		if !s.includePeer(address) {
+			continue
+		}
+		addSocket(ps.peers, endpoint{
+			protocol: sock.protocol,
+			address:  address,
+			port:     int64(sock.remote.Port()),
+		}, sock)
+	}
+	return processes
+}
+
+// acceptingListener returns the listening socket of a socket, which is the socket itself when it
+// is listening, or the listening socket which accepted the connection, if any. The listening
+// sockets on a specific address are preferred to the ones on the unspecified address.
+func acceptingListener(listeners map[listenerKey][]socket, sock socket) (socket, bool) {
+	if sock.isListener() {
+		return sock, true
+	}
+	var found socket
+	ok := false
+	for _, listener := range listeners[listenerKey{protocol: sock.protocol, ipv4: sock.local.Addr().Is4(), port: sock.local.Port()}] {
+		if !listener.accepts(sock) {
+			continue
+		}
+		found, ok = listener, true
+		if !listener.local.Addr().IsUnspecified() {
+			break
+		}
+	}
+	return found, ok
+}
+
+func (s *connectionsScraper) includePeer(address string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(address)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(address))
+}
+
+// limitEndpoints returns the listening ports or remote endpoints to report, which are the maxCount
+// endpoints with the most connections across the processes.
+func (s *connectionsScraper) limitEndpoints(
+	processes map[process]*processSockets,
+	endpointsOf func(*processSockets) map[endpoint]*endpointSockets,
+	maxCount int,
+	setting string,
+) map[endpoint]int64 {
+	counts := map[endpoint]int64{}
+	for _, ps := range processes {
+		for ep, es := range endpointsOf(ps) {
+			counts[ep] += es.count()
+		}
+	}
+	if maxCount == 0 || len(counts) <= maxCount {
+		return counts
+	}
+
+	endpoints := make([]endpoint, 0, len(counts))
+	for ep := range counts {
+		endpoints = append(endpoints, ep)
+	}
+	slices.SortFunc(endpoints, func(a, b endpoint) int {
+		return cmp.Or(
+			cmp.Compare(counts[b], counts[a]),
+			cmp.Compare(a.protocol, b.protocol),
+			cmp.Compare(a.address, b.address),
+			cmp.Compare(a.port, b.port),
+		)
+	})
+	for _, ep := range endpoints[maxCount:] {
+		delete(counts, ep)
+	}
+
+	s.settings.Logger.Debug("Dropped the endpoints with the fewest connections over "+setting+"::max_count",
+		zap.Int("max_count", maxCount), zap.Int("dropped", len(endpoints)-maxCount))
+	return counts
+}
@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectionsscraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper/internal/metadata"
+)
+
+func skipTestOnUnsupportedOS(t *testing.T) {
+	if !supportedOS {
+		t.Skip("the connections scraper is only available on Linux")
+	}
+}
+
+func newTestContext(t *testing.T, procPath string) context.Context {
+	return context.WithValue(t.Context(), common.EnvKey, common.EnvMap{
+		common.HostProcEnvKey: procPath,
+	})
+}
+
+func newTestScraper(t *testing.T, cfg *Config) *connectionsScraper {
+	s, err := newConnectionsScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	s.now = func() time.Time { return time.Unix(200, 0) }
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	return s
+}
+
+// dataPoints returns the values of the data points of a metric of a resource, by the keys
+// formatted by dataPointKey.
+func dataPoints(t *testing.T, rm pmetric.ResourceMetrics, name string) map[string]int64 {
+	values := map[string]int64{}
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		dps := m.Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			assert.Equal(t, pcommon.Timestamp(100*1e9), dp.StartTimestamp())
+			assert.Equal(t, pcommon.Timestamp(200*1e9), dp.Timestamp())
+			values[dataPointKey(dp.Attributes())] = dp.IntValue()
+		}
+	}
+	return values
+}
+
+// dataPointKey formats the attributes of a data point as protocol/address:port/state or direction.
+func dataPointKey(attrs pcommon.Map) string {
+	get := func(key string) string {
+		v, _ := attrs.Get(key)
+		return v.AsString()
+	}
+	address, port := get("network.local.address"), get("network.local.port")
+	if _, ok := attrs.Get("network.peer.address"); ok {
+		address, port = get("network.peer.address"), get("network.peer.port")
+	}
+	last := get("state")
+	if _, ok := attrs.Get("direction"); ok {
+		last = get("direction")
+	}
+	return get("protocol") + "/" + address + ":" + port + "/" + last
+}
+
+func TestScrape(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	s := newTestScraper(t, createDefaultConfig().(*Config))
+	md, err := s.scrape(newTestContext(t, "testdata/proc"))
+	require.NoError(t, err)
+
+	rms := md.ResourceMetrics()
+	require.Equal(t, 5, rms.Len())
+
+	// The sockets without owning process
+	unowned := rms.At(0)
+	assert.Equal(t, 0, unowned.Resource().Attributes().Len())
+	assert.Equal(t, map[string]int64{
+		"tcp/0.0.0.0:80/TIME_WAIT": 1,
+	}, dataPoints(t, unowned, "system.network.listener.connections"))
+	assert.Empty(t, dataPoints(t, unowned, "system.network.peer.connections"))
+
+	nginx := rms.At(1)
+	assert.Equal(t, map[string]any{
+		"process.pid":             int64(100),
+		"process.executable.name": "nginx",
+	}, nginx.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]int64{
+		"tcp/0.0.0.0:80/LISTEN":      1,
+		"tcp/0.0.0.0:80/ESTABLISHED": 1,
+	}, dataPoints(t, nginx, "system.network.listener.connections"))
+	assert.Equal(t, map[string]int64{
+		"tcp/0.0.0.0:80/receive":  32,
+		"tcp/0.0.0.0:80/transmit": 16,
+	}, dataPoints(t, nginx, "system.network.listener.queue.size"))
+
+	postgres := rms.At(2)
+	assert.Equal(t, map[string]int64{
+		"tcp/127.0.0.1:5432/LISTEN":      1,
+		"tcp/127.0.0.1:5432/ESTABLISHED": 1,
+	}, dataPoints(t, postgres, "system.network.listener.connections"))
+
+	app := rms.At(3)
+	assert.Equal(t, map[string]any{
+		"process.pid":             int64(300),
+		"process.executable.name": "app",
+	}, app.Resource().Attributes().AsRaw())
+	// The IPv4 connection accepted by the IPv6 listening socket
+	assert.Equal(t, map[string]int64{
+		"tcp/:::8080/LISTEN":      1,
+		"tcp/:::8080/ESTABLISHED": 1,
+	}, dataPoints(t, app, "system.network.listener.connections"))
+	assert.Equal(t, map[string]int64{
+		"tcp/127.0.0.1:5432/ESTABLISHED":    1,
+		"tcp/93.184.216.34:443/ESTABLISHED": 1,
+		"tcp/93.184.216.34:443/CLOSE_WAIT":  1,
+		"tcp/10.0.0.2:6379/SYN_SENT":        1,
+		"udp/8.8.8.8:53/ESTABLISHED":        1,
+	}, dataPoints(t, app, "system.network.peer.connections"))
+	assert.Equal(t, map[string]int64{
+		"tcp/127.0.0.1:5432/receive":     0,
+		"tcp/127.0.0.1:5432/transmit":    0,
+		"tcp/93.184.216.34:443/receive":  8,
+		"tcp/93.184.216.34:443/transmit": 256,
+		"tcp/10.0.0.2:6379/receive":      0,
+		"tcp/10.0.0.2:6379/transmit":     0,
+		"udp/8.8.8.8:53/receive":         0,
+		"udp/8.8.8.8:53/transmit":        0,
+	}, dataPoints(t, app, "system.network.peer.queue.size"))
+
+	resolved := rms.At(4)
+	assert.Equal(t, map[string]int64{
+		"udp/0.0.0.0:53/UNCONN": 1,
+	}, dataPoints(t, resolved, "system.network.listener.connections"))
+	assert.Equal(t, map[string]int64{
+		"udp/0.0.0.0:53/receive":  64,
+		"udp/0.0.0.0:53/transmit": 0,
+	}, dataPoints(t, resolved, "system.network.listener.queue.size"))
+}
+
+func TestScrapeWithoutProcesses(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResolveProcesses = false
+
+	s := newTestScraper(t, cfg)
+	md, err := s.scrape(newTestContext(t, "testdata/proc"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, 0, rm.Resource().Attributes().Len())
+	assert.Equal(t, map[string]int64{
+		"tcp/0.0.0.0:80/LISTEN":          1,
+		"tcp/0.0.0.0:80/ESTABLISHED":     1,
+		"tcp/0.0.0.0:80/TIME_WAIT":       1,
+		"tcp/127.0.0.1:5432/LISTEN":      1,
+		"tcp/127.0.0.1:5432/ESTABLISHED": 1,
+		"tcp/:::8080/LISTEN":             1,
+		"tcp/:::8080/ESTABLISHED":        1,
+		"udp/0.0.0.0:53/UNCONN":          1,
+	}, dataPoints(t, rm, "system.network.listener.connections"))
+	assert.Len(t, dataPoints(t, rm, "system.network.peer.connections"), 5)
+}
+
+func TestScrapeListeners(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ResolveProcesses = false
+	cfg.Listeners.MaxCount = 2
+
+	s := newTestScraper(t, cfg)
+	md, err := s.scrape(newTestContext(t, "testdata/proc"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	// The listening ports with the most connections, then in the order of the protocols and addresses
+	assert.Equal(t, map[string]int64{
+		"tcp/0.0.0.0:80/LISTEN":          1,
+		"tcp/0.0.0.0:80/ESTABLISHED":     1,
+		"tcp/0.0.0.0:80/TIME_WAIT":       1,
+		"tcp/127.0.0.1:5432/LISTEN":      1,
+		"tcp/127.0.0.1:5432/ESTABLISHED": 1,
+	}, dataPoints(t, md.ResourceMetrics().At(0), "system.network.listener.connections"))
+}
+
+func TestScrapePeers(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	tests := []struct {
+		name          string
+		peers         PeersConfig
+		expectedPeers []string
+	}{
+		{
+			name: "max count",
+			peers: PeersConfig{
+				MaxCount: 2,
+			},
+			// The remote endpoints with the most connections, then in the order of the protocols and addresses
+			expectedPeers: []string{
+				"tcp/93.184.216.34:443/ESTABLISHED",
+				"tcp/93.184.216.34:443/CLOSE_WAIT",
+				"tcp/10.0.0.2:6379/SYN_SENT",
+			},
+		},
+		{
+			name: "no limit",
+			expectedPeers: []string{
+				"tcp/127.0.0.1:5432/ESTABLISHED",
+				"tcp/93.184.216.34:443/ESTABLISHED",
+				"tcp/93.184.216.34:443/CLOSE_WAIT",
+				"tcp/10.0.0.2:6379/SYN_SENT",
+				"udp/8.8.8.8:53/ESTABLISHED",
+			},
+		},
+		{
+			name: "include",
+			peers: PeersConfig{
+				Include: MatchConfig{
+					Config:    filterset.Config{MatchType: filterset.Strict},
+					Addresses: []string{"8.8.8.8", "10.0.0.2"},
+				},
+			},
+			expectedPeers: []string{
+				"tcp/10.0.0.2:6379/SYN_SENT",
+				"udp/8.8.8.8:53/ESTABLISHED",
+			},
+		},
+		{
+			name: "exclude",
+			peers: PeersConfig{
+				Exclude: MatchConfig{
+					Config:    filterset.Config{MatchType: filterset.Regexp},
+					Addresses: []string{`^127\.`, `^10\.`},
+				},
+			},
+			expectedPeers: []string{
+				"tcp/93.184.216.34:443/ESTABLISHED",
+				"tcp/93.184.216.34:443/CLOSE_WAIT",
+				"udp/8.8.8.8:53/ESTABLISHED",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.ResolveProcesses = false
+			cfg.Peers = tt.peers
+
+			s := newTestScraper(t, cfg)
+			md, err := s.scrape(newTestContext(t, "testdata/proc"))
+			require.NoError(t, err)
+
+			require.Equal(t, 1, md.ResourceMetrics().Len())
+			var peers []string
+			for key := range dataPoints(t, md.ResourceMetrics().At(0), "system.network.peer.connections") {
+				peers = append(peers, key)
+			}
+			assert.ElementsMatch(t, tt.expectedPeers, peers)
+		})
+	}
+}
+
+func TestScrapeMissingTables(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	s := newTestScraper(t, createDefaultConfig().(*Config))
+	md, err := s.scrape(newTestContext(t, "testdata/noproc"))
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	// The missing IPv6 tables are not reported
+	assert.ErrorContains(t, err, "failed to read the tcp sockets")
+	assert.ErrorContains(t, err, "failed to read the udp sockets")
+	assert.NotContains(t, err.Error(), "tcp6")
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+}
+
+func TestNewConnectionsScraperInvalidMaxCount(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Peers.MaxCount = -1
+
+	_, err := newConnectionsScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorIs(t, err, errInvalidMaxPeers)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Listeners.MaxCount = -1
+
+	_, err = newConnectionsScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorIs(t, err, errInvalidMaxListeners)
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectionsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper"
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper/internal/metadata"
+)
+
+// socketTable is a table of the sockets of a protocol in /proc/net.
+type socketTable struct {
+	name     string
+	protocol metadata.AttributeProtocol
+	ipv6     bool
+}
+
+var socketTables = []socketTable{
+	{name: "tcp", protocol: metadata.AttributeProtocolTcp},
+	{name: "tcp6", protocol: metadata.AttributeProtocolTcp, ipv6: true},
+	{name: "udp", protocol: metadata.AttributeProtocolUdp},
+	{name: "udp6", protocol: metadata.AttributeProtocolUdp, ipv6: true},
+}
+
+// tcpStates are the names of the states of the TCP sockets, as reported by gopsutil, by the
+// hexadecimal value of the state in /proc/net/tcp.
+var tcpStates = map[uint64]string{
+	0x01: "ESTABLISHED",
+	0x02: "SYN_SENT",
+	0x03: "SYN_RECV",
+	0x04: "FIN_WAIT1",
+	0x05: "FIN_WAIT2",
+	0x06: "TIME_WAIT",
+	0x07: "CLOSE",
+	0x08: "CLOSE_WAIT",
+	0x09: "LAST_ACK",
+	0x0A: "LISTEN",
+	0x0B: "CLOSING",
+	0x0C: "NEW_SYN_RECV",
+}
+
+const (
+	stateListen = "LISTEN"
+	// stateUnconnected is the state of the UDP sockets without remote endpoint, named after ss.
+	stateUnconnected = "UNCONN"
+)
+
+// socket is an entry of a socket table, for instance:
+//
+//	sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+//	 0: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 12345 ...
+type socket struct {
+	protocol metadata.AttributeProtocol
+	local    netip.AddrPort
+	remote   netip.AddrPort
+	state    string
+	txQueue  int64
+	rxQueue  int64
+	inode    uint64
+}
+
+// isListener returns whether the socket is a listening TCP socket or an unconnected UDP socket.
+func (s socket) isListener() bool {
+	return s.state == stateListen || s.state == stateUnconnected
+}
+
+// accepts returns whether the connection was accepted by the listening socket, which is the case
+// when they are in the same table and the socket listens on the local address and port of the connection.
+func (s socket) accepts(conn socket) bool {
+	return s.protocol == conn.protocol &&
+		s.local.Port() == conn.local.Port() &&
+		s.local.Addr().Is4() == conn.local.Addr().Is4() &&
+		(s.local.Addr().IsUnspecified() || s.local.Addr() == conn.local.Addr())
+}
+
+func readSocketTable(path string, protocol metadata.AttributeProtocol) ([]socket, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseSocketTable(f, protocol)
+}
+
+// parseSocketTable parses the sockets of a socket table.
+func parseSocketTable(r io.Reader, protocol metadata.AttributeProtocol) ([]socket, error) {
+	var sockets []socket
+	scanner := bufio.NewScanner(r)
+	// Skip the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 10 {
+			return nil, fmt.Errorf("invalid socket entry %q", scanner.Text())
+		}
+
+		s := socket{protocol: protocol}
+		var err error
+		if s.local, err = parseAddrPort(fields[1]); err != nil {
+			return nil, err
+		}
+		if s.remote, err = parseAddrPort(fields[2]); err != nil {
+			return nil, err
+		}
+		if s.state, err = parseState(fields[3], protocol); err != nil {
+			return nil, err
+		}
+		tx, rx, _ := strings.Cut(fields[4], ":")
+		if s.txQueue, err = strconv.ParseInt(tx, 16, 64); err != nil {
+			return nil, fmt.Errorf("invalid transmit queue %q: %w", fields[4], err)
+		}
+		if s.rxQueue, err = strconv.ParseInt(rx, 16, 64); err != nil {
+			return nil, fmt.Errorf("invalid receive queue %q: %w", fields[4], err)
+		}
+		if s.inode, err = strconv.ParseUint(fields[9], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid inode %q: %w", fields[9], err)
+		}
+		sockets = append(sockets, s)
+	}
+	return sockets, scanner.Err()
+}
+
+// parseAddrPort parses an address of a socket table, made of the address in hexadecimal, as 32-bit
+// words in host byte order, and of the port in hexadecimal.
+func parseAddrPort(s string) (netip.AddrPort, error) {
+	addrHex, portHex, found := strings.Cut(s, ":")
+	if !found {
+		return netip.AddrPort{}, fmt.Errorf("invalid socket address %q", s)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return netip.AddrPort{}, fmt.Errorf("invalid socket address %q: %w", s, err)
+	}
+	b, err := hex.DecodeString(addrHex)
+	if err != nil || (len(b) != 4 && len(b) != 16) {
+		return netip.AddrPort{}, fmt.Errorf("invalid socket address %q", s)
+	}
+	for i := 0; i < len(b); i += 4 {
+		binary.NativeEndian.PutUint32(b[i:], binary.BigEndian.Uint32(b[i:]))
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return netip.AddrPortFrom(addr, uint16(port)), nil
+}
+
+func parseState(s string, protocol metadata.AttributeProtocol) (string, error) {
+	st, err := strconv.ParseUint(s, 16, 8)
+	if err != nil {
+		return "", fmt.Errorf("invalid socket state %q: %w", s, err)
+	}
+	// The UDP sockets only use the established and close states, the latter for the unconnected sockets
+	if protocol == metadata.AttributeProtocolUdp && st == 0x07 {
+		return stateUnconnected, nil
+	}
+	if state, ok := tcpStates[st]; ok {
+		return state, nil
+	}
+	return "UNKNOWN", nil
+}
+
+// portRange is an inclusive range of ports.
+type portRange struct {
+	first uint16
+	last  uint16
+}
+
+// defaultEphemeralPorts is the default range of the ports assigned to the sockets which are not
+// explicitly bound to a port, e.g. the client UDP sockets.
+var defaultEphemeralPorts = portRange{first: 32768, last: 60999}
+
+func (r portRange) contains(port uint16) bool {
+	return port >= r.first && port <= r.last
+}
+
+// readEphemeralPorts reads the range of the ephemeral ports from /proc/sys/net/ipv4/ip_local_port_range,
+// which applies to both IPv4 and IPv6.
+func readEphemeralPorts(procRoot string) (portRange, error) {
+	by, err := os.ReadFile(filepath.Join(procRoot, "sys", "net", "ipv4", "ip_local_port_range"))
+	if err != nil {
+		return portRange{}, err
+	}
+	fields := strings.Fields(string(by))
+	if len(fields) != 2 {
+		return portRange{}, fmt.Errorf("invalid port range %q", by)
+	}
+	first, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return portRange{}, fmt.Errorf("invalid port range %q: %w", by, err)
+	}
+	last, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil {
+		return portRange{}, fmt.Errorf("invalid port range %q: %w", by, err)
+	}
+	return portRange{first: uint16(first), last: uint16(last)}, nil
+}
+
+// process is a process owning sockets.
+type process struct {
+	pid  int64
+	name string
+}
+
+// readSocketOwners returns the processes owning the sockets by inode, read from the file descriptors
+// of the processes. The processes that exit or whose file descriptors cannot be read are skipped.
+func readSocketOwners(procRoot string) (map[uint64]*process, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := map[uint64]*process{}
+	for _, entry := range entries {
+		pid, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		fdDir := filepath.Join(procRoot, entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+
+		var p *process
+		for _, fd := range fds {
+			inode, ok := readSocketInode(filepath.Join(fdDir, fd.Name()))
+			if !ok {
+				continue
+			}
+			if p == nil {
+				p = &process{pid: pid, name: readProcessName(filepath.Join(procRoot, entry.Name()))}
+			}
+			// The sockets shared by several processes, e.g. after a fork, are attributed to the first one
+			if _, ok := owners[inode]; !ok {
+				owners[inode] = p
+			}
+		}
+	}
+	return owners, nil
+}
+
+// readSocketInode returns the inode of the socket of a file descriptor, whose link is socket:[<inode>].
+func readSocketInode(fdPath string) (uint64, bool) {
+	link, err := os.Readlink(fdPath)
+	if err != nil {
+		return 0, false
+	}
+	inode, found := strings.CutPrefix(link, "socket:[")
+	if !found {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(strings.TrimSuffix(inode, "]"), 10, 64)
+	return n, err == nil
+}
+
+func readProcessName(procDir string) string {
+	comm, err := os.ReadFile(filepath.Join(procDir, "comm"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
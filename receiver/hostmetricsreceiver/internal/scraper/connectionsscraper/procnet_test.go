@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectionsscraper
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/connectionsscraper/internal/metadata"
+)
+
+func TestParseSocketTable(t *testing.T) {
+	sockets, err := readSocketTable("testdata/proc/net/tcp", metadata.AttributeProtocolTcp)
+	require.NoError(t, err)
+	require.Len(t, sockets, 9)
+
+	assert.Equal(t, socket{
+		protocol: metadata.AttributeProtocolTcp,
+		local:    netip.MustParseAddrPort("0.0.0.0:80"),
+		remote:   netip.MustParseAddrPort("0.0.0.0:0"),
+		state:    "LISTEN",
+		inode:    1001,
+	}, sockets[0])
+	assert.Equal(t, socket{
+		protocol: metadata.AttributeProtocolTcp,
+		local:    netip.MustParseAddrPort("10.0.0.1:80"),
+		remote:   netip.MustParseAddrPort("192.168.1.10:50000"),
+		state:    "ESTABLISHED",
+		txQueue:  16,
+		rxQueue:  32,
+		inode:    1002,
+	}, sockets[2])
+	assert.Equal(t, "TIME_WAIT", sockets[3].state)
+	assert.Equal(t, uint64(0), sockets[3].inode)
+}
+
+func TestParseSocketTableIPv6(t *testing.T) {
+	sockets, err := readSocketTable("testdata/proc/net/tcp6", metadata.AttributeProtocolTcp)
+	require.NoError(t, err)
+	require.Len(t, sockets, 2)
+
+	assert.Equal(t, netip.MustParseAddrPort("[::]:8080"), sockets[0].local)
+	assert.Equal(t, netip.MustParseAddrPort("[::ffff:10.0.0.1]:8080"), sockets[1].local)
+	assert.Equal(t, netip.MustParseAddrPort("[::ffff:192.168.1.12]:50002"), sockets[1].remote)
+}
+
+func TestParseSocketTableUDP(t *testing.T) {
+	sockets, err := readSocketTable("testdata/proc/net/udp", metadata.AttributeProtocolUdp)
+	require.NoError(t, err)
+	require.Len(t, sockets, 3)
+
+	assert.Equal(t, "UNCONN", sockets[0].state)
+	assert.True(t, sockets[0].isListener())
+	assert.Equal(t, int64(64), sockets[0].rxQueue)
+	assert.Equal(t, "ESTABLISHED", sockets[1].state)
+	assert.False(t, sockets[1].isListener())
+
+	sockets, err = readSocketTable("testdata/proc/net/udp6", metadata.AttributeProtocolUdp)
+	require.NoError(t, err)
+	assert.Empty(t, sockets)
+}
+
+func TestParseSocketTableInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+	}{
+		{
+			name:  "missing fields",
+			entry: "0: 0100007F:0050 00000000:0000 0A",
+		},
+		{
+			name:  "invalid address",
+			entry: "0: 0100007G:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 1 1",
+		},
+		{
+			name:  "invalid address length",
+			entry: "0: 01000000007F:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 1 1",
+		},
+		{
+			name:  "invalid port",
+			entry: "0: 0100007F:10050 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 1 1",
+		},
+		{
+			name:  "invalid state",
+			entry: "0: 0100007F:0050 00000000:0000 ZZ 00000000:00000000 00:00000000 00000000 0 0 1 1",
+		},
+		{
+			name:  "invalid queue",
+			entry: "0: 0100007F:0050 00000000:0000 0A 00000000:0000000Z 00:00000000 00000000 0 0 1 1",
+		},
+		{
+			name:  "invalid inode",
+			entry: "0: 0100007F:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000 0 0 -1 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSocketTable(strings.NewReader("header\n"+tt.entry+"\n"), metadata.AttributeProtocolTcp)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	listener := func(addrPort string) socket {
+		return socket{protocol: metadata.AttributeProtocolTcp, local: netip.MustParseAddrPort(addrPort), state: stateListen}
+	}
+	conn := socket{protocol: metadata.AttributeProtocolTcp, local: netip.MustParseAddrPort("10.0.0.1:80"), state: "ESTABLISHED"}
+
+	assert.True(t, listener("0.0.0.0:80").accepts(conn))
+	assert.True(t, listener("10.0.0.1:80").accepts(conn))
+	assert.False(t, listener("127.0.0.1:80").accepts(conn))
+	assert.False(t, listener("0.0.0.0:8080").accepts(conn))
+	// The IPv4 connections accepted by the IPv6 listening sockets are in the IPv6 tables
+	assert.False(t, listener("[::]:80").accepts(conn))
+	assert.True(t, listener("[::]:80").accepts(socket{
+		protocol: metadata.AttributeProtocolTcp,
+		local:    netip.MustParseAddrPort("[::ffff:10.0.0.1]:80"),
+	}))
+}
+
+func TestReadEphemeralPorts(t *testing.T) {
+	ports, err := readEphemeralPorts("testdata/proc")
+	require.NoError(t, err)
+	assert.Equal(t, defaultEphemeralPorts, ports)
+	assert.True(t, ports.contains(45270))
+	assert.False(t, ports.contains(53))
+
+	_, err = readEphemeralPorts("testdata/noproc")
+	assert.Error(t, err)
+}
+
+func TestReadSocketOwners(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	owners, err := readSocketOwners("testdata/proc")
+	require.NoError(t, err)
+
+	require.Len(t, owners, 12)
+	assert.Equal(t, &process{pid: 100, name: "nginx"}, owners[1001])
+	assert.Equal(t, &process{pid: 300, name: "app"}, owners[3007])
+	assert.Equal(t, &process{pid: 400, name: "systemd-resolve"}, owners[4001])
+	assert.NotContains(t, owners, uint64(0))
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// Config relating to Pressure Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+
+	// Cgroups specifies the cgroups whose pressure is reported by the cgroup.pressure.* metrics.
+	Cgroups CgroupsConfig `mapstructure:"cgroups"`
+}
+
+// CgroupsConfig specifies the cgroups whose pressure is reported.
+type CgroupsConfig struct {
+	// MaxDepth is the maximum depth of the reported cgroups in the cgroup v2 hierarchy, where
+	// the children of the root cgroup have a depth of 1.
+	MaxDepth int `mapstructure:"max_depth"`
+
+	// Include specifies a filter on the paths of the cgroups that should be reported.
+	// Exclude specifies a filter on the paths of the cgroups that should not be reported.
+	// If neither `include` or `exclude` are set, all the cgroups up to the max depth are reported.
+	Include MatchConfig `mapstructure:"include"`
+	Exclude MatchConfig `mapstructure:"exclude"`
+}
+
+type MatchConfig struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Paths []string `mapstructure:"paths"`
+}
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/shirou/gopsutil/v4/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/cgroups"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const (
+	systemMetricsLen = 2
+	cgroupMetricsLen = 2
+)
+
+var (
+	errInvalidMaxDepth = errors.New("cgroups::max_depth must be positive")
+	errPSIUnavailable  = errors.New("pressure stall information is not available, the kernel must be built with CONFIG_PSI and booted without psi=0")
+	errNoCgroupV2      = errors.New("the cgroup v2 hierarchy is not mounted")
+)
+
+// pressureScraper for Pressure Metrics
+type pressureScraper struct {
+	settings  scraper.Settings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+	now      func() time.Time
+}
+
+// newPressureScraper creates a Pressure Scraper
+func newPressureScraper(settings scraper.Settings, cfg *Config) (*pressureScraper, error) {
+	if cfg.Cgroups.MaxDepth <= 0 {
+		return nil, errInvalidMaxDepth
+	}
+
+	scraper := &pressureScraper{
+		settings: settings,
+		config:   cfg,
+		bootTime: host.BootTimeWithContext,
+		now:      time.Now,
+	}
+
+	var err error
+
+	if len(cfg.Cgroups.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Cgroups.Include.Paths, &cfg.Cgroups.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Cgroups.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Cgroups.Exclude.Paths, &cfg.Cgroups.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *pressureScraper) start(ctx context.Context, _ component.Host) error {
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *pressureScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(s.now())
+
+	if s.config.Metrics.SystemPressureStallTime.Enabled || s.config.Metrics.SystemPressureStallRatio.Enabled {
+		s.scrapeSystem(ctx, now, &errs)
+		s.mb.EmitForResource()
+	}
+
+	if s.config.Metrics.CgroupPressureStallTime.Enabled || s.config.Metrics.CgroupPressureStallRatio.Enabled {
+		s.scrapeCgroups(ctx, now, &errs)
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+// scrapeSystem records the pressure of the host, read from /proc/pressure.
+func (s *pressureScraper) scrapeSystem(ctx context.Context, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	dir := gopsutilenv.GetEnvWithContext(ctx, string(common.HostProcEnvKey), "/proc", "pressure")
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		errs.AddPartial(systemMetricsLen, errPSIUnavailable)
+		return
+	}
+
+	for _, resource := range pressureResources {
+		stalls, err := readPressureFile(filepath.Join(dir, resource.name))
+		if errors.Is(err, fs.ErrNotExist) {
+			// The irq pressure is only reported by kernels built with CONFIG_IRQ_TIME_ACCOUNTING
+			continue
+		}
+		if err != nil {
+			errs.AddPartial(systemMetricsLen, fmt.Errorf("failed to read %s pressure: %w", resource.name, err))
+			continue
+		}
+		for _, st := range stalls {
+			s.mb.RecordSystemPressureStallTimeDataPoint(now, st.total.Seconds(), resource.attribute, st.stallType)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, st.avg10/100, resource.attribute, st.stallType, metadata.AttributeWindow10s)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, st.avg60/100, resource.attribute, st.stallType, metadata.AttributeWindow60s)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, st.avg300/100, resource.attribute, st.stallType, metadata.AttributeWindow300s)
+		}
+	}
+}
+
+// scrapeCgroups records the pressure of the cgroups of the cgroup v2 hierarchy up to the max depth,
+// read from their *.pressure files. The pressure of the root cgroup is the pressure of the host.
+func (s *pressureScraper) scrapeCgroups(ctx context.Context, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	root, err := cgroupV2Root(gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "fs", "cgroup"))
+	if err != nil {
+		errs.AddPartial(cgroupMetricsLen, err)
+		return
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Cgroups are removed concurrently with the walk
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs.AddPartial(cgroupMetricsLen, fmt.Errorf("failed to read cgroup %q: %w", path, err))
+			}
+			return nil
+		}
+		if !d.IsDir() || path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		cgroupPath := "/" + filepath.ToSlash(rel)
+		if s.includeCgroup(cgroupPath) {
+			s.scrapeCgroup(path, cgroupPath, now, errs)
+		}
+
+		if strings.Count(cgroupPath, "/") >= s.config.Cgroups.MaxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		errs.AddPartial(cgroupMetricsLen, fmt.Errorf("failed to walk the cgroup hierarchy: %w", err))
+	}
+}
+
+func (s *pressureScraper) includeCgroup(cgroupPath string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(cgroupPath)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(cgroupPath))
+}
+
+func (s *pressureScraper) scrapeCgroup(dir, cgroupPath string, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	recorded := false
+	for _, resource := range pressureResources {
+		stalls, err := readPressureFile(filepath.Join(dir, resource.name+".pressure"))
+		// The pressure files are missing when the cgroup is removed, and reading them fails
+		// when the pressure accounting of the cgroup is disabled in cgroup.pressure
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, errors.ErrUnsupported) {
+			continue
+		}
+		if err != nil {
+			errs.AddPartial(cgroupMetricsLen, fmt.Errorf("failed to read %s pressure of cgroup %q: %w", resource.name, cgroupPath, err))
+			continue
+		}
+		for _, st := range stalls {
+			s.mb.RecordCgroupPressureStallTimeDataPoint(now, st.total.Seconds(), resource.attribute, st.stallType)
+			s.mb.RecordCgroupPressureStallRatioDataPoint(now, st.avg10/100, resource.attribute, st.stallType, metadata.AttributeWindow10s)
+			s.mb.RecordCgroupPressureStallRatioDataPoint(now, st.avg60/100, resource.attribute, st.stallType, metadata.AttributeWindow60s)
+			s.mb.RecordCgroupPressureStallRatioDataPoint(now, st.avg300/100, resource.attribute, st.stallType, metadata.AttributeWindow300s)
+			recorded = true
+		}
+	}
+	if !recorded {
+		return
+	}
+
+	rb := s.mb.NewResourceBuilder()
+	rb.SetCgroupPath(cgroupPath)
+	if containerID := cgroups.ContainerID(cgroupPath); containerID != "" {
+		rb.SetContainerID(containerID)
+	}
+	s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+// cgroupV2Root returns the root of the cgroup v2 hierarchy, which is mounted at /sys/fs/cgroup
+// in unified mode and at /sys/fs/cgroup/unified in hybrid mode.
+func cgroupV2Root(mountPoint string) (string, error) {
+	for _, root := range []string{mountPoint, filepath.Join(mountPoint, "unified")} {
+		if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+			return root, nil
+		}
+	}
+	return "", errNoCgroupV2
+}
+
+func readPressureFile(path string) ([]stall, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parsePressure(f)
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const (
+	testContainerID     = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	testContainerCgroup = "/system.slice/docker-" + testContainerID + ".scope"
+)
+
+func skipTestOnUnsupportedOS(t *testing.T) {
+	if !supportedOS {
+		t.Skip("the pressure scraper is only available on Linux")
+	}
+}
+
+func newTestContext(t *testing.T, procPath, sysPath string) context.Context {
+	return context.WithValue(t.Context(), common.EnvKey, common.EnvMap{
+		common.HostProcEnvKey: procPath,
+		common.HostSysEnvKey:  sysPath,
+	})
+}
+
+func newTestScraper(t *testing.T, cfg *Config) *pressureScraper {
+	s, err := newPressureScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	s.now = func() time.Time { return time.Unix(200, 0) }
+	require.NoError(t, s.start(t.Context(), componenttest.NewNopHost()))
+	return s
+}
+
+// dataPointValue returns the value of the data point of a metric with the given attributes.
+func dataPointValue(t *testing.T, dps pmetric.NumberDataPointSlice, attrs map[string]any) float64 {
+	for i := 0; i < dps.Len(); i++ {
+		if assert.ObjectsAreEqual(attrs, dps.At(i).Attributes().AsRaw()) {
+			return dps.At(i).DoubleValue()
+		}
+	}
+	require.Failf(t, "data point not found", "attributes: %v", attrs)
+	return 0
+}
+
+// resourceCgroupPaths returns the resource metrics of the cgroups by cgroup path.
+func resourceCgroupPaths(md pmetric.Metrics) map[string]pmetric.ResourceMetrics {
+	rms := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		if v, ok := rm.Resource().Attributes().Get("cgroup.path"); ok {
+			rms[v.Str()] = rm
+		}
+	}
+	return rms
+}
+
+func TestScrapeSystem(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	s := newTestScraper(t, createDefaultConfig().(*Config))
+	md, err := s.scrape(newTestContext(t, "testdata/proc", "testdata/sys"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, 0, rm.Resource().Attributes().Len())
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	stallRatio := metrics.At(0)
+	assert.Equal(t, "system.pressure.stall.ratio", stallRatio.Name())
+	// 3 resources, irq is not reported by the fixture, with 2 stall types and 3 windows
+	require.Equal(t, 18, stallRatio.Gauge().DataPoints().Len())
+	assert.InDelta(t, 0.1, dataPointValue(t, stallRatio.Gauge().DataPoints(), map[string]any{"resource": "memory", "type": "some", "window": "10s"}), 1e-9)
+	assert.InDelta(t, 0.01, dataPointValue(t, stallRatio.Gauge().DataPoints(), map[string]any{"resource": "memory", "type": "full", "window": "300s"}), 1e-9)
+
+	stallTime := metrics.At(1)
+	assert.Equal(t, "system.pressure.stall.time", stallTime.Name())
+	require.Equal(t, 6, stallTime.Sum().DataPoints().Len())
+	assert.Equal(t, pcommon.Timestamp(100*1e9), stallTime.Sum().DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(200*1e9), stallTime.Sum().DataPoints().At(0).Timestamp())
+	assert.InDelta(t, 2.5, dataPointValue(t, stallTime.Sum().DataPoints(), map[string]any{"resource": "cpu", "type": "some"}), 1e-9)
+	assert.InDelta(t, 3, dataPointValue(t, stallTime.Sum().DataPoints(), map[string]any{"resource": "io", "type": "full"}), 1e-9)
+}
+
+func TestScrapeSystemUnavailable(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	s := newTestScraper(t, createDefaultConfig().(*Config))
+	_, err := s.scrape(newTestContext(t, "testdata/noproc", "testdata/sys"))
+	require.ErrorIs(t, err, errPSIUnavailable)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+}
+
+func TestScrapeCgroups(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	tests := []struct {
+		name          string
+		mutateConfig  func(cfg *Config)
+		expectedPaths []string
+	}{
+		{
+			name: "default depth",
+			expectedPaths: []string{
+				"/system.slice",
+				"/user.slice",
+				testContainerCgroup,
+				testContainerCgroup + "/child",
+			},
+		},
+		{
+			name: "max depth",
+			mutateConfig: func(cfg *Config) {
+				cfg.Cgroups.MaxDepth = 2
+			},
+			expectedPaths: []string{
+				"/system.slice",
+				"/user.slice",
+				testContainerCgroup,
+			},
+		},
+		{
+			name: "include",
+			mutateConfig: func(cfg *Config) {
+				cfg.Cgroups.Include = MatchConfig{
+					Config: filterset.Config{MatchType: filterset.Regexp},
+					Paths:  []string{`/docker-.*\.scope$`},
+				}
+			},
+			expectedPaths: []string{testContainerCgroup},
+		},
+		{
+			name: "exclude",
+			mutateConfig: func(cfg *Config) {
+				cfg.Cgroups.Exclude = MatchConfig{
+					Config: filterset.Config{MatchType: filterset.Strict},
+					Paths:  []string{"/system.slice", "/user.slice"},
+				}
+			},
+			expectedPaths: []string{
+				testContainerCgroup,
+				testContainerCgroup + "/child",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Metrics.SystemPressureStallTime.Enabled = false
+			cfg.Metrics.SystemPressureStallRatio.Enabled = false
+			cfg.Metrics.CgroupPressureStallTime.Enabled = true
+			cfg.Metrics.CgroupPressureStallRatio.Enabled = true
+			if tt.mutateConfig != nil {
+				tt.mutateConfig(cfg)
+			}
+
+			s := newTestScraper(t, cfg)
+			md, err := s.scrape(newTestContext(t, "testdata/proc", "testdata/sys"))
+			require.NoError(t, err)
+
+			rms := resourceCgroupPaths(md)
+			assert.Len(t, rms, md.ResourceMetrics().Len())
+			var paths []string
+			for path := range rms {
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, tt.expectedPaths, paths)
+		})
+	}
+}
+
+func TestScrapeCgroupsResource(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.CgroupPressureStallTime.Enabled = true
+	cfg.Metrics.CgroupPressureStallRatio.Enabled = true
+
+	s := newTestScraper(t, cfg)
+	md, err := s.scrape(newTestContext(t, "testdata/proc", "testdata/sys"))
+	require.NoError(t, err)
+
+	rms := resourceCgroupPaths(md)
+	require.Contains(t, rms, testContainerCgroup)
+	rm := rms[testContainerCgroup]
+	assert.Equal(t, map[string]any{
+		"cgroup.path":  testContainerCgroup,
+		"container.id": testContainerID,
+	}, rm.Resource().Attributes().AsRaw())
+
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "cgroup.pressure.stall.ratio", metrics.At(0).Name())
+	assert.InDelta(t, 0.015, dataPointValue(t, metrics.At(0).Gauge().DataPoints(), map[string]any{"resource": "io", "type": "full", "window": "10s"}), 1e-9)
+	assert.Equal(t, "cgroup.pressure.stall.time", metrics.At(1).Name())
+	assert.InDelta(t, 2, dataPointValue(t, metrics.At(1).Sum().DataPoints(), map[string]any{"resource": "memory", "type": "some"}), 1e-9)
+
+	require.Contains(t, rms, "/system.slice")
+	_, ok := rms["/system.slice"].Resource().Attributes().Get("container.id")
+	assert.False(t, ok)
+}
+
+func TestScrapeCgroupsV1(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.CgroupPressureStallTime.Enabled = true
+
+	s := newTestScraper(t, cfg)
+	md, err := s.scrape(newTestContext(t, "testdata/proc", "testdata/cgroupv1"))
+	require.ErrorIs(t, err, errNoCgroupV2)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// pressureResource is a resource whose pressure is reported in a file named after it, both in
+// /proc/pressure and, with the .pressure suffix, in the cgroups.
+type pressureResource struct {
+	name      string
+	attribute metadata.AttributeResource
+}
+
+var pressureResources = []pressureResource{
+	{name: "cpu", attribute: metadata.AttributeResourceCPU},
+	{name: "memory", attribute: metadata.AttributeResourceMemory},
+	{name: "io", attribute: metadata.AttributeResourceIo},
+	{name: "irq", attribute: metadata.AttributeResourceIrq},
+}
+
+// stall is a line of a pressure file, for instance:
+//
+//	some avg10=0.12 avg60=0.05 avg300=0.01 total=1234567
+//
+// where the averages are the percentages of the time tasks were stalled over the last 10, 60 and
+// 300 seconds, and total is the total stall time in microseconds.
+type stall struct {
+	stallType metadata.AttributeStallType
+	avg10     float64
+	avg60     float64
+	avg300    float64
+	total     time.Duration
+}
+
+// parsePressure parses the stalls of a pressure file. The lines of unknown stall types are ignored.
+func parsePressure(r io.Reader) ([]stall, error) {
+	var stalls []stall
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		stallType, ok := metadata.MapAttributeStallType[fields[0]]
+		if !ok {
+			continue
+		}
+
+		s := stall{stallType: stallType}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid pressure field %q", field)
+			}
+			var err error
+			switch key {
+			case "avg10":
+				s.avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				s.avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				s.avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				var total uint64
+				total, err = strconv.ParseUint(value, 10, 64)
+				s.total = time.Duration(total) * time.Microsecond
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid pressure field %q: %w", field, err)
+			}
+		}
+		stalls = append(stalls, s)
+	}
+	return stalls, scanner.Err()
+}
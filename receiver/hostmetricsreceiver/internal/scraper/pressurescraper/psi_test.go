@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+func TestParsePressure(t *testing.T) {
+	stalls, err := parsePressure(strings.NewReader(`some avg10=1.50 avg60=0.75 avg300=0.25 total=2500000
+full avg10=0.50 avg60=0.00 avg300=0.00 total=1000
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []stall{
+		{
+			stallType: metadata.AttributeStallTypeSome,
+			avg10:     1.5,
+			avg60:     0.75,
+			avg300:    0.25,
+			total:     2500 * time.Millisecond,
+		},
+		{
+			stallType: metadata.AttributeStallTypeFull,
+			avg10:     0.5,
+			total:     time.Millisecond,
+		},
+	}, stalls)
+}
+
+func TestParsePressureIgnoresUnknownStallTypes(t *testing.T) {
+	stalls, err := parsePressure(strings.NewReader("partial avg10=1.00 avg60=1.00 avg300=1.00 total=1\n\n"))
+	require.NoError(t, err)
+	assert.Empty(t, stalls)
+}
+
+func TestParsePressureInvalid(t *testing.T) {
+	_, err := parsePressure(strings.NewReader("some avg10=abc avg60=0.00 avg300=0.00 total=0\n"))
+	assert.ErrorContains(t, err, `invalid pressure field "avg10=abc"`)
+
+	_, err = parsePressure(strings.NewReader("some avg10\n"))
+	assert.ErrorContains(t, err, `invalid pressure field "avg10"`)
+}
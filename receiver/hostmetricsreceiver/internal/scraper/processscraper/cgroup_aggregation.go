@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
+)
+
+// cgroupResourceAttributes are the resource attributes of the metrics aggregated by cgroup, which
+// are set whether they are enabled or not.
+var cgroupResourceAttributes = metadata.ResourceAttributesConfig{
+	CgroupPath:  metadata.ResourceAttributeConfig{Enabled: true},
+	ContainerID: metadata.ResourceAttributeConfig{Enabled: true},
+}
+
+// cumulativeKey identifies a data point of a monotonic cumulative sum of a cgroup.
+type cumulativeKey struct {
+	cgroupPath string
+	metric     string
+	attributes [16]byte
+}
+
+type numberValue struct {
+	intValue    int64
+	doubleValue float64
+}
+
+func (v numberValue) add(o numberValue) numberValue {
+	return numberValue{intValue: v.intValue + o.intValue, doubleValue: v.doubleValue + o.doubleValue}
+}
+
+func (v numberValue) less(o numberValue) bool {
+	return v.intValue < o.intValue || v.doubleValue < o.doubleValue
+}
+
+// cumulativeState is the state of a data point of a monotonic cumulative sum of a cgroup. The
+// values of the processes which exited are kept, so that the sum does not decrease when a process
+// of the cgroup exits.
+type cumulativeState struct {
+	startTime pcommon.Timestamp
+	exited    numberValue
+	// processes are the last values of the running processes, by PID.
+	processes map[int32]numberValue
+}
+
+// cgroupMetrics are the aggregated metrics of the processes of a cgroup.
+type cgroupMetrics struct {
+	scopeMetrics pmetric.ScopeMetrics
+	metrics      map[string]pmetric.Metric
+}
+
+// cgroupAggregator merges the metrics of the processes of the same cgroup, as built by
+// buildCgroupResource. The values of the data points with the same attributes are summed, and the
+// aggregated data points keep the earliest start time and the latest time. The monotonic cumulative
+// sums, such as process.cpu.time, also include the values of the processes which exited since the
+// cgroup was first scraped.
+type cgroupAggregator struct {
+	cumulative map[cumulativeKey]*cumulativeState
+
+	// The metrics of the current scrape.
+	aggregated pmetric.Metrics
+	cgroups    map[string]*cgroupMetrics
+	processes  map[int32]struct{}
+}
+
+func newCgroupAggregator() *cgroupAggregator {
+	a := &cgroupAggregator{cumulative: map[cumulativeKey]*cumulativeState{}}
+	a.reset()
+	return a
+}
+
+func (a *cgroupAggregator) reset() {
+	a.aggregated = pmetric.NewMetrics()
+	a.cgroups = map[string]*cgroupMetrics{}
+	a.processes = map[int32]struct{}{}
+}
+
+// add merges the metrics of a process of the current scrape.
+func (a *cgroupAggregator) add(cgroupPath string, pid int32, md pmetric.Metrics) {
+	a.processes[pid] = struct{}{}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		cm, ok := a.cgroups[cgroupPath]
+		if !ok {
+			arm := a.aggregated.ResourceMetrics().AppendEmpty()
+			arm.SetSchemaUrl(rm.SchemaUrl())
+			rm.Resource().CopyTo(arm.Resource())
+			cm = &cgroupMetrics{
+				scopeMetrics: arm.ScopeMetrics().AppendEmpty(),
+				metrics:      map[string]pmetric.Metric{},
+			}
+			a.cgroups[cgroupPath] = cm
+		}
+
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			sm.Scope().CopyTo(cm.scopeMetrics.Scope())
+			cm.scopeMetrics.SetSchemaUrl(sm.SchemaUrl())
+
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				if isMonotonicCumulative(m) {
+					a.recordCumulative(cgroupPath, pid, m)
+				}
+				am, ok := cm.metrics[m.Name()]
+				if !ok {
+					am = cm.scopeMetrics.Metrics().AppendEmpty()
+					m.CopyTo(am)
+					cm.metrics[m.Name()] = am
+					continue
+				}
+				mergeMetric(am, m)
+			}
+		}
+	}
+}
+
+// emit returns the aggregated metrics of the current scrape. The state of the cgroups which have
+// no process left is dropped.
+func (a *cgroupAggregator) emit() pmetric.Metrics {
+	for key, state := range a.cumulative {
+		cm, ok := a.cgroups[key.cgroupPath]
+		if !ok {
+			delete(a.cumulative, key)
+			continue
+		}
+
+		total := state.exited
+		for pid, value := range state.processes {
+			if _, ok := a.processes[pid]; !ok {
+				state.exited = state.exited.add(value)
+				delete(state.processes, pid)
+			}
+			total = total.add(value)
+		}
+
+		m, ok := cm.metrics[key.metric]
+		if !ok {
+			continue
+		}
+		if dp, ok := findDataPointByHash(m.Sum().DataPoints(), key.attributes); ok {
+			setNumberValue(dp, total)
+			dp.SetStartTimestamp(state.startTime)
+		}
+	}
+
+	aggregated := a.aggregated
+	a.reset()
+	return aggregated
+}
+
+// recordCumulative records the values of a monotonic cumulative sum of a process. A value lower
+// than the last one means that the PID was reused by a new process.
+func (a *cgroupAggregator) recordCumulative(cgroupPath string, pid int32, m pmetric.Metric) {
+	dps := m.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := cumulativeKey{cgroupPath: cgroupPath, metric: m.Name(), attributes: pdatautil.MapHash(dp.Attributes())}
+		state, ok := a.cumulative[key]
+		if !ok {
+			state = &cumulativeState{startTime: dp.StartTimestamp(), processes: map[int32]numberValue{}}
+			a.cumulative[key] = state
+		}
+		if dp.StartTimestamp() < state.startTime {
+			state.startTime = dp.StartTimestamp()
+		}
+		value := numberValue{intValue: dp.IntValue(), doubleValue: dp.DoubleValue()}
+		if last, ok := state.processes[pid]; ok && value.less(last) {
+			state.exited = state.exited.add(last)
+		}
+		state.processes[pid] = value
+	}
+}
+
+func isMonotonicCumulative(m pmetric.Metric) bool {
+	return m.Type() == pmetric.MetricTypeSum && m.Sum().IsMonotonic() &&
+		m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+}
+
+func mergeMetric(dest, src pmetric.Metric) {
+	switch src.Type() {
+	case pmetric.MetricTypeSum:
+		mergeDataPoints(dest.Sum().DataPoints(), src.Sum().DataPoints())
+	case pmetric.MetricTypeGauge:
+		mergeDataPoints(dest.Gauge().DataPoints(), src.Gauge().DataPoints())
+	}
+}
+
+func mergeDataPoints(dest, src pmetric.NumberDataPointSlice) {
+	for i := 0; i < src.Len(); i++ {
+		sdp := src.At(i)
+		ddp, ok := findDataPointByHash(dest, pdatautil.MapHash(sdp.Attributes()))
+		if !ok {
+			sdp.CopyTo(dest.AppendEmpty())
+			continue
+		}
+
+		setNumberValue(ddp, numberValue{intValue: ddp.IntValue(), doubleValue: ddp.DoubleValue()}.
+			add(numberValue{intValue: sdp.IntValue(), doubleValue: sdp.DoubleValue()}))
+		if sdp.StartTimestamp() < ddp.StartTimestamp() {
+			ddp.SetStartTimestamp(sdp.StartTimestamp())
+		}
+		if sdp.Timestamp() > ddp.Timestamp() {
+			ddp.SetTimestamp(sdp.Timestamp())
+		}
+	}
+}
+
+func setNumberValue(dp pmetric.NumberDataPoint, v numberValue) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		dp.SetIntValue(v.intValue)
+	case pmetric.NumberDataPointValueTypeDouble:
+		dp.SetDoubleValue(v.doubleValue)
+	}
+}
+
+func findDataPointByHash(dps pmetric.NumberDataPointSlice, hash [16]byte) (pmetric.NumberDataPoint, bool) {
+	for i := 0; i < dps.Len(); i++ {
+		if pdatautil.MapHash(dps.At(i).Attributes()) == hash {
+			return dps.At(i), true
+		}
+	}
+	return pmetric.NumberDataPoint{}, false
+}
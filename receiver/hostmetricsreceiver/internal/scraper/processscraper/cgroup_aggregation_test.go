@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processscraper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
+)
+
+func newProcessMetrics(cgroupPath string, start, ts pcommon.Timestamp, cpuTime float64, memoryUsage int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rb := metadata.NewResourceBuilder(cgroupResourceAttributes)
+	rb.SetCgroupPath(cgroupPath)
+	rb.Emit().CopyTo(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("processscraper")
+
+	cpu := sm.Metrics().AppendEmpty()
+	cpu.SetName("process.cpu.time")
+	cpuSum := cpu.SetEmptySum()
+	cpuSum.SetIsMonotonic(true)
+	cpuSum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, state := range []string{"user", "system"} {
+		dp := cpuSum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("state", state)
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(ts)
+		dp.SetDoubleValue(cpuTime)
+	}
+
+	memory := sm.Metrics().AppendEmpty()
+	memory.SetName("process.memory.usage")
+	memorySum := memory.SetEmptySum()
+	memorySum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := memorySum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(memoryUsage)
+	return md
+}
+
+func TestCgroupAggregator(t *testing.T) {
+	a := newCgroupAggregator()
+	a.add("/a", 1, newProcessMetrics("/a", 10, 100, 1.5, 100))
+	a.add("/b", 2, newProcessMetrics("/b", 20, 100, 2, 200))
+	a.add("/a", 3, newProcessMetrics("/a", 5, 101, 0.5, 300))
+
+	aggregated := a.emit()
+	require.Equal(t, 2, aggregated.ResourceMetrics().Len())
+
+	rm := aggregated.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"cgroup.path": "/a"}, rm.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	assert.Equal(t, "processscraper", rm.ScopeMetrics().At(0).Scope().Name())
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	cpuDps := metrics.At(0).Sum().DataPoints()
+	require.Equal(t, 2, cpuDps.Len())
+	for i := 0; i < cpuDps.Len(); i++ {
+		assert.Equal(t, 2.0, cpuDps.At(i).DoubleValue())
+		assert.Equal(t, pcommon.Timestamp(5), cpuDps.At(i).StartTimestamp())
+		assert.Equal(t, pcommon.Timestamp(101), cpuDps.At(i).Timestamp())
+	}
+	assert.Equal(t, int64(400), metrics.At(1).Sum().DataPoints().At(0).IntValue())
+
+	rm = aggregated.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]any{"cgroup.path": "/b"}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, int64(200), rm.ScopeMetrics().At(0).Metrics().At(1).Sum().DataPoints().At(0).IntValue())
+
+	// The process 3 exits, the CPU time of the cgroup keeps its CPU time, while its memory usage
+	// is not included anymore. The PID 1 is reused by a new process.
+	a.add("/a", 1, newProcessMetrics("/a", 200, 201, 0.25, 100))
+	aggregated = a.emit()
+	require.Equal(t, 1, aggregated.ResourceMetrics().Len())
+
+	metrics = aggregated.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	cpuDps = metrics.At(0).Sum().DataPoints()
+	require.Equal(t, 2, cpuDps.Len())
+	for i := 0; i < cpuDps.Len(); i++ {
+		assert.Equal(t, 2.25, cpuDps.At(i).DoubleValue())
+		assert.Equal(t, pcommon.Timestamp(5), cpuDps.At(i).StartTimestamp())
+		assert.Equal(t, pcommon.Timestamp(201), cpuDps.At(i).Timestamp())
+	}
+	assert.Equal(t, int64(100), metrics.At(1).Sum().DataPoints().At(0).IntValue())
+
+	// The state of the cgroups without processes is dropped
+	assert.Len(t, a.cumulative, 2)
+	a.add("/b", 2, newProcessMetrics("/b", 20, 300, 3, 200))
+	aggregated = a.emit()
+	require.Equal(t, 1, aggregated.ResourceMetrics().Len())
+	assert.Len(t, a.cumulative, 2)
+	a.add("/a", 4, newProcessMetrics("/a", 400, 401, 1, 100))
+	aggregated = a.emit()
+	cpuDps = aggregated.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	assert.Equal(t, 1.0, cpuDps.At(0).DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(400), cpuDps.At(0).StartTimestamp())
+}
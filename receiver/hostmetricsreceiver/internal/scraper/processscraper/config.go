@@ -53,6 +53,11 @@ type Config struct {
 	// ScrapeProcessDelay is used to indicate the minimum amount of time a process must be running
 	// before metrics are scraped for it.  The default value is 0 seconds (0s).
 	ScrapeProcessDelay time.Duration `mapstructure:"scrape_process_delay"`
+
+	// AggregateByCgroup is a flag that aggregates the metrics of the processes by cgroup (Linux only).
+	// The metrics of the processes of a cgroup are summed and emitted with the `cgroup.path` and
+	// `container.id` resource attributes only. The `process.uptime` metric is not emitted.
+	AggregateByCgroup bool `mapstructure:"aggregate_by_cgroup"`
 }
 
 type MatchConfig struct {
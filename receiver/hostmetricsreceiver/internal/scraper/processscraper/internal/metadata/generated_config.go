@@ -119,6 +119,8 @@ func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
 
 // ResourceAttributesConfig provides config for process resource attributes.
 type ResourceAttributesConfig struct {
+	CgroupPath            ResourceAttributeConfig `mapstructure:"cgroup.path"`
+	ContainerID           ResourceAttributeConfig `mapstructure:"container.id"`
 	ProcessCgroup         ResourceAttributeConfig `mapstructure:"process.cgroup"`
 	ProcessCommand        ResourceAttributeConfig `mapstructure:"process.command"`
 	ProcessCommandLine    ResourceAttributeConfig `mapstructure:"process.command_line"`
@@ -131,6 +133,12 @@ type ResourceAttributesConfig struct {
 
 func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 	return ResourceAttributesConfig{
+		CgroupPath: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		ContainerID: ResourceAttributeConfig{
+			Enabled: false,
+		},
 		ProcessCgroup: ResourceAttributeConfig{
 			Enabled: false,
 		},
@@ -43,6 +43,8 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					ProcessUptime:              MetricConfig{Enabled: true},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
+					CgroupPath:            ResourceAttributeConfig{Enabled: true},
+					ContainerID:           ResourceAttributeConfig{Enabled: true},
 					ProcessCgroup:         ResourceAttributeConfig{Enabled: true},
 					ProcessCommand:        ResourceAttributeConfig{Enabled: true},
 					ProcessCommandLine:    ResourceAttributeConfig{Enabled: true},
@@ -74,6 +76,8 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					ProcessUptime:              MetricConfig{Enabled: false},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
+					CgroupPath:            ResourceAttributeConfig{Enabled: false},
+					ContainerID:           ResourceAttributeConfig{Enabled: false},
 					ProcessCgroup:         ResourceAttributeConfig{Enabled: false},
 					ProcessCommand:        ResourceAttributeConfig{Enabled: false},
 					ProcessCommandLine:    ResourceAttributeConfig{Enabled: false},
@@ -117,6 +121,8 @@ func TestResourceAttributesConfig(t *testing.T) {
 		{
 			name: "all_set",
 			want: ResourceAttributesConfig{
+				CgroupPath:            ResourceAttributeConfig{Enabled: true},
+				ContainerID:           ResourceAttributeConfig{Enabled: true},
 				ProcessCgroup:         ResourceAttributeConfig{Enabled: true},
 				ProcessCommand:        ResourceAttributeConfig{Enabled: true},
 				ProcessCommandLine:    ResourceAttributeConfig{Enabled: true},
@@ -130,6 +136,8 @@ func TestResourceAttributesConfig(t *testing.T) {
 		{
 			name: "none_set",
 			want: ResourceAttributesConfig{
+				CgroupPath:            ResourceAttributeConfig{Enabled: false},
+				ContainerID:           ResourceAttributeConfig{Enabled: false},
 				ProcessCgroup:         ResourceAttributeConfig{Enabled: false},
 				ProcessCommand:        ResourceAttributeConfig{Enabled: false},
 				ProcessCommandLine:    ResourceAttributeConfig{Enabled: false},
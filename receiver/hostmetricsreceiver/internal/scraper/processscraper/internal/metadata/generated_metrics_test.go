@@ -115,6 +115,8 @@ func TestMetricsBuilder(t *testing.T) {
 			mb.RecordProcessUptimeDataPoint(ts, 1)
 
 			rb := mb.NewResourceBuilder()
+			rb.SetCgroupPath("cgroup.path-val")
+			rb.SetContainerID("container.id-val")
 			rb.SetProcessCgroup("process.cgroup-val")
 			rb.SetProcessCommand("process.command-val")
 			rb.SetProcessCommandLine("process.command_line-val")
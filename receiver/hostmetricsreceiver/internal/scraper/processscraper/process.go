@@ -34,9 +34,11 @@ type processMetadata struct {
 }
 
 type executableMetadata struct {
-	name   string
-	path   string
-	cgroup string
+	name        string
+	path        string
+	cgroup      string
+	cgroupPath  string
+	containerID string
 }
 
 type commandMetadata struct {
@@ -51,6 +53,12 @@ func (m *processMetadata) buildResource(rb *metadata.ResourceBuilder) pcommon.Re
 	rb.SetProcessExecutableName(m.executable.name)
 	rb.SetProcessExecutablePath(m.executable.path)
 	rb.SetProcessCgroup(m.executable.cgroup)
+	if m.executable.cgroupPath != "" {
+		rb.SetCgroupPath(m.executable.cgroupPath)
+	}
+	if m.executable.containerID != "" {
+		rb.SetContainerID(m.executable.containerID)
+	}
 	if m.command != nil {
 		rb.SetProcessCommand(m.command.command)
 		if m.command.commandLineSlice != nil {
@@ -67,6 +75,18 @@ func (m *processMetadata) buildResource(rb *metadata.ResourceBuilder) pcommon.Re
 	return rb.Emit()
 }
 
+// buildCgroupResource returns the resource of the cgroup of the process, which the metrics of the
+// processes are aggregated by when aggregate_by_cgroup is enabled.
+func (m *processMetadata) buildCgroupResource(rb *metadata.ResourceBuilder) pcommon.Resource {
+	if m.executable.cgroupPath != "" {
+		rb.SetCgroupPath(m.executable.cgroupPath)
+	}
+	if m.executable.containerID != "" {
+		rb.SetContainerID(m.executable.containerID)
+	}
+	return rb.Emit()
+}
+
 // processHandles provides a wrapper around []*process.Process
 // to support testing
 
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/scraper/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/cgroups"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/ucal"
 )
@@ -51,6 +52,7 @@ type processScraper struct {
 	scrapeProcessDelay time.Duration
 	ucals              map[int32]*ucal.CPUUtilizationCalculator
 	logicalCores       int
+	cgroupAggregator   *cgroupAggregator
 
 	// for mocking
 	getProcessCreateTime func(p processHandle, ctx context.Context) (int64, error)
@@ -68,6 +70,10 @@ func newProcessScraper(settings scraper.Settings, cfg *Config) (*processScraper,
 		ucals:                make(map[int32]*ucal.CPUUtilizationCalculator),
 	}
 
+	if cfg.AggregateByCgroup {
+		scraper.cgroupAggregator = newCgroupAggregator()
+	}
+
 	var err error
 
 	if len(cfg.Include.Names) > 0 {
@@ -172,6 +178,14 @@ func (s *processScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 			errs.AddPartial(signalMetricsLen, fmt.Errorf("error reading pending signals for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
 
+		if s.config.AggregateByCgroup {
+			// The uptimes of the processes of a cgroup cannot be aggregated
+			s.cgroupAggregator.add(md.executable.cgroupPath, md.pid,
+				s.mb.Emit(metadata.WithResource(md.buildCgroupResource(metadata.NewResourceBuilder(cgroupResourceAttributes))),
+					metadata.WithStartTimeOverride(pcommon.Timestamp(md.createTime*1e6))))
+			continue
+		}
+
 		if err = s.scrapeAndAppendUptimeMetric(ctx, now, md.handle); err != nil {
 			errs.AddPartial(uptimeMetricsLen, fmt.Errorf("error calculating uptime for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
@@ -187,11 +201,16 @@ func (s *processScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 		}
 	}
 
+	metrics := s.mb.Emit()
+	if s.config.AggregateByCgroup {
+		metrics = s.cgroupAggregator.emit()
+	}
+
 	if s.config.MuteProcessAllErrors {
-		return s.mb.Emit(), nil
+		return metrics, nil
 	}
 
-	return s.mb.Emit(), errs.Combine()
+	return metrics, errs.Combine()
 }
 
 // getProcessMetadata returns a slice of processMetadata, including handles,
@@ -233,7 +252,14 @@ func (s *processScraper) getProcessMetadata(ctx context.Context) ([]*processMeta
 			continue
 		}
 
-		executable := &executableMetadata{name: name, path: exe, cgroup: cgroup}
+		cgroupPath := cgroups.ParsePath(cgroup)
+		executable := &executableMetadata{
+			name:        name,
+			path:        exe,
+			cgroup:      cgroup,
+			cgroupPath:  cgroupPath,
+			containerID: cgroups.ContainerID(cgroupPath),
+		}
 
 		// filter processes by name
 		if (s.includeFS != nil && !s.includeFS.Matches(executable.name)) ||
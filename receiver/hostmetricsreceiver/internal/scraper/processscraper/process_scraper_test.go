@@ -1390,3 +1390,66 @@ func handleCountErrorMessageIfSupportedOnPlatform() string {
 
 	return ""
 }
+
+func TestScrapeMetrics_AggregateByCgroup(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("skipping test on %v", runtime.GOOS)
+	}
+
+	const containerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	containerCgroup := "/system.slice/docker-" + containerID + ".scope"
+
+	metricsBuilderConfig := metadata.DefaultMetricsBuilderConfig()
+	metricsBuilderConfig.Metrics.ProcessUptime.Enabled = true
+	config := &Config{
+		MetricsBuilderConfig: metricsBuilderConfig,
+		AggregateByCgroup:    true,
+	}
+
+	scraper, err := newProcessScraper(scrapertest.NewNopSettings(metadata.Type), config)
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(t.Context(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	processes := []struct {
+		cgroup     string
+		rss        uint64
+		createTime int64
+	}{
+		{cgroup: "0::" + containerCgroup + "\n", rss: 100, createTime: 1000},
+		{cgroup: "0::/system.slice/sshd.service\n", rss: 200, createTime: 2000},
+		{cgroup: "0::" + containerCgroup + "\n", rss: 400, createTime: 500},
+	}
+	handles := make([]*processHandleMock, 0, len(processes))
+	for _, p := range processes {
+		handleMock := &processHandleMock{}
+		handleMock.On("NameWithContext", mock.Anything).Return("test", nil)
+		handleMock.On("CgroupWithContext", mock.Anything).Return(p.cgroup, nil)
+		handleMock.On("MemoryInfoWithContext", mock.Anything).Return(&process.MemoryInfoStat{RSS: p.rss}, nil)
+		handleMock.On("CreateTimeWithContext", mock.Anything).Return(p.createTime, nil)
+		initDefaultsHandleMock(t, handleMock)
+		handles = append(handles, handleMock)
+	}
+	scraper.getProcessHandles = func(context.Context) (processHandles, error) {
+		return &processHandlesMock{handles: handles}, nil
+	}
+
+	md, err := scraper.scrape(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"cgroup.path":  containerCgroup,
+		"container.id": containerID,
+	}, rm.Resource().Attributes().AsRaw())
+	memoryUsage := getMetric(t, "process.memory.usage", md.ResourceMetrics())
+	require.Equal(t, 1, memoryUsage.Sum().DataPoints().Len())
+	assert.Equal(t, int64(500), memoryUsage.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(500*1e6), memoryUsage.Sum().DataPoints().At(0).StartTimestamp())
+	assertMetricMissing(t, md.ResourceMetrics(), "process.uptime")
+
+	assert.Equal(t, map[string]any{
+		"cgroup.path": "/system.slice/sshd.service",
+	}, md.ResourceMetrics().At(1).Resource().Attributes().AsRaw())
+}
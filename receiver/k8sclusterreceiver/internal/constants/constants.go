@@ -23,6 +23,7 @@ const (
 	K8sKeyNamespaceName             = "k8s.namespace.name"
 	K8sKeyPodName                   = "k8s.pod.name"
 	K8sKeyNodeName                  = "k8s.node.name"
+	K8sKeyServiceName               = "k8s.service.name"
 
 	// Kubernetes resource kinds
 	K8sKindCronJob               = "CronJob"
@@ -43,6 +43,16 @@ func GetEntityEvents(oldMetadata, newMetadata map[metadataPkg.ResourceID]*Kubern
 		for k, v := range newObj.Metadata {
 			attrs.PutStr(k, v)
 		}
+
+		if len(newObj.Relationships) != 0 {
+			relationships := state.Relationships()
+			for _, r := range newObj.Relationships {
+				relationship := relationships.AppendEmpty()
+				relationship.SetType(r.Type)
+				relationship.SetEntityType(r.EntityType)
+				relationship.EntityID().PutStr(r.ResourceIDKey, string(r.ResourceID))
+			}
+		}
 	}
 
 	return out
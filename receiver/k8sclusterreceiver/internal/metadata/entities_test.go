@@ -42,6 +42,50 @@ func Test_GetEntityEvents(t *testing.T) {
 				return out
 			}(),
 		},
+		{
+			name: "new entity with relationships",
+			new: map[metadataPkg.ResourceID]*KubernetesMetadata{
+				"123": {
+					EntityType:    "k8s.pod",
+					ResourceIDKey: "k8s.pod.uid",
+					ResourceID:    "123",
+					Metadata: map[string]string{
+						"label1": "value1",
+					},
+					Relationships: []Relationship{
+						{
+							Type:          RelationshipOwnedBy,
+							EntityType:    "k8s.replicaset",
+							ResourceIDKey: "k8s.replicaset.uid",
+							ResourceID:    "456",
+						},
+						{
+							Type:          RelationshipScheduledOn,
+							EntityType:    "k8s.node",
+							ResourceIDKey: "k8s.node.uid",
+							ResourceID:    "789",
+						},
+					},
+				},
+			},
+			events: func() metadataPkg.EntityEventsSlice {
+				out := metadataPkg.NewEntityEventsSlice()
+				event := out.AppendEmpty()
+				_ = event.ID().FromRaw(map[string]any{"k8s.pod.uid": "123"})
+				state := event.SetEntityState()
+				state.SetEntityType("k8s.pod")
+				_ = state.Attributes().FromRaw(map[string]any{"label1": "value1"})
+				relationship := state.Relationships().AppendEmpty()
+				relationship.SetType("owned_by")
+				relationship.SetEntityType("k8s.replicaset")
+				relationship.EntityID().PutStr("k8s.replicaset.uid", "456")
+				relationship = state.Relationships().AppendEmpty()
+				relationship.SetType("scheduled_on")
+				relationship.SetEntityType("k8s.node")
+				relationship.EntityID().PutStr("k8s.node.uid", "789")
+				return out
+			}(),
+		},
 		{
 			name: "deleted entity",
 			old: map[metadataPkg.ResourceID]*KubernetesMetadata{
@@ -202,6 +246,14 @@ func Test_GetEntityEvents(t *testing.T) {
 					assert.Equal(t, estate.EntityType(), astate.EntityType())
 					assert.Equal(t, 1*time.Hour, astate.Interval())
 					assert.Equal(t, estate.Attributes().AsRaw(), astate.Attributes().AsRaw())
+					erelationships := estate.Relationships()
+					arelationships := astate.Relationships()
+					require.Equal(t, erelationships.Len(), arelationships.Len())
+					for j := 0; j < erelationships.Len(); j++ {
+						assert.Equal(t, erelationships.At(j).Type(), arelationships.At(j).Type())
+						assert.Equal(t, erelationships.At(j).EntityType(), arelationships.At(j).EntityType())
+						assert.Equal(t, erelationships.At(j).EntityID().AsRaw(), arelationships.At(j).EntityID().AsRaw())
+					}
 				}
 			}
 		},
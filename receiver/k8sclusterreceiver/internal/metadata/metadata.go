@@ -26,6 +26,44 @@ type KubernetesMetadata struct {
 	ResourceID metadataPkg.ResourceID
 	// metadata is a set of key-value pairs that describe a resource.
 	Metadata map[string]string
+	// Relationships of the resource to other resources, reported with entity events.
+	Relationships []Relationship
+}
+
+const (
+	// RelationshipOwnedBy relates an object to its owner, e.g. a pod to its replicaset.
+	RelationshipOwnedBy = "owned_by"
+	// RelationshipScheduledOn relates a pod to the node it is scheduled on.
+	RelationshipScheduledOn = "scheduled_on"
+	// RelationshipMemberOf relates a pod to a service selecting it.
+	RelationshipMemberOf = "member_of"
+)
+
+// Relationship describes a relationship of a resource to a target resource.
+type Relationship struct {
+	// Type of the relationship, e.g. owned_by.
+	Type string
+	// EntityType is the type of the target entity, e.g. k8s.replicaset.
+	EntityType string
+	// ResourceIDKey is the label key of the UID label of the target resource.
+	ResourceIDKey string
+	// ResourceID is the Kubernetes UID of the target resource.
+	ResourceID metadataPkg.ResourceID
+}
+
+// OwnerRelationships returns the owned_by relationships of an object to its owners.
+func OwnerRelationships(ors []v1.OwnerReference) []Relationship {
+	var out []Relationship
+	for _, or := range ors {
+		kind := strings.ToLower(or.Kind)
+		out = append(out, Relationship{
+			Type:          RelationshipOwnedBy,
+			EntityType:    getOTelEntityTypeFromKind(kind),
+			ResourceIDKey: GetOTelUIDFromKind(kind),
+			ResourceID:    metadataPkg.ResourceID(or.UID),
+		})
+	}
+	return out
 }
 
 func TransformObjectMeta(om v1.ObjectMeta) v1.ObjectMeta {
@@ -68,6 +106,7 @@ func GetGenericMetadata(om *v1.ObjectMeta, resourceType string) *KubernetesMetad
 		ResourceIDKey: GetOTelUIDFromKind(rType),
 		ResourceID:    metadataPkg.ResourceID(om.UID),
 		Metadata:      metadata,
+		Relationships: OwnerRelationships(om.OwnerReferences),
 	}
 }
 
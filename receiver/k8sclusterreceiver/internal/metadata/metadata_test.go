@@ -56,6 +56,20 @@ func Test_getGenericMetadata(t *testing.T) {
 		"foo":                             "bar",
 		"foo1":                            "",
 	}, rm.Metadata)
+	assert.Equal(t, []Relationship{
+		{
+			Type:          RelationshipOwnedBy,
+			EntityType:    "k8s.owner-kind-1",
+			ResourceIDKey: "k8s.owner-kind-1.uid",
+			ResourceID:    "owner1",
+		},
+		{
+			Type:          RelationshipOwnedBy,
+			EntityType:    "k8s.owner-kind-2",
+			ResourceIDKey: "k8s.owner-kind-2.uid",
+			ResourceID:    "owner2",
+		},
+	}, rm.Relationships)
 }
 
 func metadataMap(mdata map[string]string) map[experimentalmetricmetadata.ResourceID]*KubernetesMetadata {
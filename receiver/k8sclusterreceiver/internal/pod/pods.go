@@ -155,8 +155,21 @@ func GetMetadata(pod *corev1.Pod, mc *metadata.Store, logger *zap.Logger) map[ex
 		meta[constants.K8sKeyWorkLoadName] = or.Name
 	}
 
+	relationships := metadata.OwnerRelationships(pod.OwnerReferences)
+	if r, ok := nodeRelationship(pod, mc.Get(gvk.Node)); ok {
+		relationships = append(relationships, r)
+	}
+
 	if store := mc.Get(gvk.Service); store != nil {
 		meta = maps.MergeStringMaps(meta, service.GetPodServiceTags(pod, store))
+		for _, s := range service.GetPodServices(pod, store) {
+			relationships = append(relationships, metadata.Relationship{
+				Type:          metadata.RelationshipMemberOf,
+				EntityType:    "k8s.service",
+				ResourceIDKey: metadata.GetOTelUIDFromKind("service"),
+				ResourceID:    experimentalmetricmetadata.ResourceID(s.UID),
+			})
+		}
 	}
 
 	if store := mc.Get(gvk.Job); store != nil {
@@ -177,10 +190,38 @@ func GetMetadata(pod *corev1.Pod, mc *metadata.Store, logger *zap.Logger) map[ex
 			ResourceIDKey: string(conventions.K8SPodUIDKey),
 			ResourceID:    podID,
 			Metadata:      meta,
+			Relationships: relationships,
 		},
 	}, getPodContainerProperties(pod, logger))
 }
 
+// nodeRelationship returns the relationship of the pod to the node it is scheduled on,
+// if the node is cached.
+func nodeRelationship(pod *corev1.Pod, nodeStores map[string]cache.Store) (metadata.Relationship, bool) {
+	if pod.Spec.NodeName == "" {
+		return metadata.Relationship{}, false
+	}
+	store, ok := nodeStores[metadata.ClusterWideInformerKey]
+	if !ok {
+		return metadata.Relationship{}, false
+	}
+	// Nodes are cluster-scoped, so they are keyed by name only.
+	obj, exists, err := store.GetByKey(pod.Spec.NodeName)
+	if err != nil || !exists {
+		return metadata.Relationship{}, false
+	}
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return metadata.Relationship{}, false
+	}
+	return metadata.Relationship{
+		Type:          metadata.RelationshipScheduledOn,
+		EntityType:    "k8s.node",
+		ResourceIDKey: string(conventions.K8SNodeUIDKey),
+		ResourceID:    experimentalmetricmetadata.ResourceID(node.UID),
+	}, true
+}
+
 // collectPodJobProperties checks if pod owner of type Job is cached. Check owners reference
 // on Job to see if it was created by a CronJob. Sync metadata accordingly.
 func collectPodJobProperties(pod *corev1.Pod, jobStores map[string]cache.Store, logger *zap.Logger) map[string]string {
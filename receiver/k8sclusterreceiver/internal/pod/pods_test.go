@@ -257,6 +257,14 @@ func expectedKubernetesMetadata(to testCaseOptions) map[experimentalmetricmetada
 				"k8s.pod.phase":                    "Unknown", // Default value when phase is not set.
 				"k8s.namespace.name":               namespaceLabel,
 			},
+			Relationships: []metadata.Relationship{
+				{
+					Type:          metadata.RelationshipOwnedBy,
+					EntityType:    "k8s." + kindLower,
+					ResourceIDKey: kindUIDLabel,
+					ResourceID:    experimentalmetricmetadata.ResourceID(kindObjUID),
+				},
+			},
 		},
 	}
 
@@ -563,6 +571,53 @@ func TestPodMetadata(t *testing.T) {
 	}
 }
 
+func TestPodRelationships(t *testing.T) {
+	pod := podWithOwnerReference("ReplicaSet")
+	pod.Spec.NodeName = "test-node-1"
+	pod.Labels = map[string]string{"app": "my-app"}
+
+	metadataStore := metadata.NewStore()
+	metadataStore.Setup(gvk.Node, metadata.ClusterWideInformerKey, &testutils.MockStore{
+		Cache: map[string]any{"test-node-1": testutils.NewNode("1")},
+	})
+	metadataStore.Setup(gvk.Service, metadata.ClusterWideInformerKey, &testutils.MockStore{
+		Cache: map[string]any{
+			"test-namespace/test-service": &corev1.Service{
+				ObjectMeta: v1.ObjectMeta{Name: "test-service", Namespace: "test-namespace", UID: "test-service-uid"},
+				Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "my-app"}},
+			},
+		},
+	})
+
+	meta := GetMetadata(pod, metadataStore, zap.NewNop())
+	require.Contains(t, meta, experimentalmetricmetadata.ResourceID("test-pod-0-uid"))
+	assert.Equal(t, []metadata.Relationship{
+		{
+			Type:          metadata.RelationshipOwnedBy,
+			EntityType:    "k8s.replicaset",
+			ResourceIDKey: "k8s.replicaset.uid",
+			ResourceID:    "test-replicaset-0-uid",
+		},
+		{
+			Type:          metadata.RelationshipScheduledOn,
+			EntityType:    "k8s.node",
+			ResourceIDKey: "k8s.node.uid",
+			ResourceID:    "test-node-1-uid",
+		},
+		{
+			Type:          metadata.RelationshipMemberOf,
+			EntityType:    "k8s.service",
+			ResourceIDKey: "k8s.service.uid",
+			ResourceID:    "test-service-uid",
+		},
+	}, meta["test-pod-0-uid"].Relationships)
+
+	// The node relationship is omitted if the node isn't cached.
+	pod.Spec.NodeName = "unknown-node"
+	meta = GetMetadata(pod, metadataStore, zap.NewNop())
+	assert.Len(t, meta["test-pod-0-uid"].Relationships, 2)
+}
+
 func TestPodContainerStateMetrics(t *testing.T) {
 	pod := testutils.NewPodWithContainer(
 		"1",
@@ -4,15 +4,22 @@
 package service // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/service"
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/maps"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/constants"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
 )
 
+// Keys for service metadata and entity attributes. These are NOT used by resource attributes.
+const serviceCreationTime = "service.creation_timestamp"
+
 // Transform transforms the pod to remove the fields that we don't use to reduce RAM utilization.
 // IMPORTANT: Make sure to update this function before using new service fields.
 func Transform(service *corev1.Service) *corev1.Service {
@@ -42,3 +49,74 @@ func GetPodServiceTags(pod *corev1.Pod, services map[string]cache.Store) map[str
 	}
 	return properties
 }
+
+// GetPodServices returns the services selecting the pod, sorted by name.
+func GetPodServices(pod *corev1.Pod, services map[string]cache.Store) []*corev1.Service {
+	var out []*corev1.Service
+	seen := map[string]bool{}
+	for _, storeKey := range [2]string{metadata.ClusterWideInformerKey, pod.Namespace} {
+		servicesStore, ok := services[storeKey]
+		if !ok {
+			continue
+		}
+		for _, ser := range servicesStore.List() {
+			serObj, ok := ser.(*corev1.Service)
+			if !ok || serObj.Namespace != pod.Namespace || len(serObj.Spec.Selector) == 0 || seen[string(serObj.UID)] {
+				continue
+			}
+			if labels.Set(serObj.Spec.Selector).AsSelectorPreValidated().Matches(labels.Set(pod.Labels)) {
+				seen[string(serObj.UID)] = true
+				out = append(out, serObj)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// GetServicePods returns the pods selected by the service, whose member_of relationships
+// depend on the service.
+func GetServicePods(service *corev1.Service, pods map[string]cache.Store) []*corev1.Pod {
+	if len(service.Spec.Selector) == 0 {
+		return nil
+	}
+	selector := labels.Set(service.Spec.Selector).AsSelectorPreValidated()
+	var out []*corev1.Pod
+	seen := map[string]bool{}
+	for _, storeKey := range [2]string{metadata.ClusterWideInformerKey, service.Namespace} {
+		podsStore, ok := pods[storeKey]
+		if !ok {
+			continue
+		}
+		for _, p := range podsStore.List() {
+			podObj, ok := p.(*corev1.Pod)
+			if !ok || podObj.Namespace != service.Namespace || seen[string(podObj.UID)] {
+				continue
+			}
+			if selector.Matches(labels.Set(podObj.Labels)) {
+				seen[string(podObj.UID)] = true
+				out = append(out, podObj)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// GetMetadata returns the metadata of the service entity.
+func GetMetadata(service *corev1.Service) map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata {
+	meta := maps.MergeStringMaps(map[string]string{}, service.Labels)
+	meta[constants.K8sKeyServiceName] = service.Name
+	meta[constants.K8sKeyNamespaceName] = service.Namespace
+	meta[serviceCreationTime] = service.CreationTimestamp.Format(time.RFC3339)
+
+	serviceID := experimentalmetricmetadata.ResourceID(service.UID)
+	return map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{
+		serviceID: {
+			EntityType:    "k8s.service",
+			ResourceIDKey: metadata.GetOTelUIDFromKind("service"),
+			ResourceID:    serviceID,
+			Metadata:      meta,
+		},
+	}
+}
@@ -5,10 +5,17 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
 )
 
 func TestTransform(t *testing.T) {
@@ -53,3 +60,110 @@ func TestTransform(t *testing.T) {
 	}
 	assert.Equal(t, wantService, Transform(originalService))
 }
+
+func newService(name, namespace string, selector map[string]string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(name + "-uid"),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+		},
+	}
+}
+
+func TestGetPodServices(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pod",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":  "my-app",
+				"tier": "backend",
+			},
+		},
+	}
+	stores := map[string]cache.Store{
+		metadata.ClusterWideInformerKey: &testutils.MockStore{
+			Cache: map[string]any{
+				"default/web":         newService("web", "default", map[string]string{"app": "my-app"}),
+				"default/backend":     newService("backend", "default", map[string]string{"tier": "backend"}),
+				"default/other":       newService("other", "default", map[string]string{"app": "other-app"}),
+				"default/headless":    newService("headless", "default", nil),
+				"other-namespace/web": newService("web-other-namespace", "other-namespace", map[string]string{"app": "my-app"}),
+			},
+		},
+		"default": &testutils.MockStore{
+			Cache: map[string]any{
+				"default/web": newService("web", "default", map[string]string{"app": "my-app"}),
+			},
+		},
+	}
+
+	var names []string
+	for _, s := range GetPodServices(pod, stores) {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"backend", "web"}, names)
+	assert.Empty(t, GetPodServices(pod, nil))
+}
+
+func TestGetServicePods(t *testing.T) {
+	newPod := func(name, namespace string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				UID:       types.UID(name + "-uid"),
+				Labels:    labels,
+			},
+		}
+	}
+	stores := map[string]cache.Store{
+		metadata.ClusterWideInformerKey: &testutils.MockStore{
+			Cache: map[string]any{
+				"default/web-1":         newPod("web-1", "default", map[string]string{"app": "my-app"}),
+				"default/web-0":         newPod("web-0", "default", map[string]string{"app": "my-app", "tier": "backend"}),
+				"default/other":         newPod("other", "default", map[string]string{"app": "other-app"}),
+				"other-namespace/web-0": newPod("web-other-namespace", "other-namespace", map[string]string{"app": "my-app"}),
+			},
+		},
+		"default": &testutils.MockStore{
+			Cache: map[string]any{
+				"default/web-0": newPod("web-0", "default", map[string]string{"app": "my-app", "tier": "backend"}),
+			},
+		},
+	}
+
+	var names []string
+	for _, p := range GetServicePods(newService("web", "default", map[string]string{"app": "my-app"}), stores) {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"web-0", "web-1"}, names)
+	// Services without selector don't select any pod.
+	assert.Empty(t, GetServicePods(newService("headless", "default", nil), stores))
+	assert.Empty(t, GetServicePods(newService("web", "default", map[string]string{"app": "my-app"}), nil))
+}
+
+func TestGetMetadata(t *testing.T) {
+	now := time.Now()
+	s := newService("web", "default", map[string]string{"app": "my-app"})
+	s.Labels = map[string]string{"team": "payments"}
+	s.CreationTimestamp = metav1.NewTime(now)
+
+	assert.Equal(t, map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{
+		"web-uid": {
+			EntityType:    "k8s.service",
+			ResourceIDKey: "k8s.service.uid",
+			ResourceID:    "web-uid",
+			Metadata: map[string]string{
+				"team":                       "payments",
+				"k8s.service.name":           "web",
+				"k8s.namespace.name":         "default",
+				"service.creation_timestamp": now.Format(time.RFC3339),
+			},
+		},
+	}, GetMetadata(s))
+}
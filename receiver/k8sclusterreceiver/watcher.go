@@ -42,6 +42,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/pod"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/replicaset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/replicationcontroller"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/service"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/statefulset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/utils"
 )
@@ -327,7 +328,8 @@ func (rw *resourceWatcher) onAdd(obj any) {
 		return
 	}
 
-	rw.syncMetadataUpdate(map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{}, rw.objMetadata(obj))
+	related := rw.relatedMetadata(obj)
+	rw.syncMetadataUpdate(related, metadata.MergeKubernetesMetadataMaps(related, rw.objMetadata(obj)))
 }
 
 func (rw *resourceWatcher) hasDestination() bool {
@@ -342,7 +344,8 @@ func (rw *resourceWatcher) onUpdate(oldObj, newObj any) {
 		return
 	}
 
-	rw.syncMetadataUpdate(rw.objMetadata(oldObj), rw.objMetadata(newObj))
+	related := rw.relatedMetadata(oldObj, newObj)
+	rw.syncMetadataUpdate(metadata.MergeKubernetesMetadataMaps(related, rw.objMetadata(oldObj)), metadata.MergeKubernetesMetadataMaps(related, rw.objMetadata(newObj)))
 }
 
 func (rw *resourceWatcher) onDelete(oldObj any) {
@@ -353,7 +356,30 @@ func (rw *resourceWatcher) onDelete(oldObj any) {
 		return
 	}
 
-	rw.syncMetadataUpdate(rw.objMetadata(oldObj), map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{})
+	related := rw.relatedMetadata(oldObj)
+	rw.syncMetadataUpdate(metadata.MergeKubernetesMetadataMaps(related, rw.objMetadata(oldObj)), related)
+}
+
+// relatedMetadata returns the current metadata of the objects whose relationships depend on the
+// given objects, so that they are emitted again with the events of the given objects: the pods
+// selected by a service are related to it with member_of relationships. The metadata is the same
+// before and after the event, as the caches are already updated when it's handled.
+func (rw *resourceWatcher) relatedMetadata(objs ...any) map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata {
+	out := map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{}
+	podStores := rw.metadataStore.Get(gvk.Pod)
+	if podStores == nil {
+		return out
+	}
+	for _, obj := range objs {
+		svc, ok := obj.(*corev1.Service)
+		if !ok {
+			continue
+		}
+		for _, p := range service.GetServicePods(svc, podStores) {
+			out = metadata.MergeKubernetesMetadataMaps(out, pod.GetMetadata(p, rw.metadataStore, rw.logger))
+		}
+	}
+	return out
 }
 
 // objMetadata returns the metadata for the given object.
@@ -363,6 +389,8 @@ func (rw *resourceWatcher) objMetadata(obj any) map[experimentalmetricmetadata.R
 		return pod.GetMetadata(o, rw.metadataStore, rw.logger)
 	case *corev1.Node:
 		return node.GetMetadata(o)
+	case *corev1.Service:
+		return service.GetMetadata(o)
 	case *corev1.ReplicationController:
 		return replicationcontroller.GetMetadata(o)
 	case *appsv1.Deployment:
@@ -323,6 +323,80 @@ func TestSyncMetadataAndEmitEntityEvents(t *testing.T) {
 	assert.WithinRange(t, lr.Timestamp().AsTime(), step5, step6)
 }
 
+func TestServiceEventsEmitPodRelationships(t *testing.T) {
+	logsConsumer := new(consumertest.LogsSink)
+	ms := metadata.NewStore()
+	ms.Setup(gvk.Pod, metadata.ClusterWideInformerKey, &testutils.MockStore{
+		Cache: map[string]any{
+			"test-namespace/test-pod-0": podWithAdditionalLabels(
+				map[string]string{"k8s-app": "my-app"},
+				testutils.NewPodWithContainer("0", &corev1.PodSpec{NodeName: "test-node"}, &corev1.PodStatus{Phase: corev1.PodRunning}),
+			),
+		},
+	})
+	services := &testutils.MockStore{Cache: map[string]any{}}
+	ms.Setup(gvk.Service, metadata.ClusterWideInformerKey, services)
+
+	rw := newResourceWatcher(receivertest.NewNopSettings(metadata.Type), &Config{}, ms)
+	rw.entityLogConsumer = logsConsumer
+	rw.initialSyncDone.Store(true)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: "test-namespace",
+			UID:       "test-service-uid",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"k8s-app": "my-app"},
+		},
+	}
+	// entityEvents returns the attributes of the entity events of the last emitted logs by entity type.
+	entityEvents := func() map[string]map[string]any {
+		out := map[string]map[string]any{}
+		lrs := logsConsumer.AllLogs()[len(logsConsumer.AllLogs())-1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < lrs.Len(); i++ {
+			attrs := lrs.At(i).Attributes().AsRaw()
+			out[attrs["otel.entity.type"].(string)] = attrs
+		}
+		return out
+	}
+
+	// The pods selected by a new service are related to it.
+	services.Cache["test-namespace/test-service"] = svc
+	rw.onAdd(svc)
+	events := entityEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, "entity_state", events["k8s.service"]["otel.entity.event.type"])
+	assert.Equal(t, "entity_state", events["k8s.pod"]["otel.entity.event.type"])
+	assert.Equal(t, []any{map[string]any{
+		"relationship.type": "member_of",
+		"entity.type":       "k8s.service",
+		"entity.id":         map[string]any{"k8s.service.uid": "test-service-uid"},
+	}}, events["k8s.pod"]["otel.entity.relationships"])
+
+	// The pods no longer selected by the service are not related to it anymore.
+	updatedSvc := svc.DeepCopy()
+	updatedSvc.Spec.Selector = map[string]string{"k8s-app": "other-app"}
+	services.Cache["test-namespace/test-service"] = updatedSvc
+	rw.onUpdate(svc, updatedSvc)
+	events = entityEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, "entity_state", events["k8s.pod"]["otel.entity.event.type"])
+	assert.NotContains(t, events["k8s.pod"], "otel.entity.relationships")
+
+	// The pods of a deleted service are not related to it anymore.
+	services.Cache["test-namespace/test-service"] = svc
+	rw.onUpdate(updatedSvc, svc)
+	delete(services.Cache, "test-namespace/test-service")
+	rw.onDelete(svc)
+	events = entityEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, "entity_delete", events["k8s.service"]["otel.entity.event.type"])
+	assert.Equal(t, "entity_state", events["k8s.pod"]["otel.entity.event.type"])
+	assert.NotContains(t, events["k8s.pod"], "otel.entity.relationships")
+}
+
 func TestObjMetadata(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -389,6 +463,14 @@ func TestObjMetadata(t *testing.T) {
 						"k8s.namespace.name":               "test-namespace",
 						string(conventions.K8SNodeNameKey): "test-node",
 					}),
+					Relationships: []metadata.Relationship{
+						{
+							Type:          metadata.RelationshipOwnedBy,
+							EntityType:    "k8s.statefulset",
+							ResourceIDKey: "k8s.statefulset.uid",
+							ResourceID:    "test-statefulset-0-uid",
+						},
+					},
 				},
 			},
 		},
@@ -431,6 +513,37 @@ func TestObjMetadata(t *testing.T) {
 						"k8s.pod.name":                     "test-pod-0",
 						string(conventions.K8SNodeNameKey): "test-node",
 					}),
+					Relationships: []metadata.Relationship{
+						{
+							Type:          metadata.RelationshipMemberOf,
+							EntityType:    "k8s.service",
+							ResourceIDKey: "k8s.service.uid",
+							ResourceID:    "test-service-uid",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "Service simple case",
+			metadataStore: &metadata.Store{},
+			resource: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service",
+					Namespace: "test-namespace",
+					UID:       types.UID("test-service-uid"),
+				},
+			},
+			want: map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{
+				experimentalmetricmetadata.ResourceID("test-service-uid"): {
+					EntityType:    "k8s.service",
+					ResourceIDKey: "k8s.service.uid",
+					ResourceID:    "test-service-uid",
+					Metadata: map[string]string{
+						"k8s.service.name":           "test-service",
+						"k8s.namespace.name":         "test-namespace",
+						"service.creation_timestamp": "0001-01-01T00:00:00Z",
+					},
 				},
 			},
 		},
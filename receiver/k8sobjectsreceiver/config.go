@@ -27,6 +27,9 @@ const (
 	defaultPullInterval    time.Duration = time.Hour
 	defaultMode            mode          = PullMode
 	defaultResourceVersion               = "1"
+
+	// defaultCheckpointInterval is the interval at which the watch checkpoints are persisted.
+	defaultCheckpointInterval = 10 * time.Second
 )
 
 var modeMap = map[mode]bool{
@@ -52,6 +55,7 @@ type K8sObjectsConfig struct {
 	Interval         time.Duration        `mapstructure:"interval"`
 	ResourceVersion  string               `mapstructure:"resource_version"`
 	ExcludeWatchType []apiWatch.EventType `mapstructure:"exclude_watch_type"`
+	DeltaMode        bool                 `mapstructure:"delta_mode"`
 	exclude          map[apiWatch.EventType]bool
 	gvr              *schema.GroupVersionResource
 }
@@ -65,6 +69,10 @@ type Config struct {
 
 	K8sLeaderElector *component.ID `mapstructure:"k8s_leader_elector"`
 
+	// StorageID is the storage extension persisting the resourceVersion of the watches,
+	// so that they are resumed from it after a restart.
+	StorageID *component.ID `mapstructure:"storage"`
+
 	// For mocking purposes only.
 	makeDiscoveryClient func() (discovery.ServerResourcesInterface, error)
 	makeDynamicClient   func() (dynamic.Interface, error)
@@ -92,6 +100,10 @@ func (c *Config) Validate() error {
 			return errors.New("the Exclude config can only be used with watch mode")
 		}
 
+		if object.Mode == PullMode && object.DeltaMode {
+			return errors.New("delta_mode can only be used with watch mode")
+		}
+
 		if object.Mode == PullMode && c.IncludeInitialState {
 			return errors.New("include_initial_state can only be used with watch mode")
 		}
@@ -162,6 +174,7 @@ func (k *K8sObjectsConfig) DeepCopy() *K8sObjectsConfig {
 		FieldSelector:   k.FieldSelector,
 		Interval:        k.Interval,
 		ResourceVersion: k.ResourceVersion,
+		DeltaMode:       k.DeltaMode,
 	}
 
 	copied.Namespaces = make([]string, len(k.Namespaces))
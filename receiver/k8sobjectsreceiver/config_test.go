@@ -97,6 +97,26 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "watch_with_storage"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{
+					AuthType: k8sconfig.AuthTypeServiceAccount,
+				},
+				StorageID: func() *component.ID {
+					id := component.MustNewID("file_storage")
+					return &id
+				}(),
+				Objects: []*K8sObjectsConfig{
+					{
+						Name:      "events",
+						Mode:      WatchMode,
+						Group:     "events.k8s.io",
+						DeltaMode: true,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,6 +133,7 @@ func TestLoadConfig(t *testing.T) {
 
 			assert.Equal(t, tt.expected.AuthType, cfg.AuthType)
 			assert.Equal(t, tt.expected.Objects, cfg.Objects)
+			assert.Equal(t, tt.expected.StorageID, cfg.StorageID)
 
 			err = cfg.Validate()
 			if tt.expected == nil {
@@ -159,6 +180,20 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: "the Exclude config can only be used with watch mode",
 		},
+		{
+			desc: "delta mode with pull mode",
+			cfg: &Config{
+				ErrorMode: PropagateError,
+				Objects: []*K8sObjectsConfig{
+					{
+						Name:      "pods",
+						Mode:      PullMode,
+						DeltaMode: true,
+					},
+				},
+			},
+			expectedErr: "delta_mode can only be used with watch mode",
+		},
 		{
 			desc: "default mode is set",
 			cfg: &Config{
@@ -214,6 +249,7 @@ func TestDeepCopy(t *testing.T) {
 				LabelSelector:    "environment in (production),tier in (frontend)",
 				Interval:         time.Hour,
 				ResourceVersion:  "1",
+				DeltaMode:        true,
 				ExcludeWatchType: []apiWatch.EventType{apiWatch.Added},
 				exclude:          map[apiWatch.EventType]bool{apiWatch.Added: true},
 				gvr: &schema.GroupVersionResource{
@@ -239,6 +275,7 @@ func TestDeepCopy(t *testing.T) {
 			actual.LabelSelector = "changed"
 			actual.Interval = time.Minute
 			actual.ResourceVersion = "changed"
+			actual.DeltaMode = false
 			actual.ExcludeWatchType[0] = apiWatch.Deleted
 			actual.exclude[apiWatch.Bookmark] = true
 			actual.gvr.Group = "changed"
@@ -16,6 +16,7 @@ require (
 	go.opentelemetry.io/collector/confmap v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/consumer v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/consumer/consumertest v0.134.1-0.20250908133507-3166bac6544f
+	go.opentelemetry.io/collector/extension/xextension v0.134.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/pdata v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/receiver v1.40.1-0.20250908133507-3166bac6544f
 	go.opentelemetry.io/collector/receiver/otlpreceiver v0.134.1-0.20250908133507-3166bac6544f
@@ -46,6 +46,16 @@ func (c mockDynamicClient) createPods(objects ...*unstructured.Unstructured) {
 	}
 }
 
+func (c mockDynamicClient) updatePods(objects ...*unstructured.Unstructured) {
+	pods := c.client.Resource(schema.GroupVersionResource{
+		Version:  "v1",
+		Resource: "pods",
+	})
+	for _, pod := range objects {
+		_, _ = pods.Namespace(pod.GetNamespace()).Update(context.Background(), pod, v1.UpdateOptions{})
+	}
+}
+
 func (c mockDynamicClient) deletePods(objects ...*unstructured.Unstructured) {
 	pods := c.client.Resource(schema.GroupVersionResource{
 		Version:  "v1",
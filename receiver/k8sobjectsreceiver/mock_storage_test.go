@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+type mockStorageClient struct {
+	mtx  sync.Mutex
+	data map[string][]byte
+}
+
+func newMockStorageClient() *mockStorageClient {
+	return &mockStorageClient{data: make(map[string][]byte)}
+}
+
+func (c *mockStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.data[key], nil
+}
+
+func (c *mockStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *mockStorageClient) Delete(_ context.Context, key string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *mockStorageClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*mockStorageClient) Close(context.Context) error {
+	return nil
+}
+
+type mockStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *mockStorageClient
+}
+
+func (e *mockStorageExtension) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return e.client, nil
+}
+
+type mockHost struct {
+	extensions map[component.ID]component.Component
+}
+
+func (h *mockHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
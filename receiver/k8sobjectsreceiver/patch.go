@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// patchIgnoredMetadata are the metadata fields changing with every update of an object,
+// which are left out of the patches.
+var patchIgnoredMetadata = []string{"resourceVersion", "managedFields"}
+
+// createJSONPatch returns the JSON patch (RFC 6902) operations turning the object from
+// into the object to. Arrays are replaced as a whole when they differ.
+func createJSONPatch(from, to map[string]any) []any {
+	return diffObjects("", withoutIgnoredMetadata(from), withoutIgnoredMetadata(to), []any{})
+}
+
+func diffObjects(path string, from, to map[string]any, ops []any) []any {
+	for _, key := range sortedKeys(from) {
+		if _, ok := to[key]; !ok {
+			ops = append(ops, map[string]any{"op": "remove", "path": path + "/" + escapePointer(key)})
+		}
+	}
+	for _, key := range sortedKeys(to) {
+		keyPath := path + "/" + escapePointer(key)
+		toValue := to[key]
+		fromValue, ok := from[key]
+		if !ok {
+			ops = append(ops, map[string]any{"op": "add", "path": keyPath, "value": toValue})
+			continue
+		}
+		fromMap, fromIsMap := fromValue.(map[string]any)
+		toMap, toIsMap := toValue.(map[string]any)
+		if fromIsMap && toIsMap {
+			ops = diffObjects(keyPath, fromMap, toMap, ops)
+			continue
+		}
+		if !reflect.DeepEqual(fromValue, toValue) {
+			ops = append(ops, map[string]any{"op": "replace", "path": keyPath, "value": toValue})
+		}
+	}
+	return ops
+}
+
+// withoutIgnoredMetadata returns a shallow copy of the object without the ignored metadata fields.
+func withoutIgnoredMetadata(object map[string]any) map[string]any {
+	metadata, ok := object["metadata"].(map[string]any)
+	if !ok {
+		return object
+	}
+	copied := make(map[string]any, len(object))
+	for k, v := range object {
+		copied[k] = v
+	}
+	copiedMetadata := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		copiedMetadata[k] = v
+	}
+	for _, field := range patchIgnoredMetadata {
+		delete(copiedMetadata, field)
+	}
+	copied["metadata"] = copiedMetadata
+	return copied
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointer escapes a key to be used as a JSON pointer (RFC 6901) reference token.
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
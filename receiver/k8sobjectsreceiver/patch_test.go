@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to map[string]any
+		expected []any
+	}{
+		{
+			name:     "unchanged object",
+			from:     map[string]any{"metadata": map[string]any{"name": "pod1", "resourceVersion": "1"}},
+			to:       map[string]any{"metadata": map[string]any{"name": "pod1", "resourceVersion": "2"}},
+			expected: []any{},
+		},
+		{
+			name:     "managed fields are ignored",
+			from:     map[string]any{"metadata": map[string]any{"managedFields": []any{"a"}}},
+			to:       map[string]any{"metadata": map[string]any{"managedFields": []any{"b"}}},
+			expected: []any{},
+		},
+		{
+			name: "added, removed and replaced fields",
+			from: map[string]any{
+				"metadata": map[string]any{
+					"name":   "pod1",
+					"labels": map[string]any{"app": "web", "team": "payments"},
+				},
+				"spec": map[string]any{"nodeName": "node1", "containers": []any{"a"}},
+			},
+			to: map[string]any{
+				"metadata": map[string]any{
+					"name":   "pod1",
+					"labels": map[string]any{"app": "api", "app.kubernetes.io/name": "api"},
+				},
+				"spec":   map[string]any{"nodeName": "node1", "containers": []any{"a", "b"}},
+				"status": map[string]any{"phase": "Running"},
+			},
+			expected: []any{
+				map[string]any{"op": "remove", "path": "/metadata/labels/team"},
+				map[string]any{"op": "replace", "path": "/metadata/labels/app", "value": "api"},
+				map[string]any{"op": "add", "path": "/metadata/labels/app.kubernetes.io~1name", "value": "api"},
+				map[string]any{"op": "replace", "path": "/spec/containers", "value": []any{"a", "b"}},
+				map[string]any{"op": "add", "path": "/status", "value": map[string]any{"phase": "Running"}},
+			},
+		},
+		{
+			name:     "value replaced by an object",
+			from:     map[string]any{"data": "x"},
+			to:       map[string]any{"data": map[string]any{"a~b": "x"}},
+			expected: []any{map[string]any{"op": "replace", "path": "/data", "value": map[string]any{"a~b": "x"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, createJSONPatch(tt.from, tt.to))
+		})
+	}
+}
+
+func TestEscapePointer(t *testing.T) {
+	assert.Equal(t, "app.kubernetes.io~1name", escapePointer("app.kubernetes.io/name"))
+	assert.Equal(t, "a~0b~1c", escapePointer("a~b/c"))
+}
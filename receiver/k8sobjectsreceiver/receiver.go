@@ -13,11 +13,14 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/wait"
 	apiWatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
@@ -38,6 +41,10 @@ type k8sobjectsreceiver struct {
 	obsrecv         *receiverhelper.ObsReport
 	mu              sync.Mutex
 	cancel          context.CancelFunc
+	storageClient   storage.Client
+	watchers        sync.WaitGroup
+
+	checkpointInterval time.Duration
 }
 
 func newReceiver(params receiver.Settings, config *Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -72,6 +79,8 @@ func newReceiver(params receiver.Settings, config *Config, consumer consumer.Log
 		consumer: consumer,
 		obsrecv:  obsrecv,
 		mu:       sync.Mutex{},
+
+		checkpointInterval: defaultCheckpointInterval,
 	}, nil
 }
 
@@ -120,6 +129,13 @@ func (kr *k8sobjectsreceiver) Start(ctx context.Context, host component.Host) er
 		return err
 	}
 
+	if kr.config.StorageID != nil {
+		kr.storageClient, err = getStorageClient(ctx, host, *kr.config.StorageID, kr.setting.ID)
+		if err != nil {
+			return err
+		}
+	}
+
 	if kr.config.K8sLeaderElector != nil {
 		k8sLeaderElector := host.GetExtensions()[*kr.config.K8sLeaderElector]
 		if k8sLeaderElector == nil {
@@ -143,10 +159,7 @@ func (kr *k8sobjectsreceiver) Start(ctx context.Context, host component.Host) er
 			},
 			func() {
 				kr.setting.Logger.Info("no longer leader, stopping")
-				err = kr.Shutdown(context.Background())
-				if err != nil {
-					kr.setting.Logger.Error("shutdown receiver error:", zap.Error(err))
-				}
+				kr.stop()
 			})
 	} else {
 		cctx, cancel := context.WithCancel(ctx)
@@ -159,8 +172,17 @@ func (kr *k8sobjectsreceiver) Start(ctx context.Context, host component.Host) er
 	return nil
 }
 
-func (kr *k8sobjectsreceiver) Shutdown(context.Context) error {
+func (kr *k8sobjectsreceiver) Shutdown(ctx context.Context) error {
+	kr.stop()
 	kr.setting.Logger.Info("Object Receiver stopped")
+	if kr.storageClient != nil {
+		return kr.storageClient.Close(ctx)
+	}
+	return nil
+}
+
+// stop stops collecting the objects, and waits for the watches to persist their state.
+func (kr *k8sobjectsreceiver) stop() {
 	if kr.cancel != nil {
 		kr.cancel()
 	}
@@ -169,8 +191,9 @@ func (kr *k8sobjectsreceiver) Shutdown(context.Context) error {
 	for _, stopperChan := range kr.stopperChanList {
 		close(stopperChan)
 	}
+	kr.stopperChanList = nil
 	kr.mu.Unlock()
-	return nil
+	kr.watchers.Wait()
 }
 
 func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfig) {
@@ -192,15 +215,30 @@ func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfi
 
 	case WatchMode:
 		if len(object.Namespaces) == 0 {
-			go kr.startWatch(ctx, object, resource)
+			kr.goWatch(ctx, object, resource, "")
 		} else {
 			for _, ns := range object.Namespaces {
-				go kr.startWatch(ctx, object, resource.Namespace(ns))
+				kr.goWatch(ctx, object, resource.Namespace(ns), ns)
 			}
 		}
 	}
 }
 
+// goWatch starts a watch in a goroutine. The watch is registered beforehand, so that stop
+// always signals it and waits for it to save its state.
+func (kr *k8sobjectsreceiver) goWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, namespace string) {
+	stopperChan := make(chan struct{})
+	kr.mu.Lock()
+	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
+	kr.mu.Unlock()
+
+	kr.watchers.Add(1)
+	go func() {
+		defer kr.watchers.Done()
+		kr.startWatch(ctx, config, resource, namespace, stopperChan)
+	}()
+}
+
 func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
 	stopperChan := make(chan struct{})
 	kr.mu.Lock()
@@ -239,11 +277,19 @@ func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsC
 	}
 }
 
-func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
-	stopperChan := make(chan struct{})
-	kr.mu.Lock()
-	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
-	kr.mu.Unlock()
+func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, namespace string, stopperChan chan struct{}) {
+	// The objects are tracked to resume the watch after a restart, to find the changes
+	// missed on a 410 Gone, and to diff the modified objects in delta mode.
+	var state *watchState
+	if kr.storageClient != nil || config.DeltaMode {
+		state = newWatchState(kr.storageClient, config.gvr, namespace, config.DeltaMode)
+		if err := state.load(ctx); err != nil {
+			kr.setting.Logger.Warn("could not load the watch checkpoint, starting from the current state",
+				zap.String("resource", config.gvr.String()),
+				zap.String("namespace", namespace),
+				zap.Error(err))
+		}
+	}
 
 	if kr.config.IncludeInitialState {
 		kr.sendInitialState(ctx, config, resource)
@@ -258,7 +304,13 @@ func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjects
 	cancelCtx, cancel := context.WithCancel(ctx)
 	cfgCopy := *config
 	wait.UntilWithContext(cancelCtx, func(newCtx context.Context) {
-		resourceVersion, err := getResourceVersion(newCtx, &cfgCopy, resource)
+		var resourceVersion string
+		var err error
+		if state != nil {
+			resourceVersion, err = kr.resumeResourceVersion(newCtx, &cfgCopy, resource, state)
+		} else {
+			resourceVersion, err = getResourceVersion(newCtx, &cfgCopy, resource)
+		}
 		if err != nil {
 			kr.setting.Logger.Error("could not retrieve a resourceVersion",
 				zap.String("resource", cfgCopy.gvr.String()),
@@ -267,7 +319,7 @@ func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjects
 			return
 		}
 
-		done := kr.doWatch(newCtx, &cfgCopy, resourceVersion, watchFunc, stopperChan)
+		done := kr.doWatch(newCtx, &cfgCopy, resourceVersion, watchFunc, stopperChan, state)
 		if done {
 			cancel()
 			return
@@ -275,9 +327,55 @@ func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjects
 
 		// need to restart with a fresh resource version
 		cfgCopy.ResourceVersion = ""
+		if state != nil {
+			state.resourceVersion = ""
+		}
 	}, 0)
 }
 
+// resumeResourceVersion returns the resourceVersion to resume the watch from. When there is
+// none, the objects are relisted and the changes since they were last known are emitted.
+func (kr *k8sobjectsreceiver) resumeResourceVersion(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, state *watchState) (string, error) {
+	if state.resourceVersion != "" {
+		return state.resourceVersion, nil
+	}
+	if config.ResourceVersion != "" && config.ResourceVersion != "0" {
+		return config.ResourceVersion, nil
+	}
+
+	objects, err := resource.List(ctx, metav1.ListOptions{
+		FieldSelector: config.FieldSelector,
+		LabelSelector: config.LabelSelector,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not perform initial list for watch on %v, %w", config.gvr.String(), err)
+	}
+	if objects == nil {
+		return "", errors.New("nil objects returned, this is an error in the k8sobjectsreceiver")
+	}
+
+	changes := state.relist(objects, config.gvr)
+	if len(changes) > 0 {
+		kr.setting.Logger.Info("emitting the changes missed while not watching",
+			zap.String("resource", config.gvr.String()),
+			zap.Int("change_count", len(changes)))
+	}
+	for i := range changes {
+		kr.emitWatchEvent(ctx, config, &changes[i].event, changes[i].previous)
+	}
+
+	// The fake client used in unit tests doesn't return resource versions for lists.
+	if state.resourceVersion == "" || state.resourceVersion == "0" {
+		state.resourceVersion = defaultResourceVersion
+	}
+	if err := state.save(ctx); err != nil {
+		kr.setting.Logger.Error("could not save the watch checkpoint",
+			zap.String("resource", config.gvr.String()),
+			zap.Error(err))
+	}
+	return state.resourceVersion, nil
+}
+
 // sendInitialState sends the current state of objects as synthetic Added events
 func (kr *k8sobjectsreceiver) sendInitialState(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
 	kr.setting.Logger.Info("sending initial state",
@@ -330,7 +428,7 @@ func (kr *k8sobjectsreceiver) sendInitialState(ctx context.Context, config *K8sO
 }
 
 // doWatch returns true when watching is done, false when watching should be restarted.
-func (kr *k8sobjectsreceiver) doWatch(ctx context.Context, config *K8sObjectsConfig, resourceVersion string, watchFunc func(options metav1.ListOptions) (apiWatch.Interface, error), stopperChan chan struct{}) bool {
+func (kr *k8sobjectsreceiver) doWatch(ctx context.Context, config *K8sObjectsConfig, resourceVersion string, watchFunc func(options metav1.ListOptions) (apiWatch.Interface, error), stopperChan chan struct{}, state *watchState) bool {
 	watcher, err := watch.NewRetryWatcher(resourceVersion, &cache.ListWatch{WatchFunc: watchFunc})
 	if err != nil {
 		kr.setting.Logger.Error("error in watching object",
@@ -340,6 +438,23 @@ func (kr *k8sobjectsreceiver) doWatch(ctx context.Context, config *K8sObjectsCon
 	}
 
 	defer watcher.Stop()
+
+	// The state is persisted periodically rather than on each event, so that the storage
+	// isn't written to for every change of busy resources.
+	var checkpointCh <-chan time.Time
+	if state != nil {
+		ticker := time.NewTicker(kr.checkpointInterval)
+		defer ticker.Stop()
+		checkpointCh = ticker.C
+	}
+	checkpoint := func(ctx context.Context) {
+		if err := state.saveIfDirty(ctx); err != nil {
+			kr.setting.Logger.Error("could not save the watch checkpoint",
+				zap.String("resource", config.gvr.String()),
+				zap.Error(err))
+		}
+	}
+
 	res := watcher.ResultChan()
 	for {
 		select {
@@ -358,30 +473,66 @@ func (kr *k8sobjectsreceiver) doWatch(ctx context.Context, config *K8sObjectsCon
 			if !ok {
 				kr.setting.Logger.Warn("Watch channel closed unexpectedly",
 					zap.String("resource", config.gvr.String()))
+				if state != nil {
+					checkpoint(context.Background())
+				}
 				return true
 			}
 
-			if config.exclude[data.Type] {
-				kr.setting.Logger.Debug("dropping excluded data",
-					zap.String("type", string(data.Type)))
-				continue
+			var previous *unstructured.Unstructured
+			u, isObject := data.Object.(*unstructured.Unstructured)
+			if state != nil && isObject {
+				previous = state.update(data.Type, u)
 			}
 
-			logs, err := watchObjectsToLogData(&data, time.Now(), config, kr.setting.BuildInfo.Version)
-			if err != nil {
-				kr.setting.Logger.Error("error converting objects to log data", zap.Error(err))
-			} else {
-				obsCtx := kr.obsrecv.StartLogsOp(ctx)
-				err := kr.consumer.ConsumeLogs(obsCtx, logs)
-				kr.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), 1, err)
-			}
+			// The state is only persisted once the event is emitted, so that it is emitted
+			// again if the collector stops before.
+			kr.emitWatchEvent(ctx, config, &data, previous)
+		case <-checkpointCh:
+			checkpoint(ctx)
 		case <-stopperChan:
 			watcher.Stop()
+			if state != nil {
+				checkpoint(context.Background())
+			}
 			return true
 		}
 	}
 }
 
+// emitWatchEvent sends the watch event to the consumer, unless its type is excluded. In delta
+// mode, modified objects are sent as the JSON patch of their changes since the previous state.
+func (kr *k8sobjectsreceiver) emitWatchEvent(ctx context.Context, config *K8sObjectsConfig, event *apiWatch.Event, previous *unstructured.Unstructured) {
+	if config.exclude[event.Type] {
+		kr.setting.Logger.Debug("dropping excluded data",
+			zap.String("type", string(event.Type)))
+		return
+	}
+
+	var logs plog.Logs
+	var err error
+	if u, ok := event.Object.(*unstructured.Unstructured); ok && config.DeltaMode && event.Type == apiWatch.Modified && previous != nil {
+		patch := createJSONPatch(previous.Object, u.Object)
+		if len(patch) == 0 {
+			kr.setting.Logger.Debug("dropping unchanged object",
+				zap.String("resource", config.gvr.String()),
+				zap.String("name", u.GetName()))
+			return
+		}
+		logs, err = watchObjectPatchToLogData(event, patch, time.Now(), config, kr.setting.BuildInfo.Version)
+	} else {
+		logs, err = watchObjectsToLogData(event, time.Now(), config, kr.setting.BuildInfo.Version)
+	}
+	if err != nil {
+		kr.setting.Logger.Error("error converting objects to log data", zap.Error(err))
+		return
+	}
+
+	obsCtx := kr.obsrecv.StartLogsOp(ctx)
+	err = kr.consumer.ConsumeLogs(obsCtx, logs)
+	kr.obsrecv.EndLogsOp(obsCtx, metadata.Type.String(), 1, err)
+}
+
 func getResourceVersion(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) (string, error) {
 	resourceVersion := config.ResourceVersion
 	if resourceVersion == "" || resourceVersion == "0" {
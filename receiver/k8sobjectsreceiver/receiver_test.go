@@ -516,3 +516,179 @@ func TestReceiverWithLeaderElection(t *testing.T) {
 	}, 20*time.Second, 100*time.Millisecond,
 		"logs not collected")
 }
+
+func TestWatchObjectDeltaMode(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+	mockClient.createPods(
+		generatePod("pod1", "default", map[string]any{
+			"environment": "production",
+		}, "1"),
+	)
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.ErrorMode = PropagateError
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+			DeltaMode:  true,
+		},
+	}
+
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(
+		receivertest.NewNopSettings(metadata.Type),
+		rCfg,
+		consumer,
+	)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, 0, consumer.Count())
+
+	mockClient.updatePods(
+		generatePod("pod1", "default", map[string]any{
+			"environment": "test",
+		}, "2"),
+	)
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	body := consumer.Logs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw()
+	assert.Equal(t, map[string]any{
+		"type": "MODIFIED",
+		"object": map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pods",
+			"metadata": map[string]any{
+				"name":            "pod1",
+				"namespace":       "default",
+				"resourceVersion": "2",
+			},
+		},
+		"patch": []any{
+			map[string]any{"op": "replace", "path": "/metadata/labels/environment", "value": "test"},
+		},
+	}, body)
+
+	// Updates without changes are dropped.
+	mockClient.updatePods(
+		generatePod("pod1", "default", map[string]any{
+			"environment": "test",
+		}, "3"),
+	)
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, 1, consumer.Count())
+
+	assert.NoError(t, r.Shutdown(ctx))
+}
+
+func TestWatchObjectWithStorage(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+	mockClient.createPods(
+		generatePod("pod1", "default", map[string]any{
+			"environment": "production",
+		}, "1"),
+	)
+
+	storageID := component.MustNewID("file_storage")
+	client := newMockStorageClient()
+	// pod0 was known before the restart, but deleted since.
+	client.data["/v1/pods/default/objects"] = []byte(`{"default/pod0":{"name":"pod0","namespace":"default","resource_version":"1"}}`)
+	host := &mockHost{extensions: map[component.ID]component.Component{
+		storageID: &mockStorageExtension{client: client},
+	}}
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.ErrorMode = PropagateError
+	rCfg.StorageID = &storageID
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+		},
+	}
+
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(
+		receivertest.NewNopSettings(metadata.Type),
+		rCfg,
+		consumer,
+	)
+	require.NoError(t, err)
+	r.(*k8sobjectsreceiver).checkpointInterval = 10 * time.Millisecond
+
+	ctx := t.Context()
+	require.NoError(t, r.Start(ctx, host))
+
+	// The relist emits the changes since the objects were last known.
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	var types []string
+	for _, logs := range consumer.Logs() {
+		body := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map()
+		eventType, _ := body.Get("type")
+		types = append(types, eventType.Str())
+	}
+	assert.ElementsMatch(t, []string{"ADDED", "DELETED"}, types)
+
+	mockClient.createPods(
+		generatePod("pod2", "default", map[string]any{
+			"environment": "production",
+		}, "2"),
+	)
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The resourceVersion and the objects are persisted together periodically.
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		value, err := client.Get(ctx, "/v1/pods/default/resource_version")
+		assert.NoError(tt, err)
+		assert.Equal(tt, "2", string(value))
+		value, err = client.Get(ctx, "/v1/pods/default/objects")
+		assert.NoError(tt, err)
+		assert.JSONEq(tt, `{
+			"default/pod1":{"name":"pod1","namespace":"default","resource_version":"1"},
+			"default/pod2":{"name":"pod2","namespace":"default","resource_version":"2"}
+		}`, string(value))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, r.Shutdown(ctx))
+}
+
+func TestStartWithUnknownStorage(t *testing.T) {
+	mockClient := newMockDynamicClient()
+	storageID := component.MustNewID("file_storage")
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.StorageID = &storageID
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name: "pods",
+			Mode: WatchMode,
+		},
+	}
+
+	r, err := newReceiver(receivertest.NewNopSettings(metadata.Type), rCfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.EqualError(t, r.Start(t.Context(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+	require.NoError(t, r.Shutdown(t.Context()))
+}
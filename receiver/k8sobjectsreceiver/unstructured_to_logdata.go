@@ -43,6 +43,47 @@ func watchObjectsToLogData(event *watch.Event, observedAt time.Time, config *K8s
 	}), nil
 }
 
+// watchObjectPatchToLogData converts a watch event to log data holding the JSON patch of the
+// changes of the object instead of the whole object. The object of the log body only holds
+// the fields identifying the object.
+func watchObjectPatchToLogData(event *watch.Event, patch []any, observedAt time.Time, config *K8sObjectsConfig, version string) (plog.Logs, error) {
+	udata, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		return plog.Logs{}, fmt.Errorf("received data that wasnt unstructure, %v", event)
+	}
+
+	objectMeta := map[string]any{
+		"name":            udata.GetName(),
+		"resourceVersion": udata.GetResourceVersion(),
+	}
+	if uid := udata.GetUID(); uid != "" {
+		objectMeta["uid"] = string(uid)
+	}
+	if namespace := udata.GetNamespace(); namespace != "" {
+		objectMeta["namespace"] = namespace
+	}
+	ul := unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{{
+			Object: map[string]any{
+				"type": string(event.Type),
+				"object": map[string]any{
+					"apiVersion": udata.GetAPIVersion(),
+					"kind":       udata.GetKind(),
+					"metadata":   objectMeta,
+				},
+				"patch": patch,
+			},
+		}},
+	}
+
+	return unstructuredListToLogData(&ul, observedAt, config, version, func(attrs pcommon.Map) {
+		if name := udata.GetName(); name != "" {
+			attrs.PutStr("event.domain", "k8s")
+			attrs.PutStr("event.name", name)
+		}
+	}), nil
+}
+
 func pullObjectsToLogData(event *unstructured.UnstructuredList, observedAt time.Time, config *K8sObjectsConfig, version string) plog.Logs {
 	return unstructuredListToLogData(event, observedAt, config, version)
 }
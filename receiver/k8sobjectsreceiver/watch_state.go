@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	resourceVersionKeySuffix = "resource_version"
+	objectsKeySuffix         = "objects"
+)
+
+// objectVersion is the last known version of a watched object.
+type objectVersion struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ResourceVersion string `json:"resource_version"`
+}
+
+// watchState tracks the objects of a watch on a resource in a namespace, so that the watch
+// can be resumed after a restart, and the changes missed on a 410 Gone can be found by
+// diffing a relist against the known objects.
+type watchState struct {
+	client storage.Client
+	key    string
+
+	// resourceVersion is the resourceVersion to resume the watch from, empty when a relist is needed.
+	resourceVersion string
+	// known is true when objects describes the objects of the resource, i.e. after a list or
+	// after being loaded from the storage.
+	known   bool
+	objects map[string]objectVersion
+	// lastObjects holds the last state of the objects in delta mode.
+	lastObjects map[string]*unstructured.Unstructured
+	// dirty is true when the state changed since it was last persisted.
+	dirty bool
+}
+
+func newWatchState(client storage.Client, gvr *schema.GroupVersionResource, namespace string, deltaMode bool) *watchState {
+	s := &watchState{
+		client:  client,
+		key:     strings.Join([]string{gvr.Group, gvr.Version, gvr.Resource, namespace}, "/"),
+		objects: map[string]objectVersion{},
+	}
+	if deltaMode {
+		s.lastObjects = map[string]*unstructured.Unstructured{}
+	}
+	return s
+}
+
+// load restores the checkpoint of the watch from the storage.
+func (s *watchState) load(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	rv, err := s.client.Get(ctx, s.storageKey(resourceVersionKeySuffix))
+	if err != nil {
+		return fmt.Errorf("failed to read the resourceVersion checkpoint: %w", err)
+	}
+	s.resourceVersion = string(rv)
+
+	data, err := s.client.Get(ctx, s.storageKey(objectsKeySuffix))
+	if err != nil {
+		return fmt.Errorf("failed to read the objects checkpoint: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	objects := map[string]objectVersion{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return fmt.Errorf("failed to unmarshal the objects checkpoint: %w", err)
+	}
+	s.objects = objects
+	s.known = true
+	return nil
+}
+
+// save persists the resourceVersion and the known objects together, so that they
+// always describe the same state of the resource.
+func (s *watchState) save(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	data, err := json.Marshal(s.objects)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the objects checkpoint: %w", err)
+	}
+	if err := s.client.Batch(ctx,
+		storage.SetOperation(s.storageKey(resourceVersionKeySuffix), []byte(s.resourceVersion)),
+		storage.SetOperation(s.storageKey(objectsKeySuffix), data),
+	); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// saveIfDirty persists the state if it changed since it was last persisted.
+func (s *watchState) saveIfDirty(ctx context.Context) error {
+	if !s.dirty {
+		return nil
+	}
+	return s.save(ctx)
+}
+
+func (s *watchState) storageKey(suffix string) string {
+	return s.key + "/" + suffix
+}
+
+// update records the object of a watch event, and returns the previous state of the
+// object in delta mode.
+func (s *watchState) update(eventType apiWatch.EventType, u *unstructured.Unstructured) *unstructured.Unstructured {
+	key := objectKey(u)
+	var previous *unstructured.Unstructured
+	if s.lastObjects != nil {
+		previous = s.lastObjects[key]
+	}
+
+	switch eventType {
+	case apiWatch.Added, apiWatch.Modified:
+		s.objects[key] = objectVersion{
+			Name:            u.GetName(),
+			Namespace:       u.GetNamespace(),
+			ResourceVersion: u.GetResourceVersion(),
+		}
+		if s.lastObjects != nil {
+			s.lastObjects[key] = u
+		}
+	case apiWatch.Deleted:
+		delete(s.objects, key)
+		if s.lastObjects != nil {
+			delete(s.lastObjects, key)
+		}
+	}
+	if rv := u.GetResourceVersion(); rv != "" {
+		s.resourceVersion = rv
+	}
+	s.dirty = true
+	return previous
+}
+
+// watchChange is a change of an object, with its previous state in delta mode.
+type watchChange struct {
+	event    apiWatch.Event
+	previous *unstructured.Unstructured
+}
+
+// relist replaces the known objects with the listed ones. If the objects were known
+// before, it returns the changes between them and the listed objects.
+func (s *watchState) relist(list *unstructured.UnstructuredList, gvr *schema.GroupVersionResource) []watchChange {
+	var changes []watchChange
+	previous, previousObjects := s.objects, s.lastObjects
+	s.objects = make(map[string]objectVersion, len(list.Items))
+	if s.lastObjects != nil {
+		s.lastObjects = make(map[string]*unstructured.Unstructured, len(list.Items))
+	}
+
+	for i := range list.Items {
+		u := &list.Items[i]
+		key := objectKey(u)
+		old, existed := previous[key]
+		if s.known {
+			switch {
+			case !existed:
+				changes = append(changes, watchChange{event: apiWatch.Event{Type: apiWatch.Added, Object: u}})
+			case old.ResourceVersion != u.GetResourceVersion():
+				changes = append(changes, watchChange{
+					event:    apiWatch.Event{Type: apiWatch.Modified, Object: u},
+					previous: previousObjects[key],
+				})
+			}
+		}
+		delete(previous, key)
+		s.objects[key] = objectVersion{
+			Name:            u.GetName(),
+			Namespace:       u.GetNamespace(),
+			ResourceVersion: u.GetResourceVersion(),
+		}
+		if s.lastObjects != nil {
+			s.lastObjects[key] = u
+		}
+	}
+
+	if s.known {
+		// The objects left were deleted while the watch was down.
+		for key, old := range previous {
+			deleted := previousObjects[key]
+			if deleted == nil {
+				deleted = &unstructured.Unstructured{Object: map[string]any{}}
+				deleted.SetAPIVersion(gvr.GroupVersion().String())
+				deleted.SetName(old.Name)
+				deleted.SetNamespace(old.Namespace)
+				deleted.SetResourceVersion(old.ResourceVersion)
+			}
+			changes = append(changes, watchChange{event: apiWatch.Event{Type: apiWatch.Deleted, Object: deleted}})
+		}
+	}
+
+	s.resourceVersion = list.GetResourceVersion()
+	s.known = true
+	s.dirty = true
+	return changes
+}
+
+// objectKey identifies an object by its UID, or by its namespace and name if it has none.
+func objectKey(u *unstructured.Unstructured) string {
+	if uid := u.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return u.GetNamespace() + "/" + u.GetName()
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, componentID, "")
+}
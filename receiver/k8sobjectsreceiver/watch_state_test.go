@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+var podsGVR = &schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func newPodList(resourceVersion string, pods ...*unstructured.Unstructured) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetResourceVersion(resourceVersion)
+	for _, pod := range pods {
+		list.Items = append(list.Items, *pod)
+	}
+	return list
+}
+
+func TestWatchStateSaveLoad(t *testing.T) {
+	client := newMockStorageClient()
+	state := newWatchState(client, podsGVR, "default", false)
+	require.NoError(t, state.load(t.Context()))
+	assert.Empty(t, state.resourceVersion)
+	assert.False(t, state.known)
+
+	state.relist(newPodList("10", generatePod("pod1", "default", nil, "5")), podsGVR)
+	require.NoError(t, state.save(t.Context()))
+	assert.False(t, state.dirty)
+
+	// Unchanged states aren't persisted again.
+	client.data["/v1/pods/default/resource_version"] = []byte("9")
+	require.NoError(t, state.saveIfDirty(t.Context()))
+	assert.Equal(t, []byte("9"), client.data["/v1/pods/default/resource_version"])
+
+	state.update(apiWatch.Added, generatePod("pod2", "default", nil, "11"))
+	assert.True(t, state.dirty)
+	require.NoError(t, state.saveIfDirty(t.Context()))
+	assert.False(t, state.dirty)
+	assert.Equal(t, []byte("11"), client.data["/v1/pods/default/resource_version"])
+
+	// The objects are saved along with the resourceVersion.
+	loaded := newWatchState(client, podsGVR, "default", false)
+	require.NoError(t, loaded.load(t.Context()))
+	assert.Equal(t, "11", loaded.resourceVersion)
+	assert.True(t, loaded.known)
+	assert.Equal(t, map[string]objectVersion{
+		"default/pod1": {Name: "pod1", Namespace: "default", ResourceVersion: "5"},
+		"default/pod2": {Name: "pod2", Namespace: "default", ResourceVersion: "11"},
+	}, loaded.objects)
+
+	// Without storage, nothing is persisted.
+	state = newWatchState(nil, podsGVR, "default", false)
+	require.NoError(t, state.load(t.Context()))
+	require.NoError(t, state.save(t.Context()))
+	state.update(apiWatch.Added, generatePod("pod2", "default", nil, "11"))
+	require.NoError(t, state.saveIfDirty(t.Context()))
+}
+
+func TestWatchStateRelist(t *testing.T) {
+	state := newWatchState(nil, podsGVR, "default", true)
+
+	// The first list only records the objects.
+	changes := state.relist(newPodList("10",
+		generatePod("pod1", "default", nil, "1"),
+		generatePod("pod2", "default", nil, "2"),
+		generatePod("pod3", "default", nil, "3"),
+	), podsGVR)
+	assert.Empty(t, changes)
+	assert.Equal(t, "10", state.resourceVersion)
+	assert.True(t, state.known)
+
+	previous := state.update(apiWatch.Modified, generatePod("pod3", "default", map[string]any{"app": "web"}, "11"))
+	assert.Equal(t, "3", previous.GetResourceVersion())
+	assert.Equal(t, "11", state.resourceVersion)
+
+	changes = state.relist(newPodList("20",
+		generatePod("pod1", "default", nil, "1"),
+		generatePod("pod3", "default", map[string]any{"app": "api"}, "15"),
+		generatePod("pod4", "default", nil, "16"),
+	), podsGVR)
+	assert.Equal(t, "20", state.resourceVersion)
+
+	byType := map[apiWatch.EventType][]string{}
+	for _, change := range changes {
+		u := change.event.Object.(*unstructured.Unstructured)
+		byType[change.event.Type] = append(byType[change.event.Type], u.GetName())
+		if change.event.Type == apiWatch.Modified {
+			require.NotNil(t, change.previous)
+			assert.Equal(t, "11", change.previous.GetResourceVersion())
+		}
+	}
+	assert.Equal(t, map[apiWatch.EventType][]string{
+		apiWatch.Added:    {"pod4"},
+		apiWatch.Modified: {"pod3"},
+		apiWatch.Deleted:  {"pod2"},
+	}, byType)
+	assert.Len(t, state.objects, 3)
+	assert.Len(t, state.lastObjects, 3)
+
+	previous = state.update(apiWatch.Deleted, generatePod("pod4", "default", nil, "21"))
+	assert.Equal(t, "16", previous.GetResourceVersion())
+	assert.NotContains(t, state.objects, "default/pod4")
+	assert.NotContains(t, state.lastObjects, "default/pod4")
+}
+
+func TestWatchStateRelistDeletedWithoutLastObject(t *testing.T) {
+	client := newMockStorageClient()
+	client.data["/v1/pods/default/objects"] = []byte(`{"default/pod1":{"name":"pod1","namespace":"default","resource_version":"1"}}`)
+
+	state := newWatchState(client, podsGVR, "default", false)
+	require.NoError(t, state.load(t.Context()))
+
+	changes := state.relist(newPodList("10"), podsGVR)
+	require.Len(t, changes, 1)
+	assert.Equal(t, apiWatch.Deleted, changes[0].event.Type)
+	deleted := changes[0].event.Object.(*unstructured.Unstructured)
+	assert.Equal(t, "v1", deleted.GetAPIVersion())
+	assert.Equal(t, "pod1", deleted.GetName())
+	assert.Equal(t, "default", deleted.GetNamespace())
+}
+
+func TestWatchStateLoadInvalidCheckpoint(t *testing.T) {
+	client := newMockStorageClient()
+	client.data["/v1/pods/default/objects"] = []byte("invalid")
+
+	state := newWatchState(client, podsGVR, "default", false)
+	assert.ErrorContains(t, state.load(t.Context()), "failed to unmarshal the objects checkpoint")
+	assert.False(t, state.known)
+}
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/targetallocator"
 )
 
@@ -43,6 +44,10 @@ type Config struct {
 
 	TargetAllocator configoptional.Optional[targetallocator.Config] `mapstructure:"target_allocator"`
 
+	// Sharding spreads the discovered targets across the replicas of the receiver
+	// without a target allocator, each replica scraping its share of the targets.
+	Sharding configoptional.Optional[sharding.Config] `mapstructure:"sharding"`
+
 	//  APIServer has the settings to enable the receiver to host the Prometheus API
 	// server in agent mode. This allows the user to call the endpoint to get
 	// the config, service discovery, and targets for debugging purposes.
@@ -55,6 +60,10 @@ func (cfg *Config) Validate() error {
 		return errors.New("no Prometheus scrape_configs or target_allocator set")
 	}
 
+	if cfg.TargetAllocator.HasValue() && cfg.Sharding.HasValue() {
+		return errors.New("target_allocator and sharding cannot be used together")
+	}
+
 	if err := cfg.APIServer.Validate(); err != nil {
 		return fmt.Errorf("invalid API server configuration settings: %w", err)
 	}
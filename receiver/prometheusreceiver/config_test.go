@@ -30,6 +30,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -355,6 +356,67 @@ func TestTargetAllocatorInvalidHTTPScrape(t *testing.T) {
 	require.Error(t, sub.Unmarshal(cfg))
 }
 
+func TestLoadShardingConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_sharding.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		errorMessage string
+		check        func(t *testing.T, cfg *sharding.Config)
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			check: func(t *testing.T, cfg *sharding.Config) {
+				assert.Equal(t, "10.0.0.1", cfg.ID)
+				assert.Equal(t, 30*time.Second, cfg.HandoffDelay)
+				require.True(t, cfg.Resolver.DNS.HasValue())
+				assert.Equal(t, "otelcol-headless.monitoring.svc.cluster.local", cfg.Resolver.DNS.Get().Hostname)
+				assert.Equal(t, 10*time.Second, cfg.Resolver.DNS.Get().Interval)
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "static"),
+			check: func(t *testing.T, cfg *sharding.Config) {
+				assert.Equal(t, "otelcol-0", cfg.ID)
+				assert.Equal(t, time.Minute, cfg.HandoffDelay)
+				require.True(t, cfg.Resolver.Static.HasValue())
+				assert.Equal(t, []string{"otelcol-0", "otelcol-1", "otelcol-2"}, cfg.Resolver.Static.Get().Peers)
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "target_allocator"),
+			errorMessage: "target_allocator and sharding cannot be used together",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "multiple_resolvers"),
+			errorMessage: "only one peer resolver can be specified",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "no_id"),
+			errorMessage: "id must be set to the identity of this replica",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+			require.NoError(t, xconfmap.Validate(cfg))
+			r := cfg.(*Config)
+			require.True(t, r.Sharding.HasValue())
+			tt.check(t, r.Sharding.Get())
+		})
+	}
+}
+
 func TestFileSDConfigWithoutSDFile(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "nonexistent-prometheus-sd-file-config.yaml"))
 	require.NoError(t, err)
@@ -46,6 +46,7 @@ import (
 	"golang.org/x/net/netutil"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/targetallocator"
 )
 
@@ -70,6 +71,7 @@ type pReceiver struct {
 	scrapeManager          *scrape.Manager
 	discoveryManager       *discovery.Manager
 	targetAllocatorManager *targetallocator.Manager
+	sharder                *sharding.Sharder
 	apiServer              *http.Server
 	registry               *prometheus.Registry
 	registerer             prometheus.Registerer
@@ -101,6 +103,13 @@ func newPrometheusReceiver(set receiver.Settings, cfg *Config, next consumer.Met
 			enableNativeHistogramsGate.IsEnabled(),
 		),
 	}
+	if cfg.Sharding.HasValue() {
+		sharder, err := sharding.NewSharder(set.Logger, cfg.Sharding.Get())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sharder: %w", err)
+		}
+		pr.sharder = sharder
+	}
 	return pr, nil
 }
 
@@ -112,6 +121,14 @@ func (r *pReceiver) Start(ctx context.Context, host component.Host) error {
 
 	logger := slog.New(zapslog.NewHandler(r.settings.Logger.Core()))
 
+	// The peers are resolved before the scrape manager starts, so that this replica
+	// doesn't start scraping targets owned by its peers.
+	if r.sharder != nil {
+		if err := r.sharder.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start sharder: %w", err)
+		}
+	}
+
 	err := r.initPrometheusComponents(discoveryCtx, logger, host)
 	if err != nil {
 		r.settings.Logger.Error("Failed to initPrometheusComponents Prometheus components", zap.Error(err))
@@ -214,7 +231,11 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, logger *slog.L
 		// The scrape manager needs to wait for the configuration to be loaded before beginning
 		<-r.configLoaded
 		r.settings.Logger.Info("Starting scrape manager")
-		if err := r.scrapeManager.Run(r.discoveryManager.SyncCh()); err != nil {
+		syncCh := r.discoveryManager.SyncCh()
+		if r.sharder != nil {
+			syncCh = r.sharder.Run(syncCh)
+		}
+		if err := r.scrapeManager.Run(syncCh); err != nil {
 			r.settings.Logger.Error("Scrape manager failed", zap.Error(err))
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(err))
 		}
@@ -430,6 +451,11 @@ func (r *pReceiver) Shutdown(ctx context.Context) error {
 	if r.targetAllocatorManager != nil {
 		r.targetAllocatorManager.Shutdown()
 	}
+	if r.sharder != nil {
+		if err := r.sharder.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
 	if r.unregisterMetrics != nil {
 		r.unregisterMetrics()
 	}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configoptional"
+)
+
+const defaultHandoffDelay = 30 * time.Second
+
+// Config defines how the targets are sharded across the replicas of the receiver.
+type Config struct {
+	// ID identifies this replica among the peers returned by the resolver,
+	// for example its pod IP.
+	ID string `mapstructure:"id"`
+	// Resolver configures how the peers are discovered.
+	Resolver ResolverSettings `mapstructure:"resolver"`
+	// HandoffDelay is how long a replica keeps scraping a target after it was
+	// assigned to another peer, so that the target isn't left unscraped while
+	// the new owner starts scraping it.
+	HandoffDelay time.Duration `mapstructure:"handoff_delay"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultConfig returns the default sharding configuration, without a resolver.
+func NewDefaultConfig() Config {
+	return Config{
+		HandoffDelay: defaultHandoffDelay,
+	}
+}
+
+// ResolverSettings defines the configurations for the peer resolvers.
+// Exactly one of them must be set.
+type ResolverSettings struct {
+	Static configoptional.Optional[StaticResolver] `mapstructure:"static"`
+	DNS    configoptional.Optional[DNSResolver]    `mapstructure:"dns"`
+	K8sSvc configoptional.Optional[K8sSvcResolver] `mapstructure:"k8s"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// StaticResolver defines a fixed list of peers.
+type StaticResolver struct {
+	Peers []string `mapstructure:"peers"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// DNSResolver defines the peers as the IP addresses a hostname resolves to.
+type DNSResolver struct {
+	Hostname string        `mapstructure:"hostname"`
+	Interval time.Duration `mapstructure:"interval"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// K8sSvcResolver defines the peers as the ready endpoints of a Kubernetes service.
+type K8sSvcResolver struct {
+	// Service is the name of the service, optionally followed by its namespace: <name>.<namespace>.
+	Service string        `mapstructure:"service"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// ReturnHostnames identifies the peers by the hostnames of their endpoints instead of their IP addresses.
+	ReturnHostnames bool `mapstructure:"return_hostnames"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks the sharding configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.ID == "" || strings.Contains(cfg.ID, "${") {
+		return errors.New("id must be set to the identity of this replica")
+	}
+	if cfg.HandoffDelay < 0 {
+		return errors.New("handoff_delay must not be negative")
+	}
+
+	resolvers := 0
+	if cfg.Resolver.Static.HasValue() {
+		resolvers++
+		if len(cfg.Resolver.Static.Get().Peers) == 0 {
+			return errors.New("resolver::static::peers must not be empty")
+		}
+	}
+	if cfg.Resolver.DNS.HasValue() {
+		resolvers++
+		if cfg.Resolver.DNS.Get().Hostname == "" {
+			return errNoHostname
+		}
+	}
+	if cfg.Resolver.K8sSvc.HasValue() {
+		resolvers++
+		if cfg.Resolver.K8sSvc.Get().Service == "" {
+			return errNoSvc
+		}
+	}
+	switch resolvers {
+	case 0:
+		return errNoResolver
+	case 1:
+		return nil
+	default:
+		return errMultipleResolvers
+	}
+}
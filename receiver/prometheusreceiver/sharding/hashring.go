@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"sort"
+)
+
+// pointsPerPeer is the number of positions of each peer in the ring. The more
+// positions, the more evenly the targets are spread across the peers.
+const pointsPerPeer = 100
+
+// ringItem connects a position in the ring with a peer.
+type ringItem struct {
+	pos  uint32
+	peer string
+}
+
+// hashRing is an immutable consistent hash ring: when a peer joins or leaves,
+// only the targets of the ring segments it takes over or releases move.
+type hashRing struct {
+	items []ringItem
+}
+
+func newHashRing(peers []string) *hashRing {
+	items := make([]ringItem, 0, len(peers)*pointsPerPeer)
+	buf := make([]byte, 4)
+	for _, peer := range peers {
+		for i := 0; i < pointsPerPeer; i++ {
+			binary.LittleEndian.PutUint32(buf, uint32(i))
+			h := crc32.NewIEEE()
+			h.Write([]byte(peer))
+			h.Write(buf)
+			items = append(items, ringItem{pos: h.Sum32(), peer: peer})
+		}
+	}
+	// Break ties on the peer so that all the replicas build the same ring.
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].pos != items[j].pos {
+			return items[i].pos < items[j].pos
+		}
+		return items[i].peer < items[j].peer
+	})
+	return &hashRing{items: items}
+}
+
+// peerFor returns the peer owning the given key: the first one found clockwise
+// from the position of the key. It returns an empty string if the ring is empty.
+func (h *hashRing) peerFor(key string) string {
+	if len(h.items) == 0 {
+		return ""
+	}
+	pos := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(h.items), func(i int) bool {
+		return h.items[i].pos >= pos
+	})
+	if i == len(h.items) {
+		i = 0
+	}
+	return h.items[i].peer
+}
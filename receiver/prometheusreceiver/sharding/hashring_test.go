@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashRingEmpty(t *testing.T) {
+	assert.Empty(t, newHashRing(nil).peerFor("job/localhost:8080"))
+}
+
+func TestHashRingDistribution(t *testing.T) {
+	peers := []string{"otelcol-0", "otelcol-1", "otelcol-2"}
+	ring := newHashRing(peers)
+
+	owned := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		owned[ring.peerFor(fmt.Sprintf("job/10.0.%d.%d:8080", i/256, i%256))]++
+	}
+	assert.Len(t, owned, len(peers))
+	for _, peer := range peers {
+		// Each peer owns roughly a third of the targets.
+		assert.Greater(t, owned[peer], 600, peer)
+	}
+}
+
+func TestHashRingMembershipChange(t *testing.T) {
+	before := newHashRing([]string{"otelcol-0", "otelcol-1", "otelcol-2"})
+	after := newHashRing([]string{"otelcol-0", "otelcol-1", "otelcol-2", "otelcol-3"})
+
+	moved := 0
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("job/10.0.%d.%d:8080", i/256, i%256)
+		if peer := after.peerFor(key); peer != before.peerFor(key) {
+			// Only the targets claimed by the new peer move.
+			assert.Equal(t, "otelcol-3", peer)
+			moved++
+		}
+	}
+	assert.Greater(t, moved, 400)
+	assert.Less(t, moved, 1200)
+}
+
+func TestHashRingIsDeterministic(t *testing.T) {
+	a := newHashRing([]string{"otelcol-0", "otelcol-1"})
+	b := newHashRing([]string{"otelcol-0", "otelcol-1"})
+	assert.Equal(t, a.items, b.items)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	errNoResolver        = errors.New("no peer resolver specified")
+	errMultipleResolvers = errors.New("only one peer resolver can be specified")
+	errNoHostname        = errors.New("no hostname specified to resolve the peers")
+	errNoSvc             = errors.New("no service specified to resolve the peers")
+)
+
+// resolver discovers the peers the targets are sharded across.
+type resolver interface {
+	// start begins the resolution and resolves the initial peers.
+	start(ctx context.Context) error
+
+	// shutdown stops the resolution.
+	shutdown(ctx context.Context) error
+
+	// onChange registers a callback called with the sorted peers whenever they change.
+	onChange(func([]string))
+}
+
+func newResolver(logger *zap.Logger, cfg ResolverSettings) (resolver, error) {
+	switch {
+	case cfg.Static.HasValue():
+		return newStaticResolver(cfg.Static.Get().Peers), nil
+	case cfg.DNS.HasValue():
+		dnsCfg := cfg.DNS.Get()
+		return newDNSResolver(logger, dnsCfg.Hostname, dnsCfg.Interval, dnsCfg.Timeout)
+	case cfg.K8sSvc.HasValue():
+		k8sCfg := cfg.K8sSvc.Get()
+		clt, err := newInClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		return newK8sResolver(clt, logger, k8sCfg.Service, k8sCfg.Timeout, k8sCfg.ReturnHostnames)
+	default:
+		return nil, errNoResolver
+	}
+}
+
+// peerSet holds the last resolved peers and the change callbacks, shared by the resolvers.
+type peerSet struct {
+	peers     []string
+	callbacks []func([]string)
+	lock      sync.Mutex
+}
+
+// update stores the peers and notifies the callbacks if they changed.
+func (s *peerSet) update(peers []string) {
+	sort.Strings(peers)
+	peers = slices.Compact(peers)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.peers != nil && slices.Equal(s.peers, peers) {
+		return
+	}
+	s.peers = peers
+	for _, callback := range s.callbacks {
+		callback(peers)
+	}
+}
+
+func (s *peerSet) onChange(f func([]string)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.callbacks = append(s.callbacks, f)
+}
+
+func (s *peerSet) clearCallbacks() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.callbacks = nil
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var _ resolver = (*dnsResolver)(nil)
+
+const (
+	defaultResInterval = 5 * time.Second
+	defaultResTimeout  = time.Second
+)
+
+type netResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type dnsResolver struct {
+	peerSet
+
+	logger      *zap.Logger
+	hostname    string
+	resolver    netResolver
+	resInterval time.Duration
+	resTimeout  time.Duration
+
+	stopCh     chan struct{}
+	shutdownWg sync.WaitGroup
+}
+
+func newDNSResolver(logger *zap.Logger, hostname string, interval, timeout time.Duration) (*dnsResolver, error) {
+	if hostname == "" {
+		return nil, errNoHostname
+	}
+	if interval == 0 {
+		interval = defaultResInterval
+	}
+	if timeout == 0 {
+		timeout = defaultResTimeout
+	}
+
+	return &dnsResolver{
+		logger:      logger,
+		hostname:    hostname,
+		resolver:    &net.Resolver{},
+		resInterval: interval,
+		resTimeout:  timeout,
+		stopCh:      make(chan struct{}),
+	}, nil
+}
+
+func (r *dnsResolver) start(ctx context.Context) error {
+	resolveCtx, cancel := context.WithTimeout(ctx, r.resTimeout)
+	defer cancel()
+	if err := r.resolve(resolveCtx); err != nil {
+		r.logger.Warn("Failed to resolve the peers", zap.Error(err))
+	}
+
+	r.shutdownWg.Add(1)
+	go r.periodicallyResolve()
+
+	r.logger.Debug("DNS peer resolver started",
+		zap.String("hostname", r.hostname),
+		zap.Duration("interval", r.resInterval),
+		zap.Duration("timeout", r.resTimeout))
+	return nil
+}
+
+func (r *dnsResolver) shutdown(_ context.Context) error {
+	r.clearCallbacks()
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *dnsResolver) periodicallyResolve() {
+	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
+	defer r.shutdownWg.Done()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.resTimeout)
+			if err := r.resolve(ctx); err != nil {
+				r.logger.Warn("Failed to resolve the peers", zap.Error(err))
+			}
+			cancel()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *dnsResolver) resolve(ctx context.Context) error {
+	addrs, err := r.resolver.LookupIPAddr(ctx, r.hostname)
+	if err != nil {
+		return err
+	}
+
+	peers := make([]string, len(addrs))
+	for i, addr := range addrs {
+		peers[i] = addr.IP.String()
+	}
+	r.update(peers)
+	return nil
+}
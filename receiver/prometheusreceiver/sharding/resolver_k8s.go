@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultListWatchTimeout = time.Second
+	inClusterNamespacePath  = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+type k8sResolver struct {
+	peerSet
+
+	logger          *zap.Logger
+	svcName         string
+	svcNs           string
+	returnHostnames bool
+	lwTimeout       time.Duration
+
+	listWatcher cache.ListerWatcher
+	informer    cache.SharedInformer
+	once        sync.Once
+	stopCh      chan struct{}
+}
+
+func newK8sResolver(clt kubernetes.Interface, logger *zap.Logger, service string, timeout time.Duration, returnHostnames bool) (*k8sResolver, error) {
+	if service == "" {
+		return nil, errNoSvc
+	}
+	if timeout == 0 {
+		timeout = defaultListWatchTimeout
+	}
+
+	name, namespace, found := strings.Cut(service, ".")
+	if !found {
+		namespace = "default"
+		if ns, err := getInClusterNamespace(); err == nil {
+			namespace = ns
+		} else {
+			logger.Warn(`Could not determine the namespace of the peers service, using "default"`, zap.Error(err))
+		}
+	}
+
+	selector := fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, name)
+	timeoutSeconds := int64(timeout.Seconds())
+	listWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			options.TimeoutSeconds = &timeoutSeconds
+			return clt.DiscoveryV1().EndpointSlices(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			options.TimeoutSeconds = &timeoutSeconds
+			return clt.DiscoveryV1().EndpointSlices(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	return &k8sResolver{
+		logger:          logger,
+		svcName:         name,
+		svcNs:           namespace,
+		returnHostnames: returnHostnames,
+		lwTimeout:       timeout,
+		listWatcher:     listWatcher,
+		stopCh:          make(chan struct{}),
+	}, nil
+}
+
+func (r *k8sResolver) start(_ context.Context) error {
+	var initErr error
+	r.once.Do(func() {
+		r.informer = cache.NewSharedInformer(r.listWatcher, &discoveryv1.EndpointSlice{}, 0)
+		handler := cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(any) { r.resolve() },
+			UpdateFunc: func(any, any) { r.resolve() },
+			DeleteFunc: func(any) { r.resolve() },
+		}
+		if _, err := r.informer.AddEventHandler(handler); err != nil {
+			initErr = err
+			return
+		}
+		go r.informer.Run(r.stopCh)
+		if !cache.WaitForCacheSync(r.stopCh, r.informer.HasSynced) {
+			initErr = errors.New("endpoint slices informer not synced")
+			return
+		}
+		// Notify the peers even if the service has no endpoint slices yet.
+		r.resolve()
+	})
+	if initErr != nil {
+		return initErr
+	}
+
+	r.logger.Debug("Kubernetes service peer resolver started",
+		zap.String("service", r.svcName),
+		zap.String("namespace", r.svcNs),
+		zap.Duration("timeout", r.lwTimeout))
+	return nil
+}
+
+func (r *k8sResolver) shutdown(_ context.Context) error {
+	r.clearCallbacks()
+	close(r.stopCh)
+	return nil
+}
+
+// resolve computes the peers from the ready endpoints of all the endpoint slices of the service.
+func (r *k8sResolver) resolve() {
+	var peers []string
+	for _, obj := range r.informer.GetStore().List() {
+		slice, ok := obj.(*discoveryv1.EndpointSlice)
+		if !ok {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			switch {
+			case r.returnHostnames && endpoint.Hostname != nil:
+				peers = append(peers, *endpoint.Hostname)
+			case len(endpoint.Addresses) > 0:
+				peers = append(peers, endpoint.Addresses[0])
+			}
+		}
+	}
+	if peers == nil {
+		peers = []string{}
+	}
+	r.update(peers)
+}
+
+func newInClusterClient() (kubernetes.Interface, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+func getInClusterNamespace() (string, error) {
+	namespace, err := os.ReadFile(inClusterNamespacePath)
+	if err != nil {
+		return "", fmt.Errorf("not running in-cluster, please specify the namespace: %w", err)
+	}
+	return strings.TrimSpace(string(namespace)), nil
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"context"
+	"slices"
+)
+
+var _ resolver = (*staticResolver)(nil)
+
+type staticResolver struct {
+	peerSet
+	configured []string
+}
+
+func newStaticResolver(peers []string) *staticResolver {
+	return &staticResolver{configured: slices.Clone(peers)}
+}
+
+func (r *staticResolver) start(_ context.Context) error {
+	r.update(slices.Clone(r.configured))
+	return nil
+}
+
+func (r *staticResolver) shutdown(_ context.Context) error {
+	r.clearCallbacks()
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+type peerRecorder struct {
+	lock    sync.Mutex
+	updates [][]string
+}
+
+func (r *peerRecorder) record(peers []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.updates = append(r.updates, peers)
+}
+
+func (r *peerRecorder) last() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.updates) == 0 {
+		return nil
+	}
+	return r.updates[len(r.updates)-1]
+}
+
+func (r *peerRecorder) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.updates)
+}
+
+func TestStaticResolver(t *testing.T) {
+	res := newStaticResolver([]string{"otelcol-2", "otelcol-0", "otelcol-1", "otelcol-0"})
+	recorder := &peerRecorder{}
+	res.onChange(recorder.record)
+
+	require.NoError(t, res.start(t.Context()))
+	assert.Equal(t, [][]string{{"otelcol-0", "otelcol-1", "otelcol-2"}}, recorder.updates)
+	require.NoError(t, res.shutdown(t.Context()))
+}
+
+type mockNetResolver struct {
+	lock  sync.Mutex
+	addrs []net.IPAddr
+	err   error
+}
+
+func (m *mockNetResolver) LookupIPAddr(context.Context, string) ([]net.IPAddr, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.addrs, m.err
+}
+
+func (m *mockNetResolver) set(addrs []net.IPAddr, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.addrs, m.err = addrs, err
+}
+
+func TestDNSResolver(t *testing.T) {
+	res, err := newDNSResolver(zap.NewNop(), "otelcol-headless", 10*time.Millisecond, 0)
+	require.NoError(t, err)
+	netRes := &mockNetResolver{addrs: []net.IPAddr{
+		{IP: net.IPv4(10, 0, 0, 2)},
+		{IP: net.IPv4(10, 0, 0, 1)},
+	}}
+	res.resolver = netRes
+	recorder := &peerRecorder{}
+	res.onChange(recorder.record)
+
+	require.NoError(t, res.start(t.Context()))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, recorder.last())
+
+	// Failed resolutions keep the last peers.
+	netRes.set(nil, errors.New("no such host"))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, recorder.count())
+
+	netRes.set([]net.IPAddr{{IP: net.IPv4(10, 0, 0, 1)}, {IP: net.ParseIP("fd00::3")}}, nil)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.1", "fd00::3"}, recorder.last())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, res.shutdown(t.Context()))
+}
+
+func TestDNSResolverNoHostname(t *testing.T) {
+	_, err := newDNSResolver(zap.NewNop(), "", 0, 0)
+	assert.ErrorIs(t, err, errNoHostname)
+}
+
+func endpointSlice(name string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "monitoring",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "otelcol"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+	}
+}
+
+func TestK8sResolver(t *testing.T) {
+	tests := []struct {
+		name            string
+		returnHostnames bool
+		expected        []string
+		updated         []string
+	}{
+		{
+			name:     "addresses",
+			expected: []string{"10.0.0.1", "10.0.0.2"},
+			updated:  []string{"10.0.0.1", "10.0.0.2", "10.0.0.4"},
+		},
+		{
+			name:            "hostnames",
+			returnHostnames: true,
+			expected:        []string{"otelcol-0", "otelcol-1"},
+			updated:         []string{"otelcol-0", "otelcol-1", "otelcol-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clt := fake.NewClientset(
+				endpointSlice("otelcol-a",
+					discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}, Hostname: ptr.To("otelcol-0")},
+					discoveryv1.Endpoint{Addresses: []string{"10.0.0.2"}, Hostname: ptr.To("otelcol-1")},
+					// Endpoints that aren't ready aren't peers.
+					discoveryv1.Endpoint{
+						Addresses:  []string{"10.0.0.3"},
+						Hostname:   ptr.To("otelcol-2"),
+						Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)},
+					},
+				),
+				// The endpoint slices of other services are ignored.
+				&discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other",
+						Namespace: "monitoring",
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "other"},
+					},
+					Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"10.0.1.1"}}},
+				},
+			)
+
+			res, err := newK8sResolver(clt, zap.NewNop(), "otelcol.monitoring", 0, tt.returnHostnames)
+			require.NoError(t, err)
+			recorder := &peerRecorder{}
+			res.onChange(recorder.record)
+
+			require.NoError(t, res.start(t.Context()))
+			assert.Equal(t, tt.expected, recorder.last())
+
+			_, err = clt.DiscoveryV1().EndpointSlices("monitoring").Create(t.Context(),
+				endpointSlice("otelcol-b", discoveryv1.Endpoint{Addresses: []string{"10.0.0.4"}, Hostname: ptr.To("otelcol-3")}),
+				metav1.CreateOptions{})
+			require.NoError(t, err)
+			assert.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual(tt.updated, recorder.last())
+			}, 5*time.Second, 10*time.Millisecond)
+
+			require.NoError(t, res.shutdown(t.Context()))
+		})
+	}
+}
+
+func TestK8sResolverNoService(t *testing.T) {
+	_, err := newK8sResolver(fake.NewClientset(), zap.NewNop(), "", 0, false)
+	assert.ErrorIs(t, err, errNoSvc)
+}
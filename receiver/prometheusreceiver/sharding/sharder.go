@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/sharding"
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"go.uber.org/zap"
+)
+
+// Sharder filters the targets discovered by the receiver down to the ones owned
+// by this replica. The targets are assigned to the peers with a consistent hash
+// of their job and address, so that each peer claims its share of the targets
+// without coordination, and only a fraction of them move when the peers change.
+type Sharder struct {
+	logger       *zap.Logger
+	id           string
+	handoffDelay time.Duration
+	resolver     resolver
+
+	ringLock    sync.RWMutex
+	ring        *hashRing
+	ringChanged chan struct{}
+
+	// assignments holds the targets scraped by this replica, with the time until
+	// which they are kept if they were assigned to another peer. It is only
+	// accessed by the goroutine started by Run.
+	assignments map[string]time.Time
+
+	stopCh     chan struct{}
+	shutdownWg sync.WaitGroup
+}
+
+// NewSharder creates a Sharder for the given configuration.
+func NewSharder(logger *zap.Logger, cfg *Config) (*Sharder, error) {
+	res, err := newResolver(logger, cfg.Resolver)
+	if err != nil {
+		return nil, err
+	}
+	return newSharder(logger, cfg, res), nil
+}
+
+func newSharder(logger *zap.Logger, cfg *Config, res resolver) *Sharder {
+	return &Sharder{
+		logger:       logger,
+		id:           cfg.ID,
+		handoffDelay: cfg.HandoffDelay,
+		resolver:     res,
+		ring:         newHashRing([]string{cfg.ID}),
+		ringChanged:  make(chan struct{}, 1),
+		assignments:  make(map[string]time.Time),
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Start starts resolving the peers.
+func (s *Sharder) Start(ctx context.Context) error {
+	s.resolver.onChange(s.onPeersChange)
+	return s.resolver.start(ctx)
+}
+
+// Shutdown stops resolving the peers and filtering the targets.
+func (s *Sharder) Shutdown(ctx context.Context) error {
+	close(s.stopCh)
+	err := s.resolver.shutdown(ctx)
+	s.shutdownWg.Wait()
+	return err
+}
+
+func (s *Sharder) onPeersChange(peers []string) {
+	if !slices.Contains(peers, s.id) {
+		// This replica is running, so it takes part in the sharding even if it isn't
+		// resolved yet, rather than leaving its share of the targets unscraped.
+		s.logger.Warn("This replica isn't part of the resolved peers, check the sharding id",
+			zap.String("id", s.id), zap.Strings("peers", peers))
+		peers = append(slices.Clone(peers), s.id)
+		slices.Sort(peers)
+	}
+	s.logger.Info("Sharding peers changed", zap.Strings("peers", peers))
+
+	ring := newHashRing(peers)
+	s.ringLock.Lock()
+	s.ring = ring
+	s.ringLock.Unlock()
+
+	select {
+	case s.ringChanged <- struct{}{}:
+	default:
+	}
+}
+
+// Run filters the target sets received from the discovery manager, and sends the
+// targets owned by this replica to the returned channel, which is meant to be
+// consumed by the scrape manager. The target sets are filtered again whenever the
+// peers change, or a target handed off to another peer is released.
+func (s *Sharder) Run(in <-chan map[string][]*targetgroup.Group) <-chan map[string][]*targetgroup.Group {
+	out := make(chan map[string][]*targetgroup.Group)
+	s.shutdownWg.Add(1)
+	go func() {
+		defer s.shutdownWg.Done()
+
+		var latest map[string][]*targetgroup.Group
+		// The handoff timer fires when the next handed off target is released.
+		handoff := time.NewTimer(time.Hour)
+		handoff.Stop()
+		defer handoff.Stop()
+
+		// The peers resolved before the first target sets don't require filtering again.
+		select {
+		case <-s.ringChanged:
+		default:
+		}
+
+		for {
+			select {
+			case tsets, ok := <-in:
+				if !ok {
+					return
+				}
+				latest = tsets
+			case <-s.ringChanged:
+			case <-handoff.C:
+			case <-s.stopCh:
+				return
+			}
+			if latest == nil {
+				continue
+			}
+
+			filtered, nextRelease := s.filter(time.Now(), latest)
+			handoff.Stop()
+			if !nextRelease.IsZero() {
+				handoff.Reset(time.Until(nextRelease))
+			}
+
+			select {
+			case out <- filtered:
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// filter returns the targets of the target sets that are scraped by this replica:
+// the ones it owns, and the ones that were recently assigned to another peer.
+// It also returns the time at which the next handed off target is released, or
+// the zero time if there is none.
+func (s *Sharder) filter(now time.Time, tsets map[string][]*targetgroup.Group) (map[string][]*targetgroup.Group, time.Time) {
+	s.ringLock.RLock()
+	ring := s.ring
+	s.ringLock.RUnlock()
+
+	var nextRelease time.Time
+	assignments := make(map[string]time.Time, len(s.assignments))
+	filtered := make(map[string][]*targetgroup.Group, len(tsets))
+	for job, groups := range tsets {
+		filteredGroups := make([]*targetgroup.Group, 0, len(groups))
+		for _, group := range groups {
+			if group == nil {
+				continue
+			}
+			filteredGroup := &targetgroup.Group{
+				Source: group.Source,
+				Labels: group.Labels,
+			}
+			for _, target := range group.Targets {
+				key := targetKey(job, group, target)
+				if ring.peerFor(key) == s.id {
+					assignments[key] = time.Time{}
+					filteredGroup.Targets = append(filteredGroup.Targets, target)
+					continue
+				}
+
+				release, scraped := s.assignments[key]
+				if !scraped || s.handoffDelay == 0 {
+					continue
+				}
+				if release.IsZero() {
+					release = now.Add(s.handoffDelay)
+				}
+				if !now.Before(release) {
+					continue
+				}
+				assignments[key] = release
+				filteredGroup.Targets = append(filteredGroup.Targets, target)
+				if nextRelease.IsZero() || release.Before(nextRelease) {
+					nextRelease = release
+				}
+			}
+			filteredGroups = append(filteredGroups, filteredGroup)
+		}
+		filtered[job] = filteredGroups
+	}
+	s.assignments = assignments
+	return filtered, nextRelease
+}
+
+// targetKey returns the key the target is assigned with, which identifies the
+// target within its job the same way as a hashmod relabeling of its address.
+func targetKey(job string, group *targetgroup.Group, target model.LabelSet) string {
+	address, ok := target[model.AddressLabel]
+	if !ok {
+		address = group.Labels[model.AddressLabel]
+	}
+	return job + "/" + string(address)
+}
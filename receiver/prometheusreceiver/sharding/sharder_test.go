@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestSharder(t *testing.T, id string, handoffDelay time.Duration, peers ...string) (*Sharder, *staticResolver) {
+	res := newStaticResolver(peers)
+	s := newSharder(zap.NewNop(), &Config{ID: id, HandoffDelay: handoffDelay}, res)
+	require.NoError(t, s.Start(t.Context()))
+	return s, res
+}
+
+func targetSets(targets int) map[string][]*targetgroup.Group {
+	group := &targetgroup.Group{
+		Source: "demo/0",
+		Labels: model.LabelSet{"env": "prod"},
+	}
+	for i := 0; i < targets; i++ {
+		group.Targets = append(group.Targets, model.LabelSet{
+			model.AddressLabel: model.LabelValue(fmt.Sprintf("10.0.0.%d:8080", i)),
+		})
+	}
+	return map[string][]*targetgroup.Group{"demo": {group}}
+}
+
+func addresses(tsets map[string][]*targetgroup.Group) []string {
+	var result []string
+	for _, groups := range tsets {
+		for _, group := range groups {
+			for _, target := range group.Targets {
+				result = append(result, string(target[model.AddressLabel]))
+			}
+		}
+	}
+	return result
+}
+
+func TestSharderFilter(t *testing.T) {
+	peers := []string{"otelcol-0", "otelcol-1", "otelcol-2"}
+	tsets := targetSets(100)
+
+	var all []string
+	for _, peer := range peers {
+		s, _ := newTestSharder(t, peer, 0, peers...)
+		filtered, nextRelease := s.filter(time.Now(), tsets)
+		require.NoError(t, s.Shutdown(t.Context()))
+		assert.Zero(t, nextRelease)
+
+		require.Len(t, filtered["demo"], 1)
+		group := filtered["demo"][0]
+		assert.Equal(t, "demo/0", group.Source)
+		assert.Equal(t, model.LabelSet{"env": "prod"}, group.Labels)
+		assert.NotEmpty(t, group.Targets, peer)
+		all = append(all, addresses(filtered)...)
+	}
+	// Each target is scraped by exactly one replica.
+	assert.ElementsMatch(t, addresses(tsets), all)
+	// The discovered target sets are left untouched.
+	assert.Len(t, tsets["demo"][0].Targets, 100)
+}
+
+func TestSharderNotInPeers(t *testing.T) {
+	s, _ := newTestSharder(t, "otelcol-1", 0, "otelcol-0")
+	defer func() { require.NoError(t, s.Shutdown(t.Context())) }()
+
+	// The replica takes part in the sharding even if it isn't resolved yet.
+	filtered, _ := s.filter(time.Now(), targetSets(100))
+	assert.NotEmpty(t, addresses(filtered))
+	assert.Less(t, len(addresses(filtered)), 100)
+}
+
+func TestSharderHandoff(t *testing.T) {
+	tests := []struct {
+		name         string
+		handoffDelay time.Duration
+	}{
+		{name: "with handoff delay", handoffDelay: time.Minute},
+		{name: "without handoff delay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, res := newTestSharder(t, "otelcol-0", tt.handoffDelay, "otelcol-0")
+			defer func() { require.NoError(t, s.Shutdown(t.Context())) }()
+
+			now := time.Now()
+			tsets := targetSets(100)
+			filtered, _ := s.filter(now, tsets)
+			require.Len(t, addresses(filtered), 100)
+
+			res.update([]string{"otelcol-0", "otelcol-1"})
+			owned := newHashRing([]string{"otelcol-0", "otelcol-1"})
+			var kept []string
+			for _, address := range addresses(tsets) {
+				if owned.peerFor("demo/"+address) == "otelcol-0" {
+					kept = append(kept, address)
+				}
+			}
+			require.NotEmpty(t, kept)
+			require.Less(t, len(kept), 100)
+
+			filtered, nextRelease := s.filter(now, tsets)
+			if tt.handoffDelay == 0 {
+				assert.Zero(t, nextRelease)
+				assert.ElementsMatch(t, kept, addresses(filtered))
+				return
+			}
+
+			// The targets assigned to the new peer are scraped until the end of the handoff.
+			assert.Equal(t, now.Add(tt.handoffDelay), nextRelease)
+			assert.Len(t, addresses(filtered), 100)
+
+			filtered, nextRelease = s.filter(now.Add(tt.handoffDelay/2), tsets)
+			assert.Equal(t, now.Add(tt.handoffDelay), nextRelease)
+			assert.Len(t, addresses(filtered), 100)
+
+			filtered, nextRelease = s.filter(now.Add(tt.handoffDelay), tsets)
+			assert.Zero(t, nextRelease)
+			assert.ElementsMatch(t, kept, addresses(filtered))
+
+			// The targets assigned back to this replica are scraped again.
+			res.update([]string{"otelcol-0"})
+			filtered, _ = s.filter(now.Add(tt.handoffDelay), tsets)
+			assert.Len(t, addresses(filtered), 100)
+		})
+	}
+}
+
+func TestSharderRun(t *testing.T) {
+	s, res := newTestSharder(t, "otelcol-0", 0, "otelcol-0")
+	in := make(chan map[string][]*targetgroup.Group)
+	out := s.Run(in)
+
+	tsets := targetSets(100)
+	in <- tsets
+	assert.Len(t, addresses(<-out), 100)
+
+	// A membership change filters the latest target sets again.
+	res.update([]string{"otelcol-0", "otelcol-1"})
+	assert.Less(t, len(addresses(<-out)), 100)
+
+	require.NoError(t, s.Shutdown(t.Context()))
+}
+
+func TestSharderRunHandoff(t *testing.T) {
+	s, res := newTestSharder(t, "otelcol-0", 100*time.Millisecond, "otelcol-0")
+	in := make(chan map[string][]*targetgroup.Group)
+	out := s.Run(in)
+
+	in <- targetSets(100)
+	assert.Len(t, addresses(<-out), 100)
+
+	res.update([]string{"otelcol-0", "otelcol-1"})
+	assert.Len(t, addresses(<-out), 100)
+
+	// The handed off targets are released once the delay has passed.
+	select {
+	case tsets := <-out:
+		assert.Less(t, len(addresses(tsets)), 100)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handed off targets weren't released")
+	}
+
+	require.NoError(t, s.Shutdown(t.Context()))
+}
@@ -4,6 +4,9 @@
 package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
@@ -11,11 +14,35 @@ import (
 // Config holds common fields and embedded protocol-specific configurations
 type Config struct {
 	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// StartTimeAdjustment configures the tracking of the series across requests to set the start
+	// timestamp of cumulative datapoints and detect resets.
+	StartTimeAdjustment StartTimeAdjustmentConfig `mapstructure:"start_time_adjustment"`
+}
+
+// StartTimeAdjustmentConfig configures the stateful start timestamp adjustment.
+type StartTimeAdjustmentConfig struct {
+	// Enabled enables the start timestamp adjustment.
+	Enabled bool `mapstructure:"enabled"`
+	// GCInterval is the interval at which the series that were not received are removed.
+	GCInterval time.Duration `mapstructure:"gc_interval"`
+	// MaxSeries is the maximum number of series tracked, the datapoints of the series exceeding
+	// the limit are not adjusted.
+	MaxSeries int `mapstructure:"max_series"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
-func (*Config) Validate() error {
+func (cfg *Config) Validate() error {
+	if !cfg.StartTimeAdjustment.Enabled {
+		return nil
+	}
+	if cfg.StartTimeAdjustment.GCInterval <= 0 {
+		return errors.New("start_time_adjustment::gc_interval must be positive")
+	}
+	if cfg.StartTimeAdjustment.MaxSeries <= 0 {
+		return errors.New("start_time_adjustment::max_series must be positive")
+	}
 	return nil
 }
@@ -5,6 +5,7 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -15,3 +16,41 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.NotNil(t, cfg, "failed to create default config")
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
+
+func TestValidateConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		adjustment  StartTimeAdjustmentConfig
+		expectedErr string
+	}{
+		{
+			name:       "disabled start time adjustment",
+			adjustment: StartTimeAdjustmentConfig{},
+		},
+		{
+			name:       "enabled start time adjustment",
+			adjustment: StartTimeAdjustmentConfig{Enabled: true, GCInterval: time.Minute, MaxSeries: 10},
+		},
+		{
+			name:        "invalid gc interval",
+			adjustment:  StartTimeAdjustmentConfig{Enabled: true, MaxSeries: 10},
+			expectedErr: "start_time_adjustment::gc_interval must be positive",
+		},
+		{
+			name:        "invalid max series",
+			adjustment:  StartTimeAdjustmentConfig{Enabled: true, GCInterval: time.Minute},
+			expectedErr: "start_time_adjustment::max_series must be positive",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.StartTimeAdjustment = tc.adjustment
+			err := cfg.Validate()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
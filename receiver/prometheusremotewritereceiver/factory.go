@@ -5,6 +5,7 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -27,6 +28,10 @@ func createDefaultConfig() component.Config {
 		ServerConfig: confighttp.ServerConfig{
 			Endpoint: "localhost:9090",
 		},
+		StartTimeAdjustment: StartTimeAdjustmentConfig{
+			GCInterval: 15 * time.Minute,
+			MaxSeries:  100000,
+		},
 	}
 }
 
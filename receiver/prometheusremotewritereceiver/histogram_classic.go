@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	promremote "github.com/prometheus/prometheus/storage/remote"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+type classicSeriesKind int
+
+const (
+	classicSeriesBucket classicSeriesKind = iota
+	classicSeriesSum
+	classicSeriesCount
+)
+
+// classicHistograms reassembles the classic histograms of a request from their _bucket, _sum and _count series.
+// The datapoints are created when the first series of a histogram is processed, and are completed by finalize
+// once all the series of the request were processed.
+//
+// Only the series received in the same request can be reassembled, a histogram whose series are split across
+// requests results in several partial datapoints.
+type classicHistograms struct {
+	points map[uint64]*classicHistogramPoint
+}
+
+type classicHistogramPoint struct {
+	dp      pmetric.HistogramDataPoint
+	buckets []classicBucket
+	count   float64
+	// hasCount is true if the _count series of the histogram was received.
+	hasCount bool
+}
+
+type classicBucket struct {
+	upperBound float64
+	// cumulativeCount is the number of observations less than or equal to the upper bound.
+	cumulativeCount float64
+}
+
+func newClassicHistograms() *classicHistograms {
+	return &classicHistograms{points: make(map[uint64]*classicHistogramPoint)}
+}
+
+// classicHistogramSeries returns the histogram name and the kind of a classic histogram series.
+func classicHistogramSeries(metricName string, ls labels.Labels) (string, classicSeriesKind, bool) {
+	if name, ok := strings.CutSuffix(metricName, "_bucket"); ok && ls.Has(labels.BucketLabel) {
+		return name, classicSeriesBucket, true
+	}
+	if name, ok := strings.CutSuffix(metricName, "_sum"); ok {
+		return name, classicSeriesSum, true
+	}
+	if name, ok := strings.CutSuffix(metricName, "_count"); ok {
+		return name, classicSeriesCount, true
+	}
+	return "", 0, false
+}
+
+// addClassicHistogramSeries adds the samples of a _bucket, _sum or _count series to the datapoints of its histogram.
+func (prw *prometheusRemoteWriteReceiver) addClassicHistogramSeries(
+	otelMetrics pmetric.Metrics,
+	ls labels.Labels,
+	ts writev2.TimeSeries,
+	scopeName, scopeVersion, metricName, unit, description string,
+	symbols []string,
+	metricCache map[uint64]pmetric.Metric,
+	classic *classicHistograms,
+	stats *promremote.WriteResponseStats,
+) {
+	histogramName, kind, ok := classicHistogramSeries(metricName, ls)
+	if !ok {
+		prw.settings.Logger.Info("Dropping classic histogram series without _bucket, _sum or _count suffix",
+			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: metricName})
+		return
+	}
+	var upperBound float64
+	if kind == classicSeriesBucket {
+		var err error
+		if upperBound, err = strconv.ParseFloat(ls.Get(labels.BucketLabel), 64); err != nil {
+			prw.settings.Logger.Info("Dropping classic histogram bucket series with invalid upper bound",
+				zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: metricName})
+			return
+		}
+	}
+
+	rm := prw.getOrCreateResourceMetrics(otelMetrics, ls)
+	scope := getOrCreateScope(rm, scopeName, scopeVersion)
+
+	// Classic histograms and NHCBs are both translated into explicit bucket histograms, so they share the same metric.
+	metricID := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s",
+		identity.OfResource(rm.Resource()).String(),
+		scopeName,
+		scopeVersion,
+		histogramName,
+		unit,
+		fmt.Sprintf("%d", ts.Metadata.Type),
+		"nhcb",
+	)
+	metricIDHash := xxhash.Sum64String(metricID)
+
+	histMetric, exists := metricCache[metricIDHash]
+	if !exists {
+		histMetric = setMetric(scope, histogramName, unit, description)
+		histMetric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		metricCache[metricIDHash] = histMetric
+	} else if len(histMetric.Description()) < len(description) {
+		histMetric.SetDescription(description)
+	}
+
+	attrs := extractAttributes(ls)
+	attrs.Remove(labels.BucketLabel)
+	seriesHash := labels.NewBuilder(ls).Del(labels.MetricName, labels.BucketLabel).Labels().Hash()
+
+	var point *classicHistogramPoint
+	for _, sample := range ts.Samples {
+		pointKey := xxhash.Sum64String(fmt.Sprintf("%d:%d:%d", metricIDHash, seriesHash, sample.Timestamp))
+		point = classic.points[pointKey]
+		if point == nil {
+			point = &classicHistogramPoint{dp: histMetric.Histogram().DataPoints().AppendEmpty()}
+			point.dp.SetStartTimestamp(pcommon.Timestamp(ts.CreatedTimestamp * int64(time.Millisecond)))
+			point.dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
+			attrs.CopyTo(point.dp.Attributes())
+			classic.points[pointKey] = point
+		}
+
+		if value.IsStaleNaN(sample.Value) {
+			point.dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+		switch kind {
+		case classicSeriesBucket:
+			point.buckets = append(point.buckets, classicBucket{upperBound: upperBound, cumulativeCount: sample.Value})
+		case classicSeriesSum:
+			point.dp.SetSum(sample.Value)
+		case classicSeriesCount:
+			point.count = sample.Value
+			point.hasCount = true
+		}
+	}
+	stats.Samples += len(ts.Samples)
+
+	// Exemplars of the bucket series are attached to the latest datapoint of the histogram
+	if kind == classicSeriesBucket && point != nil {
+		addExemplars(point.dp.Exemplars(), ts.Exemplars, symbols, stats)
+	}
+}
+
+// finalize converts the cumulative bucket counts received into the bucket counts of the datapoints.
+func (c *classicHistograms) finalize() {
+	for _, point := range c.points {
+		if point.dp.Flags().NoRecordedValue() {
+			continue
+		}
+		sort.Slice(point.buckets, func(i, j int) bool {
+			return point.buckets[i].upperBound < point.buckets[j].upperBound
+		})
+
+		bounds := make([]float64, 0, len(point.buckets))
+		counts := make([]uint64, 0, len(point.buckets)+1)
+		var previous float64
+		total, hasTotal := point.count, point.hasCount
+		for _, bucket := range point.buckets {
+			if math.IsInf(bucket.upperBound, 1) {
+				// The +Inf bucket counts all the observations, it is used when the _count series was not received.
+				if !hasTotal {
+					total, hasTotal = bucket.cumulativeCount, true
+				}
+				break
+			}
+			bounds = append(bounds, bucket.upperBound)
+			counts = append(counts, uint64(math.Max(bucket.cumulativeCount-previous, 0)))
+			previous = bucket.cumulativeCount
+		}
+		if !hasTotal {
+			total = previous
+		}
+		// The observations above the last finite upper bound go into the overflow bucket
+		counts = append(counts, uint64(math.Max(total-previous, 0)))
+
+		point.dp.SetCount(uint64(total))
+		point.dp.ExplicitBounds().FromRaw(bounds)
+		point.dp.BucketCounts().FromRaw(counts)
+	}
+}
+
+// getOrCreateResourceMetrics returns the resource metrics of the job and instance labels, creating it if needed.
+func (prw *prometheusRemoteWriteReceiver) getOrCreateResourceMetrics(otelMetrics pmetric.Metrics, ls labels.Labels) pmetric.ResourceMetrics {
+	hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
+	if rm, ok := prw.rmCache.Get(hashedLabels); ok {
+		return rm
+	}
+	rm := otelMetrics.ResourceMetrics().AppendEmpty()
+	parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
+	prw.rmCache.Add(hashedLabels, rm)
+	return rm
+}
+
+// getOrCreateScope returns the scope metrics with the given name and version, creating it if needed.
+func getOrCreateScope(rm pmetric.ResourceMetrics, scopeName, scopeVersion string) pmetric.ScopeMetrics {
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		s := rm.ScopeMetrics().At(i)
+		if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
+			return s
+		}
+	}
+	scope := rm.ScopeMetrics().AppendEmpty()
+	scope.Scope().SetName(scopeName)
+	scope.Scope().SetVersion(scopeVersion)
+	return scope
+}
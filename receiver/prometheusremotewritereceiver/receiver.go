@@ -4,6 +4,7 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	promremote "github.com/prometheus/prometheus/storage/remote"
 	"go.opentelemetry.io/collector/component"
@@ -37,6 +39,15 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
 	}
+	metadataCache, err := lru.New[string, prompb.MetricMetadata](metadataCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata LRU cache: %w", err)
+	}
+
+	var adjuster *startTimeAdjuster
+	if cfg.StartTimeAdjustment.Enabled {
+		adjuster = newStartTimeAdjuster(settings.Logger, cfg.StartTimeAdjustment)
+	}
 
 	return &prometheusRemoteWriteReceiver{
 		settings:     settings,
@@ -45,7 +56,9 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 		server: &http.Server{
 			ReadTimeout: 60 * time.Second,
 		},
-		rmCache: cache,
+		rmCache:       cache,
+		metadataCache: metadataCache,
+		adjuster:      adjuster,
 	}, nil
 }
 
@@ -58,7 +71,12 @@ type prometheusRemoteWriteReceiver struct {
 	wg     sync.WaitGroup
 
 	rmCache *lru.Cache[uint64, pmetric.ResourceMetrics]
-	obsrecv *receiverhelper.ObsReport
+	// metadataCache stores the metadata of the metric families received through remote-write v1,
+	// where metadata is sent separately from the samples.
+	metadataCache *lru.Cache[string, prompb.MetricMetadata]
+	obsrecv       *receiverhelper.ObsReport
+	// adjuster sets the start timestamp of the cumulative datapoints, it is nil when the adjustment is disabled.
+	adjuster *startTimeAdjuster
 }
 
 // metricIdentity contains all the components that uniquely identify a metric
@@ -160,12 +178,6 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
 		return
 	}
-	if msgType != promconfig.RemoteWriteProtoMsgV2 {
-		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
-		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
-		return
-	}
-
 	// After parsing the content-type header, the next step would be to handle content-encoding.
 	// Luckly confighttp's Server has middleware that already decompress the request body for us.
 
@@ -177,9 +189,25 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 	}
 
 	var prw2Req writev2.Request
-	if err = proto.Unmarshal(body, &prw2Req); err != nil {
-		prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	switch msgType {
+	case promconfig.RemoteWriteProtoMsgV1:
+		// Remote-write v1 requests are converted into v2 requests, so that both versions share the same translation.
+		var prw1Req prompb.WriteRequest
+		if err = proto.Unmarshal(body, &prw1Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		prw2Req = prw.convertV1(&prw1Req)
+	case promconfig.RemoteWriteProtoMsgV2:
+		if err = proto.Unmarshal(body, &prw2Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
+		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
 		return
 	}
 
@@ -192,6 +220,10 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 
 	w.WriteHeader(http.StatusNoContent)
 
+	if prw.adjuster != nil {
+		prw.adjuster.AdjustMetrics(m)
+	}
+
 	obsrecvCtx := prw.obsrecv.StartMetricsOp(req.Context())
 	err = prw.nextConsumer.ConsumeMetrics(req.Context(), m)
 	if err != nil {
@@ -238,12 +270,13 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		otelMetrics      = pmetric.NewMetrics()
 		labelsBuilder    = labels.NewScratchBuilder(0)
 		// More about stats: https://github.com/prometheus/docs/blob/main/docs/specs/prw/remote_write_spec_2_0.md#required-written-response-headers
-		// TODO: add exemplars to the stats.
 		stats = promremote.WriteResponseStats{
 			Confirmed: true,
 		}
 		// The key is composed by: resource_hash:scope_name:scope_version:metric_name:unit:type
 		metricCache = make(map[uint64]pmetric.Metric)
+		// Classic histograms are split into several series, they are completed once all the series were processed.
+		classic = newClassicHistograms()
 	)
 
 	for _, ts := range req.Timeseries {
@@ -298,7 +331,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 
 		// Handle histograms separately due to their complex mixed-schema processing
 		if ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_HISTOGRAM {
-			prw.processHistogramTimeSeries(otelMetrics, ls, ts, scopeName, scopeVersion, metricName, unit, description, metricCache, &stats)
+			prw.processHistogramTimeSeries(otelMetrics, ls, ts, scopeName, scopeVersion, metricName, unit, description, req.Symbols, metricCache, classic, &stats)
 			continue
 		}
 
@@ -371,9 +404,9 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 
 		switch ts.Metadata.Type {
 		case writev2.Metadata_METRIC_TYPE_GAUGE:
-			addNumberDatapoints(metric.Gauge().DataPoints(), ls, ts, &stats)
+			addNumberDatapoints(metric.Gauge().DataPoints(), ls, ts, req.Symbols, &stats)
 		case writev2.Metadata_METRIC_TYPE_COUNTER:
-			addNumberDatapoints(metric.Sum().DataPoints(), ls, ts, &stats)
+			addNumberDatapoints(metric.Sum().DataPoints(), ls, ts, req.Symbols, &stats)
 		case writev2.Metadata_METRIC_TYPE_SUMMARY:
 			// Drop summary series as we will not handle them.
 			continue
@@ -381,6 +414,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
 		}
 	}
+	classic.finalize()
 
 	return otelMetrics, stats, badRequestErrors
 }
@@ -391,13 +425,15 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 	ls labels.Labels,
 	ts writev2.TimeSeries,
 	scopeName, scopeVersion, metricName, unit, description string,
+	symbols []string,
 	metricCache map[uint64]pmetric.Metric,
+	classic *classicHistograms,
 	stats *promremote.WriteResponseStats,
 ) {
-	// Drop classic histogram series (those with samples)
+	// Classic histograms populate samples instead of histograms, with a series per bucket and
+	// separate series for the sum and the count of the observations.
 	if len(ts.Samples) != 0 {
-		prw.settings.Logger.Info("Dropping classic histogram series. Please configure Prometheus to convert classic histograms into Native Histograms Custom Buckets",
-			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")})
+		prw.addClassicHistogramSeries(otelMetrics, ls, ts, scopeName, scopeVersion, metricName, unit, description, symbols, metricCache, classic, stats)
 		return
 	}
 
@@ -518,7 +554,7 @@ func parseJobAndInstance(dest pcommon.Map, job, instance string) {
 }
 
 // addNumberDatapoints adds the labels to the datapoints attributes.
-func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, stats *promremote.WriteResponseStats) {
+func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labels, ts writev2.TimeSeries, symbols []string, stats *promremote.WriteResponseStats) {
 	// Add samples from the timeseries
 	for _, sample := range ts.Samples {
 		dp := datapoints.AppendEmpty()
@@ -531,6 +567,42 @@ func addNumberDatapoints(datapoints pmetric.NumberDataPointSlice, ls labels.Labe
 		extractAttributes(ls).CopyTo(attributes)
 	}
 	stats.Samples += len(ts.Samples)
+
+	// Exemplars are attached to the latest sample of the series
+	if len(ts.Samples) > 0 {
+		addExemplars(datapoints.At(datapoints.Len()-1).Exemplars(), ts.Exemplars, symbols, stats)
+	}
+}
+
+// addExemplars converts the exemplars of a series. The trace_id and span_id labels set the trace and span
+// IDs of the exemplars, the other labels become filtered attributes.
+func addExemplars(dest pmetric.ExemplarSlice, exemplars []writev2.Exemplar, symbols []string, stats *promremote.WriteResponseStats) {
+	for _, exemplar := range exemplars {
+		e := dest.AppendEmpty()
+		e.SetTimestamp(pcommon.Timestamp(exemplar.Timestamp * int64(time.Millisecond)))
+		e.SetDoubleValue(exemplar.Value)
+		for i := 0; i+1 < len(exemplar.LabelsRefs); i += 2 {
+			nameRef, valueRef := exemplar.LabelsRefs[i], exemplar.LabelsRefs[i+1]
+			if nameRef >= uint32(len(symbols)) || valueRef >= uint32(len(symbols)) {
+				continue
+			}
+			name, val := symbols[nameRef], symbols[valueRef]
+			switch name {
+			case "trace_id":
+				if id, err := hex.DecodeString(val); err == nil && len(id) == 16 {
+					e.SetTraceID(pcommon.TraceID(id))
+					continue
+				}
+			case "span_id":
+				if id, err := hex.DecodeString(val); err == nil && len(id) == 8 {
+					e.SetSpanID(pcommon.SpanID(id))
+					continue
+				}
+			}
+			e.FilteredAttributes().PutStr(name, val)
+		}
+		stats.Exemplars++
+	}
 }
 
 func (prw *prometheusRemoteWriteReceiver) addExponentialHistogramDatapoint(datapoints pmetric.ExponentialHistogramDataPointSlice, histogram writev2.Histogram, ls labels.Labels, createdTimestamp int64, stats *promremote.WriteResponseStats) {
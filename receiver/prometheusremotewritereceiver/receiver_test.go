@@ -119,9 +119,9 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/no proto parameter",
 			contentType:  "application/x-protobuf",
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
-				Confirmed:  false,
+				Confirmed:  true,
 				Samples:    0,
 				Histograms: 0,
 				Exemplars:  0,
@@ -130,9 +130,9 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/v1 proto parameter",
 			contentType:  fmt.Sprintf("application/x-protobuf;proto=%s", promconfig.RemoteWriteProtoMsgV1),
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
-				Confirmed:  false,
+				Confirmed:  true,
 				Samples:    0,
 				Histograms: 0,
 				Exemplars:  0,
@@ -999,7 +999,7 @@ func TestTranslateV2(t *testing.T) {
 			expectedMetrics: pmetric.NewMetrics(), // Reset hint gauge should be dropped completely, no resources should be created
 		},
 		{
-			name: "classic histogram - should be dropped",
+			name: "classic histogram without bucket, sum or count suffix - should be dropped",
 			request: &writev2.Request{
 				Symbols: []string{
 					"",
@@ -1015,7 +1015,7 @@ func TestTranslateV2(t *testing.T) {
 						Metadata: writev2.Metadata{
 							Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
 						},
-						// Classic histograms populate samples instead of histograms. Those should be dropped.
+						// Classic histograms populate samples instead of histograms. Series which are not part of a classic histogram should be dropped.
 						Histograms: []writev2.Histogram{},
 						Samples: []writev2.Sample{
 							{
@@ -1035,6 +1035,90 @@ func TestTranslateV2(t *testing.T) {
 			},
 			expectedMetrics: pmetric.NewMetrics(), // Classic histograms should be dropped completely, no resources should be created
 		},
+		{
+			name: "classic histogram reassembled from bucket, sum and count series",
+			request: &writev2.Request{
+				Symbols: []string{
+					"",
+					"__name__", "test_histogram_bucket", // 1, 2
+					"job", "test", // 3, 4
+					"instance", "localhost:8080", // 5, 6
+					"le", "1", // 7, 8
+					"5", "+Inf", // 9, 10
+					"test_histogram_sum", "test_histogram_count", // 11, 12
+					"trace_id", "0102030405060708090a0b0c0d0e0f10", // 13, 14
+					"span_id", "0102030405060708", // 15, 16
+					"seconds", "Test classic histogram", // 17, 18
+				},
+				Timeseries: []writev2.TimeSeries{
+					{
+						// Buckets can be received in any order
+						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 7, 9},
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, UnitRef: 17, HelpRef: 18},
+						Samples:    []writev2.Sample{{Value: 8, Timestamp: 1}},
+						Exemplars:  []writev2.Exemplar{{LabelsRefs: []uint32{13, 14, 15, 16}, Value: 3.5, Timestamp: 1}},
+					},
+					{
+						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 7, 8},
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, UnitRef: 17, HelpRef: 18},
+						Samples:    []writev2.Sample{{Value: 3, Timestamp: 1}},
+					},
+					{
+						LabelsRefs: []uint32{1, 2, 3, 4, 5, 6, 7, 10},
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, UnitRef: 17, HelpRef: 18},
+						Samples:    []writev2.Sample{{Value: 10, Timestamp: 1}},
+					},
+					{
+						LabelsRefs: []uint32{1, 11, 3, 4, 5, 6},
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, UnitRef: 17, HelpRef: 18},
+						Samples:    []writev2.Sample{{Value: 42.5, Timestamp: 1}},
+					},
+					{
+						LabelsRefs: []uint32{1, 12, 3, 4, 5, 6},
+						Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, UnitRef: 17, HelpRef: 18},
+						Samples:    []writev2.Sample{{Value: 10, Timestamp: 1}},
+					},
+				},
+			},
+			expectedStats: remote.WriteResponseStats{
+				Confirmed:  true,
+				Samples:    5,
+				Histograms: 0,
+				Exemplars:  1,
+			},
+			expectedMetrics: func() pmetric.Metrics {
+				metrics := pmetric.NewMetrics()
+				rm := metrics.ResourceMetrics().AppendEmpty()
+				attrs := rm.Resource().Attributes()
+				attrs.PutStr("service.name", "test")
+				attrs.PutStr("service.instance.id", "localhost:8080")
+
+				sm := rm.ScopeMetrics().AppendEmpty()
+				sm.Scope().SetName("OpenTelemetry Collector")
+				sm.Scope().SetVersion("latest")
+				m := sm.Metrics().AppendEmpty()
+				m.SetName("test_histogram")
+				m.SetUnit("seconds")
+				m.SetDescription("Test classic histogram")
+				hist := m.SetEmptyHistogram()
+				hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+				dp := hist.DataPoints().AppendEmpty()
+				dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				dp.SetSum(42.5)
+				dp.SetCount(10)
+				dp.ExplicitBounds().FromRaw([]float64{1, 5})
+				dp.BucketCounts().FromRaw([]uint64{3, 5, 2})
+
+				e := dp.Exemplars().AppendEmpty()
+				e.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+				e.SetDoubleValue(3.5)
+				e.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+				e.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+
+				return metrics
+			}(),
+		},
 		{
 			name: "summary - should be dropped",
 			request: &writev2.Request{
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/identity"
+)
+
+// startTimeAdjuster tracks the cumulative series across requests to set the start timestamp of their datapoints
+// and to detect counter resets, as remote-write samples only carry a start timestamp when the sender knows the
+// created timestamp of the series.
+//
+// The first datapoint received for a series starts at its own timestamp, the next datapoints share its start
+// timestamp until a reset is detected. A series restarts after its previous datapoint when its value, count or sum
+// decreases, or when the sender sends a new created timestamp.
+//
+// Memory is bounded by the maximum number of tracked series, datapoints of the series exceeding the limit are not
+// adjusted. The series are garbage collected with a mark-and-sweep approach: the series that were not received
+// since the previous collection are removed at each collection.
+type startTimeAdjuster struct {
+	logger     *zap.Logger
+	gcInterval time.Duration
+	maxSeries  int
+
+	mu     sync.Mutex
+	series map[identity.Stream]*seriesState
+	lastGC time.Time
+}
+
+// seriesState contains the information necessary to adjust the start timestamp of a series and to detect its resets.
+type seriesState struct {
+	mark bool
+
+	startTime pcommon.Timestamp
+	lastTime  pcommon.Timestamp
+	// previousValue is the value of sums, or the sum of histograms.
+	previousValue float64
+	// previousCount is the count of histograms.
+	previousCount uint64
+}
+
+// cumulativePoint is implemented by the datapoints of cumulative metrics.
+type cumulativePoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	Flags() pmetric.DataPointFlags
+}
+
+func newStartTimeAdjuster(logger *zap.Logger, cfg StartTimeAdjustmentConfig) *startTimeAdjuster {
+	return &startTimeAdjuster{
+		logger:     logger,
+		gcInterval: cfg.GCInterval,
+		maxSeries:  cfg.MaxSeries,
+		series:     make(map[identity.Stream]*seriesState),
+		lastGC:     time.Now(),
+	}
+}
+
+// AdjustMetrics sets the start timestamp of the datapoints of the cumulative metrics.
+func (a *startTimeAdjuster) AdjustMetrics(metrics pmetric.Metrics) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				metricID := identity.OfResourceMetric(rm.Resource(), sm.Scope(), metric)
+
+				switch metric.Type() {
+				case pmetric.MetricTypeSum:
+					if metric.Sum().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						continue
+					}
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						a.adjustPoint(identity.OfStream(metricID, dp), dp, dp.DoubleValue(), 0)
+					}
+				case pmetric.MetricTypeHistogram:
+					if metric.Histogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						continue
+					}
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						a.adjustPoint(identity.OfStream(metricID, dp), dp, dp.Sum(), dp.Count())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					if metric.ExponentialHistogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						continue
+					}
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						a.adjustPoint(identity.OfStream(metricID, dp), dp, dp.Sum(), dp.Count())
+					}
+				default:
+					// gauges don't need to be adjusted
+				}
+			}
+		}
+	}
+
+	if time.Since(a.lastGC) > a.gcInterval {
+		a.gc()
+	}
+}
+
+func (a *startTimeAdjuster) adjustPoint(id identity.Stream, dp cumulativePoint, value float64, count uint64) {
+	createdTime := dp.StartTimestamp()
+	if createdTime >= dp.Timestamp() {
+		createdTime = 0
+	}
+
+	state, found := a.series[id]
+	if !found {
+		if len(a.series) >= a.maxSeries {
+			a.logger.Debug("Too many series tracked, not adjusting the start timestamp", zap.Int("max_series", a.maxSeries))
+			return
+		}
+		state = &seriesState{startTime: dp.Timestamp()}
+		if createdTime != 0 {
+			state.startTime = createdTime
+		}
+		a.series[id] = state
+	}
+	state.mark = true
+
+	if found {
+		// Stale markers and out of order datapoints don't update the state of the series
+		if dp.Flags().NoRecordedValue() || math.IsNaN(value) || dp.Timestamp() <= state.lastTime {
+			dp.SetStartTimestamp(state.startTime)
+			return
+		}
+
+		switch {
+		case createdTime != 0:
+			// The created timestamp known by the sender takes precedence
+			state.startTime = createdTime
+		case value < state.previousValue || count < state.previousCount:
+			// The series was reset sometime after the previous datapoint
+			state.startTime = state.lastTime
+		}
+	}
+
+	dp.SetStartTimestamp(state.startTime)
+	state.lastTime = dp.Timestamp()
+	state.previousValue = value
+	state.previousCount = count
+}
+
+// gc removes the series that were not received since the previous collection.
+func (a *startTimeAdjuster) gc() {
+	for id, state := range a.series {
+		if !state.mark {
+			delete(a.series, id)
+			continue
+		}
+		state.mark = false
+	}
+	a.lastGC = time.Now()
+}
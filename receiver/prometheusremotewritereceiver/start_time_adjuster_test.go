@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type testPoint struct {
+	start int64
+	ts    int64
+	value float64
+}
+
+func sumMetrics(series string, points ...testPoint) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests_total")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("series", series)
+		dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+		dp.SetTimestamp(pcommon.Timestamp(p.ts))
+		dp.SetDoubleValue(p.value)
+	}
+	return metrics
+}
+
+func startTimestamps(metrics pmetric.Metrics) []pcommon.Timestamp {
+	var starts []pcommon.Timestamp
+	dps := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		starts = append(starts, dps.At(i).StartTimestamp())
+	}
+	return starts
+}
+
+func testAdjuster(maxSeries int) *startTimeAdjuster {
+	return newStartTimeAdjuster(zap.NewNop(), StartTimeAdjustmentConfig{
+		Enabled:    true,
+		GCInterval: time.Hour,
+		MaxSeries:  maxSeries,
+	})
+}
+
+func TestStartTimeAdjusterSum(t *testing.T) {
+	a := testAdjuster(10)
+
+	// The first datapoint starts at its own timestamp
+	first := sumMetrics("a", testPoint{ts: 10, value: 5}, testPoint{ts: 20, value: 7})
+	a.AdjustMetrics(first)
+	assert.Equal(t, []pcommon.Timestamp{10, 10}, startTimestamps(first))
+
+	// The start timestamp is kept across requests, until the counter is reset
+	second := sumMetrics("a", testPoint{ts: 30, value: 9}, testPoint{ts: 40, value: 2}, testPoint{ts: 50, value: 3})
+	a.AdjustMetrics(second)
+	assert.Equal(t, []pcommon.Timestamp{10, 30, 30}, startTimestamps(second))
+
+	// Out of order datapoints don't reset the series
+	third := sumMetrics("a", testPoint{ts: 45, value: 1}, testPoint{ts: 60, value: 4})
+	a.AdjustMetrics(third)
+	assert.Equal(t, []pcommon.Timestamp{30, 30}, startTimestamps(third))
+
+	// A new created timestamp takes precedence
+	fourth := sumMetrics("a", testPoint{start: 65, ts: 70, value: 1}, testPoint{ts: 80, value: 2})
+	a.AdjustMetrics(fourth)
+	assert.Equal(t, []pcommon.Timestamp{65, 65}, startTimestamps(fourth))
+}
+
+func TestStartTimeAdjusterHistogram(t *testing.T) {
+	a := testAdjuster(10)
+
+	histogram := func(ts int64, count uint64, sum float64) pmetric.Metrics {
+		metrics := pmetric.NewMetrics()
+		m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("duration_seconds")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		return metrics
+	}
+	start := func(metrics pmetric.Metrics) pcommon.Timestamp {
+		return metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0).StartTimestamp()
+	}
+
+	for _, tc := range []struct {
+		metrics       pmetric.Metrics
+		expectedStart pcommon.Timestamp
+	}{
+		{metrics: histogram(10, 5, 2.5), expectedStart: 10},
+		{metrics: histogram(20, 8, 3), expectedStart: 10},
+		{metrics: histogram(30, 2, 0.5), expectedStart: 20},
+		{metrics: histogram(40, 3, 1), expectedStart: 20},
+	} {
+		a.AdjustMetrics(tc.metrics)
+		assert.Equal(t, tc.expectedStart, start(tc.metrics))
+	}
+}
+
+func TestStartTimeAdjusterMaxSeries(t *testing.T) {
+	a := testAdjuster(1)
+
+	tracked := sumMetrics("a", testPoint{ts: 10, value: 1})
+	a.AdjustMetrics(tracked)
+	assert.Equal(t, []pcommon.Timestamp{10}, startTimestamps(tracked))
+
+	// Series exceeding the limit are not adjusted
+	untracked := sumMetrics("b", testPoint{ts: 10, value: 1})
+	a.AdjustMetrics(untracked)
+	assert.Equal(t, []pcommon.Timestamp{0}, startTimestamps(untracked))
+	assert.Len(t, a.series, 1)
+}
+
+func TestStartTimeAdjusterGC(t *testing.T) {
+	a := testAdjuster(10)
+
+	a.AdjustMetrics(sumMetrics("a", testPoint{ts: 10, value: 1}))
+	a.AdjustMetrics(sumMetrics("b", testPoint{ts: 10, value: 1}))
+	require.Len(t, a.series, 2)
+
+	// Series are removed when they are not received between two collections
+	a.gc()
+	a.AdjustMetrics(sumMetrics("a", testPoint{ts: 20, value: 2}))
+	a.gc()
+	assert.Len(t, a.series, 1)
+	a.gc()
+	assert.Empty(t, a.series)
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+)
+
+// metadataCacheSize is the maximum number of metric families whose remote-write v1 metadata is cached.
+const metadataCacheSize = 10000
+
+// metadataSuffixes are the suffixes of the series names that are stripped to find the metadata of their metric family.
+var metadataSuffixes = []string{"_bucket", "_sum", "_count", "_total"}
+
+// convertV1 converts a remote-write v1 request into a remote-write v2 request.
+// The metadata of remote-write v1 is sent separately from the samples, it is cached by metric family so that
+// series received in later requests can be translated according to their type. Series without metadata are
+// translated as gauges, except for native histograms.
+func (prw *prometheusRemoteWriteReceiver) convertV1(req *prompb.WriteRequest) writev2.Request {
+	for _, md := range req.Metadata {
+		prw.metadataCache.Add(md.MetricFamilyName, md)
+	}
+
+	symbols := writev2.NewSymbolTable()
+	timeseries := make([]writev2.TimeSeries, 0, len(req.Timeseries))
+	for _, ts := range req.Timeseries {
+		var metricName string
+		labelsRefs := make([]uint32, 0, 2*len(ts.Labels))
+		for _, l := range ts.Labels {
+			if l.Name == labels.MetricName {
+				metricName = l.Value
+			}
+			labelsRefs = append(labelsRefs, symbols.Symbolize(l.Name), symbols.Symbolize(l.Value))
+		}
+
+		ts2 := writev2.TimeSeries{
+			LabelsRefs: labelsRefs,
+			Samples:    make([]writev2.Sample, 0, len(ts.Samples)),
+		}
+		for _, sample := range ts.Samples {
+			ts2.Samples = append(ts2.Samples, writev2.Sample{Value: sample.Value, Timestamp: sample.Timestamp})
+		}
+		for _, h := range ts.Histograms {
+			if h.IsFloatHistogram() {
+				ts2.Histograms = append(ts2.Histograms, writev2.FromFloatHistogram(h.Timestamp, h.ToFloatHistogram()))
+			} else {
+				ts2.Histograms = append(ts2.Histograms, writev2.FromIntHistogram(h.Timestamp, h.ToIntHistogram()))
+			}
+		}
+		for _, e := range ts.Exemplars {
+			exemplarRefs := make([]uint32, 0, 2*len(e.Labels))
+			for _, l := range e.Labels {
+				exemplarRefs = append(exemplarRefs, symbols.Symbolize(l.Name), symbols.Symbolize(l.Value))
+			}
+			ts2.Exemplars = append(ts2.Exemplars, writev2.Exemplar{LabelsRefs: exemplarRefs, Value: e.Value, Timestamp: e.Timestamp})
+		}
+
+		md, found := prw.lookupMetadata(metricName)
+		ts2.Metadata = writev2.Metadata{
+			Type:    metricTypeFromV1(md.Type, found, len(ts.Histograms) > 0),
+			HelpRef: symbols.Symbolize(md.Help),
+			UnitRef: symbols.Symbolize(md.Unit),
+		}
+		timeseries = append(timeseries, ts2)
+	}
+
+	return writev2.Request{
+		Symbols:    symbols.Symbols(),
+		Timeseries: timeseries,
+	}
+}
+
+// lookupMetadata returns the cached metadata of the metric family of a series.
+func (prw *prometheusRemoteWriteReceiver) lookupMetadata(metricName string) (prompb.MetricMetadata, bool) {
+	if md, ok := prw.metadataCache.Get(metricName); ok {
+		return md, true
+	}
+	for _, suffix := range metadataSuffixes {
+		if family, ok := strings.CutSuffix(metricName, suffix); ok {
+			if md, ok := prw.metadataCache.Get(family); ok {
+				return md, true
+			}
+		}
+	}
+	return prompb.MetricMetadata{}, false
+}
+
+// metricTypeFromV1 converts a remote-write v1 metric type into a remote-write v2 metric type.
+func metricTypeFromV1(metricType prompb.MetricMetadata_MetricType, found, hasHistograms bool) writev2.Metadata_MetricType {
+	if hasHistograms {
+		return writev2.Metadata_METRIC_TYPE_HISTOGRAM
+	}
+	if !found {
+		return writev2.Metadata_METRIC_TYPE_GAUGE
+	}
+	switch metricType {
+	case prompb.MetricMetadata_COUNTER:
+		return writev2.Metadata_METRIC_TYPE_COUNTER
+	case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+		return writev2.Metadata_METRIC_TYPE_HISTOGRAM
+	case prompb.MetricMetadata_SUMMARY:
+		return writev2.Metadata_METRIC_TYPE_SUMMARY
+	default:
+		// Info, stateset and unknown metrics are translated as gauges.
+		return writev2.Metadata_METRIC_TYPE_GAUGE
+	}
+}
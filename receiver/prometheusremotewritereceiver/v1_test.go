@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+)
+
+func v1Labels(nameValues ...string) []prompb.Label {
+	ls := make([]prompb.Label, 0, len(nameValues)/2)
+	for i := 0; i+1 < len(nameValues); i += 2 {
+		ls = append(ls, prompb.Label{Name: nameValues[i], Value: nameValues[i+1]})
+	}
+	return ls
+}
+
+func TestConvertV1MetricTypes(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+
+	// Metadata is sent separately from the samples
+	prwReceiver.convertV1(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "http_requests", Type: prompb.MetricMetadata_COUNTER},
+			{MetricFamilyName: "http_duration_seconds", Type: prompb.MetricMetadata_HISTOGRAM},
+			{MetricFamilyName: "rpc_duration_seconds", Type: prompb.MetricMetadata_SUMMARY},
+			{MetricFamilyName: "build_info", Type: prompb.MetricMetadata_INFO},
+		},
+	})
+
+	req := prwReceiver.convertV1(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{Labels: v1Labels("__name__", "http_requests_total")},
+			{Labels: v1Labels("__name__", "http_duration_seconds_bucket", "le", "1")},
+			{Labels: v1Labels("__name__", "http_duration_seconds_count")},
+			{Labels: v1Labels("__name__", "rpc_duration_seconds_sum")},
+			{Labels: v1Labels("__name__", "build_info")},
+			{Labels: v1Labels("__name__", "without_metadata")},
+			{
+				Labels:     v1Labels("__name__", "native_without_metadata"),
+				Histograms: []prompb.Histogram{prompb.FromIntHistogram(1, &histogram.Histogram{Count: 1, Sum: 1})},
+			},
+		},
+	})
+
+	expected := []writev2.Metadata_MetricType{
+		writev2.Metadata_METRIC_TYPE_COUNTER,
+		writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+		writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+		writev2.Metadata_METRIC_TYPE_SUMMARY,
+		writev2.Metadata_METRIC_TYPE_GAUGE,
+		writev2.Metadata_METRIC_TYPE_GAUGE,
+		writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+	}
+	require.Len(t, req.Timeseries, len(expected))
+	for i, ts := range req.Timeseries {
+		assert.Equal(t, expected[i], ts.Metadata.Type, "timeseries %d", i)
+	}
+	assert.Len(t, req.Timeseries[6].Histograms, 1)
+}
+
+func TestTranslateV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+
+	req := prwReceiver.convertV1(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "http_requests_total", Type: prompb.MetricMetadata_COUNTER, Help: "Total requests"},
+			{MetricFamilyName: "http_duration_seconds", Type: prompb.MetricMetadata_HISTOGRAM, Unit: "seconds"},
+		},
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:    v1Labels("__name__", "http_requests_total", "job", "test", "instance", "localhost:8080", "code", "200"),
+				Samples:   []prompb.Sample{{Value: 10, Timestamp: 1}, {Value: 12, Timestamp: 2}},
+				Exemplars: []prompb.Exemplar{{Labels: v1Labels("trace_id", "0102030405060708090a0b0c0d0e0f10", "user", "alice"), Value: 1, Timestamp: 2}},
+			},
+			{
+				Labels:  v1Labels("__name__", "http_duration_seconds_bucket", "job", "test", "instance", "localhost:8080", "le", "0.5"),
+				Samples: []prompb.Sample{{Value: 4, Timestamp: 2}},
+			},
+			{
+				Labels:  v1Labels("__name__", "http_duration_seconds_bucket", "job", "test", "instance", "localhost:8080", "le", "+Inf"),
+				Samples: []prompb.Sample{{Value: 6, Timestamp: 2}},
+			},
+			{
+				Labels:  v1Labels("__name__", "http_duration_seconds_sum", "job", "test", "instance", "localhost:8080"),
+				Samples: []prompb.Sample{{Value: 2.5, Timestamp: 2}},
+			},
+		},
+	})
+
+	metrics, stats, err := prwReceiver.translateV2(t.Context(), &req)
+	require.NoError(t, err)
+	assert.Equal(t, remote.WriteResponseStats{Confirmed: true, Samples: 5, Exemplars: 1}, stats)
+
+	expected := pmetric.NewMetrics()
+	rm := expected.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	rm.Resource().Attributes().PutStr("service.instance.id", "localhost:8080")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("OpenTelemetry Collector")
+	sm.Scope().SetVersion("latest")
+
+	counter := sm.Metrics().AppendEmpty()
+	counter.SetName("http_requests_total")
+	counter.SetDescription("Total requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, sample := range []prompb.Sample{{Value: 10, Timestamp: 1}, {Value: 12, Timestamp: 2}} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.Timestamp(sample.Timestamp * int64(time.Millisecond)))
+		dp.SetDoubleValue(sample.Value)
+		dp.Attributes().PutStr("code", "200")
+	}
+	e := sum.DataPoints().At(1).Exemplars().AppendEmpty()
+	e.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+	e.SetDoubleValue(1)
+	e.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	e.FilteredAttributes().PutStr("user", "alice")
+
+	// The count of the histogram is taken from the +Inf bucket when the _count series is not sent
+	hist := sm.Metrics().AppendEmpty()
+	hist.SetName("http_duration_seconds")
+	hist.SetUnit("seconds")
+	hdp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hist.Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+	hdp.SetSum(2.5)
+	hdp.SetCount(6)
+	hdp.ExplicitBounds().FromRaw([]float64{0.5})
+	hdp.BucketCounts().FromRaw([]uint64{4, 2})
+
+	assert.NoError(t, pmetrictest.CompareMetrics(expected, metrics))
+}